@@ -27,7 +27,8 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -36,7 +37,16 @@ import (
 
 	"github.com/joho/godotenv"
 
+	"km-api-go/internal/activity"
+	"km-api-go/internal/health"
 	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/metrics"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/rpc"
+	"km-api-go/internal/storage"
+	"km-api-go/internal/tracing"
 	"km-api-go/server"
 	
 	// Swagger docs
@@ -45,18 +55,79 @@ import (
 
 func main() {
 	// .envファイル読み込み
-	if err := godotenv.Overload(); err != nil {
-		log.Println("No .env file found, using system environment variables")
+	envErr := godotenv.Overload()
+
+	// ロガー初期化（LOG_LEVEL, LOG_FORMAT）
+	logger := logging.Init()
+	if envErr != nil {
+		logger.Info("No .env file found, using system environment variables")
+	}
+
+	// トレーシング初期化（TRACING_EXPORTER=otlp|stdout|none）
+	tracingConfig := tracing.LoadConfig()
+	shutdownTracing, err := tracing.Init(context.Background(), tracingConfig)
+	if err != nil {
+		logger.Error("Failed to initialize tracing", slog.Any("error", err))
+		os.Exit(1)
 	}
 
 	// データベース接続
 	db, err := infra.InitDatabase()
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		logger.Error("Failed to initialize database", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	// GORMクエリのスパン生成
+	if err := tracing.InstrumentDatabase(db); err != nil {
+		logger.Warn("Failed to instrument database tracing", slog.Any("error", err))
+	}
+
+	// 接続プール統計・クエリ時間のメトリクス収集
+	if err := metrics.InstrumentDatabase(db); err != nil {
+		logger.Warn("Failed to instrument database metrics", slog.Any("error", err))
+	}
+
+	// バックグラウンドジョブとドメインイベントの配信（JOB_WORKER_EMBEDDED=false の場合はcmd/workerで処理する）
+	serverConfig := server.LoadConfig()
+	if err := serverConfig.Auth.Validate(); err != nil {
+		logger.Error("Invalid auth configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if err := serverConfig.Security.Validate(); err != nil {
+		logger.Error("Invalid security configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	jobStore := job.NewPostgresStore(db)
+	jobClient := job.NewClient(jobStore, serverConfig.Jobs)
+	var worker *job.Worker
+	var relay *outbox.Relay
+	backgroundCtx := logging.WithLogger(context.Background(), logger)
+	if serverConfig.Jobs.Embedded {
+		relay, err = server.NewOutboxRelay(db, jobClient, serverConfig)
+		if err != nil {
+			logger.Error("Failed to initialize outbox relay", slog.Any("error", err))
+			os.Exit(1)
+		}
+		worker = job.NewWorker(jobStore, server.NewJobRegistry(db, jobClient, serverConfig), serverConfig.Jobs)
+		worker.Start(backgroundCtx)
+		relay.Start(backgroundCtx)
+	}
+
+	// 会社のアクティビティストリーム（アウトボックスのイベントをSSEの接続に配る）
+	activityHub := activity.NewHub(outbox.NewPostgresStore(db), serverConfig.Activity)
+	activityHub.Start(backgroundCtx)
+
+	// アップロードされたファイルの保存先（ローカルのディレクトリまたはS3互換ストレージ）
+	blobs, err := storage.New(serverConfig.Storage)
+	if err != nil {
+		logger.Error("Failed to initialize storage", slog.Any("error", err))
+		os.Exit(1)
 	}
 
 	// ルーターのセットアップ
-	e := server.SetupRouter(db)
+	healthChecker := health.New(infra.GetEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second))
+	e := server.SetupRouter(db, logger, healthChecker, serverConfig, jobClient, activityHub, blobs)
 
 	// サーバーの起動とグレースフルシャットダウン
 	go func() {
@@ -65,28 +136,83 @@ func main() {
 			port = "8080"
 		}
 		if err := e.Start(":" + port); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatalf("shutting down the server: %v", err)
+			logger.Error("shutting down the server", slog.Any("error", err))
+			os.Exit(1)
 		}
 	}()
 
+	// gRPCサーバー（HTTPとは別のポート）
+	var grpcServer *rpc.Server
+	if serverConfig.GRPC.Enabled {
+		lis, err := net.Listen("tcp", ":"+serverConfig.GRPC.Port)
+		if err != nil {
+			logger.Error("Failed to listen for gRPC", slog.Any("error", err))
+			os.Exit(1)
+		}
+		grpcServer = server.NewGRPCServer(db, logger, serverConfig, blobs)
+		go func() {
+			logger.Info("gRPC server started", slog.String("addr", lis.Addr().String()))
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Error("shutting down the gRPC server", slog.Any("error", err))
+				os.Exit(1)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("Shutting down server...")
+
+	// 接続を閉じる前にレディネスを失敗させ、ロードバランサーに振り分けを止めさせる
+	healthChecker.MarkShuttingDown()
+	if grpcServer != nil {
+		grpcServer.MarkShuttingDown()
+	}
+	time.Sleep(infra.GetEnvDuration("SHUTDOWN_DRAIN_DELAY", 5*time.Second))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// SSEの接続は終了しないためe.Shutdownが待ち続ける。先に購読を終了させ、クライアントに他のサーバーへ再接続させる
+	if err := activityHub.Shutdown(ctx); err != nil {
+		logger.Error("Failed to stop activity hub", slog.Any("error", err))
+	}
+
 	if err := e.Shutdown(ctx); err != nil {
-		e.Logger.Fatal(err)
+		logger.Error("Failed to shut down server", slog.Any("error", err))
 	}
 
-	log.Println("Server gracefully stopped")
+	if grpcServer != nil {
+		grpcCtx, cancelGRPC := context.WithTimeout(context.Background(), serverConfig.GRPC.ShutdownTimeout)
+		if err := grpcServer.Shutdown(grpcCtx); err != nil {
+			logger.Error("Failed to shut down gRPC server", slog.Any("error", err))
+		}
+		cancelGRPC()
+	}
+
+	logger.Info("Server gracefully stopped")
+
+	if worker != nil {
+		workerCtx, cancelWorker := context.WithTimeout(context.Background(), serverConfig.Jobs.ShutdownTimeout)
+		if err := worker.Shutdown(workerCtx); err != nil {
+			logger.Error("Failed to wait for running jobs", slog.Any("error", err))
+		}
+		if err := relay.Shutdown(workerCtx); err != nil {
+			logger.Error("Failed to stop outbox relay", slog.Any("error", err))
+		}
+		cancelWorker()
+	}
 
 	if err := infra.CloseDatabase(db); err != nil {
-		log.Printf("Failed to close database: %v", err)
+		logger.Error("Failed to close database", slog.Any("error", err))
 	}
 
-	log.Println("Database connection closed")
+	// 他の終了処理でタイムアウトした場合もスパンを送信できるよう、専用のタイムアウトを使う
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), tracingConfig.ShutdownTimeout)
+	defer cancelTracing()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		logger.Error("Failed to flush traces", slog.Any("error", err))
+	}
 }
@@ -0,0 +1,87 @@
+// Package main swagで生成したSwagger 2.0の仕様から、OpenAPI 3.1の仕様とGoクライアントを生成する
+//
+// `task docs`（swag init の後）で実行する
+//
+//	go run ./cmd/openapi
+//	go run ./cmd/openapi -spec swagger/v2/v2_swagger.json -out swagger/v2 -client ""
+//
+// 出力:
+//   - swagger/src/openapi.json, swagger/src/openapi.yaml（OpenAPI 3.1）
+//   - pkg/client/api.gen.go（エンドポイントごとの型とメソッド。-client "" で生成しない）
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"km-api-go/internal/clientgen"
+	"km-api-go/internal/openapi"
+)
+
+func main() {
+	specPath := flag.String("spec", "swagger/src/swagger.json", "swagが生成したSwagger 2.0の仕様")
+	outDir := flag.String("out", "swagger/src", "OpenAPI 3.1の仕様の出力先")
+	clientPath := flag.String("client", "pkg/client/api.gen.go", "Goクライアントの出力先（空の場合は生成しない）")
+	flag.Parse()
+
+	if err := run(*specPath, *outDir, *clientPath); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(specPath, outDir, clientPath string) error {
+	spec, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+
+	// OpenAPI 3.1
+	doc, err := openapi.ToV3(spec)
+	if err != nil {
+		return err
+	}
+	v31, err := openapi.MarshalV31(doc)
+	if err != nil {
+		return err
+	}
+	jsonDoc, err := openapi.EncodeJSON(v31)
+	if err != nil {
+		return err
+	}
+	if err := write(filepath.Join(outDir, "openapi.json"), jsonDoc); err != nil {
+		return err
+	}
+	var yamlDoc bytes.Buffer
+	enc := yaml.NewEncoder(&yamlDoc)
+	enc.SetIndent(2)
+	if err := enc.Encode(v31); err != nil {
+		return fmt.Errorf("failed to encode openapi yaml: %w", err)
+	}
+	if err := write(filepath.Join(outDir, "openapi.yaml"), yamlDoc.Bytes()); err != nil {
+		return err
+	}
+
+	// Goクライアント（3.0のドキュメントから生成する。スキーマの内容は3.1と同じ）
+	if clientPath == "" {
+		return nil
+	}
+	src, err := clientgen.Generate(doc, "client")
+	if err != nil {
+		return err
+	}
+	return write(clientPath, src)
+}
+
+func write(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	log.Printf("create %s", path)
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package main KM API のバックグラウンドジョブワーカー（ドメインイベントの配信も行う）
+//
+// APIサーバーとは別プロセスでジョブを処理する場合に使う（APIサーバー側は JOB_WORKER_EMBEDDED=false にする）
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/metrics"
+	"km-api-go/internal/tracing"
+	"km-api-go/server"
+)
+
+func main() {
+	// .envファイル読み込み
+	envErr := godotenv.Overload()
+
+	// ロガー初期化（LOG_LEVEL, LOG_FORMAT）
+	logger := logging.Init()
+	if envErr != nil {
+		logger.Info("No .env file found, using system environment variables")
+	}
+
+	// トレーシング初期化（TRACING_EXPORTER=otlp|stdout|none）
+	tracingConfig := tracing.LoadConfig()
+	shutdownTracing, err := tracing.Init(context.Background(), tracingConfig)
+	if err != nil {
+		logger.Error("Failed to initialize tracing", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	// データベース接続
+	db, err := infra.InitDatabase()
+	if err != nil {
+		logger.Error("Failed to initialize database", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if err := tracing.InstrumentDatabase(db); err != nil {
+		logger.Warn("Failed to instrument database tracing", slog.Any("error", err))
+	}
+	if err := metrics.InstrumentDatabase(db); err != nil {
+		logger.Warn("Failed to instrument database metrics", slog.Any("error", err))
+	}
+
+	// ワーカーとドメインイベントのリレーの起動
+	cfg := server.LoadConfig()
+	store := job.NewPostgresStore(db)
+	client := job.NewClient(store, cfg.Jobs)
+	relay, err := server.NewOutboxRelay(db, client, cfg)
+	if err != nil {
+		logger.Error("Failed to initialize outbox relay", slog.Any("error", err))
+		os.Exit(1)
+	}
+	worker := job.NewWorker(store, server.NewJobRegistry(db, client, cfg), cfg.Jobs)
+
+	backgroundCtx := logging.WithLogger(context.Background(), logger)
+	worker.Start(backgroundCtx)
+	relay.Start(backgroundCtx)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutting down worker...")
+
+	// 新しいジョブの取得を止め、実行中のジョブの終了を待つ
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Jobs.ShutdownTimeout)
+	defer cancel()
+
+	if err := worker.Shutdown(ctx); err != nil {
+		logger.Error("Failed to wait for running jobs", slog.Any("error", err))
+	}
+	if err := relay.Shutdown(ctx); err != nil {
+		logger.Error("Failed to stop outbox relay", slog.Any("error", err))
+	}
+
+	logger.Info("Worker gracefully stopped")
+
+	if err := infra.CloseDatabase(db); err != nil {
+		logger.Error("Failed to close database", slog.Any("error", err))
+	}
+
+	// 他の終了処理でタイムアウトした場合もスパンを送信できるよう、専用のタイムアウトを使う
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), tracingConfig.ShutdownTimeout)
+	defer cancelTracing()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		logger.Error("Failed to flush traces", slog.Any("error", err))
+	}
+}
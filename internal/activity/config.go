@@ -0,0 +1,29 @@
+package activity
+
+import (
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// Config 会社のアクティビティストリーム（SSE）の設定
+type Config struct {
+	PollInterval      time.Duration // 新しいイベントを確認する間隔
+	HeartbeatInterval time.Duration // 接続を維持するためにコメント行を送る間隔
+	HistoryLimit      int           // Last-Event-IDで再開するときに送る履歴の上限（超える場合はresetを送る）
+	BufferSize        int           // 購読ごとに溜められるイベントの数（溢れた接続は切断し、クライアントに再接続させる）
+	CommitGrace       time.Duration // ID順とコミット順の差を待つ時間（この間は小さいIDのイベントも取りこぼさない）
+	RetryInterval     time.Duration // 切断後にクライアントが再接続するまでの時間（retryフィールド）
+}
+
+// LoadConfig 環境変数からアクティビティストリームの設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		PollInterval:      infra.GetEnvDuration("ACTIVITY_POLL_INTERVAL", time.Second),
+		HeartbeatInterval: infra.GetEnvDuration("ACTIVITY_HEARTBEAT_INTERVAL", 15*time.Second),
+		HistoryLimit:      infra.GetEnvInt("ACTIVITY_HISTORY_LIMIT", 100),
+		BufferSize:        infra.GetEnvInt("ACTIVITY_BUFFER_SIZE", 64),
+		CommitGrace:       infra.GetEnvDuration("ACTIVITY_COMMIT_GRACE", 5*time.Second),
+		RetryInterval:     infra.GetEnvDuration("ACTIVITY_RETRY_INTERVAL", 3*time.Second),
+	}
+}
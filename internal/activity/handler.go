@@ -0,0 +1,201 @@
+package activity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/outbox"
+)
+
+// EventReset 履歴を送れないため、最新の状態を取得し直す必要があることを示すイベント
+const EventReset = "reset"
+
+// resetイベントの理由
+const (
+	ResetHistoryLimit     = "history_limit"     // 履歴の上限を超えて離れていた
+	ResetHistoryTruncated = "history_truncated" // 再開するイベントより前の履歴が削除されていた
+)
+
+// ResetEvent resetイベントのdata
+type ResetEvent struct {
+	Reason string `json:"reason" enums:"history_limit,history_truncated" example:"history_truncated"` // 履歴を送れない理由
+}
+
+// Event ストリームで送るイベントのdata
+type Event struct {
+	ID         string          `json:"id" example:"0b6b0c1e-8f4a-4a43-9d1e-2b0c6f3e5a10"` // イベントID
+	Type       string          `json:"type" example:"company.member_added"`               // イベントの種類
+	CompanyID  uint            `json:"company_id" example:"1"`                            // 会社ID
+	Data       json.RawMessage `json:"data" swaggertype:"object"`                         // イベントの内容
+	OccurredAt time.Time       `json:"occurred_at" format:"date-time"`                    // 発生日時
+}
+
+// NewEvent アウトボックスのメッセージからイベントを作成
+func NewEvent(msg outbox.Message) Event {
+	return Event{
+		ID:         msg.EventID,
+		Type:       msg.Type,
+		CompanyID:  msg.AggregateID,
+		Data:       msg.Payload,
+		OccurredAt: msg.OccurredAt,
+	}
+}
+
+type ActivityHandler struct {
+	usecase ActivityUsecase
+	cfg     Config
+}
+
+func NewActivityHandler(usecase ActivityUsecase, cfg Config) *ActivityHandler {
+	return &ActivityHandler{usecase: usecase, cfg: cfg}
+}
+
+// Stream godoc
+// @Summary 会社のアクティビティストリーム
+// @ID streamCompanyEvents
+// @Description 会社情報の更新・メンバーの追加・ロール変更・削除をServer-Sent Eventsで配信します。会社に所属するユーザーまたはAPIキーのみ購読できます。
+// @Description 各イベントのidはLast-Event-IDヘッダー（またはlast_event_idクエリ）に渡すと、それ以降のイベントから再開できます。
+// @Description 履歴の上限を超えた・履歴が削除済みで再開できない場合はresetイベント（dataのreasonはhistory_limit・history_truncated）を送るため、最新の状態を取得し直してください。接続の維持のため定期的にコメント行を送ります
+// @Tags companies
+// @Produce text/event-stream
+// @Param id path int true "会社ID"
+// @Param Last-Event-ID header string false "最後に受け取ったイベントのid"
+// @Param last_event_id query string false "最後に受け取ったイベントのid（ヘッダーを指定できない場合）"
+// @Success 200 {object} Event
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 503 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/events [get]
+func (h *ActivityHandler) Stream(c echo.Context) error {
+	var req helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	stream, err := h.usecase.Subscribe(ctx, req.ID, lastEventID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrForbidden):
+			return helper.ForbiddenResponse(c)
+		case errors.Is(err, domain.ErrNotFound):
+			return helper.NotFoundResponse(c, "")
+		case errors.Is(err, ErrHubClosed):
+			return helper.ErrorResponse(c, http.StatusServiceUnavailable, helper.ErrorCodeServiceUnavailable, "サーバーを停止しています", "")
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to subscribe activity", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+	defer h.usecase.Unsubscribe(stream)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // リバースプロキシでバッファさせない
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", h.cfg.RetryInterval.Milliseconds()); err != nil {
+		return nil
+	}
+	if stream.ResetID != 0 {
+		data, err := json.Marshal(ResetEvent{Reason: stream.ResetReason})
+		if err != nil {
+			return nil
+		}
+		if err := writeEvent(res, strconv.FormatUint(uint64(stream.ResetID), 10), EventReset, data); err != nil {
+			return nil
+		}
+	}
+
+	// 購読の開始から履歴の取得までのイベントは履歴と重複するため、送信済みのIDを除く
+	sent := make(map[uint]struct{}, len(stream.History))
+	for _, msg := range stream.History {
+		if err := writeMessage(res, msg); err != nil {
+			return nil
+		}
+		sent[msg.ID] = struct{}{}
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-stream.Closed():
+			// シャットダウン・バッファ溢れ。クライアントはLast-Event-IDで再接続する
+			return nil
+		case msg := <-stream.Events():
+			if _, ok := sent[msg.ID]; ok {
+				continue
+			}
+			if err := writeMessage(res, msg); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+// parseLastEventID Last-Event-IDヘッダー（ない場合はlast_event_idクエリ）を取得
+func parseLastEventID(c echo.Context) (uint, error) {
+	value := c.Request().Header.Get(helper.HeaderLastEventID)
+	if value == "" {
+		value = c.QueryParam("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", helper.HeaderLastEventID, value)
+	}
+	return uint(id), nil
+}
+
+func writeMessage(w io.Writer, msg outbox.Message) error {
+	data, err := json.Marshal(NewEvent(msg))
+	if err != nil {
+		return err
+	}
+	return writeEvent(w, strconv.FormatUint(uint64(msg.ID), 10), msg.Type, data)
+}
+
+// writeEvent SSEのイベントを1件書き込む（dataは改行を含まないJSON）
+func writeEvent(w io.Writer, id, event string, data []byte) error {
+	frame := []byte("id: " + id + "\nevent: " + event + "\n")
+	frame = append(frame, "data: "...)
+	frame = append(frame, data...)
+	frame = append(frame, "\n\n"...)
+	_, err := w.Write(frame)
+	return err
+}
@@ -0,0 +1,218 @@
+package activity
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/outbox"
+)
+
+// sseEvent 受信したSSEのイベント
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readEvent コメント行・retryを読み飛ばして次のイベントを読み込む
+func readEvent(t *testing.T, r *bufio.Reader) sseEvent {
+	t.Helper()
+
+	var ev sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			if ev.Event != "" {
+				return ev
+			}
+		case strings.HasPrefix(line, "id: "):
+			ev.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			ev.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			ev.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func newTestServer(t *testing.T, uc ActivityUsecase, principal *auth.Principal) *httptest.Server {
+	e := echo.New()
+	e.Validator = helper.NewValidator()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), principal)))
+			return next(c)
+		}
+	})
+	e.GET("/companies/:id/events", NewActivityHandler(uc, testConfig()).Stream)
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestActivityHandler_Stream(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	companyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+	companyUserRepo := companyMocks.NewMockCompanyUserRepository(ctrl)
+	companyUserRepo.EXPECT().GetRelation(gomock.Any(), uint(1), uint(1)).
+		Return(&domain.CompanyUser{UserID: 1, CompanyID: 1, Role: "member"}, nil)
+
+	store := outbox.NewMemoryStore()
+	events := outbox.New(store)
+	require.NoError(t, events.Record(ctx,
+		domain.MemberAdded{CompanyID: 1, UserID: 1, Role: "admin"},
+		domain.MemberAdded{CompanyID: 1, UserID: 2, Role: "member"},
+	))
+
+	hub := NewHub(store, testConfig())
+	_, err := hub.Poll(ctx)
+	require.NoError(t, err)
+
+	uc := NewActivityUsecase(hub, companyRepo, companyUserRepo)
+	srv := newTestServer(t, uc, &auth.Principal{UserID: 1})
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/companies/1/events", nil)
+	require.NoError(t, err)
+	req.Header.Set(helper.HeaderLastEventID, "1")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+	body := bufio.NewReader(res.Body)
+
+	// Last-Event-IDより後の履歴
+	ev := readEvent(t, body)
+	assert.Equal(t, "2", ev.ID)
+	assert.Equal(t, domain.EventMemberAdded, ev.Event)
+
+	var data Event
+	require.NoError(t, json.Unmarshal([]byte(ev.Data), &data))
+	assert.Equal(t, uint(1), data.CompanyID)
+	assert.JSONEq(t, `{"company_id":1,"user_id":2,"role":"member"}`, string(data.Data))
+
+	// 購読後のイベント（他の会社のイベントは届かない）
+	require.NoError(t, events.Record(ctx,
+		domain.CompanyUpdated{CompanyID: 2, Name: "Other"},
+		domain.MemberRemoved{CompanyID: 1, UserID: 2},
+	))
+	_, err = hub.Poll(ctx)
+	require.NoError(t, err)
+
+	ev = readEvent(t, body)
+	assert.Equal(t, "4", ev.ID)
+	assert.Equal(t, domain.EventMemberRemoved, ev.Event)
+
+	// シャットダウンでストリームを終了する
+	require.NoError(t, hub.Shutdown(ctx))
+	_, err = body.ReadString('\n')
+	assert.Error(t, err)
+}
+
+func TestActivityHandler_Stream_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		lastEventID string
+		setupMock   func(companyUserRepo *companyMocks.MockCompanyUserRepository)
+		wantStatus  int
+	}{
+		{
+			name: "異常系: 会社に所属していない",
+			path: "/companies/1/events",
+			setupMock: func(companyUserRepo *companyMocks.MockCompanyUserRepository) {
+				companyUserRepo.EXPECT().GetRelation(gomock.Any(), uint(1), uint(1)).Return(nil, domain.ErrNotFound)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "異常系: Last-Event-IDが数値でない",
+			path:        "/companies/1/events",
+			lastEventID: "abc",
+			setupMock:   func(companyUserRepo *companyMocks.MockCompanyUserRepository) {},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "異常系: 会社IDが不正",
+			path:       "/companies/0/events",
+			setupMock:  func(companyUserRepo *companyMocks.MockCompanyUserRepository) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			companyUserRepo := companyMocks.NewMockCompanyUserRepository(ctrl)
+			tt.setupMock(companyUserRepo)
+
+			hub := NewHub(outbox.NewMemoryStore(), testConfig())
+			uc := NewActivityUsecase(hub, companyMocks.NewMockCompanyRepository(ctrl), companyUserRepo)
+			srv := newTestServer(t, uc, &auth.Principal{UserID: 1})
+
+			req, err := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.lastEventID != "" {
+				req.Header.Set(helper.HeaderLastEventID, tt.lastEventID)
+			}
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestActivityHandler_Stream_Reset(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	companyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+	companyRepo.EXPECT().Exists(gomock.Any(), uint(1)).Return(true, nil)
+
+	store := outbox.NewMemoryStore()
+	events := outbox.New(store)
+	for i := uint(1); i <= 12; i++ {
+		require.NoError(t, events.Record(ctx, domain.MemberAdded{CompanyID: 1, UserID: i, Role: "member"}))
+	}
+
+	hub := NewHub(store, testConfig())
+	uc := NewActivityUsecase(hub, companyRepo, companyMocks.NewMockCompanyUserRepository(ctrl))
+	srv := newTestServer(t, uc, &auth.Principal{APIKey: "dashboard"})
+
+	// 履歴の上限（10件）を超えて離れていた
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/companies/1/events?last_event_id=1", nil)
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	ev := readEvent(t, bufio.NewReader(res.Body))
+	assert.Equal(t, EventReset, ev.Event)
+	assert.Equal(t, "12", ev.ID)
+	assert.JSONEq(t, `{"reason":"history_limit"}`, ev.Data)
+
+	require.NoError(t, hub.Shutdown(ctx))
+}
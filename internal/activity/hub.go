@@ -0,0 +1,297 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/outbox"
+)
+
+// EventTypes アクティビティストリームで配信するイベントの種類
+var EventTypes = []string{
+	domain.EventCompanyUpdated,
+	domain.EventCompanyDeleted,
+	domain.EventMemberAdded,
+	domain.EventRoleChanged,
+	domain.EventMemberRemoved,
+}
+
+// ErrHubClosed シャットダウン中のため購読できない
+var ErrHubClosed = errors.New("activity hub is closed")
+
+// 1回の確認で読み込む新しいイベントの上限
+const pollBatchSize = 500
+
+// Subscription 会社のアクティビティの購読
+type Subscription struct {
+	CompanyID uint
+	events    chan outbox.Message
+	closed    chan struct{}
+	once      sync.Once
+}
+
+// Events 購読した会社の新しいイベント
+func (s *Subscription) Events() <-chan outbox.Message {
+	return s.events
+}
+
+// Closed シャットダウン・バッファ溢れで購読が終了すると閉じる
+func (s *Subscription) Closed() <-chan struct{} {
+	return s.closed
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.closed) })
+}
+
+// Hub アウトボックスに保存されたイベントを読み込み、会社ごとの購読者に配る
+// アウトボックスはAPIサーバー間で共有されるため、どのインスタンスに接続しても全てのイベントを受け取れる
+type Hub struct {
+	reader outbox.Reader
+	cfg    Config
+	now    func() time.Time
+
+	mu      sync.Mutex
+	subs    map[uint]map[*Subscription]struct{}
+	started bool
+	closed  bool
+
+	// floor 以下のIDは処理済み。seenはfloorより大きい処理済みのIDと処理した日時
+	// IDは採番順でコミットされるとは限らないため、CommitGraceの間はfloorを上げずに小さいIDを待つ
+	ready bool
+	floor uint
+	seen  map[uint]time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewHub Hubのコンストラクタ
+func NewHub(reader outbox.Reader, cfg Config) *Hub {
+	return &Hub{
+		reader: reader,
+		cfg:    cfg,
+		now:    time.Now,
+		subs:   make(map[uint]map[*Subscription]struct{}),
+		seen:   make(map[uint]time.Time),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start バックグラウンドで新しいイベントの確認を開始
+func (h *Hub) Start(ctx context.Context) {
+	h.mu.Lock()
+	h.started = true
+	h.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	go h.run(ctx)
+	logging.FromContext(ctx).InfoContext(ctx, "activity hub started")
+}
+
+// Shutdown 確認を止め、全ての購読を終了する（接続中のストリームはクライアントに再接続させる）
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	for _, subs := range h.subs {
+		for sub := range subs {
+			sub.close()
+		}
+	}
+	h.subs = make(map[uint]map[*Subscription]struct{})
+	started := h.started
+	h.mu.Unlock()
+
+	close(h.stop)
+	if !started {
+		return nil
+	}
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe 会社のアクティビティを購読する
+func (h *Hub) Subscribe(companyID uint) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	sub := &Subscription{
+		CompanyID: companyID,
+		events:    make(chan outbox.Message, h.cfg.BufferSize),
+		closed:    make(chan struct{}),
+	}
+	if h.subs[companyID] == nil {
+		h.subs[companyID] = make(map[*Subscription]struct{})
+	}
+	h.subs[companyID][sub] = struct{}{}
+	return sub, nil
+}
+
+// Unsubscribe 購読を終了する
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(sub)
+}
+
+// History 会社のafterIDより後のイベントを古い順に取得
+// 途中のイベントを送れない場合（削除済み・上限を超える）は、履歴の代わりにリセットの理由を返す
+func (h *Hub) History(ctx context.Context, companyID, afterID uint) (_ []outbox.Message, reset string, err error) {
+	// afterIDの次のイベントより後から残っている場合、途中のイベントが削除された可能性がある
+	oldest, err := h.reader.OldestID(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get oldest outbox message id: %w", err)
+	}
+	if oldest > afterID+1 {
+		return nil, ResetHistoryTruncated, nil
+	}
+
+	msgs, err := h.reader.List(ctx, outbox.ListFilter{
+		AggregateType: domain.AggregateCompany,
+		AggregateID:   companyID,
+		AfterID:       afterID,
+		Limit:         h.cfg.HistoryLimit + 1,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get activity history: %w", err)
+	}
+	if len(msgs) > h.cfg.HistoryLimit {
+		return nil, ResetHistoryLimit, nil
+	}
+
+	history := make([]outbox.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if slices.Contains(EventTypes, msg.Type) {
+			history = append(history, msg)
+		}
+	}
+	return history, "", nil
+}
+
+func (h *Hub) run(ctx context.Context) {
+	defer close(h.done)
+
+	ticker := time.NewTicker(h.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := h.Poll(ctx)
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to poll activity", slog.Any("error", err))
+		}
+
+		// バッチが埋まった場合は待たずに続ける
+		if err == nil && n >= pollBatchSize {
+			select {
+			case <-h.stop:
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll 新しいイベントを読み込み、購読者に配る。新しく処理したイベントの件数を返す
+// 最初の呼び出しでは起動前のイベントを配らないよう、最新のIDを記録するだけ
+func (h *Hub) Poll(ctx context.Context) (int, error) {
+	if !h.ready {
+		id, err := h.reader.LatestID(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get latest outbox message id: %w", err)
+		}
+		h.floor = id
+		h.ready = true
+		return 0, nil
+	}
+
+	// 処理済みのIDも含めて読み直すため、上限にその件数を足す
+	msgs, err := h.reader.List(ctx, outbox.ListFilter{
+		AggregateType: domain.AggregateCompany,
+		AfterID:       h.floor,
+		Limit:         pollBatchSize + len(h.seen),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	now := h.now()
+	var n int
+	for _, msg := range msgs {
+		if _, ok := h.seen[msg.ID]; ok {
+			continue
+		}
+		h.seen[msg.ID] = now
+		n++
+		if slices.Contains(EventTypes, msg.Type) {
+			h.broadcast(ctx, msg)
+		}
+	}
+
+	// CommitGraceより前に処理したIDまでfloorを上げる
+	for id, seenAt := range h.seen {
+		if now.Sub(seenAt) >= h.cfg.CommitGrace && id > h.floor {
+			h.floor = id
+		}
+	}
+	for id := range h.seen {
+		if id <= h.floor {
+			delete(h.seen, id)
+		}
+	}
+	return n, nil
+}
+
+// broadcast 会社の購読者にイベントを配る
+// バッファが溢れた購読者は終了させ、クライアントにLast-Event-IDで再接続させる
+func (h *Hub) broadcast(ctx context.Context, msg outbox.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs[msg.AggregateID] {
+		select {
+		case sub.events <- msg:
+		default:
+			logging.FromContext(ctx).WarnContext(ctx, "activity subscriber is too slow, closing",
+				slog.Uint64("company_id", uint64(msg.AggregateID)),
+			)
+			h.remove(sub)
+			sub.close()
+		}
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	subs := h.subs[sub.CompanyID]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subs, sub.CompanyID)
+	}
+}
@@ -0,0 +1,275 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/outbox"
+)
+
+func testConfig() Config {
+	return Config{
+		PollInterval:      time.Hour,
+		HeartbeatInterval: time.Hour,
+		HistoryLimit:      10,
+		BufferSize:        10,
+		CommitGrace:       5 * time.Second,
+		RetryInterval:     time.Second,
+	}
+}
+
+// hidingReader コミット前のメッセージを見えなくするReader（ID順とコミット順の差の再現用）
+type hidingReader struct {
+	*outbox.MemoryStore
+	hidden map[uint]bool
+}
+
+func (r *hidingReader) List(ctx context.Context, filter outbox.ListFilter) ([]outbox.Message, error) {
+	msgs, err := r.MemoryStore.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	visible := msgs[:0]
+	for _, msg := range msgs {
+		if !r.hidden[msg.ID] {
+			visible = append(visible, msg)
+		}
+	}
+	return visible, nil
+}
+
+func receive(sub *Subscription) []string {
+	var types []string
+	for {
+		select {
+		case msg := <-sub.Events():
+			types = append(types, msg.Type)
+		default:
+			return types
+		}
+	}
+}
+
+func TestHub_Poll(t *testing.T) {
+	ctx := context.Background()
+	store := outbox.NewMemoryStore()
+	events := outbox.New(store)
+
+	// 起動前のイベントは配らない
+	require.NoError(t, events.Record(ctx, domain.MemberAdded{CompanyID: 1, UserID: 9, Role: "member"}))
+
+	hub := NewHub(store, testConfig())
+	now := time.Unix(1757246400, 0)
+	hub.now = func() time.Time { return now }
+
+	n, err := hub.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	sub1, err := hub.Subscribe(1)
+	require.NoError(t, err)
+	sub2, err := hub.Subscribe(2)
+	require.NoError(t, err)
+
+	require.NoError(t, events.Record(ctx,
+		domain.MemberAdded{CompanyID: 1, UserID: 1, Role: "admin"},
+		domain.UserCreated{UserID: 1},
+		domain.CompanyUpdated{CompanyID: 2, Name: "Acme"},
+		domain.CompanyCreated{CompanyID: 1},
+	))
+
+	n, err = hub.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n) // ユーザーのイベントは読み込まない
+	assert.Equal(t, []string{domain.EventMemberAdded}, receive(sub1))
+	assert.Equal(t, []string{domain.EventCompanyUpdated}, receive(sub2))
+
+	// 処理済みのイベントは再度配らない
+	n, err = hub.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Empty(t, receive(sub1))
+
+	// CommitGraceを過ぎるとfloorを上げる
+	now = now.Add(10 * time.Second)
+	_, err = hub.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), hub.floor)
+	assert.Empty(t, hub.seen)
+
+	hub.Unsubscribe(sub1)
+	require.NoError(t, events.Record(ctx, domain.MemberRemoved{CompanyID: 1, UserID: 1}))
+	_, err = hub.Poll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, receive(sub1))
+}
+
+func TestHub_Poll_CommitOrder(t *testing.T) {
+	ctx := context.Background()
+	reader := &hidingReader{MemoryStore: outbox.NewMemoryStore(), hidden: map[uint]bool{}}
+	events := outbox.New(reader.MemoryStore)
+
+	hub := NewHub(reader, testConfig())
+	now := time.Unix(1757246400, 0)
+	hub.now = func() time.Time { return now }
+	_, err := hub.Poll(ctx)
+	require.NoError(t, err)
+
+	sub, err := hub.Subscribe(1)
+	require.NoError(t, err)
+
+	// ID 1がコミットされる前にID 2がコミットされた
+	require.NoError(t, events.Record(ctx,
+		domain.MemberAdded{CompanyID: 1, UserID: 1, Role: "admin"},
+		domain.MemberAdded{CompanyID: 1, UserID: 2, Role: "member"},
+	))
+	reader.hidden[1] = true
+
+	_, err = hub.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{domain.EventMemberAdded}, receive(sub))
+
+	// CommitGrace内にコミットされたID 1も配る
+	now = now.Add(time.Second)
+	delete(reader.hidden, 1)
+	n, err := hub.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	msg := <-sub.Events()
+	assert.Equal(t, uint(1), msg.ID)
+}
+
+func TestHub_SlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	store := outbox.NewMemoryStore()
+	events := outbox.New(store)
+
+	cfg := testConfig()
+	cfg.BufferSize = 1
+	hub := NewHub(store, cfg)
+	_, err := hub.Poll(ctx)
+	require.NoError(t, err)
+
+	sub, err := hub.Subscribe(1)
+	require.NoError(t, err)
+
+	require.NoError(t, events.Record(ctx,
+		domain.MemberAdded{CompanyID: 1, UserID: 1, Role: "admin"},
+		domain.MemberAdded{CompanyID: 1, UserID: 2, Role: "member"},
+	))
+	_, err = hub.Poll(ctx)
+	require.NoError(t, err)
+
+	select {
+	case <-sub.Closed():
+	default:
+		t.Fatal("slow subscriber should be closed")
+	}
+	assert.Empty(t, hub.subs)
+}
+
+func TestHub_History(t *testing.T) {
+	ctx := context.Background()
+	store := outbox.NewMemoryStore()
+	events := outbox.New(store)
+	require.NoError(t, events.Record(ctx,
+		domain.CompanyCreated{CompanyID: 1},
+		domain.MemberAdded{CompanyID: 1, UserID: 1, Role: "admin"},
+		domain.MemberAdded{CompanyID: 2, UserID: 1, Role: "admin"},
+		domain.RoleChanged{CompanyID: 1, UserID: 1, OldRole: "admin", NewRole: "member"},
+	))
+
+	cfg := testConfig()
+	cfg.HistoryLimit = 2
+	hub := NewHub(store, cfg)
+
+	tests := []struct {
+		name      string
+		afterID   uint
+		wantIDs   []uint
+		wantReset string
+	}{
+		{name: "正常系: 上限以内の履歴を返す", afterID: 1, wantIDs: []uint{2, 4}},
+		{name: "正常系: 最新のイベントまで受け取っている", afterID: 4, wantIDs: []uint{}},
+		{name: "異常系: 上限を超える場合は再開できない", afterID: 0, wantReset: ResetHistoryLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, reset, err := hub.History(ctx, 1, tt.afterID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReset, reset)
+
+			if tt.wantReset == "" {
+				ids := []uint{}
+				for _, msg := range history {
+					ids = append(ids, msg.ID)
+				}
+				assert.Equal(t, tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestHub_History_Truncated(t *testing.T) {
+	ctx := context.Background()
+	store := outbox.NewMemoryStore()
+	events := outbox.New(store)
+	require.NoError(t, events.Record(ctx,
+		domain.CompanyCreated{CompanyID: 1},
+		domain.MemberAdded{CompanyID: 1, UserID: 1, Role: "admin"},
+		domain.MemberAdded{CompanyID: 1, UserID: 2, Role: "member"},
+	))
+	published := time.Now()
+	_, err := store.Dispatch(ctx, 10, func(_ context.Context, msg *outbox.Message) { msg.PublishedAt = &published })
+	require.NoError(t, err)
+	hub := NewHub(store, testConfig())
+
+	// 配信済みのイベント（ID=1〜3）を削除し、以降のイベントだけが残っている
+	_, err = store.Cleanup(ctx, published)
+	require.NoError(t, err)
+	require.NoError(t, events.Record(ctx, domain.RoleChanged{CompanyID: 1, UserID: 2, OldRole: "member", NewRole: "admin"}))
+
+	tests := []struct {
+		name      string
+		afterID   uint
+		wantReset string
+	}{
+		{name: "正常系: 削除されたのが受け取り済みのイベントのみなら続きを返す", afterID: 3},
+		{name: "異常系: 受け取っていないイベントが削除されていれば再開できない", afterID: 2, wantReset: ResetHistoryTruncated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, reset, err := hub.History(ctx, 1, tt.afterID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReset, reset)
+			if tt.wantReset == "" {
+				require.Len(t, history, 1)
+				assert.Equal(t, uint(4), history[0].ID)
+			}
+		})
+	}
+}
+
+func TestHub_Shutdown(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub(outbox.NewMemoryStore(), testConfig())
+	hub.Start(ctx)
+
+	sub, err := hub.Subscribe(1)
+	require.NoError(t, err)
+
+	require.NoError(t, hub.Shutdown(ctx))
+	<-sub.Closed()
+
+	_, err = hub.Subscribe(1)
+	assert.ErrorIs(t, err, ErrHubClosed)
+	assert.NoError(t, hub.Shutdown(ctx))
+}
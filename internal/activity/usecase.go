@@ -0,0 +1,125 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"km-api-go/internal/auth"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/tracing"
+)
+
+// Stream 購読の開始時点で送るイベントと、それ以降のイベントの購読
+type Stream struct {
+	*Subscription
+	History []outbox.Message // Last-Event-IDより後のイベント（古い順）
+	ResetID uint             // 0以外の場合、履歴を送れないため最新の状態を取得し直させる（このIDから再開させる）
+	// ResetReason 履歴を送れない理由（ResetHistoryLimit, ResetHistoryTruncated）
+	ResetReason string
+}
+
+// ActivityUsecase 会社のアクティビティストリームに関するビジネスロジック
+// 会社に所属するユーザー（ロールは問わない）またはAPIキーのみ購読できる
+type ActivityUsecase interface {
+	// Subscribe 会社のアクティビティを購読する。lastEventIDが0以外の場合、それより後の履歴も返す
+	// 購読が不要になったら Unsubscribe を呼ぶ
+	Subscribe(ctx context.Context, companyID, lastEventID uint) (*Stream, error)
+	Unsubscribe(stream *Stream)
+}
+
+type activityUsecase struct {
+	hub             *Hub
+	companyRepo     companyRepo.CompanyRepository
+	companyUserRepo companyRepo.CompanyUserRepository
+}
+
+// NewActivityUsecase アクティビティユースケースのコンストラクタ
+func NewActivityUsecase(
+	hub *Hub,
+	companyRepository companyRepo.CompanyRepository,
+	companyUserRepository companyRepo.CompanyUserRepository,
+) ActivityUsecase {
+	return &activityUsecase{
+		hub:             hub,
+		companyRepo:     companyRepository,
+		companyUserRepo: companyUserRepository,
+	}
+}
+
+// authorize 呼び出し元が会社のアクティビティを購読できるか確認
+func (uc *activityUsecase) authorize(ctx context.Context, companyID uint) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrForbidden
+	}
+
+	if !principal.IsUser() {
+		exists, err := uc.companyRepo.Exists(ctx, companyID)
+		if err != nil {
+			return fmt.Errorf("failed to check company existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
+		}
+		return nil
+	}
+
+	_, err := uc.companyUserRepo.GetRelation(ctx, principal.UserID, companyID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrForbidden
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get company relation: %w", err)
+	}
+	return nil
+}
+
+func (uc *activityUsecase) Subscribe(ctx context.Context, companyID, lastEventID uint) (_ *Stream, err error) {
+	ctx, span := tracing.Start(ctx, "ActivityUsecase.Subscribe")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	// 履歴の取得中に発生したイベントを取りこぼさないよう、先に購読する
+	sub, err := uc.hub.Subscribe(companyID)
+	if err != nil {
+		return nil, err
+	}
+	stream := &Stream{Subscription: sub}
+
+	if lastEventID != 0 {
+		history, reset, err := uc.hub.History(ctx, companyID, lastEventID)
+		if err != nil {
+			uc.hub.Unsubscribe(sub)
+			return nil, err
+		}
+		stream.History = history
+		stream.ResetReason = reset
+		if reset != "" {
+			if stream.ResetID, err = uc.hub.reader.LatestID(ctx); err != nil {
+				uc.hub.Unsubscribe(sub)
+				return nil, fmt.Errorf("failed to get latest outbox message id: %w", err)
+			}
+		}
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "activity stream subscribed",
+		slog.Uint64("company_id", uint64(companyID)),
+		slog.Uint64("last_event_id", uint64(lastEventID)),
+		slog.Int("history", len(stream.History)),
+		slog.Uint64("reset_id", uint64(stream.ResetID)),
+		slog.String("reset_reason", stream.ResetReason),
+	)
+	return stream, nil
+}
+
+func (uc *activityUsecase) Unsubscribe(stream *Stream) {
+	uc.hub.Unsubscribe(stream.Subscription)
+}
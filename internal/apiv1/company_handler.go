@@ -0,0 +1,103 @@
+package apiv1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/company"
+)
+
+// CompanyHandler 会社のハンドラー（v1）
+type CompanyHandler struct {
+	companies *company.CompanyHandler
+}
+
+func NewCompanyHandler(usecase company.CompanyUsecase, expand company.Expander) *CompanyHandler {
+	return &CompanyHandler{companies: company.NewCompanyHandler(usecase, expand)}
+}
+
+// CreateCompany godoc
+// @Summary 会社作成
+// @ID createCompany
+// @Description 新しい会社を作成します
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param company body company.CreateCompanyRequest true "会社情報"
+// @Success 201 {object} helper.APIResponse{data=company.CompanyResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies [post]
+func (h *CompanyHandler) CreateCompany(c echo.Context) error {
+	return h.companies.CreateCompany(c)
+}
+
+// GetCompany godoc
+// @Summary 会社取得
+// @ID getCompany
+// @Description 指定したIDの会社を取得します。ETagを返し、If-None-Matchが一致する場合は304を返します。fieldsで返すフィールドを選択でき（idは常に返します）、include=membersで所属するユーザー、include=members.userでユーザーの情報も展開します。includeを指定した場合は304を返しません。所属していない会社は404を返します
+// @Tags companies
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param fields query string false "返すフィールド（カンマ区切り）"
+// @Param include query string false "展開する関連（カンマ区切り）: members, members.user"
+// @Param If-None-Match header string false "取得済みのETag"
+// @Success 200 {object} helper.APIResponse{data=company.CompanyResponse}
+// @Success 304 "Not Modified"
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id} [get]
+func (h *CompanyHandler) GetCompany(c echo.Context) error {
+	return h.companies.GetCompany(c)
+}
+
+// UpdateCompany godoc
+// @Summary 会社更新
+// @ID updateCompany
+// @Description 指定したIDの会社を更新します。取得時のETagをIf-Matchに指定する必要があります。会社の管理者とAPIキーのみ操作できます
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param If-Match header string true "取得時のETag"
+// @Param company body company.UpdateCompanyRequest true "会社情報"
+// @Success 200 {object} helper.APIResponse{data=company.CompanyResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 412 {object} helper.APIResponse
+// @Failure 428 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id} [put]
+func (h *CompanyHandler) UpdateCompany(c echo.Context) error {
+	return h.companies.UpdateCompany(c)
+}
+
+// PatchCompany godoc
+// @Summary 会社部分更新
+// @ID patchCompany
+// @Description 指定したIDの会社を部分更新します。application/merge-patch+json（RFC 7396）または application/json-patch+json（RFC 6902）を受け付け、変更されたフィールドのみ保存します。取得時のETagをIf-Matchに指定する必要があります。会社の管理者とAPIキーのみ操作できます
+// @Tags companies
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param If-Match header string true "取得時のETag"
+// @Param patch body interface{} true "パッチ（JSON Merge Patch / JSON Patch）"
+// @Success 200 {object} helper.APIResponse{data=company.CompanyResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 412 {object} helper.APIResponse
+// @Failure 415 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 428 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id} [patch]
+func (h *CompanyHandler) PatchCompany(c echo.Context) error {
+	return h.companies.PatchCompany(c)
+}
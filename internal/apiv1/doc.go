@@ -0,0 +1,5 @@
+// Package apiv1 /api/v1 のユーザー・会社のハンドラー
+//
+// 処理は internal/user, internal/company のハンドラーに委ね、v1の仕様（swagのアノテーション）だけを定義する。
+// 仕様の全般情報は cmd/api/main.go に記載する。v1は非推奨で、レスポンスにDeprecation・Sunsetヘッダーを付ける
+package apiv1
@@ -0,0 +1,29 @@
+// Package apiv2 /api/v2 のハンドラーとレスポンス（DTO）
+//
+// v1とユースケースを共有し、レスポンスの形式だけが異なるリソース（ユーザー・会社）を定義する。
+// それ以外のエンドポイントはv1と同じハンドラーを /api/v2 にも登録する
+//
+// @title KM API
+// @version 2.0
+// @description KM API for Go backend application（v2）
+// @termsOfService http://swagger.io/terms/
+//
+// @contact.name API Support
+// @contact.url http://www.swagger.io/support
+// @contact.email support@swagger.io
+//
+// @license.name Apache 2.0
+// @license.url http://www.apache.org/licenses/LICENSE-2.0.html
+//
+// @host localhost:8080
+// @BasePath /api/v2
+//
+// @schemes http https
+//
+// @tag.name users
+// @tag.description ユーザー関連のAPI
+//
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+package apiv2
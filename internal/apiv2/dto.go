@@ -0,0 +1,108 @@
+package apiv2
+
+import (
+	"time"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/user"
+)
+
+// UserResponse ユーザー（v2）
+// v1に加えて作成・更新日時（ISO 8601・UTC）と所属する会社を返す
+type UserResponse struct {
+	ID          uint                 `json:"id" example:"1"`                     // ユーザーID
+	Name        string               `json:"name" example:"山田太郎"`                // ユーザー名
+	Email       string               `json:"email" example:"yamada@example.com"` // メールアドレス
+	DisplayName string               `json:"display_name" example:"やまだ"`         // 表示名（未設定の場合は空）
+	KanaName    string               `json:"kana_name" example:"ヤマダ タロウ"`        // フリガナ
+	Locale      string               `json:"locale" example:"ja-JP"`             // 言語（BCP 47）
+	Timezone    string               `json:"timezone" example:"Asia/Tokyo"`      // タイムゾーン（IANA）
+	Bio         string               `json:"bio" example:"営業部で法人営業を担当しています"`     // 自己紹介
+	Avatar      *user.AvatarResponse `json:"avatar"`                             // アバター画像の署名付きURL（未設定の場合はnull）
+	Version     uint                 `json:"version" example:"1"`                // バージョン（If-Matchに指定する）
+	CreatedAt   time.Time            `json:"created_at" format:"date-time"`      // 作成日時
+	UpdatedAt   time.Time            `json:"updated_at" format:"date-time"`      // 更新日時
+	Memberships []UserMembership     `json:"memberships"`                        // 所属する会社
+}
+
+// UserMembership ユーザーが所属する会社
+type UserMembership struct {
+	CompanyID uint      `json:"company_id" example:"1"`       // 会社ID
+	Role      string    `json:"role" example:"admin"`         // 役割（admin, member）
+	JoinedAt  time.Time `json:"joined_at" format:"date-time"` // 所属日時
+}
+
+// NewUserResponse ドメインモデルと所属からレスポンスを作成
+func NewUserResponse(u *domain.User, memberships []domain.CompanyUser, avatar *domain.AvatarURLs) UserResponse {
+	res := UserResponse{
+		ID:          u.ID,
+		Name:        u.Name,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		KanaName:    u.KanaName,
+		Locale:      u.Locale,
+		Timezone:    u.Timezone,
+		Bio:         u.Bio,
+		Avatar:      user.NewAvatarResponse(avatar),
+		Version:     u.Version,
+		CreatedAt:   u.CreatedAt.UTC(),
+		UpdatedAt:   u.UpdatedAt.UTC(),
+		Memberships: make([]UserMembership, 0, len(memberships)),
+	}
+	for _, m := range memberships {
+		res.Memberships = append(res.Memberships, UserMembership{
+			CompanyID: m.CompanyID,
+			Role:      m.Role,
+			JoinedAt:  m.CreatedAt.UTC(),
+		})
+	}
+	return res
+}
+
+// CompanyResponse 会社（v2）
+// v1に加えて所属するユーザーを返す。日時はUTCで返す
+type CompanyResponse struct {
+	ID          uint            `json:"id" example:"1"`                          // 会社ID
+	Name        string          `json:"name" example:"株式会社サンプル"`                 // 会社名
+	Email       string          `json:"email" example:"info@sample.co.jp"`       // 会社メールアドレス
+	Phone       string          `json:"phone" example:"03-1234-5678"`            // 電話番号
+	Address     string          `json:"address" example:"東京都渋谷区..."`             // 住所
+	Website     string          `json:"website" example:"https://sample.co.jp"`  // ウェブサイト
+	Description string          `json:"description" example:"IT関連のサービスを提供しています"` // 会社説明
+	Version     uint            `json:"version" example:"1"`                     // バージョン（If-Matchに指定する）
+	CreatedAt   time.Time       `json:"created_at" format:"date-time"`           // 作成日時
+	UpdatedAt   time.Time       `json:"updated_at" format:"date-time"`           // 更新日時
+	Members     []CompanyMember `json:"members"`                                 // 所属するユーザー
+}
+
+// CompanyMember 会社に所属するユーザー
+type CompanyMember struct {
+	UserID   uint      `json:"user_id" example:"1"`          // ユーザーID
+	Role     string    `json:"role" example:"admin"`         // 役割（admin, member）
+	JoinedAt time.Time `json:"joined_at" format:"date-time"` // 所属日時
+}
+
+// NewCompanyResponse ドメインモデルと所属からレスポンスを作成
+func NewCompanyResponse(c *domain.Company, members []domain.CompanyUser) CompanyResponse {
+	res := CompanyResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Email:       c.Email,
+		Phone:       c.Phone,
+		Address:     c.Address,
+		Website:     c.Website,
+		Description: c.Description,
+		Version:     c.Version,
+		CreatedAt:   c.CreatedAt.UTC(),
+		UpdatedAt:   c.UpdatedAt.UTC(),
+		Members:     make([]CompanyMember, 0, len(members)),
+	}
+	for _, m := range members {
+		res.Members = append(res.Members, CompanyMember{
+			UserID:   m.UserID,
+			Role:     m.Role,
+			JoinedAt: m.CreatedAt.UTC(),
+		})
+	}
+	return res
+}
@@ -0,0 +1,355 @@
+package apiv2
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/mocks"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/relation"
+	userMocks "km-api-go/internal/user/mocks"
+)
+
+func TestUserHandler_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	users := userMocks.NewMockUserUsecase(ctrl)
+	companies := companyMocks.NewMockCompanyUsecase(ctrl)
+	handler := NewUserHandler(users, companies, relation.NewExpander(users, companies))
+
+	jst := time.FixedZone("JST", 9*60*60)
+	user := &domain.User{
+		ID:        1,
+		Name:      "山田太郎",
+		Email:     "yamada@example.com",
+		Version:   2,
+		CreatedAt: time.Date(2026, 1, 1, 9, 0, 0, 0, jst),
+		UpdatedAt: time.Date(2026, 1, 2, 9, 0, 0, 0, jst),
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		ifNoneMatch    string
+		viewer         uint
+		setupMock      func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "正常系: 日時（UTC）と所属する会社を含む",
+			setupMock: func() {
+				users.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(user, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return([]domain.CompanyUser{
+					{UserID: 1, CompanyID: 10, Role: "admin", CreatedAt: time.Date(2026, 1, 3, 9, 0, 0, 0, jst)},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"id":1,"name":"山田太郎","email":"yamada@example.com","version":2,
+				"display_name":"","kana_name":"","locale":"","timezone":"","bio":"","avatar":null,
+				"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-02T00:00:00Z",
+				"memberships":[{"company_id":10,"role":"admin","joined_at":"2026-01-03T00:00:00Z"}]}}`,
+		},
+		{
+			name: "正常系: プロフィールとアバター画像の署名付きURLを含む",
+			setupMock: func() {
+				withAvatar := *user
+				withAvatar.DisplayName = "やまだ"
+				withAvatar.Locale = "ja-JP"
+				withAvatar.AvatarKey = "users/1/avatar/abc"
+				withAvatar.AvatarType = "image/png"
+				updatedAt := time.Date(2026, 1, 2, 9, 0, 0, 0, jst)
+				users.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(&withAvatar, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, nil)
+				users.EXPECT().AvatarURLs(gomock.Any(), &withAvatar).Return(&domain.AvatarURLs{
+					Original:  "https://files.example.com/original.png",
+					Small:     "https://files.example.com/small.png",
+					Medium:    "https://files.example.com/medium.png",
+					Large:     "https://files.example.com/large.png",
+					ExpiresAt: time.Date(2026, 1, 2, 10, 0, 0, 0, jst),
+					UpdatedAt: &updatedAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"id":1,"name":"山田太郎","email":"yamada@example.com","version":2,
+				"display_name":"やまだ","kana_name":"","locale":"ja-JP","timezone":"","bio":"",
+				"avatar":{"original":"https://files.example.com/original.png","small":"https://files.example.com/small.png",
+					"medium":"https://files.example.com/medium.png","large":"https://files.example.com/large.png",
+					"expires_at":"2026-01-02T01:00:00Z","updated_at":"2026-01-02T00:00:00Z"},
+				"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-02T00:00:00Z","memberships":[]}}`,
+		},
+		{
+			name: "正常系: 所属がない場合は空の配列",
+			setupMock: func() {
+				users.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(user, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"id":1,"name":"山田太郎","email":"yamada@example.com","version":2,
+				"display_name":"","kana_name":"","locale":"","timezone":"","bio":"","avatar":null,
+				"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-02T00:00:00Z","memberships":[]}}`,
+		},
+		{
+			name:        "正常系: If-None-Matchが一致すれば所属を取得せずに304",
+			ifNoneMatch: `"2"`,
+			setupMock: func() {
+				users.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(user, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:  "正常系: fieldsで指定したフィールドのカラムのみ取得",
+			query: "?fields=name,memberships",
+			setupMock: func() {
+				users.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.User{ID: 1, Name: "山田太郎", Version: 2}, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"success":true,"data":{"id":1,"name":"山田太郎","memberships":[]}}`,
+		},
+		{
+			name:        "正常系: include=companiesで所属する会社を展開し、304は返さない",
+			query:       "?fields=name&include=companies",
+			ifNoneMatch: `"2"`,
+			setupMock: func() {
+				users.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.User{ID: 1, Name: "山田太郎", Version: 2}, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, nil)
+				users.EXPECT().CanView(gomock.Any(), uint(1)).Return(true, nil)
+				companies.EXPECT().GetMembershipsByUserIDs(gomock.Any(), []uint{1}).Return([]domain.CompanyUser{
+					{UserID: 1, CompanyID: 10, Role: "admin", CreatedAt: time.Date(2026, 1, 3, 9, 0, 0, 0, jst)},
+				}, nil)
+				companies.EXPECT().GetCompaniesByIDs(gomock.Any(), []uint{10}).Return([]domain.Company{
+					{ID: 10, Name: "株式会社サンプル", Email: "info@sample.co.jp"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"id":1,"name":"山田太郎","companies":[
+				{"id":10,"name":"株式会社サンプル","email":"info@sample.co.jp","role":"admin","joined_at":"2026-01-03T00:00:00Z"}]}}`,
+		},
+		{
+			name:   "正常系: 他のユーザーの会社は呼び出し元も所属している会社のみ展開",
+			query:  "?fields=name&include=companies",
+			viewer: 2,
+			setupMock: func() {
+				users.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.User{ID: 1, Name: "山田太郎", Version: 2}, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, nil)
+				users.EXPECT().CanView(gomock.Any(), uint(1)).Return(true, nil)
+				companies.EXPECT().GetMembershipsByUserIDs(gomock.Any(), []uint{1}).Return([]domain.CompanyUser{
+					{UserID: 1, CompanyID: 10, Role: "admin", CreatedAt: time.Date(2026, 1, 3, 9, 0, 0, 0, jst)},
+					{UserID: 1, CompanyID: 11, Role: "member", CreatedAt: time.Date(2026, 1, 3, 9, 0, 0, 0, jst)},
+				}, nil)
+				companies.EXPECT().GetMembershipsByUserIDs(gomock.Any(), []uint{2}).Return([]domain.CompanyUser{
+					{UserID: 2, CompanyID: 10, Role: "member"},
+				}, nil)
+				companies.EXPECT().GetCompaniesByIDs(gomock.Any(), []uint{10}).Return([]domain.Company{
+					{ID: 10, Name: "株式会社サンプル", Email: "info@sample.co.jp"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"id":1,"name":"山田太郎","companies":[
+				{"id":10,"name":"株式会社サンプル","email":"info@sample.co.jp","role":"admin","joined_at":"2026-01-03T00:00:00Z"}]}}`,
+		},
+		{
+			name:   "異常系: 同じ会社に所属していないユーザーの会社は展開できない",
+			query:  "?fields=name&include=companies",
+			viewer: 3,
+			setupMock: func() {
+				users.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.User{ID: 1, Name: "山田太郎", Version: 2}, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, nil)
+				users.EXPECT().CanView(gomock.Any(), uint(1)).Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "異常系: 展開できない関連",
+			query:          "?include=webhooks",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: レスポンスにないフィールド",
+			query: "?fields=name,password",
+			setupMock: func() {
+				users.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.User{ID: 1, Name: "山田太郎", Version: 2}, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "異常系: 所属の取得に失敗",
+			setupMock: func() {
+				users.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(user, nil)
+				companies.EXPECT().GetCompaniesByUser(gomock.Any(), uint(1)).Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+
+			viewer := tt.viewer
+			if viewer == 0 {
+				viewer = 1
+			}
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/users/1"+tt.query, nil)
+			req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{UserID: viewer}))
+			if tt.ifNoneMatch != "" {
+				req.Header.Set(helper.HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.setupMock()
+
+			err := handler.GetUser(c)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.expectedBody != "" {
+					assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+					assert.Equal(t, `"2"`, rec.Header().Get(helper.HeaderETag))
+				}
+			}
+		})
+	}
+}
+
+func TestCompanyHandler_CreateCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	companies := companyMocks.NewMockCompanyUsecase(ctrl)
+	handler := NewCompanyHandler(companies, nil)
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	companies.EXPECT().CreateCompany(gomock.Any(), "株式会社サンプル", "info@sample.co.jp", "", "", "", "").
+		Return(&domain.Company{ID: 1, Name: "株式会社サンプル", Email: "info@sample.co.jp", Version: 1, CreatedAt: created, UpdatedAt: created}, nil)
+	companies.EXPECT().GetUsersByCompany(gomock.Any(), uint(1)).Return([]domain.CompanyUser{
+		{UserID: 5, CompanyID: 1, Role: "admin", CreatedAt: created},
+	}, nil)
+
+	e := echo.New()
+	e.Validator = helper.NewValidator()
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/companies", bytes.NewBufferString(`{"name":"株式会社サンプル","email":"info@sample.co.jp"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	err := handler.CreateCompany(e.NewContext(req, rec))
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, `"1"`, rec.Header().Get(helper.HeaderETag))
+		assert.JSONEq(t, `{"success":true,"data":{"id":1,"name":"株式会社サンプル","email":"info@sample.co.jp",
+			"phone":"","address":"","website":"","description":"","version":1,
+			"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z",
+			"members":[{"user_id":5,"role":"admin","joined_at":"2026-01-01T00:00:00Z"}]},
+			"message":"リソースが正常に作成されました"}`, rec.Body.String())
+	}
+}
+
+func TestCompanyHandler_GetCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	users := userMocks.NewMockUserUsecase(ctrl)
+	companies := companyMocks.NewMockCompanyUsecase(ctrl)
+	handler := NewCompanyHandler(companies, relation.NewExpander(users, companies))
+
+	joined := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	members := []domain.CompanyUser{
+		{UserID: 5, CompanyID: 1, Role: "admin", CreatedAt: joined},
+		{UserID: 6, CompanyID: 1, Role: "member", CreatedAt: joined},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: include=members.userで所属するユーザーの情報をまとめて取得",
+			query: "?fields=name&include=members.user",
+			setupMock: func() {
+				companies.EXPECT().GetCompanyByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.Company{ID: 1, Name: "株式会社サンプル", Version: 3}, nil)
+				companies.EXPECT().GetUsersByCompany(gomock.Any(), uint(1)).Return(members, nil)
+				companies.EXPECT().CanView(gomock.Any(), uint(1)).Return(true, nil)
+				companies.EXPECT().GetMembersByCompanyIDs(gomock.Any(), []uint{1}).Return(members, nil)
+				users.EXPECT().GetUsersByIDs(gomock.Any(), []uint{5, 6}).Return([]domain.User{
+					{ID: 5, Name: "山田太郎", Email: "yamada@example.com"},
+					{ID: 6, Name: "佐藤花子", Email: "sato@example.com"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"id":1,"name":"株式会社サンプル","members":[
+				{"user_id":5,"role":"admin","joined_at":"2026-01-01T00:00:00Z","user":{"id":5,"name":"山田太郎","email":"yamada@example.com"}},
+				{"user_id":6,"role":"member","joined_at":"2026-01-01T00:00:00Z","user":{"id":6,"name":"佐藤花子","email":"sato@example.com"}}]}}`,
+		},
+		{
+			name:  "異常系: 所属していない会社の所属ユーザーは展開できない",
+			query: "?fields=name&include=members",
+			setupMock: func() {
+				companies.EXPECT().GetCompanyByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.Company{ID: 1, Name: "株式会社サンプル", Version: 3}, nil)
+				companies.EXPECT().GetUsersByCompany(gomock.Any(), uint(1)).Return(members, nil)
+				companies.EXPECT().CanView(gomock.Any(), uint(1)).Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "異常系: 展開できない関連",
+			query:          "?include=members.company",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/companies/1"+tt.query, nil)
+			req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{UserID: 5}))
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.setupMock()
+
+			err := handler.GetCompany(c)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.expectedBody != "" {
+					assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+					assert.Equal(t, `"3"`, rec.Header().Get(helper.HeaderETag))
+				}
+			}
+		})
+	}
+}
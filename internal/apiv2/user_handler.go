@@ -0,0 +1,171 @@
+package apiv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/company"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/user"
+)
+
+// UserHandler ユーザーのハンドラー（v2）
+// 処理はv1のハンドラーと共通で、レスポンスをUserResponse（v2）に変換する
+type UserHandler struct {
+	users     *user.UserHandler
+	usecase   user.UserUsecase
+	companies company.CompanyUsecase
+}
+
+func NewUserHandler(users user.UserUsecase, companies company.CompanyUsecase, expand user.Expander) *UserHandler {
+	h := &UserHandler{usecase: users, companies: companies}
+	h.users = user.NewUserHandlerWithPresenter(users, expand, h.present)
+	return h
+}
+
+// present 所属する会社とアバター画像のURLを含むレスポンスを作成
+func (h *UserHandler) present(ctx context.Context, u *domain.User) (any, error) {
+	memberships, err := h.companies.GetCompaniesByUser(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memberships of user %d: %w", u.ID, err)
+	}
+	var avatar *domain.AvatarURLs
+	if u.HasAvatar() {
+		if avatar, err = h.usecase.AvatarURLs(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+	return NewUserResponse(u, memberships, avatar), nil
+}
+
+// CreateUser godoc
+// @Summary ユーザー作成
+// @ID createUser
+// @Description 新しいユーザーを作成します
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body user.CreateUserRequest true "ユーザー情報"
+// @Success 201 {object} helper.APIResponse{data=UserResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /users [post]
+func (h *UserHandler) CreateUser(c echo.Context) error {
+	return h.users.CreateUser(c)
+}
+
+// GetUser godoc
+// @Summary ユーザー取得
+// @ID getUser
+// @Description 指定したIDのユーザーを所属する会社とともに取得します。ETagを返し、If-None-Matchが一致する場合は304を返します。fieldsで返すフィールドを選択でき（idは常に返します）、include=companiesで所属する会社を展開します（本人以外には呼び出し元も所属している会社のみ）。includeを指定した場合は304を返しません。本人と同じ会社に所属するユーザー以外は404を返します
+// @Tags users
+// @Produce json
+// @Param id path int true "ユーザーID"
+// @Param fields query string false "返すフィールド（カンマ区切り）"
+// @Param include query string false "展開する関連（カンマ区切り）: companies"
+// @Param If-None-Match header string false "取得済みのETag"
+// @Success 200 {object} helper.APIResponse{data=UserResponse}
+// @Success 304 "Not Modified"
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /users/{id} [get]
+func (h *UserHandler) GetUser(c echo.Context) error {
+	return h.users.GetUser(c)
+}
+
+// UpdateUser godoc
+// @Summary ユーザー更新
+// @ID updateUser
+// @Description 指定したIDのユーザーを更新します。取得時のETagをIf-Matchに指定する必要があります。本人とAPIキーのみ操作できます
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "ユーザーID"
+// @Param If-Match header string true "取得時のETag"
+// @Param user body user.UpdateUserRequest true "ユーザー情報"
+// @Success 200 {object} helper.APIResponse{data=UserResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 412 {object} helper.APIResponse
+// @Failure 428 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /users/{id} [put]
+func (h *UserHandler) UpdateUser(c echo.Context) error {
+	return h.users.UpdateUser(c)
+}
+
+// PatchUser godoc
+// @Summary ユーザー部分更新
+// @ID patchUser
+// @Description 指定したIDのユーザーを部分更新します。application/merge-patch+json（RFC 7396）または application/json-patch+json（RFC 6902）を受け付け、変更されたフィールドのみ保存します。name, email とプロフィール（display_name, kana_name, locale, timezone, bio）を変更できます。取得時のETagをIf-Matchに指定する必要があります。本人とAPIキーのみ操作できます
+// @Tags users
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "ユーザーID"
+// @Param If-Match header string true "取得時のETag"
+// @Param patch body interface{} true "パッチ（JSON Merge Patch / JSON Patch）"
+// @Success 200 {object} helper.APIResponse{data=UserResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 412 {object} helper.APIResponse
+// @Failure 415 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 428 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /users/{id} [patch]
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	return h.users.PatchUser(c)
+}
+
+// UploadAvatar godoc
+// @Summary アバター画像のアップロード
+// @ID uploadUserAvatar
+// @Description 本人のアバター画像をmultipart/form-dataのavatarフィールドで登録・置き換えます。形式（JPEG, PNG, GIF, WebP）は内容から判定し、元画像（最大1024px）と正方形のサムネイル（64, 128, 256px）をメタデータを除いて保存します。レスポンスのavatarに期限付きの署名付きURLを返します。If-Matchは任意で、指定した場合はバージョンを確認します
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "ユーザーID"
+// @Param If-Match header string false "取得時のETag"
+// @Param avatar formData file true "画像ファイル"
+// @Success 200 {object} helper.APIResponse{data=UserResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 412 {object} helper.APIResponse
+// @Failure 413 {object} helper.APIResponse
+// @Failure 415 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /users/{id}/avatar [put]
+func (h *UserHandler) UploadAvatar(c echo.Context) error {
+	return h.users.UploadAvatar(c)
+}
+
+// DeleteAvatar godoc
+// @Summary アバター画像の削除
+// @ID deleteUserAvatar
+// @Description 本人のアバター画像を削除します。設定されていない場合もそのままユーザーを返します。If-Matchは任意で、指定した場合はバージョンを確認します
+// @Tags users
+// @Produce json
+// @Param id path int true "ユーザーID"
+// @Param If-Match header string false "取得時のETag"
+// @Success 200 {object} helper.APIResponse{data=UserResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 412 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /users/{id}/avatar [delete]
+func (h *UserHandler) DeleteAvatar(c echo.Context) error {
+	return h.users.DeleteAvatar(c)
+}
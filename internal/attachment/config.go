@@ -0,0 +1,42 @@
+package attachment
+
+import (
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// defaultAllowedTypes 既定でアップロードできるファイルの形式
+var defaultAllowedTypes = []string{
+	"application/pdf",
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"text/plain",
+	"text/csv",
+	"application/zip",
+	typeDocx,
+	typeXlsx,
+	typePptx,
+}
+
+// Config 会社の添付ファイルの設定
+type Config struct {
+	MaxBytes      int64         // アップロードできるファイルの最大サイズ（バイト）
+	QuotaBytes    int64         // 会社ごとの合計サイズの上限（バイト。0以下は無制限）
+	AllowedTypes  []string      // アップロードできるファイルの形式（内容から判定したContent-Type）
+	LogoMaxPixels int           // ロゴ画像の最大ピクセル数（幅×高さ）
+	URLTTL        time.Duration // ダウンロード用の署名付きURLの有効期間
+}
+
+// LoadConfig 環境変数から添付ファイルの設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		MaxBytes:      int64(infra.GetEnvInt("ATTACHMENT_MAX_BYTES", 10<<20)),
+		QuotaBytes:    int64(infra.GetEnvInt("ATTACHMENT_QUOTA_BYTES", 1<<30)),
+		AllowedTypes:  infra.GetEnvList("ATTACHMENT_ALLOWED_TYPES", defaultAllowedTypes),
+		LogoMaxPixels: infra.GetEnvInt("ATTACHMENT_LOGO_MAX_PIXELS", 25_000_000),
+		URLTTL:        infra.GetEnvDuration("ATTACHMENT_URL_TTL", 5*time.Minute),
+	}
+}
@@ -0,0 +1,82 @@
+package attachment
+
+import (
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Office Open XML の形式（内容はZIPのため拡張子で判定する）
+const (
+	typeDocx = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	typeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	typePptx = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+)
+
+// zipTypes ZIPの内容を持つ形式の拡張子
+var zipTypes = map[string]string{
+	".docx": typeDocx,
+	".xlsx": typeXlsx,
+	".pptx": typePptx,
+}
+
+// DetectContentType ファイルの内容からContent-Typeを判定する
+// クライアントが申告したContent-Typeは信用せず、拡張子は内容と矛盾しない場合のみ判定を絞り込むのに使う
+func DetectContentType(filename string, data []byte) string {
+	detected := http.DetectContentType(data)
+	mediaType, _, err := mime.ParseMediaType(detected)
+	if err != nil {
+		mediaType = detected
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch mediaType {
+	case "application/zip":
+		if t, ok := zipTypes[ext]; ok {
+			return t
+		}
+	case "text/plain":
+		if ext == ".csv" {
+			return "text/csv"
+		}
+	}
+	return mediaType
+}
+
+// SanitizeFilename ストレージのキー・Content-Dispositionに使えるようにファイル名を整える
+// パスの区切り・制御文字を取り除き、空の場合は"file"にする
+func SanitizeFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '/' || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "file"
+	}
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		name = truncate(strings.TrimSuffix(name, ext), 255-len(ext)) + ext
+	}
+	return name
+}
+
+// truncate UTF-8の文字の途中で切らないようにnバイト以下に切り詰める
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
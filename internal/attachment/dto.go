@@ -0,0 +1,7 @@
+package attachment
+
+// AttachmentIDRequest 添付ファイルのパスパラメータID
+type AttachmentIDRequest struct {
+	CompanyID    uint `param:"id" validate:"required,min=1" example:"1"`            // 会社ID
+	AttachmentID uint `param:"attachment_id" validate:"required,min=1" example:"1"` // 添付ファイルID
+}
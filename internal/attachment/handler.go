@@ -0,0 +1,391 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/imaging"
+	"km-api-go/internal/logging"
+)
+
+// FileField アップロードするファイルのmultipart/form-dataのフィールド名
+const FileField = "file"
+
+type AttachmentHandler struct {
+	usecase AttachmentUsecase
+}
+
+func NewAttachmentHandler(usecase AttachmentUsecase) *AttachmentHandler {
+	return &AttachmentHandler{usecase: usecase}
+}
+
+// ListAttachments godoc
+// @Summary 添付ファイル一覧
+// @ID listCompanyAttachments
+// @Description 会社の添付ファイルを新しい順に取得します。会社のメンバーのみ操作できます
+// @Tags attachments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param page query int false "ページ番号"
+// @Param limit query int false "1ページあたりの件数"
+// @Success 200 {object} helper.PaginatedResponse{data=[]domain.CompanyAttachment}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var page helper.PaginationRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &page); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&page); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	attachments, pagination, err := h.usecase.ListAttachments(ctx, id.ID, page.Page, page.Limit)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to list attachments")
+	}
+
+	return helper.PaginatedSuccessResponse(c, attachments, pagination, "")
+}
+
+// UploadAttachment godoc
+// @Summary 添付ファイルのアップロード
+// @ID uploadCompanyAttachment
+// @Description 会社の添付ファイルをmultipart/form-dataのfileフィールドで登録します。形式は内容から判定し（ATTACHMENT_ALLOWED_TYPES）、ウイルススキャンと会社ごとの容量（ATTACHMENT_QUOTA_BYTES）を確認してから保存します
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param file formData file true "ファイル"
+// @Success 201 {object} helper.APIResponse{data=domain.CompanyAttachment}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 413 {object} helper.APIResponse
+// @Failure 415 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	filename, data, err := readFile(c)
+	if err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	a, err := h.usecase.UploadAttachment(ctx, id.ID, filename, data)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to upload attachment")
+	}
+
+	return helper.CreatedResponse(c, a, "")
+}
+
+// GetAttachment godoc
+// @Summary 添付ファイル取得
+// @ID getCompanyAttachment
+// @Description 会社の添付ファイルのメタデータ（アップロードしたユーザー・サイズ・SHA-256・形式）を取得します
+// @Tags attachments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param attachment_id path int true "添付ファイルID"
+// @Success 200 {object} helper.APIResponse{data=domain.CompanyAttachment}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandler) GetAttachment(c echo.Context) error {
+	var req AttachmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	a, err := h.usecase.GetAttachment(ctx, req.CompanyID, req.AttachmentID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to get attachment")
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, a, "")
+}
+
+// DownloadAttachment godoc
+// @Summary 添付ファイルのダウンロード
+// @ID downloadCompanyAttachment
+// @Description 期限付きの署名付きURL（ATTACHMENT_URL_TTL）にリダイレクトします
+// @Tags attachments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param attachment_id path int true "添付ファイルID"
+// @Success 302 "署名付きURLへのリダイレクト"
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/attachments/{attachment_id}/download [get]
+func (h *AttachmentHandler) DownloadAttachment(c echo.Context) error {
+	var req AttachmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	url, err := h.usecase.DownloadURL(ctx, req.CompanyID, req.AttachmentID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to download attachment")
+	}
+
+	return redirect(c, url)
+}
+
+// DeleteAttachment godoc
+// @Summary 添付ファイル削除
+// @ID deleteCompanyAttachment
+// @Description 会社の添付ファイルを削除します。会社の管理者とアップロードした本人のみ操作できます
+// @Tags attachments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param attachment_id path int true "添付ファイルID"
+// @Success 200 {object} helper.APIResponse
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c echo.Context) error {
+	var req AttachmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if err := h.usecase.DeleteAttachment(ctx, req.CompanyID, req.AttachmentID); err != nil {
+		return h.errorResponse(c, err, "failed to delete attachment")
+	}
+
+	return helper.DeletedResponse(c, "")
+}
+
+// GetUsage godoc
+// @Summary 添付ファイルの使用量
+// @ID getCompanyAttachmentUsage
+// @Description 会社の添付ファイル（ロゴを含む）の件数・合計サイズと上限を取得します
+// @Tags attachments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Success 200 {object} helper.APIResponse{data=Usage}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/storage [get]
+func (h *AttachmentHandler) GetUsage(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	usage, err := h.usecase.Usage(ctx, id.ID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to get attachment usage")
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, usage, "")
+}
+
+// UploadLogo godoc
+// @Summary 会社ロゴのアップロード
+// @ID uploadCompanyLogo
+// @Description 会社のロゴをmultipart/form-dataのfileフィールドで登録・置き換えます。形式（JPEG, PNG, GIF, WebP）は内容から判定し、最大512pxに縮小してメタデータを除いて保存します。会社の管理者のみ操作できます
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param file formData file true "画像ファイル"
+// @Success 200 {object} helper.APIResponse{data=domain.CompanyAttachment}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 413 {object} helper.APIResponse
+// @Failure 415 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/logo [put]
+func (h *AttachmentHandler) UploadLogo(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	filename, data, err := readFile(c)
+	if err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	logo, err := h.usecase.UploadLogo(ctx, id.ID, filename, data)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to upload company logo")
+	}
+
+	return helper.UpdatedResponse(c, logo, "")
+}
+
+// GetLogo godoc
+// @Summary 会社ロゴの取得
+// @ID getCompanyLogo
+// @Description 会社のロゴの署名付きURLにリダイレクトします
+// @Tags attachments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Success 302 "署名付きURLへのリダイレクト"
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/logo [get]
+func (h *AttachmentHandler) GetLogo(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	url, err := h.usecase.LogoURL(ctx, id.ID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to get company logo")
+	}
+
+	return redirect(c, url)
+}
+
+// DeleteLogo godoc
+// @Summary 会社ロゴの削除
+// @ID deleteCompanyLogo
+// @Description 会社のロゴを削除します。会社の管理者のみ操作できます
+// @Tags attachments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Success 200 {object} helper.APIResponse
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/logo [delete]
+func (h *AttachmentHandler) DeleteLogo(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if err := h.usecase.DeleteLogo(ctx, id.ID); err != nil {
+		return h.errorResponse(c, err, "failed to delete company logo")
+	}
+
+	return helper.DeletedResponse(c, "")
+}
+
+// readFile multipart/form-dataのfileフィールドのファイル名と内容を読み込む
+func readFile(c echo.Context) (string, []byte, error) {
+	file, err := c.FormFile(FileField)
+	if err != nil {
+		return "", nil, errors.New("multipart/form-dataのfileフィールドにファイルを指定してください")
+	}
+	src, err := file.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return "", nil, err
+	}
+	return file.Filename, data, nil
+}
+
+// redirect 署名付きURLにリダイレクトする（URLは期限付きのためキャッシュさせない）
+func redirect(c echo.Context, url string) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.Redirect(http.StatusFound, url)
+}
+
+// errorResponse ユースケースのエラーをレスポンスに変換
+func (h *AttachmentHandler) errorResponse(c echo.Context, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		return helper.ForbiddenResponse(c)
+	case errors.Is(err, domain.ErrNotFound):
+		return helper.NotFoundResponse(c, "")
+	case errors.Is(err, ErrEmpty):
+		return helper.ValidationErrorResponse(c, "空のファイルはアップロードできません")
+	case errors.Is(err, ErrTooLarge):
+		return helper.ErrorResponse(c, http.StatusRequestEntityTooLarge, helper.ErrorCodePayloadTooLarge, "ファイルのサイズが大きすぎます", err.Error())
+	case errors.Is(err, ErrQuotaExceeded):
+		return helper.ErrorResponse(c, http.StatusRequestEntityTooLarge, helper.ErrorCodeQuotaExceeded, "会社の添付ファイルの容量を超えます", err.Error())
+	case errors.Is(err, ErrUnsupportedType):
+		return helper.ErrorResponse(c, http.StatusUnsupportedMediaType, helper.ErrorCodeUnsupportedMedia, "アップロードできない形式のファイルです", err.Error())
+	case errors.Is(err, imaging.ErrUnsupportedType):
+		return helper.ErrorResponse(c, http.StatusUnsupportedMediaType, helper.ErrorCodeUnsupportedMedia,
+			"JPEG, PNG, GIF, WebP の画像を指定してください", err.Error())
+	case errors.Is(err, imaging.ErrTooLarge):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "画像の縦横のピクセル数が大きすぎます", err.Error())
+	case errors.Is(err, imaging.ErrInvalidImage):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "画像を読み込めません", err.Error())
+	case errors.Is(err, ErrInfected):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeInfected, "ファイルからウイルスが検出されました", "")
+	}
+
+	ctx := c.Request().Context()
+	logging.FromContext(ctx).ErrorContext(ctx, logMessage, slog.Any("error", err))
+	return helper.InternalErrorResponse(c, err.Error())
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// attachmentRepository GORM実装
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository 添付ファイルリポジトリのコンストラクタ
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id uint) (*domain.CompanyAttachment, error) {
+	var a domain.CompanyAttachment
+
+	if err := infra.Conn(ctx, r.db).First(&a, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attachment with id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get attachment by id %d: %w", id, err)
+	}
+
+	return &a, nil
+}
+
+func (r *attachmentRepository) GetLogo(ctx context.Context, companyID uint) (*domain.CompanyAttachment, error) {
+	var a domain.CompanyAttachment
+
+	err := infra.Conn(ctx, r.db).Where("company_id = ? AND kind = ?", companyID, domain.AttachmentKindLogo).First(&a).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("logo of company %d %w", companyID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get logo of company %d: %w", companyID, err)
+	}
+
+	return &a, nil
+}
+
+func (r *attachmentRepository) GetPaginated(ctx context.Context, companyID uint, kind string, offset, limit int) ([]domain.CompanyAttachment, error) {
+	var attachments []domain.CompanyAttachment
+
+	err := infra.Conn(ctx, r.db).
+		Where("company_id = ? AND kind = ?", companyID, kind).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&attachments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paginated attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepository) Count(ctx context.Context, companyID uint, kind string) (int64, error) {
+	var count int64
+
+	err := infra.Conn(ctx, r.db).Model(&domain.CompanyAttachment{}).
+		Where("company_id = ? AND kind = ?", companyID, kind).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attachments: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *attachmentRepository) Usage(ctx context.Context, companyID uint) (int64, int64, error) {
+	var usage struct {
+		Count int64
+		Bytes int64
+	}
+
+	err := infra.Conn(ctx, r.db).Model(&domain.CompanyAttachment{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(size), 0) AS bytes").
+		Where("company_id = ?", companyID).
+		Scan(&usage).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get attachment usage: %w", err)
+	}
+
+	return usage.Count, usage.Bytes, nil
+}
+
+func (r *attachmentRepository) LockUsage(ctx context.Context, companyID uint) (int64, error) {
+	db := infra.Conn(ctx, r.db)
+
+	// 集計関数にはFOR UPDATEを付けられないため、会社の行をロックしてから集計する
+	var company domain.Company
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&company, companyID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to lock company %d: %w", companyID, err)
+	}
+
+	var bytes int64
+	err = db.Model(&domain.CompanyAttachment{}).
+		Select("COALESCE(SUM(size), 0)").
+		Where("company_id = ?", companyID).
+		Scan(&bytes).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum attachment size: %w", err)
+	}
+
+	return bytes, nil
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, a *domain.CompanyAttachment) error {
+	if err := infra.Conn(ctx, r.db).Create(a).Error; err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id uint) error {
+	result := infra.Conn(ctx, r.db).Delete(&domain.CompanyAttachment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete attachment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("attachment with id %d %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"km-api-go/internal/domain"
+)
+
+// 添付ファイルリポジトリインターフェース
+type AttachmentRepository interface {
+	GetByID(ctx context.Context, id uint) (*domain.CompanyAttachment, error)
+	// GetLogo 会社のロゴを取得（未設定の場合はErrNotFound）
+	GetLogo(ctx context.Context, companyID uint) (*domain.CompanyAttachment, error)
+	// GetPaginated 会社の指定した種類の添付ファイルを新しい順に取得
+	GetPaginated(ctx context.Context, companyID uint, kind string, offset, limit int) ([]domain.CompanyAttachment, error)
+	Count(ctx context.Context, companyID uint, kind string) (int64, error)
+	// Usage 会社のすべての添付ファイル（ロゴを含む）の件数と合計サイズ
+	Usage(ctx context.Context, companyID uint) (count int64, bytes int64, err error)
+	// LockUsage 会社の行をロックして合計サイズを返す（トランザクション内で呼び、同時のアップロードで上限を超えないようにする）
+	LockUsage(ctx context.Context, companyID uint) (int64, error)
+	Create(ctx context.Context, attachment *domain.CompanyAttachment) error
+	Delete(ctx context.Context, id uint) error
+}
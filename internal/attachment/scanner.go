@@ -0,0 +1,24 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInfected ウイルススキャンでファイルに問題が見つかった
+var ErrInfected = errors.New("attachment is infected")
+
+// Scanner アップロードされたファイルのウイルススキャン
+// ClamAVなどの外部サービスと連携する場合はこのインターフェースを実装し、NewAttachmentUsecaseに渡す
+type Scanner interface {
+	// Scan 問題がなければnil、ウイルスを検出した場合はErrInfectedをラップしたエラーを返す
+	// それ以外のエラーの場合はアップロードを失敗させる
+	Scan(ctx context.Context, filename string, data []byte) error
+}
+
+// ScannerFunc 関数をScannerとして使う
+type ScannerFunc func(ctx context.Context, filename string, data []byte) error
+
+func (f ScannerFunc) Scan(ctx context.Context, filename string, data []byte) error {
+	return f(ctx, filename, data)
+}
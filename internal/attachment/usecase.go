@@ -0,0 +1,471 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"km-api-go/internal/attachment/repository"
+	"km-api-go/internal/auth"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/imaging"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/storage"
+	"km-api-go/internal/tracing"
+)
+
+var (
+	// ErrTooLarge アップロードされたファイルがMaxBytesを超えている
+	ErrTooLarge = errors.New("attachment file too large")
+	// ErrQuotaExceeded 会社の添付ファイルの合計サイズがQuotaBytesを超える
+	ErrQuotaExceeded = errors.New("attachment quota exceeded")
+	// ErrUnsupportedType AllowedTypesに含まれない形式のファイル
+	ErrUnsupportedType = errors.New("unsupported attachment type")
+	// ErrEmpty 空のファイル
+	ErrEmpty = errors.New("attachment file is empty")
+)
+
+// logoSize ロゴ画像は縦横比を保ってこの大きさに収める
+const logoSize = 512
+
+// Usage 会社の添付ファイルの使用量
+type Usage struct {
+	Count      int64 `json:"count" example:"12"`               // 添付ファイル数（ロゴを含む）
+	UsedBytes  int64 `json:"used_bytes" example:"10485760"`    // 合計サイズ（バイト）
+	QuotaBytes int64 `json:"quota_bytes" example:"1073741824"` // 上限（バイト。0は無制限）
+}
+
+// AttachmentUsecase 会社の添付ファイル・ロゴに関するビジネスロジック
+// 会社のメンバー（role=member, admin）またはAPIキーのみ操作できる
+// 添付ファイルの削除は管理者とアップロードした本人、ロゴの変更は管理者のみ行える
+type AttachmentUsecase interface {
+	ListAttachments(ctx context.Context, companyID uint, page, limit int) ([]domain.CompanyAttachment, *helper.PaginationResponse, error)
+	GetAttachment(ctx context.Context, companyID, id uint) (*domain.CompanyAttachment, error)
+	// UploadAttachment 形式を内容から判定し、ウイルススキャン・容量の確認をしてから保存する
+	UploadAttachment(ctx context.Context, companyID uint, filename string, data []byte) (*domain.CompanyAttachment, error)
+	// DownloadURL ダウンロード用の署名付きURLを発行する
+	DownloadURL(ctx context.Context, companyID, id uint) (string, error)
+	DeleteAttachment(ctx context.Context, companyID, id uint) error
+	// UploadLogo 画像を縮小・メタデータを除いて保存し、既存のロゴを置き換える
+	UploadLogo(ctx context.Context, companyID uint, filename string, data []byte) (*domain.CompanyAttachment, error)
+	// LogoURL ロゴの署名付きURLを発行する（未設定の場合はErrNotFound）
+	LogoURL(ctx context.Context, companyID uint) (string, error)
+	DeleteLogo(ctx context.Context, companyID uint) error
+	Usage(ctx context.Context, companyID uint) (*Usage, error)
+}
+
+type attachmentUsecase struct {
+	attachmentRepo  repository.AttachmentRepository
+	companyRepo     companyRepo.CompanyRepository
+	companyUserRepo companyRepo.CompanyUserRepository
+	tx              infra.Transactor
+	blobs           storage.BlobStore
+	scanner         Scanner
+	cfg             Config
+}
+
+// NewAttachmentUsecase 添付ファイルユースケースのコンストラクタ
+// scannerがnilの場合はウイルススキャンを行わず、scan_statusをskippedにする
+func NewAttachmentUsecase(
+	attachmentRepository repository.AttachmentRepository,
+	companyRepository companyRepo.CompanyRepository,
+	companyUserRepository companyRepo.CompanyUserRepository,
+	tx infra.Transactor,
+	blobs storage.BlobStore,
+	scanner Scanner,
+	cfg Config,
+) AttachmentUsecase {
+	return &attachmentUsecase{
+		attachmentRepo:  attachmentRepository,
+		companyRepo:     companyRepository,
+		companyUserRepo: companyUserRepository,
+		tx:              tx,
+		blobs:           blobs,
+		scanner:         scanner,
+		cfg:             cfg,
+	}
+}
+
+// authorize 呼び出し元が会社のメンバーか確認し、会社との関係を返す（APIキーの場合はnil）
+func (uc *attachmentUsecase) authorize(ctx context.Context, companyID uint) (*domain.CompanyUser, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrForbidden
+	}
+
+	if !principal.IsUser() {
+		exists, err := uc.companyRepo.Exists(ctx, companyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check company existence: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
+		}
+		return nil, nil
+	}
+
+	relation, err := uc.companyUserRepo.GetRelation(ctx, principal.UserID, companyID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrForbidden
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company relation: %w", err)
+	}
+	if !relation.IsMember() {
+		return nil, domain.ErrForbidden
+	}
+	return relation, nil
+}
+
+// authorizeAdmin 呼び出し元が会社の管理者またはAPIキーか確認
+func (uc *attachmentUsecase) authorizeAdmin(ctx context.Context, companyID uint) error {
+	relation, err := uc.authorize(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if relation != nil && !relation.IsAdmin() {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// getAttachment 会社の添付ファイルを取得（他の会社の添付ファイル・ロゴはErrNotFound）
+func (uc *attachmentUsecase) getAttachment(ctx context.Context, companyID, id uint) (*domain.CompanyAttachment, error) {
+	a, err := uc.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.CompanyID != companyID || a.Kind != domain.AttachmentKindDocument {
+		return nil, fmt.Errorf("attachment with id %d %w", id, domain.ErrNotFound)
+	}
+	return a, nil
+}
+
+func (uc *attachmentUsecase) ListAttachments(ctx context.Context, companyID uint, page, limit int) (_ []domain.CompanyAttachment, _ *helper.PaginationResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.ListAttachments")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return nil, nil, err
+	}
+
+	paginationReq := &helper.PaginationRequest{Page: page, Limit: limit}
+	offset := paginationReq.GetOffset()
+	normalizedLimit := paginationReq.GetLimit()
+
+	total, err := uc.attachmentRepo.Count(ctx, companyID, domain.AttachmentKindDocument)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count attachments: %w", err)
+	}
+	attachments, err := uc.attachmentRepo.GetPaginated(ctx, companyID, domain.AttachmentKindDocument, offset, normalizedLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	return attachments, helper.NewPaginationResponse(paginationReq.Page, normalizedLimit, total), nil
+}
+
+func (uc *attachmentUsecase) GetAttachment(ctx context.Context, companyID, id uint) (_ *domain.CompanyAttachment, err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.GetAttachment")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	return uc.getAttachment(ctx, companyID, id)
+}
+
+func (uc *attachmentUsecase) UploadAttachment(ctx context.Context, companyID uint, filename string, data []byte) (_ *domain.CompanyAttachment, err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.UploadAttachment")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	if err := uc.checkSize(data); err != nil {
+		return nil, err
+	}
+	contentType := DetectContentType(filename, data)
+	if !slices.Contains(uc.cfg.AllowedTypes, contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	a := uc.newAttachment(ctx, companyID, domain.AttachmentKindDocument, filename, contentType, data)
+	if err := uc.store(ctx, a, data, nil); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "attachment uploaded",
+		slog.Uint64("attachment_id", uint64(a.ID)),
+		slog.Uint64("company_id", uint64(companyID)),
+		slog.String("content_type", contentType),
+		slog.Int64("bytes", a.Size),
+	)
+	return a, nil
+}
+
+func (uc *attachmentUsecase) DownloadURL(ctx context.Context, companyID, id uint) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.DownloadURL")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return "", err
+	}
+	a, err := uc.getAttachment(ctx, companyID, id)
+	if err != nil {
+		return "", err
+	}
+	return uc.signedURL(ctx, a)
+}
+
+func (uc *attachmentUsecase) DeleteAttachment(ctx context.Context, companyID, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.DeleteAttachment")
+	defer tracing.End(span, &err)
+
+	relation, err := uc.authorize(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	a, err := uc.getAttachment(ctx, companyID, id)
+	if err != nil {
+		return err
+	}
+	// メンバーは自分がアップロードした添付ファイルのみ削除できる
+	if relation != nil && !relation.IsAdmin() && (a.UploadedBy == nil || *a.UploadedBy != relation.UserID) {
+		return domain.ErrForbidden
+	}
+
+	if err := uc.attachmentRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	uc.deleteFile(ctx, a)
+
+	logging.FromContext(ctx).InfoContext(ctx, "attachment deleted",
+		slog.Uint64("attachment_id", uint64(id)),
+		slog.Uint64("company_id", uint64(companyID)),
+	)
+	return nil
+}
+
+func (uc *attachmentUsecase) UploadLogo(ctx context.Context, companyID uint, filename string, data []byte) (_ *domain.CompanyAttachment, err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.UploadLogo")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return nil, err
+	}
+	if err := uc.checkSize(data); err != nil {
+		return nil, err
+	}
+	img, contentType, err := imaging.Decode(data, uc.cfg.LogoMaxPixels)
+	if err != nil {
+		return nil, err
+	}
+
+	// 再エンコードしてEXIFなどのメタデータを除く
+	contentType = imaging.OutputType(contentType)
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, imaging.Fit(img, logoSize), contentType); err != nil {
+		return nil, fmt.Errorf("failed to encode logo: %w", err)
+	}
+	data = buf.Bytes()
+
+	// 形式が変わる場合があるため、ファイル名の拡張子は保存した形式に合わせる
+	filename = SanitizeFilename(filename)
+	filename = strings.TrimSuffix(filename, filepath.Ext(filename)) + imaging.Extension(contentType)
+	a := uc.newAttachment(ctx, companyID, domain.AttachmentKindLogo, filename, contentType, data)
+	var previous *domain.CompanyAttachment
+	err = uc.store(ctx, a, data, func(ctx context.Context) error {
+		current, err := uc.attachmentRepo.GetLogo(ctx, companyID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		previous = current
+		return uc.attachmentRepo.Delete(ctx, current.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		uc.deleteFile(ctx, previous)
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "company logo uploaded",
+		slog.Uint64("company_id", uint64(companyID)),
+		slog.String("content_type", contentType),
+		slog.Int64("bytes", a.Size),
+	)
+	return a, nil
+}
+
+func (uc *attachmentUsecase) LogoURL(ctx context.Context, companyID uint) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.LogoURL")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return "", err
+	}
+	logo, err := uc.attachmentRepo.GetLogo(ctx, companyID)
+	if err != nil {
+		return "", err
+	}
+	return uc.signedURL(ctx, logo)
+}
+
+func (uc *attachmentUsecase) DeleteLogo(ctx context.Context, companyID uint) (err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.DeleteLogo")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return err
+	}
+	logo, err := uc.attachmentRepo.GetLogo(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if err := uc.attachmentRepo.Delete(ctx, logo.ID); err != nil {
+		return fmt.Errorf("failed to delete logo: %w", err)
+	}
+	uc.deleteFile(ctx, logo)
+	return nil
+}
+
+func (uc *attachmentUsecase) Usage(ctx context.Context, companyID uint) (_ *Usage, err error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.Usage")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	count, used, err := uc.attachmentRepo.Usage(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return &Usage{Count: count, UsedBytes: used, QuotaBytes: max(uc.cfg.QuotaBytes, 0)}, nil
+}
+
+// checkSize ファイルが空でなく、MaxBytes以下か確認
+func (uc *attachmentUsecase) checkSize(data []byte) error {
+	if len(data) == 0 {
+		return ErrEmpty
+	}
+	if int64(len(data)) > uc.cfg.MaxBytes {
+		return fmt.Errorf("%w: %d bytes", ErrTooLarge, len(data))
+	}
+	return nil
+}
+
+// newAttachment 保存する添付ファイルのメタデータを作成（キーは毎回新しくする）
+func (uc *attachmentUsecase) newAttachment(ctx context.Context, companyID uint, kind, filename, contentType string, data []byte) *domain.CompanyAttachment {
+	filename = SanitizeFilename(filename)
+	sum := sha256.Sum256(data)
+	a := &domain.CompanyAttachment{
+		CompanyID:   companyID,
+		Kind:        kind,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		StorageKey:  fmt.Sprintf("companies/%d/%ss/%s/%s", companyID, kind, uuid.NewString(), filename),
+	}
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		a.UploadedBy = &userID
+	}
+	return a
+}
+
+// store ウイルススキャン・容量を確認してファイルを保存し、メタデータを作成する
+// beforeCreateはメタデータを作成するトランザクション内で容量の確認の前に呼ぶ（ロゴの置き換え用）
+func (uc *attachmentUsecase) store(ctx context.Context, a *domain.CompanyAttachment, data []byte, beforeCreate func(ctx context.Context) error) error {
+	a.ScanStatus = domain.AttachmentScanSkipped
+	if uc.scanner != nil {
+		if err := uc.scanner.Scan(ctx, a.Filename, data); err != nil {
+			if errors.Is(err, ErrInfected) {
+				logging.FromContext(ctx).WarnContext(ctx, "infected attachment rejected",
+					slog.Uint64("company_id", uint64(a.CompanyID)),
+					slog.String("checksum", a.Checksum),
+					slog.Any("error", err),
+				)
+				return err
+			}
+			return fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		a.ScanStatus = domain.AttachmentScanClean
+	}
+
+	// ストレージへの保存は時間がかかるため、明らかに上限を超える場合はロックを取らずに先に断る
+	if uc.cfg.QuotaBytes > 0 {
+		_, used, err := uc.attachmentRepo.Usage(ctx, a.CompanyID)
+		if err != nil {
+			return err
+		}
+		if err := uc.checkQuota(used, a.Size); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.blobs.Put(ctx, a.StorageKey, bytes.NewReader(data), a.Size, a.ContentType); err != nil {
+		return fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if beforeCreate != nil {
+			if err := beforeCreate(ctx); err != nil {
+				return err
+			}
+		}
+		used, err := uc.attachmentRepo.LockUsage(ctx, a.CompanyID)
+		if err != nil {
+			return err
+		}
+		if err := uc.checkQuota(used, a.Size); err != nil {
+			return err
+		}
+		return uc.attachmentRepo.Create(ctx, a)
+	})
+	if err != nil {
+		uc.deleteFile(ctx, a)
+		return err
+	}
+	return nil
+}
+
+// checkQuota 現在の使用量にsizeを加えてもQuotaBytesを超えないか確認
+func (uc *attachmentUsecase) checkQuota(used, size int64) error {
+	if uc.cfg.QuotaBytes > 0 && used+size > uc.cfg.QuotaBytes {
+		return fmt.Errorf("%w: %d of %d bytes used", ErrQuotaExceeded, used, uc.cfg.QuotaBytes)
+	}
+	return nil
+}
+
+// signedURL 添付ファイルの署名付きURLを発行する
+func (uc *attachmentUsecase) signedURL(ctx context.Context, a *domain.CompanyAttachment) (string, error) {
+	url, err := uc.blobs.SignedURL(ctx, a.StorageKey, uc.cfg.URLTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign attachment url: %w", err)
+	}
+	return url, nil
+}
+
+// deleteFile ストレージのファイルを削除する（失敗してもメタデータの削除は取り消さず、ログに残す）
+func (uc *attachmentUsecase) deleteFile(ctx context.Context, a *domain.CompanyAttachment) {
+	if err := uc.blobs.Delete(ctx, a.StorageKey); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to delete attachment file",
+			slog.String("key", a.StorageKey), slog.Any("error", err))
+	}
+}
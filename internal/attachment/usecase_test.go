@@ -0,0 +1,325 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/attachment/repository/mocks"
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/imaging"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/storage"
+)
+
+var pdf = []byte("%PDF-1.7\n1 0 obj\n<<>>\nendobj\n")
+
+func testConfig() Config {
+	return Config{
+		MaxBytes:      1 << 20,
+		QuotaBytes:    1 << 20,
+		AllowedTypes:  defaultAllowedTypes,
+		LogoMaxPixels: 4 << 20,
+		URLTTL:        time.Minute,
+	}
+}
+
+type testDeps struct {
+	attachments  *mocks.MockAttachmentRepository
+	companies    *companyMocks.MockCompanyRepository
+	companyUsers *companyMocks.MockCompanyUserRepository
+	store        *recordingStore
+}
+
+// recordingStore 保存したキーを記録する（保存に失敗した場合の後始末の確認用）
+type recordingStore struct {
+	*storage.LocalStore
+	keys []string
+}
+
+func (s *recordingStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	s.keys = append(s.keys, key)
+	return s.LocalStore.Put(ctx, key, r, size, contentType)
+}
+
+func newTestUsecase(t *testing.T, scanner Scanner) (AttachmentUsecase, testDeps) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	local, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files", []byte("secret"))
+	require.NoError(t, err)
+	store := &recordingStore{LocalStore: local}
+	deps := testDeps{
+		attachments:  mocks.NewMockAttachmentRepository(ctrl),
+		companies:    companyMocks.NewMockCompanyRepository(ctrl),
+		companyUsers: companyMocks.NewMockCompanyUserRepository(ctrl),
+		store:        store,
+	}
+	uc := NewAttachmentUsecase(deps.attachments, deps.companies, deps.companyUsers, infra.NoTx, store, scanner, testConfig())
+	return uc, deps
+}
+
+// asUser 会社（ID=1）に指定した役割で所属するユーザーとして呼び出す
+func asUser(deps testDeps, userID uint, role string) context.Context {
+	deps.companyUsers.EXPECT().GetRelation(gomock.Any(), userID, uint(1)).
+		Return(&domain.CompanyUser{UserID: userID, CompanyID: 1, Role: role}, nil).AnyTimes()
+	return auth.WithPrincipal(context.Background(), &auth.Principal{UserID: userID})
+}
+
+func stored(t *testing.T, store *recordingStore, key string) []byte {
+	t.Helper()
+	f, _, err := store.Open(key)
+	require.NoError(t, err, key)
+	defer f.Close()
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(f)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestAttachmentUsecase_UploadAttachment(t *testing.T) {
+	t.Run("正常系: メタデータを記録してファイルを保存する", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		ctx := asUser(deps, 2, "member")
+		deps.attachments.EXPECT().Usage(gomock.Any(), uint(1)).Return(int64(3), int64(1000), nil)
+		deps.attachments.EXPECT().LockUsage(gomock.Any(), uint(1)).Return(int64(1000), nil)
+		deps.attachments.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *domain.CompanyAttachment) error {
+			a.ID = 10
+			return nil
+		})
+
+		a, err := uc.UploadAttachment(ctx, 1, "../契約書.pdf", pdf)
+		require.NoError(t, err)
+		sum := sha256.Sum256(pdf)
+		assert.Equal(t, uint(10), a.ID)
+		assert.Equal(t, domain.AttachmentKindDocument, a.Kind)
+		assert.Equal(t, "契約書.pdf", a.Filename)
+		assert.Equal(t, "application/pdf", a.ContentType)
+		assert.Equal(t, int64(len(pdf)), a.Size)
+		assert.Equal(t, hex.EncodeToString(sum[:]), a.Checksum)
+		assert.Equal(t, domain.AttachmentScanSkipped, a.ScanStatus)
+		require.NotNil(t, a.UploadedBy)
+		assert.Equal(t, uint(2), *a.UploadedBy)
+		assert.True(t, strings.HasPrefix(a.StorageKey, "companies/1/documents/"))
+		assert.True(t, strings.HasSuffix(a.StorageKey, "/契約書.pdf"))
+		assert.Equal(t, pdf, stored(t, deps.store, a.StorageKey))
+	})
+
+	t.Run("異常系: 会社に所属していない", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		deps.companyUsers.EXPECT().GetRelation(gomock.Any(), uint(3), uint(1)).Return(nil, domain.ErrNotFound)
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 3})
+
+		_, err := uc.UploadAttachment(ctx, 1, "a.pdf", pdf)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("異常系: 許可されていない形式", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		ctx := asUser(deps, 2, "member")
+
+		// 拡張子ではなく内容で判定する
+		_, err := uc.UploadAttachment(ctx, 1, "a.pdf", []byte("MZ\x90\x00\x03\x00\x00\x00"))
+		assert.ErrorIs(t, err, ErrUnsupportedType)
+	})
+
+	t.Run("異常系: 容量の上限を超える場合は保存しない", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		ctx := asUser(deps, 2, "member")
+		deps.attachments.EXPECT().Usage(gomock.Any(), uint(1)).Return(int64(5), int64(1<<20-10), nil)
+
+		_, err := uc.UploadAttachment(ctx, 1, "a.pdf", pdf)
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+	})
+
+	t.Run("異常系: 同時のアップロードで上限を超えた場合は保存したファイルを削除する", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		ctx := asUser(deps, 2, "member")
+		deps.attachments.EXPECT().Usage(gomock.Any(), uint(1)).Return(int64(0), int64(0), nil)
+		deps.attachments.EXPECT().LockUsage(gomock.Any(), uint(1)).Return(int64(1<<20), nil)
+
+		_, err := uc.UploadAttachment(ctx, 1, "a.pdf", pdf)
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+		require.Len(t, deps.store.keys, 1)
+		_, _, err = deps.store.Get(context.Background(), deps.store.keys[0])
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("異常系: ウイルスを検出した場合は保存しない", func(t *testing.T) {
+		scanner := ScannerFunc(func(_ context.Context, filename string, data []byte) error {
+			if bytes.Contains(data, []byte("EICAR")) {
+				return fmt.Errorf("%s: %w", filename, ErrInfected)
+			}
+			return nil
+		})
+		uc, deps := newTestUsecase(t, scanner)
+		ctx := asUser(deps, 2, "member")
+
+		_, err := uc.UploadAttachment(ctx, 1, "a.txt", []byte("X5O!P%@AP EICAR-STANDARD-ANTIVIRUS-TEST-FILE"))
+		assert.ErrorIs(t, err, ErrInfected)
+	})
+
+	t.Run("正常系: スキャンした結果を記録する", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, ScannerFunc(func(context.Context, string, []byte) error { return nil }))
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+		deps.companies.EXPECT().Exists(gomock.Any(), uint(1)).Return(true, nil)
+		deps.attachments.EXPECT().Usage(gomock.Any(), uint(1)).Return(int64(0), int64(0), nil)
+		deps.attachments.EXPECT().LockUsage(gomock.Any(), uint(1)).Return(int64(0), nil)
+		deps.attachments.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		a, err := uc.UploadAttachment(ctx, 1, "一覧.csv", []byte("name,email\n山田,yamada@example.com\n"))
+		require.NoError(t, err)
+		assert.Equal(t, domain.AttachmentScanClean, a.ScanStatus)
+		assert.Equal(t, "text/csv", a.ContentType)
+		assert.Nil(t, a.UploadedBy)
+	})
+}
+
+func TestAttachmentUsecase_DeleteAttachment(t *testing.T) {
+	uploadedBy := uint(2)
+	attachment := func() *domain.CompanyAttachment {
+		return &domain.CompanyAttachment{
+			ID: 10, CompanyID: 1, Kind: domain.AttachmentKindDocument, UploadedBy: &uploadedBy,
+			StorageKey: "companies/1/documents/abc/a.pdf",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		userID      uint
+		role        string
+		found       *domain.CompanyAttachment
+		expectError error
+	}{
+		{name: "正常系: アップロードした本人", userID: 2, role: "member", found: attachment()},
+		{name: "正常系: 管理者", userID: 3, role: "admin", found: attachment()},
+		{name: "異常系: 他のメンバー", userID: 4, role: "member", found: attachment(), expectError: domain.ErrForbidden},
+		{
+			name: "異常系: 他の会社の添付ファイル", userID: 3, role: "admin",
+			found:       &domain.CompanyAttachment{ID: 10, CompanyID: 2, Kind: domain.AttachmentKindDocument},
+			expectError: domain.ErrNotFound,
+		},
+		{
+			name: "異常系: ロゴは添付ファイルとして削除しない", userID: 3, role: "admin",
+			found:       &domain.CompanyAttachment{ID: 10, CompanyID: 1, Kind: domain.AttachmentKindLogo},
+			expectError: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, deps := newTestUsecase(t, nil)
+			ctx := asUser(deps, tt.userID, tt.role)
+			deps.attachments.EXPECT().GetByID(gomock.Any(), uint(10)).Return(tt.found, nil)
+			if tt.expectError == nil {
+				require.NoError(t, deps.store.Put(context.Background(), tt.found.StorageKey, bytes.NewReader(pdf), int64(len(pdf)), "application/pdf"))
+				deps.attachments.EXPECT().Delete(gomock.Any(), uint(10)).Return(nil)
+			}
+
+			err := uc.DeleteAttachment(ctx, 1, 10)
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			_, _, err = deps.store.Get(context.Background(), tt.found.StorageKey)
+			assert.ErrorIs(t, err, domain.ErrNotFound)
+		})
+	}
+}
+
+func TestAttachmentUsecase_UploadLogo(t *testing.T) {
+	var logo bytes.Buffer
+	require.NoError(t, png.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 1024, 256))))
+
+	t.Run("正常系: 縮小して保存し、以前のロゴを削除する", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		ctx := asUser(deps, 3, "admin")
+		previous := &domain.CompanyAttachment{ID: 5, CompanyID: 1, Kind: domain.AttachmentKindLogo, StorageKey: "companies/1/logos/old/logo.png"}
+		require.NoError(t, deps.store.Put(context.Background(), previous.StorageKey, strings.NewReader("old"), 3, imaging.TypePNG))
+
+		deps.attachments.EXPECT().Usage(gomock.Any(), uint(1)).Return(int64(1), int64(3), nil)
+		gomock.InOrder(
+			deps.attachments.EXPECT().GetLogo(gomock.Any(), uint(1)).Return(previous, nil),
+			deps.attachments.EXPECT().Delete(gomock.Any(), uint(5)).Return(nil),
+			deps.attachments.EXPECT().LockUsage(gomock.Any(), uint(1)).Return(int64(0), nil),
+			deps.attachments.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		a, err := uc.UploadLogo(ctx, 1, "logo.gif", logo.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, domain.AttachmentKindLogo, a.Kind)
+		assert.Equal(t, "logo.png", a.Filename)
+		assert.Equal(t, imaging.TypePNG, a.ContentType)
+
+		img, err := png.Decode(bytes.NewReader(stored(t, deps.store, a.StorageKey)))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 512, 128), img.Bounds())
+		_, _, err = deps.store.Get(context.Background(), previous.StorageKey)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("異常系: メンバーはロゴを変更できない", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		ctx := asUser(deps, 2, "member")
+
+		_, err := uc.UploadLogo(ctx, 1, "logo.png", logo.Bytes())
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("異常系: 画像以外のファイル", func(t *testing.T) {
+		uc, deps := newTestUsecase(t, nil)
+		ctx := asUser(deps, 3, "admin")
+
+		_, err := uc.UploadLogo(ctx, 1, "logo.pdf", pdf)
+		assert.ErrorIs(t, err, imaging.ErrUnsupportedType)
+	})
+}
+
+func TestDetectContentType(t *testing.T) {
+	zip := []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00")
+	tests := []struct {
+		filename string
+		data     []byte
+		expected string
+	}{
+		{"a.pdf", pdf, "application/pdf"},
+		{"a.txt", pdf, "application/pdf"},
+		{"a.docx", zip, typeDocx},
+		{"a.XLSX", zip, typeXlsx},
+		{"a.zip", zip, "application/zip"},
+		{"a.csv", []byte("a,b\n1,2\n"), "text/csv"},
+		{"a.txt", []byte("hello"), "text/plain"},
+		{"a.docx", pdf, "application/pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectContentType(tt.filename, tt.data))
+		})
+	}
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	assert.Equal(t, "passwd", SanitizeFilename("../../etc/passwd"))
+	assert.Equal(t, "a.pdf", SanitizeFilename(`C:\Users\a.pdf`))
+	assert.Equal(t, "ab.pdf", SanitizeFilename("a\x00\"b.pdf"))
+	assert.Equal(t, "file", SanitizeFilename(".."))
+	long := SanitizeFilename(strings.Repeat("あ", 100) + ".pdf")
+	assert.LessOrEqual(t, len(long), 255)
+	assert.True(t, strings.HasSuffix(long, "あ.pdf"))
+}
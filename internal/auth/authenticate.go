@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidAPIKey 登録されていないAPIキー
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// Authenticate Authorizationの値（Bearerトークン）・APIキーを検証して呼び出し元を返す
+// HTTPとgRPCで同じ検証を使う。認証情報がなければnil、不正な認証情報はエラーを返す
+func Authenticate(tokens *TokenService, cfg Config, authorization, apiKey string) (*Principal, error) {
+	if authorization != "" {
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		claims, err := tokens.Parse(token)
+		if err != nil {
+			return nil, err
+		}
+		userID, err := claims.UserID()
+		if err != nil {
+			return nil, err
+		}
+		return &Principal{UserID: userID}, nil
+	}
+
+	if apiKey != "" {
+		name, ok := cfg.LookupAPIKey(apiKey)
+		if !ok {
+			return nil, ErrInvalidAPIKey
+		}
+		return &Principal{APIKey: name}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tokens := NewTokenService("test-secret", time.Hour)
+	cfg := Config{APIKeys: []APIKey{{Name: "dashboard", Key: "secret-key"}}}
+
+	token, _, err := tokens.Issue(42)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		apiKey        string
+		expected      *Principal
+		expectError   error
+	}{
+		{name: "正常系: Bearerトークン", authorization: "Bearer " + token, expected: &Principal{UserID: 42}},
+		{name: "正常系: APIキー", apiKey: "secret-key", expected: &Principal{APIKey: "dashboard"}},
+		{name: "正常系: 両方ある場合はトークンを優先", authorization: "Bearer " + token, apiKey: "secret-key", expected: &Principal{UserID: 42}},
+		{name: "正常系: 認証情報なし"},
+		{name: "異常系: Bearerでない", authorization: "Basic " + token, expectError: ErrInvalidToken},
+		{name: "異常系: 不正なトークン", authorization: "Bearer not-a-token", expectError: ErrInvalidToken},
+		{name: "異常系: 不明なAPIキー", apiKey: "unknown", expectError: ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := Authenticate(tokens, cfg, tt.authorization, tt.apiKey)
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				assert.Nil(t, principal)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, principal)
+		})
+	}
+}
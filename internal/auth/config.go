@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// defaultJWTSecret 開発用の既定の署名鍵（公開されているため本番環境では使えない）
+const defaultJWTSecret = "your-jwt-secret-key-change-in-production"
+
+// APIKey サービス間連携用のAPIキー
+type APIKey struct {
+	Name string // キー名（レート制限・ログの識別に使う）
+	Key  string // キー文字列
+}
+
+// Config 認証設定
+type Config struct {
+	JWTSecret string        // アクセストークンの署名鍵
+	TokenTTL  time.Duration // アクセストークンの有効期間
+	APIKeys   []APIKey      // 有効なAPIキー
+}
+
+// LoadConfig 環境変数から認証設定を読み込み
+// API_KEYSは「名前:キー」のカンマ区切り
+func LoadConfig() Config {
+	var apiKeys []APIKey
+	for _, entry := range infra.GetEnvList("API_KEYS", nil) {
+		name, key, ok := strings.Cut(entry, ":")
+		if ok && name != "" && key != "" {
+			apiKeys = append(apiKeys, APIKey{Name: name, Key: key})
+		}
+	}
+
+	return Config{
+		JWTSecret: infra.GetEnv("JWT_SECRET", defaultJWTSecret),
+		TokenTTL:  infra.GetEnvDuration("JWT_TTL", 24*time.Hour),
+		APIKeys:   apiKeys,
+	}
+}
+
+// Validate 本番環境でJWT_SECRETが未設定・既定値のままの場合はエラー（誰でもトークンを発行できてしまうため起動しない）
+func (c Config) Validate() error {
+	if infra.IsProduction() && (c.JWTSecret == "" || c.JWTSecret == defaultJWTSecret) {
+		return errors.New("JWT_SECRET must be set to a non-default value in production")
+	}
+	return nil
+}
+
+// LookupAPIKey APIキーを照合してキー名を返す（タイミング攻撃対策に定数時間比較）
+func (c Config) LookupAPIKey(key string) (string, bool) {
+	var name string
+	for _, k := range c.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(k.Key), []byte(key)) == 1 {
+			name = k.Name
+		}
+	}
+	return name, name != ""
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		secret      string
+		expectError bool
+	}{
+		{name: "正常系: 開発環境は既定の署名鍵で起動できる", env: "development", secret: defaultJWTSecret},
+		{name: "正常系: 本番環境で署名鍵を設定", env: "production", secret: "a-long-random-secret"},
+		{name: "異常系: 本番環境で既定の署名鍵", env: "production", secret: defaultJWTSecret, expectError: true},
+		{name: "異常系: 本番環境で署名鍵が空", env: "production", secret: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GO_ENV", tt.env)
+
+			err := Config{JWTSecret: tt.secret}.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
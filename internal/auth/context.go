@@ -0,0 +1,36 @@
+package auth
+
+import "context"
+
+// Principal 認証済みの呼び出し元
+type Principal struct {
+	UserID uint   // トークン認証の場合のユーザーID
+	APIKey string // APIキー認証の場合のキー名
+}
+
+// IsUser ユーザーとして認証されているか
+func (p *Principal) IsUser() bool {
+	return p.UserID != 0
+}
+
+type principalKey struct{}
+
+// WithPrincipal コンテキストに認証済みの呼び出し元を格納
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext コンテキストから認証済みの呼び出し元を取得
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// UserIDFromContext コンテキストから認証済みユーザーIDを取得
+func UserIDFromContext(ctx context.Context) (uint, bool) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || !p.IsUser() {
+		return 0, false
+	}
+	return p.UserID, true
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+// Authenticator メールアドレスとパスワードでユーザーを認証する
+// user.UserUsecase が満たす
+type Authenticator interface {
+	AuthenticateUser(ctx context.Context, email, password string) (*domain.User, error)
+}
+
+type AuthHandler struct {
+	authenticator Authenticator
+	tokens        *TokenService
+}
+
+func NewAuthHandler(authenticator Authenticator, tokens *TokenService) *AuthHandler {
+	return &AuthHandler{authenticator: authenticator, tokens: tokens}
+}
+
+// Login godoc
+// @Summary ログイン
+// @ID login
+// @Description メールアドレスとパスワードで認証し、アクセストークンを発行します
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginRequest true "認証情報"
+// @Success 200 {object} helper.APIResponse{data=LoginResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 401 {object} helper.APIResponse
+// @Failure 429 {object} helper.APIResponse
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c echo.Context) error {
+	var req LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.authenticator.AuthenticateUser(ctx, req.Email, req.Password)
+	if err != nil {
+		return helper.ErrorResponse(c, http.StatusUnauthorized, helper.ErrorCodeUnauthorized, "メールアドレスまたはパスワードが正しくありません", "")
+	}
+
+	token, expiresAt, err := h.tokens.Issue(user.ID)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to issue token", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, "failed to issue token")
+	}
+
+	res := LoginResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, res, "Login succeeded")
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenService(t *testing.T) {
+	now := time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC)
+	service := NewTokenService("test-secret", time.Hour)
+	service.now = func() time.Time { return now }
+
+	token, expiresAt, err := service.Issue(42)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), expiresAt)
+
+	tests := []struct {
+		name        string
+		token       string
+		service     *TokenService
+		elapsed     time.Duration
+		expectError error
+	}{
+		{name: "正常系: 発行したトークンを検証できる", token: token, service: service},
+		{name: "異常系: 有効期限切れ", token: token, service: service, elapsed: time.Hour, expectError: ErrTokenExpired},
+		{name: "異常系: 署名鍵が異なる", token: token, service: NewTokenService("other-secret", time.Hour), expectError: ErrInvalidToken},
+		{name: "異常系: ペイロード改ざん", token: tamper(token), service: service, expectError: ErrInvalidToken},
+		{name: "異常系: 形式不正", token: "not-a-token", service: service, expectError: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.service.now = func() time.Time { return now.Add(tt.elapsed) }
+
+			claims, err := tt.service.Parse(tt.token)
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			userID, err := claims.UserID()
+			assert.NoError(t, err)
+			assert.Equal(t, uint(42), userID)
+		})
+	}
+}
+
+func TestConfig_LookupAPIKey(t *testing.T) {
+	cfg := Config{APIKeys: []APIKey{{Name: "billing", Key: "secret-1"}, {Name: "crm", Key: "secret-2"}}}
+
+	name, ok := cfg.LookupAPIKey("secret-2")
+	assert.True(t, ok)
+	assert.Equal(t, "crm", name)
+
+	_, ok = cfg.LookupAPIKey("unknown")
+	assert.False(t, ok)
+}
+
+// tamper ペイロード部分を別ユーザーのものに差し替える
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	other, _, _ := NewTokenService("x", time.Hour).Issue(1)
+	parts[1] = strings.Split(other, ".")[1]
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,17 @@
+package batch
+
+import "km-api-go/internal/infra"
+
+// Config 一括リクエスト（POST /batch）の設定
+type Config struct {
+	Enabled       bool // /api/v1/batch, /api/v2/batch を公開するか
+	MaxOperations int  // 1回のリクエストで実行できる操作の上限
+}
+
+// LoadConfig 環境変数から一括リクエストの設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		Enabled:       infra.GetEnvBool("BATCH_ENABLED", true),
+		MaxOperations: infra.GetEnvInt("BATCH_MAX_OPERATIONS", 20),
+	}
+}
@@ -0,0 +1,29 @@
+package batch
+
+// BatchRequest 一括リクエスト
+type BatchRequest struct {
+	Atomic     bool             `json:"atomic" example:"true"`                     // trueの場合、いずれかの操作が失敗するとすべて取り消す
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,dive"` // 先頭から順に実行する操作
+}
+
+// BatchOperation 一括リクエストの操作
+type BatchOperation struct {
+	Method  string            `json:"method" validate:"required,oneof=GET POST PUT PATCH DELETE" example:"POST"` // HTTPメソッド
+	Path    string            `json:"path" validate:"required,startswith=/" example:"/users"`                    // APIのベースパスからのパス（クエリを含められる）
+	Headers map[string]string `json:"headers,omitempty"`                                                         // 追加のヘッダー（If-Match等）
+	Body    any               `json:"body,omitempty"`                                                            // リクエストボディ（JSON。JSON Patchの場合は配列）
+}
+
+// OperationResult 操作の結果
+type OperationResult struct {
+	Status  int               `json:"status" example:"201"` // ステータスコード（実行しなかった操作は424）
+	Headers map[string]string `json:"headers,omitempty"`    // レスポンスのヘッダー（ETag, Location）
+	Body    any               `json:"body,omitempty"`       // レスポンスボディ（JSON以外は文字列）
+}
+
+// BatchResponse 一括リクエストの結果
+type BatchResponse struct {
+	Atomic     bool              `json:"atomic"`      // すべてか無しかで実行したか
+	RolledBack bool              `json:"rolled_back"` // 失敗した操作があり、すべて取り消したか
+	Results    []OperationResult `json:"results"`     // 操作ごとの結果（リクエストと同じ順）
+}
@@ -0,0 +1,272 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/helper"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/logging"
+)
+
+// 一括リクエストのパス（APIのバージョンのベースパスからの相対パス）
+const batchPath = "/batch"
+
+// inheritedHeaders 一括リクエストから各操作に引き継ぐ認証のヘッダー（操作ごとには指定できない）
+var inheritedHeaders = []string{echo.HeaderAuthorization, "X-API-Key", echo.HeaderCookie}
+
+// headerIdempotencyKey 操作ごとのIdempotency-Key（すべてか無しかの場合は指定できない）
+const headerIdempotencyKey = "Idempotency-Key"
+
+// resultHeaders 操作の結果に含めるレスポンスのヘッダー
+var resultHeaders = []string{echo.HeaderLocation, helper.HeaderETag, echo.HeaderRetryAfter}
+
+// fileResources ファイルを送受信するリソース（ベースパスからのパスの1番目と3番目のセグメント）
+// ボディ・レスポンスをJSONで表せず、アップロードはトランザクションで取り消せないため、操作に含められない
+var fileResources = map[string][]string{
+	"users":     {"avatar"},
+	"companies": {"attachments", "logo"},
+}
+
+// isFileTransfer エクスポート（CSVのストリーミング）とファイルのアップロード・ダウンロードのパスか
+func isFileTransfer(p string) bool {
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	if segments[0] == "exports" {
+		return true
+	}
+	return len(segments) >= 3 && slices.Contains(fileResources[segments[0]], segments[2])
+}
+
+// errRolledBack 操作が失敗したためトランザクションを取り消す
+var errRolledBack = errors.New("batch operation failed")
+
+// BatchHandler 一括リクエストのハンドラー
+type BatchHandler struct {
+	router http.Handler
+	tx     infra.Transactor
+	cfg    Config
+}
+
+// NewBatchHandler 一括リクエストのハンドラーのコンストラクタ
+// routerには各操作を実行するEchoを渡す（操作はルーターのミドルウェア・認証・レート制限を通る）
+func NewBatchHandler(router http.Handler, tx infra.Transactor, cfg Config) *BatchHandler {
+	return &BatchHandler{router: router, tx: tx, cfg: cfg}
+}
+
+// Batch godoc
+// @Summary 一括リクエスト
+// @ID executeBatch
+// @Description 複数の操作（メソッド・パス・ボディ）を先頭から順に実行し、操作ごとの結果を返します。パスは同じAPIバージョンのベースパスからの相対パスで、各操作は一括リクエストと同じ認証で実行します。atomicがtrueの場合は1つのトランザクションで実行し、いずれかの操作が失敗（4xx・5xx）すると、すべての操作を取り消して422を返します（以降の操作は実行せず424になります）
+// @Tags batch
+// @Accept json
+// @Produce json
+// @Param batch body BatchRequest true "操作の一覧"
+// @Success 200 {object} helper.APIResponse{data=BatchResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 401 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse{data=BatchResponse} "失敗した操作があり、すべて取り消した"
+// @Failure 500 {object} helper.APIResponse
+// @Router /batch [post]
+func (h *BatchHandler) Batch(c echo.Context) error {
+	var req BatchRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if len(req.Operations) > h.cfg.MaxOperations {
+		return helper.ValidationErrorResponse(c, fmt.Sprintf("操作は最大%d件です", h.cfg.MaxOperations))
+	}
+
+	// すべての操作を検証してから実行する
+	base := strings.TrimSuffix(c.Path(), batchPath)
+	requests := make([]*http.Request, len(req.Operations))
+	for i, op := range req.Operations {
+		r, err := h.newRequest(c, base, i, op, req.Atomic)
+		if err != nil {
+			return helper.ValidationErrorResponse(c, fmt.Sprintf("operations[%d]: %v", i, err))
+		}
+		requests[i] = r
+	}
+
+	ctx := c.Request().Context()
+	res := BatchResponse{Atomic: req.Atomic, Results: make([]OperationResult, len(requests))}
+	if !req.Atomic {
+		for i, r := range requests {
+			res.Results[i] = h.dispatch(r.WithContext(ctx))
+		}
+		return helper.SuccessResponse(c, http.StatusOK, res, "")
+	}
+
+	// すべてか無しか（各操作のユースケースは同じトランザクションに参加する）
+	failed := -1
+	err := h.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for i, r := range requests {
+			res.Results[i] = h.dispatch(r.WithContext(ctx))
+			if res.Results[i].Status >= http.StatusBadRequest {
+				failed = i
+				return errRolledBack
+			}
+		}
+		return nil
+	})
+	if failed >= 0 {
+		for i := failed + 1; i < len(res.Results); i++ {
+			res.Results[i] = OperationResult{Status: http.StatusFailedDependency}
+		}
+		res.RolledBack = true
+		return c.JSON(http.StatusUnprocessableEntity, helper.APIResponse{
+			Success: false,
+			Data:    res,
+			Error: &helper.APIError{
+				Code:    helper.ErrorCodeBatchFailed,
+				Message: "失敗した操作があるため、すべての操作を取り消しました",
+				Details: fmt.Sprintf("operations[%d]: %d", failed, res.Results[failed].Status),
+			},
+		})
+	}
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to commit batch", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+	return helper.SuccessResponse(c, http.StatusOK, res, "")
+}
+
+// newRequest 操作をAPIのリクエストに変換する
+func (h *BatchHandler) newRequest(c echo.Context, base string, index int, op BatchOperation, atomic bool) (*http.Request, error) {
+	u, err := url.Parse(op.Path)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return nil, fmt.Errorf("invalid path %q", op.Path)
+	}
+	if path.Clean(u.Path) != u.Path {
+		return nil, fmt.Errorf("path must be clean: %q", op.Path)
+	}
+	if u.Path == batchPath {
+		return nil, errors.New("batch requests cannot be nested")
+	}
+	if strings.HasSuffix(u.Path, "/events") {
+		return nil, errors.New("streaming endpoints are not allowed")
+	}
+	if isFileTransfer(u.Path) {
+		return nil, errors.New("export and file endpoints are not allowed")
+	}
+
+	var body []byte
+	if op.Body != nil {
+		if body, err = json.Marshal(op.Body); err != nil {
+			return nil, fmt.Errorf("invalid body: %w", err)
+		}
+	}
+	r, err := http.NewRequest(op.Method, base+u.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range op.Headers {
+		name = http.CanonicalHeaderKey(name)
+		for _, inherited := range inheritedHeaders {
+			if name == http.CanonicalHeaderKey(inherited) {
+				return nil, fmt.Errorf("header %s cannot be set per operation", name)
+			}
+		}
+		if atomic && name == headerIdempotencyKey {
+			return nil, fmt.Errorf("header %s cannot be used with atomic", name)
+		}
+		r.Header.Set(name, value)
+	}
+	if body != nil && r.Header.Get(echo.HeaderContentType) == "" {
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+
+	parent := c.Request()
+	for _, name := range inheritedHeaders {
+		if v := parent.Header.Values(name); len(v) > 0 {
+			r.Header[http.CanonicalHeaderKey(name)] = v
+		}
+	}
+	if requestID := c.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+		r.Header.Set(echo.HeaderXRequestID, requestID+"-"+strconv.Itoa(index))
+	}
+	// クライアントIPは一括リクエスト自体のものを使う（操作ごとに指定されたヘッダーは使わない）
+	r.RemoteAddr = parent.RemoteAddr
+	for _, name := range []string{echo.HeaderXForwardedFor, echo.HeaderXRealIP} {
+		r.Header.Del(name)
+		if v := parent.Header.Values(name); len(v) > 0 {
+			r.Header[name] = v
+		}
+	}
+	return r, nil
+}
+
+// dispatch 操作をルーターで実行し、結果を返す
+func (h *BatchHandler) dispatch(r *http.Request) OperationResult {
+	rec := newRecorder()
+	h.router.ServeHTTP(rec, r)
+
+	res := OperationResult{Status: rec.status}
+	for _, name := range resultHeaders {
+		if v := rec.header.Get(name); v != "" {
+			if res.Headers == nil {
+				res.Headers = map[string]string{}
+			}
+			res.Headers[name] = v
+		}
+	}
+	if rec.body.Len() > 0 {
+		res.Body = resultBody(rec.header.Get(echo.HeaderContentType), rec.body.Bytes())
+	}
+	return res
+}
+
+// resultBody JSONのレスポンスはそのまま、それ以外は文字列として結果に含める
+func resultBody(contentType string, body []byte) json.RawMessage {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if (mediaType == echo.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json")) && json.Valid(body) {
+		return bytes.TrimSpace(body)
+	}
+	b, _ := json.Marshal(string(body))
+	return b
+}
+
+// recorder 操作のレスポンスを保持するResponseWriter
+type recorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{header: http.Header{}, status: http.StatusOK}
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.status = status
+	r.wroteHeader = true
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.body.Write(b)
+}
@@ -0,0 +1,240 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"km-api-go/internal/helper"
+)
+
+// fakeTransactor WithinTxの結果（コミット・ロールバック）を記録する
+type fakeTransactor struct {
+	calls     int
+	committed bool
+}
+
+type txKey struct{}
+
+func (f *fakeTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	err := fn(context.WithValue(ctx, txKey{}, true))
+	f.committed = err == nil
+	return err
+}
+
+// operationLog 各操作が受け取ったリクエスト
+type operationLog struct {
+	method, path, auth, contentType, ifMatch, requestID, forwardedFor, body string
+	inTx                                                                    bool
+}
+
+func newBatchTestEcho(tx *fakeTransactor, logs *[]operationLog) *echo.Echo {
+	e := echo.New()
+	e.Validator = helper.NewValidator()
+	handler := NewBatchHandler(e, tx, Config{Enabled: true, MaxOperations: 3})
+
+	record := func(status int) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			body, _ := io.ReadAll(r.Body)
+			*logs = append(*logs, operationLog{
+				method:       r.Method,
+				path:         r.URL.RequestURI(),
+				auth:         r.Header.Get(echo.HeaderAuthorization),
+				contentType:  r.Header.Get(echo.HeaderContentType),
+				ifMatch:      r.Header.Get(helper.HeaderIfMatch),
+				requestID:    r.Header.Get(echo.HeaderXRequestID),
+				forwardedFor: r.Header.Get(echo.HeaderXForwardedFor),
+				body:         string(body),
+				inTx:         r.Context().Value(txKey{}) != nil,
+			})
+			if status >= http.StatusBadRequest {
+				return helper.NotFoundResponse(c, "ユーザー")
+			}
+			c.Response().Header().Set(helper.HeaderETag, `"1"`)
+			return helper.SuccessResponse(c, status, map[string]any{"id": 1}, "")
+		}
+	}
+
+	api := e.Group("/api/v2")
+	api.POST("/batch", handler.Batch)
+	api.POST("/users", record(http.StatusCreated))
+	api.PATCH("/users/:id", record(http.StatusOK))
+	api.GET("/users/:id", record(http.StatusNotFound))
+	api.GET("/users/:id/card", func(c echo.Context) error {
+		return c.String(http.StatusOK, "山田太郎\n")
+	})
+	return e
+}
+
+func TestBatchHandler_Batch(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedLogs   []operationLog
+		expectedTx     *fakeTransactor
+	}{
+		{
+			name: "正常系: 操作を順に実行し、失敗しても続ける",
+			body: `{"operations":[
+				{"method":"POST","path":"/users","body":{"name":"山田太郎"}},
+				{"method":"GET","path":"/users/2"},
+				{"method":"PATCH","path":"/users/1","headers":{"content-type":"application/merge-patch+json","If-Match":"\"1\""},"body":{"name":"山田花子"}}
+			]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"atomic":false,"rolled_back":false,"results":[
+				{"status":201,"headers":{"ETag":"\"1\""},"body":{"success":true,"data":{"id":1}}},
+				{"status":404,"body":{"success":false,"error":{"code":"NOT_FOUND","message":"ユーザーが見つかりません"}}},
+				{"status":200,"headers":{"ETag":"\"1\""},"body":{"success":true,"data":{"id":1}}}
+			]}}`,
+			expectedLogs: []operationLog{
+				{method: http.MethodPost, path: "/api/v2/users", auth: "Bearer token", contentType: echo.MIMEApplicationJSON, requestID: "req-0", body: `{"name":"山田太郎"}`},
+				{method: http.MethodGet, path: "/api/v2/users/2", auth: "Bearer token", requestID: "req-1"},
+				{method: http.MethodPatch, path: "/api/v2/users/1", auth: "Bearer token", contentType: "application/merge-patch+json", ifMatch: `"1"`, requestID: "req-2", body: `{"name":"山田花子"}`},
+			},
+			expectedTx: &fakeTransactor{},
+		},
+		{
+			name:           "正常系: 操作ごとのX-Forwarded-Forは使わない",
+			body:           `{"operations":[{"method":"GET","path":"/users/2","headers":{"X-Forwarded-For":"203.0.113.1"}}]}`,
+			expectedStatus: http.StatusOK,
+			expectedLogs: []operationLog{
+				{method: http.MethodGet, path: "/api/v2/users/2", auth: "Bearer token", requestID: "req-0"},
+			},
+			expectedTx: &fakeTransactor{},
+		},
+		{
+			name:           "正常系: JSON以外のレスポンスは文字列で返す",
+			body:           `{"operations":[{"method":"GET","path":"/users/1/card"}]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"atomic":false,"rolled_back":false,"results":[
+				{"status":200,"body":"山田太郎\n"}
+			]}}`,
+			expectedTx: &fakeTransactor{},
+		},
+		{
+			name: "正常系: すべて成功すればコミット",
+			body: `{"atomic":true,"operations":[
+				{"method":"POST","path":"/users","body":{"name":"山田太郎"}},
+				{"method":"PATCH","path":"/users/1","body":[{"op":"replace","path":"/name","value":"山田花子"}]}
+			]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"success":true,"data":{"atomic":true,"rolled_back":false,"results":[
+				{"status":201,"headers":{"ETag":"\"1\""},"body":{"success":true,"data":{"id":1}}},
+				{"status":200,"headers":{"ETag":"\"1\""},"body":{"success":true,"data":{"id":1}}}
+			]}}`,
+			expectedLogs: []operationLog{
+				{method: http.MethodPost, path: "/api/v2/users", auth: "Bearer token", contentType: echo.MIMEApplicationJSON, requestID: "req-0", body: `{"name":"山田太郎"}`, inTx: true},
+				{method: http.MethodPatch, path: "/api/v2/users/1", auth: "Bearer token", contentType: echo.MIMEApplicationJSON, requestID: "req-1", body: `[{"op":"replace","path":"/name","value":"山田花子"}]`, inTx: true},
+			},
+			expectedTx: &fakeTransactor{calls: 1, committed: true},
+		},
+		{
+			name: "異常系: 失敗した操作があればすべて取り消し、以降は実行しない",
+			body: `{"atomic":true,"operations":[
+				{"method":"POST","path":"/users","body":{"name":"山田太郎"}},
+				{"method":"GET","path":"/users/2"},
+				{"method":"POST","path":"/users","body":{"name":"山田花子"}}
+			]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: `{"success":false,"data":{"atomic":true,"rolled_back":true,"results":[
+				{"status":201,"headers":{"ETag":"\"1\""},"body":{"success":true,"data":{"id":1}}},
+				{"status":404,"body":{"success":false,"error":{"code":"NOT_FOUND","message":"ユーザーが見つかりません"}}},
+				{"status":424}
+			]},"error":{"code":"BATCH_FAILED","message":"失敗した操作があるため、すべての操作を取り消しました","details":"operations[1]: 404"}}`,
+			expectedLogs: []operationLog{
+				{method: http.MethodPost, path: "/api/v2/users", auth: "Bearer token", contentType: echo.MIMEApplicationJSON, requestID: "req-0", body: `{"name":"山田太郎"}`, inTx: true},
+				{method: http.MethodGet, path: "/api/v2/users/2", auth: "Bearer token", requestID: "req-1", inTx: true},
+			},
+			expectedTx: &fakeTransactor{calls: 1, committed: false},
+		},
+		{
+			name:           "異常系: 操作の上限を超える",
+			body:           `{"operations":[{"method":"GET","path":"/users/1"},{"method":"GET","path":"/users/1"},{"method":"GET","path":"/users/1"},{"method":"GET","path":"/users/1"}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedTx:     &fakeTransactor{},
+		},
+		{
+			name:           "異常系: 操作がない",
+			body:           `{"operations":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedTx:     &fakeTransactor{},
+		},
+		{
+			name:           "異常系: 不正なメソッド",
+			body:           `{"operations":[{"method":"TRACE","path":"/users/1"}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedTx:     &fakeTransactor{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTransactor{}
+			var logs []operationLog
+			e := newBatchTestEcho(tx, &logs)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v2/batch", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+			rec := httptest.NewRecorder()
+			rec.Header().Set(echo.HeaderXRequestID, "req")
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			assert.Equal(t, tt.expectedLogs, logs)
+			assert.Equal(t, tt.expectedTx, tx)
+		})
+	}
+}
+
+func TestBatchHandler_InvalidOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		atomic    bool
+	}{
+		{name: "異常系: ベースパスの外", operation: `{"method":"GET","path":"/../../livez"}`},
+		{name: "異常系: 絶対URL", operation: `{"method":"GET","path":"//example.com/users"}`},
+		{name: "異常系: 一括リクエストの入れ子", operation: `{"method":"POST","path":"/batch"}`},
+		{name: "異常系: ストリーミング", operation: `{"method":"GET","path":"/companies/1/events"}`},
+		{name: "異常系: エクスポート", operation: `{"method":"GET","path":"/exports/users?format=csv"}`},
+		{name: "異常系: アバター画像のアップロード", operation: `{"method":"PUT","path":"/users/1/avatar"}`},
+		{name: "異常系: 添付ファイルのアップロード", operation: `{"method":"POST","path":"/companies/1/attachments"}`},
+		{name: "異常系: 添付ファイルのダウンロード", operation: `{"method":"GET","path":"/companies/1/attachments/2/download"}`},
+		{name: "異常系: ロゴ画像", operation: `{"method":"DELETE","path":"/companies/1/logo"}`},
+		{name: "異常系: 認証ヘッダーの上書き", operation: `{"method":"GET","path":"/users/1","headers":{"authorization":"Bearer other"}}`},
+		{name: "異常系: すべてか無しかでIdempotency-Key", operation: `{"method":"POST","path":"/users","headers":{"Idempotency-Key":"key"}}`, atomic: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTransactor{}
+			var logs []operationLog
+			e := newBatchTestEcho(tx, &logs)
+
+			body := `{"atomic":` + map[bool]string{true: "true", false: "false"}[tt.atomic] + `,"operations":[` + tt.operation + `]}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v2/batch", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "operations[0]")
+			assert.Empty(t, logs)
+			assert.Zero(t, tx.calls)
+		})
+	}
+}
@@ -0,0 +1,774 @@
+// Package clientgen OpenAPI仕様からGoクライアント（pkg/client）の型とメソッドを生成する
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// reservedNames 手書きのクライアントと衝突するため、スキーマ名にパッケージ名を付ける型名
+var reservedNames = map[string]bool{
+	"Client": true, "Option": true, "Page": true, "Error": true, "TokenSource": true, "RetryPolicy": true,
+	"Request": true, "Response": true, "GraphQLRequest": true, "GraphQLResponse": true, "GraphQLError": true,
+}
+
+// initialisms Goの命名で大文字にする略語
+var initialisms = map[string]bool{"id": true, "url": true, "api": true, "http": true, "json": true, "uuid": true, "ip": true}
+
+// methodOrder 同じパスの操作を並べる順
+var methodOrder = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// resultKind 成功レスポンスの読み方
+type resultKind int
+
+const (
+	resultNone resultKind = iota // ボディを返さない（共通形式でdataがない）
+	resultData                   // 共通形式（helper.APIResponse）のdata
+	resultPage                   // ページネーション付きの共通形式（helper.PaginatedResponse）
+	resultJSON                   // 共通形式でないJSON
+	resultRaw                    // JSON以外（CSV, Server-Sent Events等）。*http.Responseを返す
+)
+
+type operation struct {
+	name        string
+	method      string
+	path        string
+	summary     string
+	description string
+	pathParams  []param
+	params      []param // クエリ・ヘッダー
+	body        *requestBody
+	result      resultKind
+	resultType  string // resultPageの場合は要素の型
+}
+
+type param struct {
+	name        string
+	in          string
+	goName      string
+	goType      string
+	description string
+	required    bool
+}
+
+type requestBody struct {
+	argName      string
+	goType       string
+	contentTypes []string
+	raw          bool   // JSON以外（io.Readerで受け取る）
+	formField    string // multipart/form-dataで送るファイルのフィールド名（ファイル名とio.Readerで受け取る）
+}
+
+type generator struct {
+	doc       *openapi3.T
+	basePath  string
+	names     map[string]string // スキーマのキー（user.UserResponse）→ 型名
+	used      map[string]bool
+	requests  map[string]bool // リクエストボディに使うスキーマ（省略可能な値をポインターにする）
+	envelopes map[string]bool // 共通のレスポンス形式（success, dataを持つ）
+	imports   map[string]bool
+}
+
+// Generate OpenAPI 3の仕様からpkgパッケージのソースを生成する
+// operationId（@ID）のない操作は生成しない
+func Generate(doc *openapi3.T, pkg string) ([]byte, error) {
+	basePath, err := doc.Servers.BasePath()
+	if err != nil {
+		return nil, fmt.Errorf("invalid server url: %w", err)
+	}
+	g := &generator{
+		doc:       doc,
+		basePath:  strings.TrimSuffix(basePath, "/"),
+		names:     map[string]string{},
+		used:      map[string]bool{},
+		requests:  map[string]bool{},
+		envelopes: map[string]bool{},
+		imports:   map[string]bool{"context": true, "net/http": true},
+	}
+	g.nameSchemas()
+
+	ops, err := g.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	g.writeTypes(&body)
+	for _, op := range ops {
+		g.writeOperation(&body, op)
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by cmd/openapi from swagger/src/swagger.json. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	src.WriteString("import (\n")
+	for _, path := range sortedKeys(g.imports) {
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	src.WriteString(")\n\n")
+	fmt.Fprintf(&src, "// BasePath APIのベースパス\nconst BasePath = %q\n\n", g.basePath)
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated client: %w", err)
+	}
+	return formatted, nil
+}
+
+// nameSchemas スキーマの型名を決める（パッケージ名を除き、衝突する場合はパッケージ名を付ける）
+func (g *generator) nameSchemas() {
+	keys := sortedKeys(g.doc.Components.Schemas)
+	count := map[string]int{}
+	for _, key := range keys {
+		count[bareName(key)]++
+	}
+	for _, key := range keys {
+		name := bareName(key)
+		if count[name] > 1 || reservedNames[name] {
+			if pkg, _, ok := strings.Cut(key, "."); ok {
+				name = goName(pkg) + name
+			}
+		}
+		g.names[key] = name
+
+		schema := g.doc.Components.Schemas[key].Value
+		if schema != nil && schema.Properties["success"] != nil && schema.Properties["data"] != nil {
+			g.envelopes[key] = true
+		}
+	}
+
+	// 手書きのクライアントがエラー・ページネーションの読み込みに使う
+	for key := range g.envelopes {
+		for name, prop := range g.doc.Components.Schemas[key].Value.Properties {
+			if name != "data" {
+				g.goType(prop)
+			}
+		}
+	}
+}
+
+func bareName(key string) string {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	return goName(key)
+}
+
+// operations 生成する操作をパス・メソッドの順に集める
+func (g *generator) operations() ([]operation, error) {
+	var ops []operation
+	for _, path := range g.doc.Paths.InMatchingOrder() {
+		item := g.doc.Paths.Value(path)
+		for _, method := range methodOrder {
+			op := item.GetOperation(method)
+			if op == nil || op.OperationID == "" {
+				continue
+			}
+			o, err := g.operation(method, path, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			ops = append(ops, o)
+		}
+	}
+	sort.SliceStable(ops, func(i, j int) bool {
+		if ops[i].path != ops[j].path {
+			return ops[i].path < ops[j].path
+		}
+		return slices.Index(methodOrder, ops[i].method) < slices.Index(methodOrder, ops[j].method)
+	})
+	return ops, nil
+}
+
+func (g *generator) operation(method, path string, op *openapi3.Operation) (operation, error) {
+	o := operation{
+		name:        goName(op.OperationID),
+		method:      method,
+		path:        path,
+		summary:     op.Summary,
+		description: op.Description,
+	}
+
+	for _, ref := range op.Parameters {
+		p := ref.Value
+		if p == nil {
+			continue
+		}
+		prm := param{
+			name:        p.Name,
+			in:          p.In,
+			goName:      goName(p.Name),
+			goType:      g.goType(p.Schema),
+			description: p.Description,
+			required:    p.Required,
+		}
+		switch p.In {
+		case openapi3.ParameterInPath:
+			o.pathParams = append(o.pathParams, prm)
+		case openapi3.ParameterInQuery, openapi3.ParameterInHeader:
+			// ヘッダーとクエリで同じ名前（Last-Event-ID, last_event_id）は場所を付けて区別する
+			if slices.ContainsFunc(o.params, func(p param) bool { return p.goName == prm.goName }) {
+				prm.goName += goName(p.In)
+			}
+			o.params = append(o.params, prm)
+		}
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		body, err := g.requestBody(op.RequestBody.Value)
+		if err != nil {
+			return o, err
+		}
+		o.body = body
+	}
+
+	if err := g.response(&o, op); err != nil {
+		return o, err
+	}
+	return o, nil
+}
+
+func (g *generator) requestBody(rb *openapi3.RequestBody) (*requestBody, error) {
+	contentTypes := sortedKeys(rb.Content)
+	if len(contentTypes) == 0 {
+		return nil, nil
+	}
+	body := &requestBody{argName: "body", contentTypes: contentTypes}
+	if name, ok := rb.Extensions["x-originalParamName"].(string); ok {
+		body.argName = argName(name)
+	}
+	for _, ct := range contentTypes {
+		if !isJSON(ct) {
+			body.raw = true
+		}
+	}
+	if body.raw {
+		g.imports["io"] = true
+		body.goType = "io.Reader"
+		if field, ok := multipartFile(rb, contentTypes); ok {
+			body.formField = field
+			body.argName = argName(field)
+		}
+		return body, nil
+	}
+
+	schema := rb.Content.Get(contentTypes[0]).Schema
+	g.markRequest(schema, map[string]bool{})
+	body.goType = g.goType(schema)
+	return body, nil
+}
+
+// multipartFile ファイル1つだけを送るmultipart/form-dataのボディならそのフィールド名を返す
+func multipartFile(rb *openapi3.RequestBody, contentTypes []string) (string, bool) {
+	if len(contentTypes) != 1 || contentTypes[0] != "multipart/form-data" {
+		return "", false
+	}
+	schema := rb.Content.Get(contentTypes[0]).Schema
+	if schema == nil || schema.Value == nil || len(schema.Value.Properties) != 1 {
+		return "", false
+	}
+	for name, prop := range schema.Value.Properties {
+		if prop.Value != nil && prop.Value.Format == "binary" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// markRequest リクエストボディに使うスキーマ（入れ子を含む）を記録
+func (g *generator) markRequest(s *openapi3.SchemaRef, seen map[string]bool) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		key := refKey(s.Ref)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		g.requests[key] = true
+	}
+	if v := s.Value; v != nil {
+		g.markRequest(v.Items, seen)
+		for _, prop := range v.Properties {
+			g.markRequest(prop, seen)
+		}
+		for _, sub := range v.AllOf {
+			g.markRequest(sub, seen)
+		}
+	}
+}
+
+// response 最初の2xxのレスポンスから戻り値を決める（2xxがなく3xxがある場合はリダイレクト先のレスポンス）
+func (g *generator) response(o *operation, op *openapi3.Operation) error {
+	var status string
+	for _, code := range sortedKeys(op.Responses.Map()) {
+		if strings.HasPrefix(code, "2") {
+			status = code
+			break
+		}
+	}
+	if status == "" {
+		// リダイレクトのみの場合（署名付きURLへのダウンロード等）はリダイレクト先のレスポンスを返す
+		if slices.ContainsFunc(sortedKeys(op.Responses.Map()), func(code string) bool { return strings.HasPrefix(code, "3") }) {
+			o.result = resultRaw
+			return nil
+		}
+		return fmt.Errorf("no success response")
+	}
+	resp := op.Responses.Value(status).Value
+	if resp == nil || len(resp.Content) == 0 {
+		o.result = resultNone
+		return nil
+	}
+
+	media := resp.Content.Get("application/json")
+	if media == nil {
+		o.result = resultRaw
+		return nil
+	}
+
+	envelope, data := g.unwrap(media.Schema)
+	switch {
+	case envelope == "":
+		o.result = resultJSON
+		o.resultType = g.goType(media.Schema)
+	case data == nil || isEmpty(data):
+		o.result = resultNone
+	case g.doc.Components.Schemas[envelope].Value.Properties["pagination"] != nil:
+		if data.Value == nil || !data.Value.Type.Is(openapi3.TypeArray) {
+			return fmt.Errorf("paginated response data must be an array")
+		}
+		o.result = resultPage
+		o.resultType = g.goType(data.Value.Items)
+	default:
+		o.result = resultData
+		o.resultType = g.goType(data)
+	}
+	return nil
+}
+
+// unwrap 共通のレスポンス形式（allOf: [helper.APIResponse, {data: T}]）ならその名前とdataのスキーマを返す
+func (g *generator) unwrap(s *openapi3.SchemaRef) (string, *openapi3.SchemaRef) {
+	if s == nil {
+		return "", nil
+	}
+	if s.Ref != "" {
+		key := refKey(s.Ref)
+		if g.envelopes[key] {
+			return key, s.Value.Properties["data"]
+		}
+		return "", nil
+	}
+	if s.Value == nil {
+		return "", nil
+	}
+
+	var envelope string
+	var data *openapi3.SchemaRef
+	for _, sub := range s.Value.AllOf {
+		if sub.Ref != "" && g.envelopes[refKey(sub.Ref)] {
+			envelope = refKey(sub.Ref)
+			if data == nil {
+				data = sub.Value.Properties["data"]
+			}
+		} else if sub.Value != nil && sub.Value.Properties["data"] != nil {
+			data = sub.Value.Properties["data"]
+		}
+	}
+	return envelope, data
+}
+
+// goType スキーマのGoの型（参照したスキーマを生成の対象にする）
+func (g *generator) goType(s *openapi3.SchemaRef) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		key := refKey(s.Ref)
+		g.used[key] = true
+		return g.names[key]
+	}
+	v := s.Value
+	if v == nil {
+		return "any"
+	}
+	// swagは説明を付けた参照を allOf: [{$ref}] で出力する
+	if len(v.AllOf) == 1 && len(v.Properties) == 0 && v.Type == nil {
+		return g.goType(v.AllOf[0])
+	}
+
+	switch {
+	case v.Type.Is(openapi3.TypeString):
+		if v.Format == "date-time" {
+			g.imports["time"] = true
+			return "time.Time"
+		}
+		return "string"
+	case v.Type.Is(openapi3.TypeInteger):
+		return "int64"
+	case v.Type.Is(openapi3.TypeNumber):
+		return "float64"
+	case v.Type.Is(openapi3.TypeBoolean):
+		return "bool"
+	case v.Type.Is(openapi3.TypeArray):
+		return "[]" + g.goType(v.Items)
+	case v.Type.Is(openapi3.TypeObject):
+		if v.AdditionalProperties.Schema != nil {
+			return "map[string]" + g.goType(v.AdditionalProperties.Schema)
+		}
+		return "map[string]any"
+	}
+	return "any"
+}
+
+// writeTypes 参照されたスキーマの型を名前順に書き出す（型の中で参照したスキーマも含める）
+func (g *generator) writeTypes(w *bytes.Buffer) {
+	visited := map[string]bool{}
+	for {
+		var pending []string
+		for key := range g.used {
+			if !visited[key] {
+				pending = append(pending, key)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		for _, key := range pending {
+			visited[key] = true
+			if schema := g.doc.Components.Schemas[key].Value; schema != nil {
+				for _, prop := range schema.Properties {
+					g.goType(prop)
+				}
+				g.goType(schema.Items)
+			}
+		}
+	}
+
+	var keys []string
+	for key := range g.used {
+		if !g.envelopes[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return g.names[keys[i]] < g.names[keys[j]] })
+	for _, key := range keys {
+		g.writeType(w, key)
+	}
+}
+
+func (g *generator) writeType(w *bytes.Buffer, key string) {
+	name := g.names[key]
+	schema := g.doc.Components.Schemas[key].Value
+
+	if schema.Description != "" {
+		writeComment(w, "", name+" "+schema.Description)
+	} else {
+		fmt.Fprintf(w, "// %s %s\n", name, key)
+	}
+
+	if schema.Type.Is(openapi3.TypeString) && len(schema.Enum) > 0 {
+		fmt.Fprintf(w, "type %s string\n\n", name)
+		varNames, _ := schema.Extensions["x-enum-varnames"].([]any)
+		w.WriteString("const (\n")
+		for i, value := range schema.Enum {
+			constName := name + goName(fmt.Sprint(value))
+			if i < len(varNames) {
+				constName = fmt.Sprint(varNames[i])
+			}
+			fmt.Fprintf(w, "\t%s %s = %q\n", constName, name, fmt.Sprint(value))
+		}
+		w.WriteString(")\n\n")
+		return
+	}
+	if !schema.Type.Is(openapi3.TypeObject) {
+		fmt.Fprintf(w, "type %s = %s\n\n", name, g.goType(&openapi3.SchemaRef{Value: schema}))
+		return
+	}
+
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	for _, prop := range sortedKeys(schema.Properties) {
+		ref := schema.Properties[prop]
+		typ := g.goType(ref)
+		required := slices.Contains(schema.Required, prop)
+		tag := prop
+		if !required {
+			// time.Timeはomitemptyで省略されないためomitzeroにする
+			if typ == "time.Time" {
+				tag += ",omitzero"
+			} else {
+				tag += ",omitempty"
+			}
+			// 省略とゼロ値（false, 0）を区別して送れるようにする
+			if g.requests[key] && (typ == "bool" || typ == "int64" || typ == "float64" || typ == "time.Time") {
+				typ = "*" + typ
+			}
+		}
+		fmt.Fprintf(w, "\t%s %s `json:%q`", goName(prop), typ, tag)
+		if ref.Value != nil && ref.Value.Description != "" {
+			fmt.Fprintf(w, " // %s", oneLine(ref.Value.Description))
+		}
+		w.WriteString("\n")
+	}
+	w.WriteString("}\n\n")
+}
+
+func (g *generator) writeOperation(w *bytes.Buffer, o operation) {
+	if len(o.params) > 0 {
+		fmt.Fprintf(w, "// %sParams %sのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）\n", o.name, o.name)
+		fmt.Fprintf(w, "type %sParams struct {\n", o.name)
+		for _, p := range o.params {
+			var notes []string
+			if p.in == openapi3.ParameterInHeader {
+				notes = append(notes, p.name+"ヘッダー")
+			}
+			if p.required {
+				notes = append(notes, "必須")
+			}
+			comment := p.description
+			if len(notes) > 0 {
+				comment += "（" + strings.Join(notes, "・") + "）"
+			}
+			fmt.Fprintf(w, "\t%s %s", p.goName, p.goType)
+			if comment != "" {
+				fmt.Fprintf(w, " // %s", oneLine(comment))
+			}
+			w.WriteString("\n")
+		}
+		w.WriteString("}\n\n")
+	}
+
+	// 引数
+	args := []string{"ctx context.Context"}
+	var callArgs []string
+	for _, p := range o.pathParams {
+		args = append(args, argName(p.name)+" "+p.goType)
+		callArgs = append(callArgs, argName(p.name))
+	}
+	if o.body != nil {
+		if len(o.body.contentTypes) > 1 {
+			args = append(args, "contentType string")
+		}
+		if o.body.formField != "" {
+			args = append(args, "filename string")
+		}
+		args = append(args, o.body.argName+" "+o.body.goType)
+	}
+	if len(o.params) > 0 {
+		args = append(args, "params *"+o.name+"Params")
+	}
+
+	var returns, zero string
+	switch o.result {
+	case resultNone:
+		returns = "error"
+	case resultData, resultJSON:
+		if g.isStruct(o.resultType) {
+			returns, zero = "(*"+o.resultType+", error)", "nil"
+		} else {
+			returns, zero = "("+o.resultType+", error)", "nil"
+		}
+	case resultPage:
+		returns = "(*Page[" + o.resultType + "], error)"
+	case resultRaw:
+		returns = "(*http.Response, error)"
+	}
+
+	writeComment(w, "", o.name+" "+o.summary)
+	if o.description != "" {
+		writeComment(w, "", o.description)
+	}
+	if o.result == resultRaw {
+		w.WriteString("// 成功時のレスポンスをそのまま返すため、呼び出し元がボディを閉じる\n")
+	}
+	fmt.Fprintf(w, "//\n// %s %s%s\n", o.method, g.basePath, o.path)
+	fmt.Fprintf(w, "func (c *Client) %s(%s) %s {\n", o.name, strings.Join(args, ", "), returns)
+	fmt.Fprintf(w, "\treq := newRequest(%s, %s)\n", methodConst(o.method), pathExpr(o.path))
+	if len(o.params) > 0 {
+		w.WriteString("\tif params != nil {\n")
+		for _, p := range o.params {
+			setter := "setQuery"
+			if p.in == openapi3.ParameterInHeader {
+				setter = "setHeader"
+			}
+			fmt.Fprintf(w, "\t\treq.%s(%q, params.%s)\n", setter, p.name, p.goName)
+		}
+		w.WriteString("\t}\n")
+	}
+	if o.body != nil {
+		contentType := "contentType"
+		if len(o.body.contentTypes) == 1 {
+			contentType = fmt.Sprintf("%q", o.body.contentTypes[0])
+		}
+		setter := "setJSON"
+		if o.body.raw {
+			setter = "setRaw"
+		}
+		if o.body.formField != "" {
+			errReturn := "err"
+			if o.result != resultNone {
+				errReturn = "nil, err"
+			}
+			fmt.Fprintf(w, "\tif err := req.setMultipart(%q, filename, %s); err != nil {\n\t\treturn %s\n\t}\n",
+				o.body.formField, o.body.argName, errReturn)
+		} else {
+			fmt.Fprintf(w, "\treq.%s(%s, %s)\n", setter, contentType, o.body.argName)
+		}
+	}
+
+	switch o.result {
+	case resultNone:
+		w.WriteString("\treturn c.doData(ctx, req, nil)\n")
+	case resultData, resultJSON:
+		do := "doData"
+		if o.result == resultJSON {
+			do = "doJSON"
+		}
+		fmt.Fprintf(w, "\tvar out %s\n", o.resultType)
+		fmt.Fprintf(w, "\tif err := c.%s(ctx, req, &out); err != nil {\n\t\treturn %s, err\n\t}\n", do, zero)
+		if g.isStruct(o.resultType) {
+			w.WriteString("\treturn &out, nil\n")
+		} else {
+			w.WriteString("\treturn out, nil\n")
+		}
+	case resultPage:
+		fmt.Fprintf(w, "\treturn doPage[%s](ctx, c, req)\n", o.resultType)
+	case resultRaw:
+		w.WriteString("\treturn c.do(ctx, req)\n")
+	}
+	w.WriteString("}\n\n")
+
+	// page（ページ番号）のある一覧は全ページを順に返すイテレーターも生成する
+	if o.result == resultPage && slices.ContainsFunc(o.params, func(p param) bool { return p.goName == "Page" && p.goType == "int64" }) {
+		g.imports["iter"] = true
+		iterArgs := slices.Clone(args)
+		fmt.Fprintf(w, "// %sAll %sの全ページを順に取得する（params.Pageのページから開始）\n", o.name, o.name)
+		fmt.Fprintf(w, "func (c *Client) %sAll(%s) iter.Seq2[%s, error] {\n", o.name, strings.Join(iterArgs, ", "), o.resultType)
+		fmt.Fprintf(w, "\tvar p %sParams\n\tif params != nil {\n\t\tp = *params\n\t}\n", o.name)
+		fmt.Fprintf(w, "\treturn paginate(ctx, p.Page, func(page int64) (*Page[%s], error) {\n", o.resultType)
+		w.WriteString("\t\tq := p\n\t\tq.Page = page\n")
+		call := append([]string{"ctx"}, callArgs...)
+		if o.body != nil {
+			if len(o.body.contentTypes) > 1 {
+				call = append(call, "contentType")
+			}
+			if o.body.formField != "" {
+				call = append(call, "filename")
+			}
+			call = append(call, o.body.argName)
+		}
+		call = append(call, "&q")
+		fmt.Fprintf(w, "\t\treturn c.%s(%s)\n\t})\n}\n\n", o.name, strings.Join(call, ", "))
+	}
+}
+
+// isStruct 生成した構造体の型か（戻り値をポインターにする）
+func (g *generator) isStruct(typ string) bool {
+	for key, name := range g.names {
+		if name == typ {
+			schema := g.doc.Components.Schemas[key].Value
+			return schema.Type.Is(openapi3.TypeObject)
+		}
+	}
+	return false
+}
+
+// pathExpr パスのテンプレート（/users/{id}）を文字列の式にする
+func pathExpr(path string) string {
+	var parts []string
+	for path != "" {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			parts = append(parts, fmt.Sprintf("%q", path))
+			break
+		}
+		end := strings.Index(path[start:], "}") + start
+		if start > 0 {
+			parts = append(parts, fmt.Sprintf("%q", path[:start]))
+		}
+		parts = append(parts, "pathParam("+argName(path[start+1:end])+")")
+		path = path[end+1:]
+	}
+	return strings.Join(parts, " + ")
+}
+
+func methodConst(method string) string {
+	return "http.Method" + method[:1] + strings.ToLower(method[1:])
+}
+
+// goName 名前（snake_case, kebab-case, camelCase）をGoの公開名にする
+func goName(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if initialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// argName 名前を引数名にする（webhook_id → webhookID）
+func argName(s string) string {
+	name := goName(s)
+	runes := []rune(name)
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	// 先頭の略語（ID, URL）はまとめて小文字にする
+	if i > 1 && i < len(runes) {
+		i--
+	}
+	name = strings.ToLower(string(runes[:i])) + string(runes[i:])
+	if token.IsKeyword(name) || name == "ctx" || name == "params" || name == "c" || name == "req" || name == "contentType" {
+		name += "Value"
+	}
+	return name
+}
+
+func refKey(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+func isEmpty(s *openapi3.SchemaRef) bool {
+	v := s.Value
+	return s.Ref == "" && v != nil && v.Type == nil && len(v.Properties) == 0 && len(v.AllOf) == 0 && v.Items == nil
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+func writeComment(w *bytes.Buffer, indent, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(w, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,143 @@
+package clientgen
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/openapi"
+	"km-api-go/swagger/src"
+)
+
+// 小さな仕様（共通のレスポンス形式・ページネーション・名前の衝突）
+const testSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "test", "version": "1.0"},
+	"host": "localhost:8080",
+	"basePath": "/api/v1",
+	"paths": {
+		"/items": {
+			"get": {
+				"operationId": "listItems",
+				"summary": "一覧",
+				"produces": ["application/json"],
+				"parameters": [
+					{"name": "page", "in": "query", "type": "integer"},
+					{"name": "X-Trace", "in": "header", "type": "string", "required": true}
+				],
+				"responses": {"200": {"description": "OK", "schema": {"allOf": [
+					{"$ref": "#/definitions/helper.PaginatedResponse"},
+					{"type": "object", "properties": {"data": {"type": "array", "items": {"$ref": "#/definitions/item.Request"}}}}
+				]}}}
+			},
+			"post": {
+				"summary": "operationIdがないため生成しない",
+				"responses": {"200": {"description": "OK"}}
+			}
+		},
+		"/items/{item_id}": {
+			"put": {
+				"operationId": "updateItem",
+				"summary": "更新",
+				"consumes": ["application/json"],
+				"produces": ["application/json"],
+				"parameters": [
+					{"name": "item_id", "in": "path", "type": "integer", "required": true},
+					{"name": "item", "in": "body", "required": true, "schema": {"$ref": "#/definitions/item.Request"}}
+				],
+				"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/helper.APIResponse"}}}
+			}
+		},
+		"/items/{item_id}/image": {
+			"put": {
+				"operationId": "uploadItemImage",
+				"summary": "画像のアップロード",
+				"consumes": ["multipart/form-data"],
+				"parameters": [
+					{"name": "item_id", "in": "path", "type": "integer", "required": true},
+					{"name": "image", "in": "formData", "type": "file", "required": true}
+				],
+				"responses": {"204": {"description": "No Content"}}
+			}
+		},
+		"/items/{item_id}/download": {
+			"get": {
+				"operationId": "downloadItem",
+				"summary": "ダウンロード",
+				"parameters": [
+					{"name": "item_id", "in": "path", "type": "integer", "required": true}
+				],
+				"responses": {"302": {"description": "Found"}, "404": {"description": "Not Found"}}
+			}
+		}
+	},
+	"definitions": {
+		"helper.APIResponse": {"type": "object", "properties": {"success": {"type": "boolean"}, "data": {}, "error": {"$ref": "#/definitions/helper.APIError"}}},
+		"helper.PaginatedResponse": {"type": "object", "properties": {"success": {"type": "boolean"}, "data": {}, "pagination": {"$ref": "#/definitions/helper.PaginationResponse"}}},
+		"helper.APIError": {"type": "object", "properties": {"code": {"type": "string"}}},
+		"helper.PaginationResponse": {"type": "object", "properties": {"page": {"type": "integer"}, "total_pages": {"type": "integer"}}},
+		"item.Request": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}, "active": {"type": "boolean"}}}
+	}
+}`
+
+func TestGenerate(t *testing.T) {
+	doc, err := openapi.ToV3([]byte(testSpec))
+	require.NoError(t, err)
+
+	out, err := Generate(doc, "client")
+	require.NoError(t, err)
+	code := string(out)
+
+	// 手書きのクライアントと衝突する名前にはパッケージ名を付け、リクエストの省略可能な真偽値はポインターにする
+	assert.Contains(t, code, "type ItemRequest struct {\n\tActive *bool  `json:\"active,omitempty\"`\n\tName   string `json:\"name\"`\n}")
+	// 共通のレスポンス形式そのものは生成しない
+	assert.NotContains(t, code, "type APIResponse struct")
+	assert.Contains(t, code, "type APIError struct")
+	assert.Contains(t, code, "XTrace string // （X-Traceヘッダー・必須）")
+	assert.Contains(t, code, "func (c *Client) ListItems(ctx context.Context, params *ListItemsParams) (*Page[ItemRequest], error) {")
+	assert.Contains(t, code, "func (c *Client) ListItemsAll(ctx context.Context, params *ListItemsParams) iter.Seq2[ItemRequest, error] {")
+	assert.Contains(t, code, "func (c *Client) UpdateItem(ctx context.Context, itemID int64, item ItemRequest) error {")
+	assert.Contains(t, code, `req := newRequest(http.MethodPut, "/items/"+pathParam(itemID))`)
+	assert.NotContains(t, code, "http.MethodPost")
+	// ファイルを1つ送るmultipart/form-dataはファイル名と内容を受け取る
+	assert.Contains(t, code, "func (c *Client) UploadItemImage(ctx context.Context, itemID int64, filename string, image io.Reader) error {")
+	assert.Contains(t, code, "if err := req.setMultipart(\"image\", filename, image); err != nil {\n\t\treturn err\n\t}")
+	// リダイレクトのみの操作はリダイレクト先のレスポンスを返す
+	assert.Contains(t, code, "func (c *Client) DownloadItem(ctx context.Context, itemID int64) (*http.Response, error) {")
+}
+
+// pkg/client/api.gen.go がswagの仕様から再生成されているか（task docs の実行漏れを検出する）
+func TestGenerate_ClientIsUpToDate(t *testing.T) {
+	doc, err := openapi.ToV3([]byte(src.SwaggerInfo.ReadDoc()))
+	require.NoError(t, err)
+	expected, err := Generate(doc, "client")
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile("../../pkg/client/api.gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual), "pkg/client/api.gen.go is stale; run `task docs`")
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		goName  string
+		argName string
+	}{
+		{name: "正常系: snake_case", input: "webhook_id", goName: "WebhookID", argName: "webhookID"},
+		{name: "正常系: ヘッダー", input: "If-None-Match", goName: "IfNoneMatch", argName: "ifNoneMatch"},
+		{name: "正常系: 略語のみ", input: "id", goName: "ID", argName: "id"},
+		{name: "正常系: camelCase", input: "operationName", goName: "OperationName", argName: "operationName"},
+		{name: "正常系: 予約語", input: "type", goName: "Type", argName: "typeValue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.goName, goName(tt.input))
+			assert.Equal(t, tt.argName, argName(tt.input))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package company
+
+import (
+	"time"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+)
+
+type CreateCompanyRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Email       string `json:"email" validate:"required,email"`
+	Phone       string `json:"phone" validate:"omitempty,min=10,max=20"`
+	Address     string `json:"address" validate:"omitempty,max=500"`
+	Website     string `json:"website" validate:"omitempty,url"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type UpdateCompanyRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Email       string `json:"email" validate:"required,email"`
+	Phone       string `json:"phone" validate:"omitempty,min=10,max=20"`
+	Address     string `json:"address" validate:"omitempty,max=500"`
+	Website     string `json:"website" validate:"omitempty,url"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+}
+
+// GetCompanyRequest 会社取得のパス・クエリパラメータ
+type GetCompanyRequest struct {
+	helper.IDRequest
+	helper.SparseRequest
+}
+
+type CompanyResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Phone       string    `json:"phone"`
+	Address     string    `json:"address"`
+	Website     string    `json:"website"`
+	Description string    `json:"description"`
+	Version     uint      `json:"version"`
+	CreatedAt   time.Time `json:"created_at" format:"date-time"`
+	UpdatedAt   time.Time `json:"updated_at" format:"date-time"`
+}
+
+// NewCompanyResponse ドメインモデルからレスポンスを作成
+func NewCompanyResponse(c *domain.Company) CompanyResponse {
+	return CompanyResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Email:       c.Email,
+		Phone:       c.Phone,
+		Address:     c.Address,
+		Website:     c.Website,
+		Description: c.Description,
+		Version:     c.Version,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
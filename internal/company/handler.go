@@ -0,0 +1,262 @@
+package company
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/patch"
+	"km-api-go/internal/sparse"
+)
+
+// CompanyHandler 会社のハンドラー（各APIバージョンで共通の処理）
+// バージョンごとのレスポンスと仕様（swagのアノテーション）は internal/apiv1, internal/apiv2 で定義する
+type CompanyHandler struct {
+	usecase CompanyUsecase
+	present Presenter
+	expand  Expander
+}
+
+// Presenter ドメインモデルをAPIバージョンごとのレスポンスに変換する
+type Presenter func(ctx context.Context, c *domain.Company) (any, error)
+
+// Includes GetCompanyのinclude=に指定できる関連
+var Includes = []string{"members", "members.user"}
+
+// Expander include=で指定された関連を取得する
+type Expander interface {
+	// ExpandCompany レスポンスに追加するフィールド名ごとの値を返す
+	ExpandCompany(ctx context.Context, c *domain.Company, include []string) (map[string]any, error)
+}
+
+// NewCompanyHandler v1のレスポンス（CompanyResponse）を返すハンドラーを作成
+func NewCompanyHandler(usecase CompanyUsecase, expand Expander) *CompanyHandler {
+	return NewCompanyHandlerWithPresenter(usecase, expand, func(_ context.Context, c *domain.Company) (any, error) {
+		return NewCompanyResponse(c), nil
+	})
+}
+
+// NewCompanyHandlerWithPresenter レスポンスの形式だけが異なるAPIバージョン（/api/v2等）用のハンドラーを作成
+func NewCompanyHandlerWithPresenter(usecase CompanyUsecase, expand Expander, present Presenter) *CompanyHandler {
+	return &CompanyHandler{usecase: usecase, present: present, expand: expand}
+}
+
+// CreateCompany 会社作成
+func (h *CompanyHandler) CreateCompany(c echo.Context) error {
+	var req CreateCompanyRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	company, err := h.usecase.CreateCompany(ctx, req.Name, req.Email, req.Phone, req.Address, req.Website, req.Description)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return helper.AlreadyExistsResponse(c, "会社")
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to create company", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+
+	return h.respond(c, company, func(data any) error {
+		return helper.CreatedResponse(c, data, "")
+	})
+}
+
+// GetCompany 会社取得
+// fields=で返すフィールドを選び（選んだカラムのみSELECTする）、include=で関連を展開する
+func (h *CompanyHandler) GetCompany(c echo.Context) error {
+	var req GetCompanyRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	include, err := sparse.ParseInclude(req.Include, Includes...)
+	if err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	fields := sparse.Parse(req.Fields)
+
+	ctx := c.Request().Context()
+	var company *domain.Company
+	if len(fields) == 0 {
+		company, err = h.usecase.GetCompanyByID(ctx, req.ID)
+	} else {
+		company, err = h.usecase.GetCompanyByIDWithFields(ctx, req.ID, sparse.GoFields(domain.Company{}, fields))
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return helper.NotFoundResponse(c, "会社")
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to get company", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+
+	// 展開した関連の変更はバージョンに反映されないため、関連を含む場合は304を返さない
+	if len(include) == 0 && helper.NotModified(c, company.Version) {
+		return helper.NotModifiedResponse(c, company.Version)
+	}
+
+	data, err := h.presentSparse(ctx, company, fields, include)
+	if err != nil {
+		if errors.Is(err, sparse.ErrUnknownField) {
+			return helper.ValidationErrorResponse(c, err.Error())
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return helper.ForbiddenResponse(c)
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to build company response", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+	helper.SetETag(c, company.Version)
+	return helper.SuccessResponse(c, http.StatusOK, data, "")
+}
+
+// UpdateCompany 会社更新
+func (h *CompanyHandler) UpdateCompany(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	version, ok := helper.IfMatchVersion(c)
+	if !ok {
+		return helper.PreconditionRequiredResponse(c)
+	}
+
+	var req UpdateCompanyRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	company, err := h.usecase.UpdateCompany(ctx, id.ID, version, req.Name, req.Email, req.Phone, req.Address, req.Website, req.Description)
+	if err != nil {
+		return h.updateErrorResponse(c, err)
+	}
+
+	return h.respond(c, company, func(data any) error {
+		return helper.UpdatedResponse(c, data, "")
+	})
+}
+
+// PatchCompany 会社部分更新
+func (h *CompanyHandler) PatchCompany(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	version, ok := helper.IfMatchVersion(c)
+	if !ok {
+		return helper.PreconditionRequiredResponse(c)
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+	p, err := patch.New(c.Request().Header.Get(echo.HeaderContentType), body)
+	if err != nil {
+		return h.updateErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	company, err := h.usecase.PatchCompany(ctx, id.ID, version, p)
+	if err != nil {
+		return h.updateErrorResponse(c, err)
+	}
+
+	return h.respond(c, company, func(data any) error {
+		return helper.UpdatedResponse(c, data, "")
+	})
+}
+
+// respond ETagを付け、バージョンごとのレスポンスに変換して返す
+func (h *CompanyHandler) respond(c echo.Context, company *domain.Company, write func(data any) error) error {
+	ctx := c.Request().Context()
+	data, err := h.present(ctx, company)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to build company response", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+	helper.SetETag(c, company.Version)
+	return write(data)
+}
+
+// presentSparse バージョンごとのレスポンスから指定したフィールドだけを残し、関連を展開する
+func (h *CompanyHandler) presentSparse(ctx context.Context, company *domain.Company, fields, include []string) (any, error) {
+	data, err := h.present(ctx, company)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 && len(include) == 0 {
+		return data, nil
+	}
+
+	obj, err := sparse.Project(data, fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(include) == 0 {
+		return obj, nil
+	}
+	related, err := h.expand.ExpandCompany(ctx, company, include)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(related)) {
+		if err := obj.Set(name, related[name]); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+// updateErrorResponse 更新系のエラーをレスポンスに変換
+func (h *CompanyHandler) updateErrorResponse(c echo.Context, err error) error {
+	var validationErrs helper.ValidationErrors
+	switch {
+	case errors.Is(err, patch.ErrUnsupportedMediaType):
+		return helper.ErrorResponse(c, http.StatusUnsupportedMediaType, helper.ErrorCodeUnsupportedMedia,
+			"application/merge-patch+json または application/json-patch+json を指定してください", err.Error())
+	case errors.Is(err, patch.ErrInvalidPatch):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "パッチを適用できません", err.Error())
+	case errors.As(err, &validationErrs):
+		return helper.ValidationErrorResponse(c, helper.GetValidationErrorDetails(validationErrs))
+	case errors.Is(err, domain.ErrForbidden):
+		return helper.ForbiddenResponse(c)
+	case errors.Is(err, domain.ErrNotFound):
+		return helper.NotFoundResponse(c, "会社")
+	case errors.Is(err, domain.ErrVersionConflict):
+		return helper.PreconditionFailedResponse(c)
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return helper.AlreadyExistsResponse(c, "メールアドレス")
+	}
+	ctx := c.Request().Context()
+	logging.FromContext(ctx).ErrorContext(ctx, "failed to update company", slog.Any("error", err))
+	return helper.InternalErrorResponse(c, err.Error())
+}
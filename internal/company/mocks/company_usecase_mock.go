@@ -8,3 +8,306 @@
 
 // Package mocks is a generated GoMock package.
 package mocks
+
+import (
+	context "context"
+	domain "km-api-go/internal/domain"
+	helper "km-api-go/internal/helper"
+	patch "km-api-go/internal/patch"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCompanyUsecase is a mock of CompanyUsecase interface.
+type MockCompanyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompanyUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockCompanyUsecaseMockRecorder is the mock recorder for MockCompanyUsecase.
+type MockCompanyUsecaseMockRecorder struct {
+	mock *MockCompanyUsecase
+}
+
+// NewMockCompanyUsecase creates a new mock instance.
+func NewMockCompanyUsecase(ctrl *gomock.Controller) *MockCompanyUsecase {
+	mock := &MockCompanyUsecase{ctrl: ctrl}
+	mock.recorder = &MockCompanyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompanyUsecase) EXPECT() *MockCompanyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddUserToCompany mocks base method.
+func (m *MockCompanyUsecase) AddUserToCompany(ctx context.Context, userID, companyID uint, role string) (*domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToCompany", ctx, userID, companyID, role)
+	ret0, _ := ret[0].(*domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserToCompany indicates an expected call of AddUserToCompany.
+func (mr *MockCompanyUsecaseMockRecorder) AddUserToCompany(ctx, userID, companyID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToCompany", reflect.TypeOf((*MockCompanyUsecase)(nil).AddUserToCompany), ctx, userID, companyID, role)
+}
+
+// CanView mocks base method.
+func (m *MockCompanyUsecase) CanView(ctx context.Context, companyID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanView", ctx, companyID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanView indicates an expected call of CanView.
+func (mr *MockCompanyUsecaseMockRecorder) CanView(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanView", reflect.TypeOf((*MockCompanyUsecase)(nil).CanView), ctx, companyID)
+}
+
+// CreateCompany mocks base method.
+func (m *MockCompanyUsecase) CreateCompany(ctx context.Context, name, email, phone, address, website, description string) (*domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompany", ctx, name, email, phone, address, website, description)
+	ret0, _ := ret[0].(*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompany indicates an expected call of CreateCompany.
+func (mr *MockCompanyUsecaseMockRecorder) CreateCompany(ctx, name, email, phone, address, website, description any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockCompanyUsecase)(nil).CreateCompany), ctx, name, email, phone, address, website, description)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockCompanyUsecase) DeleteCompany(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockCompanyUsecaseMockRecorder) DeleteCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockCompanyUsecase)(nil).DeleteCompany), ctx, id)
+}
+
+// GetAllCompanies mocks base method.
+func (m *MockCompanyUsecase) GetAllCompanies(ctx context.Context) ([]domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCompanies", ctx)
+	ret0, _ := ret[0].([]domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCompanies indicates an expected call of GetAllCompanies.
+func (mr *MockCompanyUsecaseMockRecorder) GetAllCompanies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCompanies", reflect.TypeOf((*MockCompanyUsecase)(nil).GetAllCompanies), ctx)
+}
+
+// GetCompaniesByIDs mocks base method.
+func (m *MockCompanyUsecase) GetCompaniesByIDs(ctx context.Context, ids []uint) ([]domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompaniesByIDs", ctx, ids)
+	ret0, _ := ret[0].([]domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompaniesByIDs indicates an expected call of GetCompaniesByIDs.
+func (mr *MockCompanyUsecaseMockRecorder) GetCompaniesByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompaniesByIDs", reflect.TypeOf((*MockCompanyUsecase)(nil).GetCompaniesByIDs), ctx, ids)
+}
+
+// GetCompaniesByUser mocks base method.
+func (m *MockCompanyUsecase) GetCompaniesByUser(ctx context.Context, userID uint) ([]domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompaniesByUser", ctx, userID)
+	ret0, _ := ret[0].([]domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompaniesByUser indicates an expected call of GetCompaniesByUser.
+func (mr *MockCompanyUsecaseMockRecorder) GetCompaniesByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompaniesByUser", reflect.TypeOf((*MockCompanyUsecase)(nil).GetCompaniesByUser), ctx, userID)
+}
+
+// GetCompaniesPaginated mocks base method.
+func (m *MockCompanyUsecase) GetCompaniesPaginated(ctx context.Context, page, limit int) ([]domain.Company, *helper.PaginationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompaniesPaginated", ctx, page, limit)
+	ret0, _ := ret[0].([]domain.Company)
+	ret1, _ := ret[1].(*helper.PaginationResponse)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCompaniesPaginated indicates an expected call of GetCompaniesPaginated.
+func (mr *MockCompanyUsecaseMockRecorder) GetCompaniesPaginated(ctx, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompaniesPaginated", reflect.TypeOf((*MockCompanyUsecase)(nil).GetCompaniesPaginated), ctx, page, limit)
+}
+
+// GetCompanyByID mocks base method.
+func (m *MockCompanyUsecase) GetCompanyByID(ctx context.Context, id uint) (*domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyByID indicates an expected call of GetCompanyByID.
+func (mr *MockCompanyUsecaseMockRecorder) GetCompanyByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyByID", reflect.TypeOf((*MockCompanyUsecase)(nil).GetCompanyByID), ctx, id)
+}
+
+// GetCompanyByIDWithFields mocks base method.
+func (m *MockCompanyUsecase) GetCompanyByIDWithFields(ctx context.Context, id uint, fields []string) (*domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyByIDWithFields", ctx, id, fields)
+	ret0, _ := ret[0].(*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyByIDWithFields indicates an expected call of GetCompanyByIDWithFields.
+func (mr *MockCompanyUsecaseMockRecorder) GetCompanyByIDWithFields(ctx, id, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyByIDWithFields", reflect.TypeOf((*MockCompanyUsecase)(nil).GetCompanyByIDWithFields), ctx, id, fields)
+}
+
+// GetMembersByCompanyIDs mocks base method.
+func (m *MockCompanyUsecase) GetMembersByCompanyIDs(ctx context.Context, companyIDs []uint) ([]domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersByCompanyIDs", ctx, companyIDs)
+	ret0, _ := ret[0].([]domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersByCompanyIDs indicates an expected call of GetMembersByCompanyIDs.
+func (mr *MockCompanyUsecaseMockRecorder) GetMembersByCompanyIDs(ctx, companyIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersByCompanyIDs", reflect.TypeOf((*MockCompanyUsecase)(nil).GetMembersByCompanyIDs), ctx, companyIDs)
+}
+
+// GetMembershipsByUserIDs mocks base method.
+func (m *MockCompanyUsecase) GetMembershipsByUserIDs(ctx context.Context, userIDs []uint) ([]domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipsByUserIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipsByUserIDs indicates an expected call of GetMembershipsByUserIDs.
+func (mr *MockCompanyUsecaseMockRecorder) GetMembershipsByUserIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipsByUserIDs", reflect.TypeOf((*MockCompanyUsecase)(nil).GetMembershipsByUserIDs), ctx, userIDs)
+}
+
+// GetUsersByCompany mocks base method.
+func (m *MockCompanyUsecase) GetUsersByCompany(ctx context.Context, companyID uint) ([]domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByCompany", ctx, companyID)
+	ret0, _ := ret[0].([]domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByCompany indicates an expected call of GetUsersByCompany.
+func (mr *MockCompanyUsecaseMockRecorder) GetUsersByCompany(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByCompany", reflect.TypeOf((*MockCompanyUsecase)(nil).GetUsersByCompany), ctx, companyID)
+}
+
+// PatchCompany mocks base method.
+func (m *MockCompanyUsecase) PatchCompany(ctx context.Context, id, version uint, p patch.Patch) (*domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchCompany", ctx, id, version, p)
+	ret0, _ := ret[0].(*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchCompany indicates an expected call of PatchCompany.
+func (mr *MockCompanyUsecaseMockRecorder) PatchCompany(ctx, id, version, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchCompany", reflect.TypeOf((*MockCompanyUsecase)(nil).PatchCompany), ctx, id, version, p)
+}
+
+// RemoveUserFromCompany mocks base method.
+func (m *MockCompanyUsecase) RemoveUserFromCompany(ctx context.Context, userID, companyID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromCompany", ctx, userID, companyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserFromCompany indicates an expected call of RemoveUserFromCompany.
+func (mr *MockCompanyUsecaseMockRecorder) RemoveUserFromCompany(ctx, userID, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromCompany", reflect.TypeOf((*MockCompanyUsecase)(nil).RemoveUserFromCompany), ctx, userID, companyID)
+}
+
+// SearchCompanies mocks base method.
+func (m *MockCompanyUsecase) SearchCompanies(ctx context.Context, name string) ([]domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCompanies", ctx, name)
+	ret0, _ := ret[0].([]domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCompanies indicates an expected call of SearchCompanies.
+func (mr *MockCompanyUsecaseMockRecorder) SearchCompanies(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCompanies", reflect.TypeOf((*MockCompanyUsecase)(nil).SearchCompanies), ctx, name)
+}
+
+// UpdateCompany mocks base method.
+func (m *MockCompanyUsecase) UpdateCompany(ctx context.Context, id, version uint, name, email, phone, address, website, description string) (*domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompany", ctx, id, version, name, email, phone, address, website, description)
+	ret0, _ := ret[0].(*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompany indicates an expected call of UpdateCompany.
+func (mr *MockCompanyUsecaseMockRecorder) UpdateCompany(ctx, id, version, name, email, phone, address, website, description any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*MockCompanyUsecase)(nil).UpdateCompany), ctx, id, version, name, email, phone, address, website, description)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockCompanyUsecase) UpdateUserRole(ctx context.Context, userID, companyID uint, role string) (*domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, userID, companyID, role)
+	ret0, _ := ret[0].(*domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockCompanyUsecaseMockRecorder) UpdateUserRole(ctx, userID, companyID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockCompanyUsecase)(nil).UpdateUserRole), ctx, userID, companyID, role)
+}
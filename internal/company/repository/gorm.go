@@ -1,15 +1,20 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
 
 	"gorm.io/gorm"
 )
 
+// sortColumns 会社の一覧で並べ替えに使える列
+var sortColumns = []string{"id", "name", "email", "created_at", "updated_at"}
+
 // companyRepository GORM実装
 type companyRepository struct {
 	db *gorm.DB
@@ -19,22 +24,22 @@ func NewCompanyRepository(db *gorm.DB) CompanyRepository {
 	return &companyRepository{db: db}
 }
 
-func (r *companyRepository) GetAll() ([]domain.Company, error) {
+func (r *companyRepository) GetAll(ctx context.Context) ([]domain.Company, error) {
 	var companies []domain.Company
 
-	if err := r.db.Find(&companies).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Find(&companies).Error; err != nil {
 		return nil, fmt.Errorf("failed to get all companies: %w", err)
 	}
 
 	return companies, nil
 }
 
-func (r *companyRepository) GetByID(id uint) (*domain.Company, error) {
+func (r *companyRepository) GetByID(ctx context.Context, id uint) (*domain.Company, error) {
 	var c domain.Company
 
-	if err := r.db.First(&c, id).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).First(&c, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company with id %d not found", id)
+			return nil, fmt.Errorf("company with id %d %w", id, domain.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get company by id %d: %w", id, err)
 	}
@@ -42,12 +47,31 @@ func (r *companyRepository) GetByID(id uint) (*domain.Company, error) {
 	return &c, nil
 }
 
-func (r *companyRepository) GetByEmail(email string) (*domain.Company, error) {
+// GetByIDWithFields 指定したフィールドのカラムのみSELECTする（ID・Versionは常に取得する）
+func (r *companyRepository) GetByIDWithFields(ctx context.Context, id uint, fields ...string) (*domain.Company, error) {
+	if len(fields) == 0 {
+		return r.GetByID(ctx, id)
+	}
+
 	var c domain.Company
 
-	if err := r.db.Where("email = ?", email).First(&c).Error; err != nil {
+	columns := append([]string{"ID", "Version"}, fields...)
+	if err := infra.Conn(ctx, r.db).Select(columns).First(&c, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company with email %s not found", email)
+			return nil, fmt.Errorf("company with id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get company by id %d: %w", id, err)
+	}
+
+	return &c, nil
+}
+
+func (r *companyRepository) GetByEmail(ctx context.Context, email string) (*domain.Company, error) {
+	var c domain.Company
+
+	if err := infra.Conn(ctx, r.db).Where("email = ?", email).First(&c).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("company with email %s %w", email, domain.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get company by email %s: %w", email, err)
 	}
@@ -55,89 +79,106 @@ func (r *companyRepository) GetByEmail(email string) (*domain.Company, error) {
 	return &c, nil
 }
 
-func (r *companyRepository) Create(c *domain.Company) error {
+func (r *companyRepository) Create(ctx context.Context, c *domain.Company) error {
 	// メール重複チェック
-	exists, err := r.ExistsByEmail(c.Email)
+	exists, err := r.ExistsByEmail(ctx, c.Email)
 	if err != nil {
 		return fmt.Errorf("failed to check email existence: %w", err)
 	}
 	if exists {
-		return fmt.Errorf("company with email %s already exists", c.Email)
+		return fmt.Errorf("company with email %s %w", c.Email, domain.ErrAlreadyExists)
 	}
 
-	if err := r.db.Create(c).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Create(c).Error; err != nil {
 		return fmt.Errorf("failed to create company: %w", err)
 	}
 
 	return nil
 }
 
-func (r *companyRepository) Update(c *domain.Company) error {
+func (r *companyRepository) Update(ctx context.Context, c *domain.Company) error {
 	// 会社存在チェック
-	exists, err := r.Exists(c.ID)
+	exists, err := r.Exists(ctx, c.ID)
 	if err != nil {
 		return fmt.Errorf("failed to check company existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("company with id %d not found", c.ID)
+		return fmt.Errorf("company with id %d %w", c.ID, domain.ErrNotFound)
 	}
 
 	// メール重複チェック（自分以外）
 	var existingCompany domain.Company
-	if err := r.db.Where("email = ? AND id != ?", c.Email, c.ID).First(&existingCompany).Error; err == nil {
-		return fmt.Errorf("company with email %s already exists", c.Email)
+	if err := infra.Conn(ctx, r.db).Where("email = ? AND id != ?", c.Email, c.ID).First(&existingCompany).Error; err == nil {
+		return fmt.Errorf("company with email %s %w", c.Email, domain.ErrAlreadyExists)
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return fmt.Errorf("failed to check email uniqueness: %w", err)
 	}
 
-	if err := r.db.Save(c).Error; err != nil {
-		return fmt.Errorf("failed to update company: %w", err)
+	return r.updateVersioned(ctx, c, "*")
+}
+
+func (r *companyRepository) UpdateFields(ctx context.Context, c *domain.Company, fields ...string) error {
+	return r.updateVersioned(ctx, c, append(fields, "Version", "UpdatedAt")...)
+}
+
+// updateVersioned バージョンが一致する場合のみ指定カラムを更新（楽観的排他制御）
+func (r *companyRepository) updateVersioned(ctx context.Context, c *domain.Company, fields ...string) error {
+	expected := c.Version
+	c.Version = expected + 1
+	result := infra.Conn(ctx, r.db).Model(c).Where("version = ?", expected).Select(fields).Omit("created_at").Updates(c)
+	if result.Error != nil {
+		c.Version = expected
+		return fmt.Errorf("failed to update company: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		c.Version = expected
+		return fmt.Errorf("company with id %d version %d: %w", c.ID, expected, domain.ErrVersionConflict)
 	}
 
 	return nil
 }
 
-func (r *companyRepository) Delete(id uint) error {
+func (r *companyRepository) Delete(ctx context.Context, id uint) error {
 	// 会社存在チェック
-	exists, err := r.Exists(id)
+	exists, err := r.Exists(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to check company existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("company with id %d not found", id)
+		return fmt.Errorf("company with id %d %w", id, domain.ErrNotFound)
 	}
 
-	if err := r.db.Delete(&domain.Company{}, id).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Delete(&domain.Company{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete company with id %d: %w", id, err)
 	}
 
 	return nil
 }
 
-func (r *companyRepository) Exists(id uint) (bool, error) {
+func (r *companyRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
 
-	if err := r.db.Model(&domain.Company{}).Where("id = ?", id).Count(&count).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Model(&domain.Company{}).Where("id = ?", id).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check company existence: %w", err)
 	}
 
 	return count > 0, nil
 }
 
-func (r *companyRepository) ExistsByEmail(email string) (bool, error) {
+func (r *companyRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
 
-	if err := r.db.Model(&domain.Company{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Model(&domain.Company{}).Where("email = ?", email).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check company email existence: %w", err)
 	}
 
 	return count > 0, nil
 }
 
-func (r *companyRepository) Count() (int64, error) {
+func (r *companyRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
 
-	if err := r.db.Model(&domain.Company{}).Count(&count).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Model(&domain.Company{}).Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count companies: %w", err)
 	}
 
@@ -145,10 +186,10 @@ func (r *companyRepository) Count() (int64, error) {
 }
 
 // GetPaginated ページネーション付きで会社を取得
-func (r *companyRepository) GetPaginated(offset, limit int) ([]domain.Company, error) {
+func (r *companyRepository) GetPaginated(ctx context.Context, offset, limit int) ([]domain.Company, error) {
 	var companies []domain.Company
 
-	if err := r.db.Offset(offset).Limit(limit).Order("created_at DESC").Find(&companies).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Offset(offset).Limit(limit).Order("created_at DESC").Find(&companies).Error; err != nil {
 		return nil, fmt.Errorf("failed to get paginated companies: %w", err)
 	}
 
@@ -156,17 +197,44 @@ func (r *companyRepository) GetPaginated(offset, limit int) ([]domain.Company, e
 }
 
 // SearchByName 名前で会社を検索
-func (r *companyRepository) SearchByName(name string) ([]domain.Company, error) {
+func (r *companyRepository) SearchByName(ctx context.Context, name string) ([]domain.Company, error) {
 	var companies []domain.Company
 	searchTerm := "%" + strings.ToLower(name) + "%"
 
-	if err := r.db.Where("LOWER(name) LIKE ?", searchTerm).Find(&companies).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Where("LOWER(name) LIKE ?", searchTerm).Find(&companies).Error; err != nil {
 		return nil, fmt.Errorf("failed to search companies by name: %w", err)
 	}
 
 	return companies, nil
 }
 
+// GetByIDs 指定したIDの会社を取得
+func (r *companyRepository) GetByIDs(ctx context.Context, ids []uint) ([]domain.Company, error) {
+	var companies []domain.Company
+
+	if err := infra.Conn(ctx, r.db).Where("id IN ?", ids).Find(&companies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get companies by ids: %w", err)
+	}
+
+	return companies, nil
+}
+
+// ListAfter 条件に一致する会社をID順に取得（カーソルページネーション用）
+func (r *companyRepository) ListAfter(ctx context.Context, filter domain.CompanyFilter, afterID uint, limit int) ([]domain.Company, error) {
+	var companies []domain.Company
+
+	conditions, args := companyConditions(filter)
+	query := infra.Conn(ctx, r.db).Where("id > ?", afterID)
+	if len(conditions) > 0 {
+		query = query.Where(strings.Join(conditions, " AND "), args...)
+	}
+	if err := query.Order("id").Limit(limit).Find(&companies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list companies: %w", err)
+	}
+
+	return companies, nil
+}
+
 // companyUserRepository ユーザー-会社関係リポジトリ GORM実装
 type companyUserRepository struct {
 	db *gorm.DB
@@ -177,72 +245,92 @@ func NewCompanyUserRepository(db *gorm.DB) CompanyUserRepository {
 	return &companyUserRepository{db: db}
 }
 
-func (r *companyUserRepository) GetUsersByCompanyID(companyID uint) ([]domain.CompanyUser, error) {
+func (r *companyUserRepository) GetUsersByCompanyID(ctx context.Context, companyID uint) ([]domain.CompanyUser, error) {
 	var companyUsers []domain.CompanyUser
 
-	if err := r.db.Where("company_id = ?", companyID).Find(&companyUsers).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Where("company_id = ?", companyID).Find(&companyUsers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get users by company id %d: %w", companyID, err)
 	}
 
 	return companyUsers, nil
 }
 
-func (r *companyUserRepository) GetCompaniesByUserID(userID uint) ([]domain.CompanyUser, error) {
+func (r *companyUserRepository) GetCompaniesByUserID(ctx context.Context, userID uint) ([]domain.CompanyUser, error) {
 	var companyUsers []domain.CompanyUser
 
-	if err := r.db.Where("user_id = ?", userID).Find(&companyUsers).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Where("user_id = ?", userID).Find(&companyUsers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get companies by user id %d: %w", userID, err)
 	}
 
 	return companyUsers, nil
 }
 
-func (r *companyUserRepository) Create(companyUser *domain.CompanyUser) error {
+func (r *companyUserRepository) GetByCompanyIDs(ctx context.Context, companyIDs []uint) ([]domain.CompanyUser, error) {
+	var companyUsers []domain.CompanyUser
+
+	if err := infra.Conn(ctx, r.db).Where("company_id IN ?", companyIDs).Order("id").Find(&companyUsers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get users by company ids: %w", err)
+	}
+
+	return companyUsers, nil
+}
+
+func (r *companyUserRepository) GetByUserIDs(ctx context.Context, userIDs []uint) ([]domain.CompanyUser, error) {
+	var companyUsers []domain.CompanyUser
+
+	if err := infra.Conn(ctx, r.db).Where("user_id IN ?", userIDs).Order("id").Find(&companyUsers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get companies by user ids: %w", err)
+	}
+
+	return companyUsers, nil
+}
+
+func (r *companyUserRepository) Create(ctx context.Context, companyUser *domain.CompanyUser) error {
 	// 既存関係チェック
-	exists, err := r.Exists(companyUser.UserID, companyUser.CompanyID)
+	exists, err := r.Exists(ctx, companyUser.UserID, companyUser.CompanyID)
 	if err != nil {
 		return fmt.Errorf("failed to check relation existence: %w", err)
 	}
 	if exists {
-		return fmt.Errorf("relation between user %d and company %d already exists", companyUser.UserID, companyUser.CompanyID)
+		return fmt.Errorf("relation between user %d and company %d %w", companyUser.UserID, companyUser.CompanyID, domain.ErrAlreadyExists)
 	}
 
 	// 作成実行
-	if err := r.db.Create(companyUser).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Create(companyUser).Error; err != nil {
 		return fmt.Errorf("failed to create company-user relation: %w", err)
 	}
 
 	return nil
 }
 
-func (r *companyUserRepository) Update(companyUser *domain.CompanyUser) error {
+func (r *companyUserRepository) Update(ctx context.Context, companyUser *domain.CompanyUser) error {
 	// 関係存在チェック
-	exists, err := r.Exists(companyUser.UserID, companyUser.CompanyID)
+	exists, err := r.Exists(ctx, companyUser.UserID, companyUser.CompanyID)
 	if err != nil {
 		return fmt.Errorf("failed to check relation existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("relation between user %d and company %d not found", companyUser.UserID, companyUser.CompanyID)
+		return fmt.Errorf("relation between user %d and company %d %w", companyUser.UserID, companyUser.CompanyID, domain.ErrNotFound)
 	}
 
-	if err := r.db.Where("user_id = ? AND company_id = ?", companyUser.UserID, companyUser.CompanyID).Updates(companyUser).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Where("user_id = ? AND company_id = ?", companyUser.UserID, companyUser.CompanyID).Updates(companyUser).Error; err != nil {
 		return fmt.Errorf("failed to update company-user relation: %w", err)
 	}
 
 	return nil
 }
 
-func (r *companyUserRepository) Delete(userID, companyID uint) error {
+func (r *companyUserRepository) Delete(ctx context.Context, userID, companyID uint) error {
 	// 関係存在チェック
-	exists, err := r.Exists(userID, companyID)
+	exists, err := r.Exists(ctx, userID, companyID)
 	if err != nil {
 		return fmt.Errorf("failed to check relation existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("relation between user %d and company %d not found", userID, companyID)
+		return fmt.Errorf("relation between user %d and company %d %w", userID, companyID, domain.ErrNotFound)
 	}
 
-	if err := r.db.Where("user_id = ? AND company_id = ?", userID, companyID).Delete(&domain.CompanyUser{}).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Where("user_id = ? AND company_id = ?", userID, companyID).Delete(&domain.CompanyUser{}).Error; err != nil {
 		return fmt.Errorf("failed to delete company-user relation: %w", err)
 	}
 
@@ -250,12 +338,12 @@ func (r *companyUserRepository) Delete(userID, companyID uint) error {
 }
 
 // GetRelation ユーザー-会社関係を取得
-func (r *companyUserRepository) GetRelation(userID, companyID uint) (*domain.CompanyUser, error) {
+func (r *companyUserRepository) GetRelation(ctx context.Context, userID, companyID uint) (*domain.CompanyUser, error) {
 	var companyUser domain.CompanyUser
 
-	if err := r.db.Where("user_id = ? AND company_id = ?", userID, companyID).First(&companyUser).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Where("user_id = ? AND company_id = ?", userID, companyID).First(&companyUser).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("relation between user %d and company %d not found", userID, companyID)
+			return nil, fmt.Errorf("relation between user %d and company %d %w", userID, companyID, domain.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get relation: %w", err)
 	}
@@ -264,12 +352,95 @@ func (r *companyUserRepository) GetRelation(userID, companyID uint) (*domain.Com
 }
 
 // Exists ユーザー-会社関係の存在確認
-func (r *companyUserRepository) Exists(userID, companyID uint) (bool, error) {
+func (r *companyUserRepository) Exists(ctx context.Context, userID, companyID uint) (bool, error) {
 	var count int64
 
-	if err := r.db.Model(&domain.CompanyUser{}).Where("user_id = ? AND company_id = ?", userID, companyID).Count(&count).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Model(&domain.CompanyUser{}).Where("user_id = ? AND company_id = ?", userID, companyID).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check relation existence: %w", err)
 	}
 
 	return count > 0, nil
 }
+
+func (r *companyUserRepository) SharesCompany(ctx context.Context, userID, otherUserID uint) (bool, error) {
+	var count int64
+
+	err := infra.Conn(ctx, r.db).Table("company_users AS cu1").
+		Joins("JOIN company_users AS cu2 ON cu2.company_id = cu1.company_id").
+		Where("cu1.user_id = ? AND cu2.user_id = ?", userID, otherUserID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check shared company: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Stream 条件に一致する会社をサーバーサイドカーソルで取得
+func (r *companyRepository) Stream(ctx context.Context, filter domain.CompanyFilter, fn func([]domain.Company) error) error {
+	conditions, args := companyConditions(filter)
+
+	query := "SELECT id, name, email, phone, address, website, description, version, created_at, updated_at FROM companies"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += infra.OrderBy(filter.SortBy, filter.SortDir, sortColumns)
+
+	if err := infra.StreamCursor(ctx, r.db, infra.DefaultCursorBatchSize, query, args, fn); err != nil {
+		return fmt.Errorf("failed to stream companies: %w", err)
+	}
+
+	return nil
+}
+
+// companyConditions 絞り込み条件のWHERE句（ANDで連結する）と引数
+func companyConditions(filter domain.CompanyFilter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Query != "" {
+		conditions = append(conditions, "LOWER(name) LIKE ?")
+		args = append(args, "%"+strings.ToLower(filter.Query)+"%")
+	}
+	if filter.VisibleTo != 0 {
+		conditions = append(conditions, "id IN (SELECT company_id FROM company_users WHERE user_id = ?)")
+		args = append(args, filter.VisibleTo)
+	}
+
+	return conditions, args
+}
+
+// StreamDetails 条件に一致する所属を会社・ユーザー情報付きでサーバーサイドカーソルで取得
+func (r *companyUserRepository) StreamDetails(ctx context.Context, filter domain.MembershipFilter, fn func([]domain.MembershipDetail) error) error {
+	var conditions []string
+	var args []interface{}
+
+	if filter.CompanyID != 0 {
+		conditions = append(conditions, "cu.company_id = ?")
+		args = append(args, filter.CompanyID)
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, "cu.role = ?")
+		args = append(args, filter.Role)
+	}
+	if filter.VisibleTo != 0 {
+		conditions = append(conditions, "cu.company_id IN (SELECT company_id FROM company_users WHERE user_id = ?)")
+		args = append(args, filter.VisibleTo)
+	}
+
+	query := `SELECT cu.company_id, c.name AS company_name, c.email AS company_email,
+		cu.user_id, u.name AS user_name, u.email AS user_email, cu.role, cu.created_at
+		FROM company_users cu
+		JOIN companies c ON c.id = cu.company_id
+		JOIN users u ON u.id = cu.user_id`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY c.name, cu.company_id, u.name, cu.user_id"
+
+	if err := infra.StreamCursor(ctx, r.db, infra.DefaultCursorBatchSize, query, args, fn); err != nil {
+		return fmt.Errorf("failed to stream memberships: %w", err)
+	}
+
+	return nil
+}
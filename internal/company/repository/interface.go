@@ -1,28 +1,51 @@
 package repository
 
-import "km-api-go/internal/domain"
+import (
+	"context"
+
+	"km-api-go/internal/domain"
+)
 
 type CompanyRepository interface {
-	GetAll() ([]domain.Company, error)
-	GetByID(id uint) (*domain.Company, error)
-	GetByEmail(email string) (*domain.Company, error)
-	Create(company *domain.Company) error
-	Update(company *domain.Company) error
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
-	ExistsByEmail(email string) (bool, error)
-	Count() (int64, error)
-	GetPaginated(offset, limit int) ([]domain.Company, error)
-	SearchByName(name string) ([]domain.Company, error)
+	GetAll(ctx context.Context) ([]domain.Company, error)
+	GetByID(ctx context.Context, id uint) (*domain.Company, error)
+	// GetByIDWithFields 指定したフィールド（Goのフィールド名）のカラムのみ取得（空の場合はGetByIDと同じ）
+	GetByIDWithFields(ctx context.Context, id uint, fields ...string) (*domain.Company, error)
+	GetByEmail(ctx context.Context, email string) (*domain.Company, error)
+	Create(ctx context.Context, company *domain.Company) error
+	// Update company.Versionが一致する場合のみ更新し、Versionを進める（不一致はdomain.ErrVersionConflict）
+	Update(ctx context.Context, company *domain.Company) error
+	// UpdateFields 指定したフィールド（Goのフィールド名）のみバージョンチェック付きで更新
+	UpdateFields(ctx context.Context, company *domain.Company, fields ...string) error
+	Delete(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	Count(ctx context.Context) (int64, error)
+	GetPaginated(ctx context.Context, offset, limit int) ([]domain.Company, error)
+	SearchByName(ctx context.Context, name string) ([]domain.Company, error)
+	// GetByIDs 指定したIDの会社を取得（存在しないIDは含まない）
+	GetByIDs(ctx context.Context, ids []uint) ([]domain.Company, error)
+	// ListAfter 条件に一致する会社をID順にafterIDより後から最大limit件取得（SortBy・SortDirは無視する）
+	ListAfter(ctx context.Context, filter domain.CompanyFilter, afterID uint, limit int) ([]domain.Company, error)
+	// Stream 条件に一致する会社をサーバーサイドカーソルで取得し、一定件数ずつfnに渡す
+	Stream(ctx context.Context, filter domain.CompanyFilter, fn func([]domain.Company) error) error
 }
 
 // ユーザー-会社関係リポジトリインターフェース
 type CompanyUserRepository interface {
-	GetUsersByCompanyID(companyID uint) ([]domain.CompanyUser, error)
-	GetCompaniesByUserID(userID uint) ([]domain.CompanyUser, error)
-	Create(companyUser *domain.CompanyUser) error
-	Update(companyUser *domain.CompanyUser) error
-	Delete(userID, companyID uint) error
-	GetRelation(userID, companyID uint) (*domain.CompanyUser, error)
-	Exists(userID, companyID uint) (bool, error)
+	GetUsersByCompanyID(ctx context.Context, companyID uint) ([]domain.CompanyUser, error)
+	GetCompaniesByUserID(ctx context.Context, userID uint) ([]domain.CompanyUser, error)
+	// GetByCompanyIDs 指定した会社の所属をID順に取得
+	GetByCompanyIDs(ctx context.Context, companyIDs []uint) ([]domain.CompanyUser, error)
+	// GetByUserIDs 指定したユーザーの所属をID順に取得
+	GetByUserIDs(ctx context.Context, userIDs []uint) ([]domain.CompanyUser, error)
+	Create(ctx context.Context, companyUser *domain.CompanyUser) error
+	Update(ctx context.Context, companyUser *domain.CompanyUser) error
+	Delete(ctx context.Context, userID, companyID uint) error
+	GetRelation(ctx context.Context, userID, companyID uint) (*domain.CompanyUser, error)
+	Exists(ctx context.Context, userID, companyID uint) (bool, error)
+	// SharesCompany 2人のユーザーが同じ会社に所属しているか
+	SharesCompany(ctx context.Context, userID, otherUserID uint) (bool, error)
+	// StreamDetails 条件に一致する所属を会社・ユーザー情報付きでサーバーサイドカーソルで取得
+	StreamDetails(ctx context.Context, filter domain.MembershipFilter, fn func([]domain.MembershipDetail) error) error
 }
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	domain "km-api-go/internal/domain"
 	reflect "reflect"
 
@@ -41,165 +42,248 @@ func (m *MockCompanyRepository) EXPECT() *MockCompanyRepositoryMockRecorder {
 }
 
 // Count mocks base method.
-func (m *MockCompanyRepository) Count() (int64, error) {
+func (m *MockCompanyRepository) Count(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count")
+	ret := m.ctrl.Call(m, "Count", ctx)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Count indicates an expected call of Count.
-func (mr *MockCompanyRepositoryMockRecorder) Count() *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) Count(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockCompanyRepository)(nil).Count))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockCompanyRepository)(nil).Count), ctx)
 }
 
 // Create mocks base method.
-func (m *MockCompanyRepository) Create(company *domain.Company) error {
+func (m *MockCompanyRepository) Create(ctx context.Context, company *domain.Company) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", company)
+	ret := m.ctrl.Call(m, "Create", ctx, company)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockCompanyRepositoryMockRecorder) Create(company any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) Create(ctx, company any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompanyRepository)(nil).Create), company)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompanyRepository)(nil).Create), ctx, company)
 }
 
 // Delete mocks base method.
-func (m *MockCompanyRepository) Delete(id uint) error {
+func (m *MockCompanyRepository) Delete(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCompanyRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompanyRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompanyRepository)(nil).Delete), ctx, id)
 }
 
 // Exists mocks base method.
-func (m *MockCompanyRepository) Exists(id uint) (bool, error) {
+func (m *MockCompanyRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Exists", id)
+	ret := m.ctrl.Call(m, "Exists", ctx, id)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Exists indicates an expected call of Exists.
-func (mr *MockCompanyRepositoryMockRecorder) Exists(id any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) Exists(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockCompanyRepository)(nil).Exists), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockCompanyRepository)(nil).Exists), ctx, id)
 }
 
 // ExistsByEmail mocks base method.
-func (m *MockCompanyRepository) ExistsByEmail(email string) (bool, error) {
+func (m *MockCompanyRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExistsByEmail", email)
+	ret := m.ctrl.Call(m, "ExistsByEmail", ctx, email)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ExistsByEmail indicates an expected call of ExistsByEmail.
-func (mr *MockCompanyRepositoryMockRecorder) ExistsByEmail(email any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) ExistsByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByEmail", reflect.TypeOf((*MockCompanyRepository)(nil).ExistsByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByEmail", reflect.TypeOf((*MockCompanyRepository)(nil).ExistsByEmail), ctx, email)
 }
 
 // GetAll mocks base method.
-func (m *MockCompanyRepository) GetAll() ([]domain.Company, error) {
+func (m *MockCompanyRepository) GetAll(ctx context.Context) ([]domain.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", ctx)
 	ret0, _ := ret[0].([]domain.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockCompanyRepositoryMockRecorder) GetAll() *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCompanyRepository)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCompanyRepository)(nil).GetAll), ctx)
 }
 
 // GetByEmail mocks base method.
-func (m *MockCompanyRepository) GetByEmail(email string) (*domain.Company, error) {
+func (m *MockCompanyRepository) GetByEmail(ctx context.Context, email string) (*domain.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByEmail", email)
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
 	ret0, _ := ret[0].(*domain.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByEmail indicates an expected call of GetByEmail.
-func (mr *MockCompanyRepositoryMockRecorder) GetByEmail(email any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockCompanyRepository)(nil).GetByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockCompanyRepository)(nil).GetByEmail), ctx, email)
 }
 
 // GetByID mocks base method.
-func (m *MockCompanyRepository) GetByID(id uint) (*domain.Company, error) {
+func (m *MockCompanyRepository) GetByID(ctx context.Context, id uint) (*domain.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
 	ret0, _ := ret[0].(*domain.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockCompanyRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCompanyRepository)(nil).GetByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCompanyRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDWithFields mocks base method.
+func (m *MockCompanyRepository) GetByIDWithFields(ctx context.Context, id uint, fields ...string) (*domain.Company, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByIDWithFields", varargs...)
+	ret0, _ := ret[0].(*domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDWithFields indicates an expected call of GetByIDWithFields.
+func (mr *MockCompanyRepositoryMockRecorder) GetByIDWithFields(ctx, id any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDWithFields", reflect.TypeOf((*MockCompanyRepository)(nil).GetByIDWithFields), varargs...)
+}
+
+// GetByIDs mocks base method.
+func (m *MockCompanyRepository) GetByIDs(ctx context.Context, ids []uint) ([]domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockCompanyRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockCompanyRepository)(nil).GetByIDs), ctx, ids)
 }
 
 // GetPaginated mocks base method.
-func (m *MockCompanyRepository) GetPaginated(offset, limit int) ([]domain.Company, error) {
+func (m *MockCompanyRepository) GetPaginated(ctx context.Context, offset, limit int) ([]domain.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPaginated", offset, limit)
+	ret := m.ctrl.Call(m, "GetPaginated", ctx, offset, limit)
 	ret0, _ := ret[0].([]domain.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPaginated indicates an expected call of GetPaginated.
-func (mr *MockCompanyRepositoryMockRecorder) GetPaginated(offset, limit any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) GetPaginated(ctx, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginated", reflect.TypeOf((*MockCompanyRepository)(nil).GetPaginated), offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginated", reflect.TypeOf((*MockCompanyRepository)(nil).GetPaginated), ctx, offset, limit)
+}
+
+// ListAfter mocks base method.
+func (m *MockCompanyRepository) ListAfter(ctx context.Context, filter domain.CompanyFilter, afterID uint, limit int) ([]domain.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAfter", ctx, filter, afterID, limit)
+	ret0, _ := ret[0].([]domain.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfter indicates an expected call of ListAfter.
+func (mr *MockCompanyRepositoryMockRecorder) ListAfter(ctx, filter, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfter", reflect.TypeOf((*MockCompanyRepository)(nil).ListAfter), ctx, filter, afterID, limit)
 }
 
 // SearchByName mocks base method.
-func (m *MockCompanyRepository) SearchByName(name string) ([]domain.Company, error) {
+func (m *MockCompanyRepository) SearchByName(ctx context.Context, name string) ([]domain.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByName", name)
+	ret := m.ctrl.Call(m, "SearchByName", ctx, name)
 	ret0, _ := ret[0].([]domain.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchByName indicates an expected call of SearchByName.
-func (mr *MockCompanyRepositoryMockRecorder) SearchByName(name any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) SearchByName(ctx, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByName", reflect.TypeOf((*MockCompanyRepository)(nil).SearchByName), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByName", reflect.TypeOf((*MockCompanyRepository)(nil).SearchByName), ctx, name)
+}
+
+// Stream mocks base method.
+func (m *MockCompanyRepository) Stream(ctx context.Context, filter domain.CompanyFilter, fn func([]domain.Company) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockCompanyRepositoryMockRecorder) Stream(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockCompanyRepository)(nil).Stream), ctx, filter, fn)
 }
 
 // Update mocks base method.
-func (m *MockCompanyRepository) Update(company *domain.Company) error {
+func (m *MockCompanyRepository) Update(ctx context.Context, company *domain.Company) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", company)
+	ret := m.ctrl.Call(m, "Update", ctx, company)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockCompanyRepositoryMockRecorder) Update(company any) *gomock.Call {
+func (mr *MockCompanyRepositoryMockRecorder) Update(ctx, company any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCompanyRepository)(nil).Update), ctx, company)
+}
+
+// UpdateFields mocks base method.
+func (m *MockCompanyRepository) UpdateFields(ctx context.Context, company *domain.Company, fields ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, company}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFields", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFields indicates an expected call of UpdateFields.
+func (mr *MockCompanyRepositoryMockRecorder) UpdateFields(ctx, company any, fields ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCompanyRepository)(nil).Update), company)
+	varargs := append([]any{ctx, company}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFields", reflect.TypeOf((*MockCompanyRepository)(nil).UpdateFields), varargs...)
 }
 
 // MockCompanyUserRepository is a mock of CompanyUserRepository interface.
@@ -227,103 +311,162 @@ func (m *MockCompanyUserRepository) EXPECT() *MockCompanyUserRepositoryMockRecor
 }
 
 // Create mocks base method.
-func (m *MockCompanyUserRepository) Create(companyUser *domain.CompanyUser) error {
+func (m *MockCompanyUserRepository) Create(ctx context.Context, companyUser *domain.CompanyUser) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", companyUser)
+	ret := m.ctrl.Call(m, "Create", ctx, companyUser)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockCompanyUserRepositoryMockRecorder) Create(companyUser any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) Create(ctx, companyUser any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompanyUserRepository)(nil).Create), companyUser)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompanyUserRepository)(nil).Create), ctx, companyUser)
 }
 
 // Delete mocks base method.
-func (m *MockCompanyUserRepository) Delete(userID, companyID uint) error {
+func (m *MockCompanyUserRepository) Delete(ctx context.Context, userID, companyID uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userID, companyID)
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, companyID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCompanyUserRepositoryMockRecorder) Delete(userID, companyID any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) Delete(ctx, userID, companyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompanyUserRepository)(nil).Delete), userID, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompanyUserRepository)(nil).Delete), ctx, userID, companyID)
 }
 
 // Exists mocks base method.
-func (m *MockCompanyUserRepository) Exists(userID, companyID uint) (bool, error) {
+func (m *MockCompanyUserRepository) Exists(ctx context.Context, userID, companyID uint) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Exists", userID, companyID)
+	ret := m.ctrl.Call(m, "Exists", ctx, userID, companyID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Exists indicates an expected call of Exists.
-func (mr *MockCompanyUserRepositoryMockRecorder) Exists(userID, companyID any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) Exists(ctx, userID, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockCompanyUserRepository)(nil).Exists), ctx, userID, companyID)
+}
+
+// GetByCompanyIDs mocks base method.
+func (m *MockCompanyUserRepository) GetByCompanyIDs(ctx context.Context, companyIDs []uint) ([]domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompanyIDs", ctx, companyIDs)
+	ret0, _ := ret[0].([]domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompanyIDs indicates an expected call of GetByCompanyIDs.
+func (mr *MockCompanyUserRepositoryMockRecorder) GetByCompanyIDs(ctx, companyIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockCompanyUserRepository)(nil).Exists), userID, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompanyIDs", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetByCompanyIDs), ctx, companyIDs)
+}
+
+// GetByUserIDs mocks base method.
+func (m *MockCompanyUserRepository) GetByUserIDs(ctx context.Context, userIDs []uint) ([]domain.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]domain.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDs indicates an expected call of GetByUserIDs.
+func (mr *MockCompanyUserRepositoryMockRecorder) GetByUserIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDs", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetByUserIDs), ctx, userIDs)
 }
 
 // GetCompaniesByUserID mocks base method.
-func (m *MockCompanyUserRepository) GetCompaniesByUserID(userID uint) ([]domain.CompanyUser, error) {
+func (m *MockCompanyUserRepository) GetCompaniesByUserID(ctx context.Context, userID uint) ([]domain.CompanyUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCompaniesByUserID", userID)
+	ret := m.ctrl.Call(m, "GetCompaniesByUserID", ctx, userID)
 	ret0, _ := ret[0].([]domain.CompanyUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCompaniesByUserID indicates an expected call of GetCompaniesByUserID.
-func (mr *MockCompanyUserRepositoryMockRecorder) GetCompaniesByUserID(userID any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) GetCompaniesByUserID(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompaniesByUserID", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetCompaniesByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompaniesByUserID", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetCompaniesByUserID), ctx, userID)
 }
 
 // GetRelation mocks base method.
-func (m *MockCompanyUserRepository) GetRelation(userID, companyID uint) (*domain.CompanyUser, error) {
+func (m *MockCompanyUserRepository) GetRelation(ctx context.Context, userID, companyID uint) (*domain.CompanyUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRelation", userID, companyID)
+	ret := m.ctrl.Call(m, "GetRelation", ctx, userID, companyID)
 	ret0, _ := ret[0].(*domain.CompanyUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRelation indicates an expected call of GetRelation.
-func (mr *MockCompanyUserRepositoryMockRecorder) GetRelation(userID, companyID any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) GetRelation(ctx, userID, companyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelation", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetRelation), userID, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelation", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetRelation), ctx, userID, companyID)
 }
 
 // GetUsersByCompanyID mocks base method.
-func (m *MockCompanyUserRepository) GetUsersByCompanyID(companyID uint) ([]domain.CompanyUser, error) {
+func (m *MockCompanyUserRepository) GetUsersByCompanyID(ctx context.Context, companyID uint) ([]domain.CompanyUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersByCompanyID", companyID)
+	ret := m.ctrl.Call(m, "GetUsersByCompanyID", ctx, companyID)
 	ret0, _ := ret[0].([]domain.CompanyUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUsersByCompanyID indicates an expected call of GetUsersByCompanyID.
-func (mr *MockCompanyUserRepositoryMockRecorder) GetUsersByCompanyID(companyID any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) GetUsersByCompanyID(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByCompanyID", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetUsersByCompanyID), ctx, companyID)
+}
+
+// SharesCompany mocks base method.
+func (m *MockCompanyUserRepository) SharesCompany(ctx context.Context, userID, otherUserID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharesCompany", ctx, userID, otherUserID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharesCompany indicates an expected call of SharesCompany.
+func (mr *MockCompanyUserRepositoryMockRecorder) SharesCompany(ctx, userID, otherUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharesCompany", reflect.TypeOf((*MockCompanyUserRepository)(nil).SharesCompany), ctx, userID, otherUserID)
+}
+
+// StreamDetails mocks base method.
+func (m *MockCompanyUserRepository) StreamDetails(ctx context.Context, filter domain.MembershipFilter, fn func([]domain.MembershipDetail) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamDetails", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamDetails indicates an expected call of StreamDetails.
+func (mr *MockCompanyUserRepositoryMockRecorder) StreamDetails(ctx, filter, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByCompanyID", reflect.TypeOf((*MockCompanyUserRepository)(nil).GetUsersByCompanyID), companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamDetails", reflect.TypeOf((*MockCompanyUserRepository)(nil).StreamDetails), ctx, filter, fn)
 }
 
 // Update mocks base method.
-func (m *MockCompanyUserRepository) Update(companyUser *domain.CompanyUser) error {
+func (m *MockCompanyUserRepository) Update(ctx context.Context, companyUser *domain.CompanyUser) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", companyUser)
+	ret := m.ctrl.Call(m, "Update", ctx, companyUser)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockCompanyUserRepositoryMockRecorder) Update(companyUser any) *gomock.Call {
+func (mr *MockCompanyUserRepositoryMockRecorder) Update(ctx, companyUser any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCompanyUserRepository)(nil).Update), companyUser)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCompanyUserRepository)(nil).Update), ctx, companyUser)
 }
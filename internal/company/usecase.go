@@ -1,27 +1,128 @@
 package company
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 
+	"km-api-go/internal/auth"
 	"km-api-go/internal/company/repository"
 	"km-api-go/internal/domain"
 	"km-api-go/internal/helper"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/metrics"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/patch"
+	"km-api-go/internal/tracing"
 )
 
-type CompanyUsecase struct {
+// CompanyUsecase 会社に関するビジネスロジック
+// 会社の更新・削除と所属の変更は会社の管理者とAPIキーのみ行える。ユーザーが作成した会社は作成したユーザーが管理者になる
+type CompanyUsecase interface {
+	GetAllCompanies(ctx context.Context) ([]domain.Company, error)
+	// CanView 呼び出し元が会社を参照できるか（所属ユーザー・APIキー）
+	CanView(ctx context.Context, companyID uint) (bool, error)
+	// GetCompanyByID 参照できない会社はdomain.ErrNotFoundを返す
+	GetCompanyByID(ctx context.Context, id uint) (*domain.Company, error)
+	GetCompanyByIDWithFields(ctx context.Context, id uint, fields []string) (*domain.Company, error)
+	GetCompaniesByIDs(ctx context.Context, ids []uint) ([]domain.Company, error)
+	CreateCompany(ctx context.Context, name, email, phone, address, website, description string) (*domain.Company, error)
+	UpdateCompany(ctx context.Context, id, version uint, name, email, phone, address, website, description string) (*domain.Company, error)
+	PatchCompany(ctx context.Context, id, version uint, p patch.Patch) (*domain.Company, error)
+	DeleteCompany(ctx context.Context, id uint) error
+	GetCompaniesPaginated(ctx context.Context, page, limit int) ([]domain.Company, *helper.PaginationResponse, error)
+	SearchCompanies(ctx context.Context, name string) ([]domain.Company, error)
+	AddUserToCompany(ctx context.Context, userID, companyID uint, role string) (*domain.CompanyUser, error)
+	UpdateUserRole(ctx context.Context, userID, companyID uint, role string) (*domain.CompanyUser, error)
+	RemoveUserFromCompany(ctx context.Context, userID, companyID uint) error
+	GetUsersByCompany(ctx context.Context, companyID uint) ([]domain.CompanyUser, error)
+	GetCompaniesByUser(ctx context.Context, userID uint) ([]domain.CompanyUser, error)
+	GetMembersByCompanyIDs(ctx context.Context, companyIDs []uint) ([]domain.CompanyUser, error)
+	GetMembershipsByUserIDs(ctx context.Context, userIDs []uint) ([]domain.CompanyUser, error)
+}
+
+type companyUsecase struct {
 	companyRepo     repository.CompanyRepository
 	companyUserRepo repository.CompanyUserRepository
+	tx              infra.Transactor
+	events          outbox.Recorder
 }
 
-func NewCompanyUsecase(companyRepo repository.CompanyRepository, companyUserRepo repository.CompanyUserRepository) *CompanyUsecase {
-	return &CompanyUsecase{
+// NewCompanyUsecase 会社ユースケースのコンストラクタ
+// 変更とドメインイベントは同じトランザクションで保存する
+func NewCompanyUsecase(
+	companyRepo repository.CompanyRepository,
+	companyUserRepo repository.CompanyUserRepository,
+	tx infra.Transactor,
+	events outbox.Recorder,
+) CompanyUsecase {
+	return &companyUsecase{
 		companyRepo:     companyRepo,
 		companyUserRepo: companyUserRepo,
+		tx:              tx,
+		events:          events,
 	}
 }
 
-func (uc *CompanyUsecase) GetAllCompanies() ([]domain.Company, error) {
-	companies, err := uc.companyRepo.GetAll()
+// authorizeAdmin 呼び出し元が会社の管理者またはAPIキーか確認
+func (uc *companyUsecase) authorizeAdmin(ctx context.Context, companyID uint) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrForbidden
+	}
+	if !principal.IsUser() {
+		return nil
+	}
+
+	relation, err := uc.companyUserRepo.GetRelation(ctx, principal.UserID, companyID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrForbidden
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get company relation: %w", err)
+	}
+	if !relation.IsAdmin() {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// CanView 呼び出し元が会社に所属しているかAPIキーか確認
+func (uc *companyUsecase) CanView(ctx context.Context, companyID uint) (bool, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	if !principal.IsUser() {
+		return true, nil
+	}
+
+	exists, err := uc.companyUserRepo.Exists(ctx, principal.UserID, companyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check company membership: %w", err)
+	}
+	return exists, nil
+}
+
+// authorizeView 参照できない会社は存在しないものとして扱う
+func (uc *companyUsecase) authorizeView(ctx context.Context, companyID uint) error {
+	visible, err := uc.CanView(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (uc *companyUsecase) GetAllCompanies(ctx context.Context) (_ []domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetAllCompanies")
+	defer tracing.End(span, &err)
+
+	companies, err := uc.companyRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all companies: %w", err)
 	}
@@ -35,12 +136,18 @@ func (uc *CompanyUsecase) GetAllCompanies() ([]domain.Company, error) {
 	return responseCompanies, nil
 }
 
-func (uc *CompanyUsecase) GetCompanyByID(id uint) (*domain.Company, error) {
+func (uc *companyUsecase) GetCompanyByID(ctx context.Context, id uint) (_ *domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetCompanyByID")
+	defer tracing.End(span, &err)
+
 	if id == 0 {
 		return nil, fmt.Errorf("invalid company id: %d", id)
 	}
+	if err := uc.authorizeView(ctx, id); err != nil {
+		return nil, err
+	}
 
-	company, err := uc.companyRepo.GetByID(id)
+	company, err := uc.companyRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get company by id %d: %w", id, err)
 	}
@@ -49,7 +156,47 @@ func (uc *CompanyUsecase) GetCompanyByID(id uint) (*domain.Company, error) {
 	return &responseCompany, nil
 }
 
-func (uc *CompanyUsecase) CreateCompany(name, email, phone, address, website, description string) (*domain.Company, error) {
+// GetCompanyByIDWithFields 指定したフィールド（Goのフィールド名）のカラムのみ取得（ID・Versionは常に取得する）
+func (uc *companyUsecase) GetCompanyByIDWithFields(ctx context.Context, id uint, fields []string) (_ *domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetCompanyByIDWithFields")
+	defer tracing.End(span, &err)
+
+	if id == 0 {
+		return nil, fmt.Errorf("invalid company id: %d", id)
+	}
+	if err := uc.authorizeView(ctx, id); err != nil {
+		return nil, err
+	}
+
+	company, err := uc.companyRepo.GetByIDWithFields(ctx, id, fields...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company by id %d: %w", id, err)
+	}
+
+	return company, nil
+}
+
+// GetCompaniesByIDs 指定したIDの会社を1回のクエリで取得（存在しないIDは含まない）
+func (uc *companyUsecase) GetCompaniesByIDs(ctx context.Context, ids []uint) (_ []domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetCompaniesByIDs")
+	defer tracing.End(span, &err)
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	companies, err := uc.companyRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get companies by ids: %w", err)
+	}
+
+	return companies, nil
+}
+
+func (uc *companyUsecase) CreateCompany(ctx context.Context, name, email, phone, address, website, description string) (_ *domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.CreateCompany")
+	defer tracing.End(span, &err)
+
 	// 入力バリデーション
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
@@ -59,12 +206,12 @@ func (uc *CompanyUsecase) CreateCompany(name, email, phone, address, website, de
 	}
 
 	// メール重複チェック
-	exists, err := uc.companyRepo.ExistsByEmail(email)
+	exists, err := uc.companyRepo.ExistsByEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check email existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("company with email %s already exists", email)
+		return nil, fmt.Errorf("company with email %s %w", email, domain.ErrAlreadyExists)
 	}
 
 	company := &domain.Company{
@@ -80,19 +227,45 @@ func (uc *CompanyUsecase) CreateCompany(name, email, phone, address, website, de
 		return nil, fmt.Errorf("invalid company data")
 	}
 
-	if err := uc.companyRepo.Create(company); err != nil {
-		return nil, fmt.Errorf("failed to create company: %w", err)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyRepo.Create(ctx, company); err != nil {
+			return fmt.Errorf("failed to create company: %w", err)
+		}
+		if err := uc.events.Record(ctx, domain.CompanyCreated{CompanyID: company.ID, Name: company.Name, Email: company.Email}); err != nil {
+			return err
+		}
+		// 作成したユーザーを管理者にする（APIキーの場合は誰も所属しない）
+		principal, ok := auth.PrincipalFromContext(ctx)
+		if !ok || !principal.IsUser() {
+			return nil
+		}
+		owner := &domain.CompanyUser{UserID: principal.UserID, CompanyID: company.ID, Role: "admin"}
+		if err := uc.companyUserRepo.Create(ctx, owner); err != nil {
+			return fmt.Errorf("failed to add owner to company: %w", err)
+		}
+		return uc.events.Record(ctx, domain.MemberAdded{CompanyID: company.ID, UserID: owner.UserID, Role: owner.Role})
+	})
+	if err != nil {
+		return nil, err
 	}
+	logging.FromContext(ctx).InfoContext(ctx, "company created", slog.Uint64("company_id", uint64(company.ID)))
 
 	responseCompany := company.ToResponseCompany()
 	return &responseCompany, nil
 }
 
-func (uc *CompanyUsecase) UpdateCompany(id uint, name, email, phone, address, website, description string) (*domain.Company, error) {
+// UpdateCompany versionはクライアントが取得した時点のバージョン（0はチェックしない）
+func (uc *companyUsecase) UpdateCompany(ctx context.Context, id, version uint, name, email, phone, address, website, description string) (_ *domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.UpdateCompany")
+	defer tracing.End(span, &err)
+
 	// 入力バリデーション
 	if id == 0 {
 		return nil, fmt.Errorf("invalid company id: %d", id)
 	}
+	if err := uc.authorizeAdmin(ctx, id); err != nil {
+		return nil, err
+	}
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
@@ -100,18 +273,38 @@ func (uc *CompanyUsecase) UpdateCompany(id uint, name, email, phone, address, we
 		return nil, fmt.Errorf("email is required")
 	}
 
-	existingCompany, err := uc.companyRepo.GetByID(id)
+	existingCompany, err := uc.companyRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get company for update: %w", err)
 	}
+	if version != 0 && existingCompany.Version != version {
+		return nil, fmt.Errorf("company with id %d version %d: %w", id, version, domain.ErrVersionConflict)
+	}
 
 	if existingCompany.Email != email {
-		exists, err := uc.companyRepo.ExistsByEmail(email)
+		exists, err := uc.companyRepo.ExistsByEmail(ctx, email)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check email existence: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("company with email %s already exists", email)
+			return nil, fmt.Errorf("company with email %s %w", email, domain.ErrAlreadyExists)
+		}
+	}
+
+	var changed []string
+	for _, f := range []struct {
+		name     string
+		old, new string
+	}{
+		{"name", existingCompany.Name, name},
+		{"email", existingCompany.Email, email},
+		{"phone", existingCompany.Phone, phone},
+		{"address", existingCompany.Address, address},
+		{"website", existingCompany.Website, website},
+		{"description", existingCompany.Description, description},
+	} {
+		if f.old != f.new {
+			changed = append(changed, f.name)
 		}
 	}
 
@@ -126,37 +319,122 @@ func (uc *CompanyUsecase) UpdateCompany(id uint, name, email, phone, address, we
 		return nil, fmt.Errorf("invalid company data")
 	}
 
-	if err := uc.companyRepo.Update(existingCompany); err != nil {
-		return nil, fmt.Errorf("failed to update company: %w", err)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyRepo.Update(ctx, existingCompany); err != nil {
+			return fmt.Errorf("failed to update company: %w", err)
+		}
+		return uc.events.Record(ctx, companyUpdated(existingCompany, changed))
+	})
+	if err != nil {
+		return nil, err
 	}
+	logging.FromContext(ctx).InfoContext(ctx, "company updated", slog.Uint64("company_id", uint64(id)))
 
 	responseCompany := existingCompany.ToResponseCompany()
 	return &responseCompany, nil
 }
 
-func (uc *CompanyUsecase) DeleteCompany(id uint) error {
+// companyPatchableFields PatchCompanyで変更できるフィールド
+var companyPatchableFields = []string{"Name", "Email", "Phone", "Address", "Website", "Description"}
+
+// PatchCompany JSON Merge Patch・JSON Patchを適用し、変更されたカラムのみ更新
+// versionはクライアントが取得した時点のバージョン（0はチェックしない）
+func (uc *companyUsecase) PatchCompany(ctx context.Context, id, version uint, p patch.Patch) (_ *domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.PatchCompany")
+	defer tracing.End(span, &err)
+
+	if id == 0 {
+		return nil, fmt.Errorf("invalid company id: %d", id)
+	}
+	if err := uc.authorizeAdmin(ctx, id); err != nil {
+		return nil, err
+	}
+
+	company, err := uc.companyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company for patch: %w", err)
+	}
+	if version != 0 && company.Version != version {
+		return nil, fmt.Errorf("company with id %d version %d: %w", id, version, domain.ErrVersionConflict)
+	}
+
+	originalEmail := company.Email
+	changed, err := patch.Apply(p, company, companyPatchableFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changed) > 0 {
+		if err := helper.ValidateStructPartial(company, changed...); err != nil {
+			return nil, err
+		}
+
+		if company.Email != originalEmail {
+			exists, err := uc.companyRepo.ExistsByEmail(ctx, company.Email)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check email existence: %w", err)
+			}
+			if exists {
+				return nil, fmt.Errorf("company with email %s %w", company.Email, domain.ErrAlreadyExists)
+			}
+		}
+
+		err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.companyRepo.UpdateFields(ctx, company, changed...); err != nil {
+				return fmt.Errorf("failed to patch company: %w", err)
+			}
+			return uc.events.Record(ctx, companyUpdated(company, patch.JSONNames(company, changed)))
+		})
+		if err != nil {
+			return nil, err
+		}
+		logging.FromContext(ctx).InfoContext(ctx, "company patched",
+			slog.Uint64("company_id", uint64(id)), slog.Any("fields", changed))
+	}
+
+	responseCompany := company.ToResponseCompany()
+	return &responseCompany, nil
+}
+
+func (uc *companyUsecase) DeleteCompany(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.DeleteCompany")
+	defer tracing.End(span, &err)
+
 	if id == 0 {
 		return fmt.Errorf("invalid company id: %d", id)
 	}
+	if err := uc.authorizeAdmin(ctx, id); err != nil {
+		return err
+	}
 
-	exists, err := uc.companyRepo.Exists(id)
+	exists, err := uc.companyRepo.Exists(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to check company existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("company with id %d not found", id)
+		return fmt.Errorf("company with id %d %w", id, domain.ErrNotFound)
 	}
 
 	// リポジトリで削除（CASCADE設定により関連データも自動削除）
-	if err := uc.companyRepo.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete company: %w", err)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete company: %w", err)
+		}
+		return uc.events.Record(ctx, domain.CompanyDeleted{CompanyID: id})
+	})
+	if err != nil {
+		return err
 	}
+	logging.FromContext(ctx).InfoContext(ctx, "company deleted", slog.Uint64("company_id", uint64(id)))
 
 	return nil
 }
 
 // ページネーション付きで会社を取得
-func (uc *CompanyUsecase) GetCompaniesPaginated(page, limit int) ([]domain.Company, *helper.PaginationResponse, error) {
+func (uc *companyUsecase) GetCompaniesPaginated(ctx context.Context, page, limit int) (_ []domain.Company, _ *helper.PaginationResponse, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetCompaniesPaginated")
+	defer tracing.End(span, &err)
+
 	// ページネーションパラメータ正規化
 	paginationReq := &helper.PaginationRequest{
 		Page:  page,
@@ -166,13 +444,13 @@ func (uc *CompanyUsecase) GetCompaniesPaginated(page, limit int) ([]domain.Compa
 	normalizedLimit := paginationReq.GetLimit()
 
 	// 総件数取得
-	total, err := uc.companyRepo.Count()
+	total, err := uc.companyRepo.Count(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to count companies: %w", err)
 	}
 
 	// ページネーション付きで会社取得
-	companies, err := uc.companyRepo.GetPaginated(offset, normalizedLimit)
+	companies, err := uc.companyRepo.GetPaginated(ctx, offset, normalizedLimit)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get paginated companies: %w", err)
 	}
@@ -189,12 +467,15 @@ func (uc *CompanyUsecase) GetCompaniesPaginated(page, limit int) ([]domain.Compa
 	return responseCompanies, pagination, nil
 }
 
-func (uc *CompanyUsecase) SearchCompanies(name string) ([]domain.Company, error) {
+func (uc *companyUsecase) SearchCompanies(ctx context.Context, name string) (_ []domain.Company, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.SearchCompanies")
+	defer tracing.End(span, &err)
+
 	if name == "" {
 		return nil, fmt.Errorf("search name is required")
 	}
 
-	companies, err := uc.companyRepo.SearchByName(name)
+	companies, err := uc.companyRepo.SearchByName(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search companies: %w", err)
 	}
@@ -208,7 +489,10 @@ func (uc *CompanyUsecase) SearchCompanies(name string) ([]domain.Company, error)
 	return responseCompanies, nil
 }
 
-func (uc *CompanyUsecase) AddUserToCompany(userID, companyID uint, role string) (*domain.CompanyUser, error) {
+func (uc *companyUsecase) AddUserToCompany(ctx context.Context, userID, companyID uint, role string) (_ *domain.CompanyUser, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.AddUserToCompany")
+	defer tracing.End(span, &err)
+
 	// 入力バリデーション
 	if userID == 0 {
 		return nil, fmt.Errorf("invalid user id: %d", userID)
@@ -219,18 +503,21 @@ func (uc *CompanyUsecase) AddUserToCompany(userID, companyID uint, role string)
 	if role == "" {
 		role = "member" // デフォルト役割
 	}
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return nil, err
+	}
 
 	// 会社存在確認
-	exists, err := uc.companyRepo.Exists(companyID)
+	exists, err := uc.companyRepo.Exists(ctx, companyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check company existence: %w", err)
 	}
 	if !exists {
-		return nil, fmt.Errorf("company with id %d not found", companyID)
+		return nil, fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
 	}
 
 	// 既存関係チェック
-	relationExists, err := uc.companyUserRepo.Exists(userID, companyID)
+	relationExists, err := uc.companyUserRepo.Exists(ctx, userID, companyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check relation existence: %w", err)
 	}
@@ -245,14 +532,26 @@ func (uc *CompanyUsecase) AddUserToCompany(userID, companyID uint, role string)
 		Role:      role,
 	}
 
-	if err := uc.companyUserRepo.Create(companyUser); err != nil {
-		return nil, fmt.Errorf("failed to add user to company: %w", err)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyUserRepo.Create(ctx, companyUser); err != nil {
+			return fmt.Errorf("failed to add user to company: %w", err)
+		}
+		return uc.events.Record(ctx, domain.MemberAdded{CompanyID: companyID, UserID: userID, Role: role})
+	})
+	if err != nil {
+		return nil, err
 	}
+	metrics.MembershipChanges.WithLabelValues(metrics.MembershipAdded).Inc()
+	logging.FromContext(ctx).InfoContext(ctx, "member added",
+		slog.Uint64("company_id", uint64(companyID)), slog.Uint64("user_id", uint64(userID)), slog.String("role", role))
 
 	return companyUser, nil
 }
 
-func (uc *CompanyUsecase) UpdateUserRole(userID, companyID uint, role string) (*domain.CompanyUser, error) {
+func (uc *companyUsecase) UpdateUserRole(ctx context.Context, userID, companyID uint, role string) (_ *domain.CompanyUser, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.UpdateUserRole")
+	defer tracing.End(span, &err)
+
 	if userID == 0 {
 		return nil, fmt.Errorf("invalid user id: %d", userID)
 	}
@@ -262,32 +561,54 @@ func (uc *CompanyUsecase) UpdateUserRole(userID, companyID uint, role string) (*
 	if role == "" {
 		return nil, fmt.Errorf("role is required")
 	}
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return nil, err
+	}
 
 	// 既存関係取得
-	companyUser, err := uc.companyUserRepo.GetRelation(userID, companyID)
+	companyUser, err := uc.companyUserRepo.GetRelation(ctx, userID, companyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user-company relation: %w", err)
 	}
 
 	// 役割更新
+	oldRole := companyUser.Role
 	companyUser.Role = role
-	if err := uc.companyUserRepo.Update(companyUser); err != nil {
-		return nil, fmt.Errorf("failed to update user role: %w", err)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyUserRepo.Update(ctx, companyUser); err != nil {
+			return fmt.Errorf("failed to update user role: %w", err)
+		}
+		if oldRole == role {
+			return nil
+		}
+		return uc.events.Record(ctx, domain.RoleChanged{CompanyID: companyID, UserID: userID, OldRole: oldRole, NewRole: role})
+	})
+	if err != nil {
+		return nil, err
 	}
+	metrics.MembershipChanges.WithLabelValues(metrics.MembershipRoleChanged).Inc()
+	logging.FromContext(ctx).InfoContext(ctx, "member role changed",
+		slog.Uint64("company_id", uint64(companyID)), slog.Uint64("user_id", uint64(userID)), slog.String("role", role))
 
 	return companyUser, nil
 }
 
-func (uc *CompanyUsecase) RemoveUserFromCompany(userID, companyID uint) error {
+func (uc *companyUsecase) RemoveUserFromCompany(ctx context.Context, userID, companyID uint) (err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.RemoveUserFromCompany")
+	defer tracing.End(span, &err)
+
 	if userID == 0 {
 		return fmt.Errorf("invalid user id: %d", userID)
 	}
 	if companyID == 0 {
 		return fmt.Errorf("invalid company id: %d", companyID)
 	}
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return err
+	}
 
 	// 関係存在確認
-	exists, err := uc.companyUserRepo.Exists(userID, companyID)
+	exists, err := uc.companyUserRepo.Exists(ctx, userID, companyID)
 	if err != nil {
 		return fmt.Errorf("failed to check relation existence: %w", err)
 	}
@@ -295,28 +616,40 @@ func (uc *CompanyUsecase) RemoveUserFromCompany(userID, companyID uint) error {
 		return fmt.Errorf("user %d is not associated with company %d", userID, companyID)
 	}
 
-	if err := uc.companyUserRepo.Delete(userID, companyID); err != nil {
-		return fmt.Errorf("failed to remove user from company: %w", err)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyUserRepo.Delete(ctx, userID, companyID); err != nil {
+			return fmt.Errorf("failed to remove user from company: %w", err)
+		}
+		return uc.events.Record(ctx, domain.MemberRemoved{CompanyID: companyID, UserID: userID})
+	})
+	if err != nil {
+		return err
 	}
+	metrics.MembershipChanges.WithLabelValues(metrics.MembershipRemoved).Inc()
+	logging.FromContext(ctx).InfoContext(ctx, "member removed",
+		slog.Uint64("company_id", uint64(companyID)), slog.Uint64("user_id", uint64(userID)))
 
 	return nil
 }
 
-func (uc *CompanyUsecase) GetUsersByCompany(companyID uint) ([]domain.CompanyUser, error) {
+func (uc *companyUsecase) GetUsersByCompany(ctx context.Context, companyID uint) (_ []domain.CompanyUser, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetUsersByCompany")
+	defer tracing.End(span, &err)
+
 	if companyID == 0 {
 		return nil, fmt.Errorf("invalid company id: %d", companyID)
 	}
 
 	// 会社存在確認
-	exists, err := uc.companyRepo.Exists(companyID)
+	exists, err := uc.companyRepo.Exists(ctx, companyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check company existence: %w", err)
 	}
 	if !exists {
-		return nil, fmt.Errorf("company with id %d not found", companyID)
+		return nil, fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
 	}
 
-	companyUsers, err := uc.companyUserRepo.GetUsersByCompanyID(companyID)
+	companyUsers, err := uc.companyUserRepo.GetUsersByCompanyID(ctx, companyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users by company: %w", err)
 	}
@@ -324,15 +657,57 @@ func (uc *CompanyUsecase) GetUsersByCompany(companyID uint) ([]domain.CompanyUse
 	return companyUsers, nil
 }
 
-func (uc *CompanyUsecase) GetCompaniesByUser(userID uint) ([]domain.CompanyUser, error) {
+func (uc *companyUsecase) GetCompaniesByUser(ctx context.Context, userID uint) (_ []domain.CompanyUser, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetCompaniesByUser")
+	defer tracing.End(span, &err)
+
 	if userID == 0 {
 		return nil, fmt.Errorf("invalid user id: %d", userID)
 	}
 
-	companyUsers, err := uc.companyUserRepo.GetCompaniesByUserID(userID)
+	companyUsers, err := uc.companyUserRepo.GetCompaniesByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get companies by user: %w", err)
 	}
 
 	return companyUsers, nil
 }
+
+// GetMembersByCompanyIDs 指定した会社の所属を1回のクエリで取得（会社の存在は確認しない）
+func (uc *companyUsecase) GetMembersByCompanyIDs(ctx context.Context, companyIDs []uint) (_ []domain.CompanyUser, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetMembersByCompanyIDs")
+	defer tracing.End(span, &err)
+
+	if len(companyIDs) == 0 {
+		return nil, nil
+	}
+
+	companyUsers, err := uc.companyUserRepo.GetByCompanyIDs(ctx, companyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by companies: %w", err)
+	}
+
+	return companyUsers, nil
+}
+
+// GetMembershipsByUserIDs 指定したユーザーの所属を1回のクエリで取得
+func (uc *companyUsecase) GetMembershipsByUserIDs(ctx context.Context, userIDs []uint) (_ []domain.CompanyUser, err error) {
+	ctx, span := tracing.Start(ctx, "CompanyUsecase.GetMembershipsByUserIDs")
+	defer tracing.End(span, &err)
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	companyUsers, err := uc.companyUserRepo.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get companies by users: %w", err)
+	}
+
+	return companyUsers, nil
+}
+
+// companyUpdated 会社の更新イベント（changedは変更されたフィールドのJSON名）
+func companyUpdated(c *domain.Company, changed []string) domain.CompanyUpdated {
+	return domain.CompanyUpdated{CompanyID: c.ID, Name: c.Name, Email: c.Email, Version: c.Version, Changed: changed}
+}
@@ -0,0 +1,137 @@
+package company
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/outbox"
+)
+
+type testDeps struct {
+	companies    *mocks.MockCompanyRepository
+	companyUsers *mocks.MockCompanyUserRepository
+}
+
+func newTestUsecase(t *testing.T) (CompanyUsecase, testDeps) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	deps := testDeps{
+		companies:    mocks.NewMockCompanyRepository(ctrl),
+		companyUsers: mocks.NewMockCompanyUserRepository(ctrl),
+	}
+	uc := NewCompanyUsecase(deps.companies, deps.companyUsers, infra.NoTx, outbox.New(outbox.NewMemoryStore()))
+	return uc, deps
+}
+
+// asUser 会社（ID=1）に指定した役割で所属するユーザーとして呼び出す（roleが空の場合は所属しない）
+func asUser(deps testDeps, userID uint, role string) context.Context {
+	if role == "" {
+		deps.companyUsers.EXPECT().GetRelation(gomock.Any(), userID, uint(1)).Return(nil, domain.ErrNotFound).AnyTimes()
+	} else {
+		deps.companyUsers.EXPECT().GetRelation(gomock.Any(), userID, uint(1)).
+			Return(&domain.CompanyUser{UserID: userID, CompanyID: 1, Role: role}, nil).AnyTimes()
+	}
+	return auth.WithPrincipal(context.Background(), &auth.Principal{UserID: userID})
+}
+
+func TestCompanyUsecase_CreateCompany_OwnerBecomesAdmin(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 10})
+
+	deps.companies.EXPECT().ExistsByEmail(gomock.Any(), "info@example.com").Return(false, nil)
+	deps.companies.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *domain.Company) error {
+		c.ID = 1
+		return nil
+	})
+	deps.companyUsers.EXPECT().Create(gomock.Any(), &domain.CompanyUser{UserID: 10, CompanyID: 1, Role: "admin"}).Return(nil)
+
+	c, err := uc.CreateCompany(ctx, "株式会社サンプル", "info@example.com", "", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), c.ID)
+}
+
+func TestCompanyUsecase_UpdateCompany_Authorization(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{name: "管理者は更新できる", role: "admin"},
+		{name: "メンバーは更新できない", role: "member", wantErr: domain.ErrForbidden},
+		{name: "所属していないユーザーは更新できない", role: "", wantErr: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, deps := newTestUsecase(t)
+			ctx := asUser(deps, 10, tt.role)
+
+			if tt.wantErr == nil {
+				deps.companies.EXPECT().GetByID(gomock.Any(), uint(1)).
+					Return(&domain.Company{ID: 1, Name: "旧社名", Email: "info@example.com", Version: 1}, nil)
+				deps.companies.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			_, err := uc.UpdateCompany(ctx, 1, 1, "新社名", "info@example.com", "", "", "", "")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCompanyUsecase_UpdateCompany_APIKey(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+
+	deps.companies.EXPECT().GetByID(gomock.Any(), uint(1)).
+		Return(&domain.Company{ID: 1, Name: "旧社名", Email: "info@example.com", Version: 1}, nil)
+	deps.companies.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err := uc.UpdateCompany(ctx, 1, 1, "新社名", "info@example.com", "", "", "", "")
+	assert.NoError(t, err)
+}
+
+func TestCompanyUsecase_GetCompanyByID_Visibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  bool
+		wantErr error
+	}{
+		{name: "所属するユーザーは取得できる", member: true},
+		{name: "所属していないユーザーには存在しないものとして扱う", member: false, wantErr: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, deps := newTestUsecase(t)
+			ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 10})
+
+			deps.companyUsers.EXPECT().Exists(gomock.Any(), uint(10), uint(1)).Return(tt.member, nil).Times(2)
+			if tt.wantErr == nil {
+				deps.companies.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&domain.Company{ID: 1, Name: "株式会社サンプル"}, nil)
+				deps.companies.EXPECT().GetByIDWithFields(gomock.Any(), uint(1), "Name").Return(&domain.Company{ID: 1, Name: "株式会社サンプル"}, nil)
+			}
+
+			_, err := uc.GetCompanyByID(ctx, 1)
+			_, fieldsErr := uc.GetCompanyByIDWithFields(ctx, 1, []string{"Name"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, fieldsErr, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, fieldsErr)
+		})
+	}
+}
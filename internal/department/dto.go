@@ -0,0 +1,91 @@
+package department
+
+import (
+	"time"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+)
+
+// DepartmentIDRequest 部署のパスパラメータID
+type DepartmentIDRequest struct {
+	CompanyID    uint `param:"id" validate:"required,min=1" example:"1"`            // 会社ID
+	DepartmentID uint `param:"department_id" validate:"required,min=1" example:"1"` // 部署ID
+}
+
+// MemberIDRequest 部署の所属のパスパラメータID
+type MemberIDRequest struct {
+	CompanyID    uint `param:"id" validate:"required,min=1" example:"1"`            // 会社ID
+	DepartmentID uint `param:"department_id" validate:"required,min=1" example:"1"` // 部署ID
+	UserID       uint `param:"user_id" validate:"required,min=1" example:"1"`       // ユーザーID
+}
+
+// ListMembersRequest 部署の所属一覧のクエリパラメータ
+type ListMembersRequest struct {
+	helper.PaginationRequest
+	Recursive bool `query:"recursive" example:"true"` // 配下のすべての部署の所属を含める
+}
+
+type CreateDepartmentRequest struct {
+	ParentID    *uint  `json:"parent_id" validate:"omitempty,min=1" example:"1"` // 親部署ID（省略時は最上位）
+	Name        string `json:"name" validate:"required,max=100" example:"第一営業課"`
+	Description string `json:"description" validate:"omitempty,max=255" example:"首都圏の法人営業"`
+}
+
+type UpdateDepartmentRequest struct {
+	Name        string `json:"name" validate:"required,max=100" example:"第一営業課"`
+	Description string `json:"description" validate:"omitempty,max=255" example:"首都圏の法人営業"`
+}
+
+// MoveDepartmentRequest 部署の移動先
+type MoveDepartmentRequest struct {
+	ParentID *uint `json:"parent_id" validate:"omitempty,min=1" example:"1"` // 新しい親部署ID（nullの場合は最上位）
+}
+
+type AssignMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=manager member" example:"member"` // 部署での役割（manager, member）
+}
+
+// DepartmentNode 部署の木構造の節
+type DepartmentNode struct {
+	ID          uint             `json:"id" example:"1"`
+	ParentID    *uint            `json:"parent_id" example:"1"`
+	Name        string           `json:"name" example:"営業部"`
+	Description string           `json:"description" example:"法人営業"`
+	Depth       int              `json:"depth" example:"0"` // 最上位の部署からの深さ（最上位は0）
+	Children    []DepartmentNode `json:"children"`
+	CreatedAt   time.Time        `json:"created_at" format:"date-time"`
+	UpdatedAt   time.Time        `json:"updated_at" format:"date-time"`
+}
+
+// NewDepartmentTree 会社の部署から木構造を組み立てる（同じ親の部署はID順）
+func NewDepartmentTree(departments []domain.Department) []DepartmentNode {
+	children := map[uint][]domain.Department{}
+	var roots []domain.Department
+	for _, d := range departments {
+		if d.ParentID == nil {
+			roots = append(roots, d)
+			continue
+		}
+		children[*d.ParentID] = append(children[*d.ParentID], d)
+	}
+
+	var build func(ds []domain.Department, depth int) []DepartmentNode
+	build = func(ds []domain.Department, depth int) []DepartmentNode {
+		nodes := make([]DepartmentNode, len(ds))
+		for i, d := range ds {
+			nodes[i] = DepartmentNode{
+				ID:          d.ID,
+				ParentID:    d.ParentID,
+				Name:        d.Name,
+				Description: d.Description,
+				Depth:       depth,
+				Children:    build(children[d.ID], depth+1),
+				CreatedAt:   d.CreatedAt,
+				UpdatedAt:   d.UpdatedAt,
+			}
+		}
+		return nodes
+	}
+	return build(roots, 0)
+}
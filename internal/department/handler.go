@@ -0,0 +1,393 @@
+package department
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+type DepartmentHandler struct {
+	usecase DepartmentUsecase
+}
+
+func NewDepartmentHandler(usecase DepartmentUsecase) *DepartmentHandler {
+	return &DepartmentHandler{usecase: usecase}
+}
+
+// ListDepartments godoc
+// @Summary 部署の一覧（木構造）
+// @ID listDepartments
+// @Description 会社の部署を木構造で取得します。会社のメンバーのみ操作できます
+// @Tags departments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Success 200 {object} helper.APIResponse{data=[]DepartmentNode}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments [get]
+func (h *DepartmentHandler) ListDepartments(c echo.Context) error {
+	var req helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	departments, err := h.usecase.ListDepartments(ctx, req.ID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to list departments")
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, NewDepartmentTree(departments), "")
+}
+
+// CreateDepartment godoc
+// @Summary 部署作成
+// @ID createDepartment
+// @Description 会社の部署を作成します。parent_idを省略すると最上位の部署になります。同じ親の下で部署名は重複できません。会社の管理者のみ操作できます
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department body CreateDepartmentRequest true "部署"
+// @Success 201 {object} helper.APIResponse{data=domain.Department}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments [post]
+func (h *DepartmentHandler) CreateDepartment(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var req CreateDepartmentRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	d, err := h.usecase.CreateDepartment(ctx, id.ID, req.ParentID, req.Name, req.Description)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to create department")
+	}
+
+	return helper.CreatedResponse(c, d, "")
+}
+
+// GetDepartment godoc
+// @Summary 部署取得
+// @ID getDepartment
+// @Description 会社の部署を取得します
+// @Tags departments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department_id path int true "部署ID"
+// @Success 200 {object} helper.APIResponse{data=domain.Department}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments/{department_id} [get]
+func (h *DepartmentHandler) GetDepartment(c echo.Context) error {
+	var req DepartmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	d, err := h.usecase.GetDepartment(ctx, req.CompanyID, req.DepartmentID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to get department")
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, d, "")
+}
+
+// UpdateDepartment godoc
+// @Summary 部署更新
+// @ID updateDepartment
+// @Description 部署の名前・説明を更新します。親部署の変更は移動（/move）で行います
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department_id path int true "部署ID"
+// @Param department body UpdateDepartmentRequest true "部署"
+// @Success 200 {object} helper.APIResponse{data=domain.Department}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments/{department_id} [put]
+func (h *DepartmentHandler) UpdateDepartment(c echo.Context) error {
+	var id DepartmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var req UpdateDepartmentRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	d, err := h.usecase.UpdateDepartment(ctx, id.CompanyID, id.DepartmentID, req.Name, req.Description)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to update department")
+	}
+
+	return helper.UpdatedResponse(c, d, "")
+}
+
+// MoveDepartment godoc
+// @Summary 部署の移動
+// @ID moveDepartment
+// @Description 部署を配下の部署・所属ごと別の親部署の下に移動します（parent_idがnullの場合は最上位）。自分自身や配下の部署の下には移動できません
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department_id path int true "部署ID"
+// @Param move body MoveDepartmentRequest true "移動先"
+// @Success 200 {object} helper.APIResponse{data=domain.Department}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments/{department_id}/move [post]
+func (h *DepartmentHandler) MoveDepartment(c echo.Context) error {
+	var id DepartmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var req MoveDepartmentRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	d, err := h.usecase.MoveDepartment(ctx, id.CompanyID, id.DepartmentID, req.ParentID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to move department")
+	}
+
+	return helper.UpdatedResponse(c, d, "")
+}
+
+// DeleteDepartment godoc
+// @Summary 部署削除
+// @ID deleteDepartment
+// @Description 部署と所属を削除します。配下の部署がある場合は先に移動・削除してください
+// @Tags departments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department_id path int true "部署ID"
+// @Success 200 {object} helper.APIResponse
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments/{department_id} [delete]
+func (h *DepartmentHandler) DeleteDepartment(c echo.Context) error {
+	var req DepartmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if err := h.usecase.DeleteDepartment(ctx, req.CompanyID, req.DepartmentID); err != nil {
+		return h.errorResponse(c, err, "failed to delete department")
+	}
+
+	return helper.DeletedResponse(c, "")
+}
+
+// ListMembers godoc
+// @Summary 部署の所属一覧
+// @ID listDepartmentMembers
+// @Description 部署の所属をユーザーID順に取得します。recursive=trueの場合は配下のすべての部署の所属を含めます（複数の部署に所属するユーザーは部署ごとに含まれます）
+// @Tags departments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department_id path int true "部署ID"
+// @Param recursive query bool false "配下の部署を含める"
+// @Param page query int false "ページ番号"
+// @Param limit query int false "1ページあたりの件数"
+// @Success 200 {object} helper.PaginatedResponse{data=[]domain.DepartmentMemberDetail}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments/{department_id}/members [get]
+func (h *DepartmentHandler) ListMembers(c echo.Context) error {
+	var id DepartmentIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var req ListMembersRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	members, pagination, err := h.usecase.ListMembers(ctx, id.CompanyID, id.DepartmentID, req.Recursive, req.Page, req.Limit)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to list department members")
+	}
+
+	return helper.PaginatedSuccessResponse(c, members, pagination, "")
+}
+
+// AssignMember godoc
+// @Summary 部署への所属
+// @ID assignDepartmentMember
+// @Description 会社のメンバーを部署に所属させます。既に所属している場合は役割を変更します。会社の管理者と、部署またはその上位の部署のmanagerが操作できます
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department_id path int true "部署ID"
+// @Param user_id path int true "ユーザーID"
+// @Param member body AssignMemberRequest true "役割"
+// @Success 200 {object} helper.APIResponse{data=domain.DepartmentMember}
+// @Success 201 {object} helper.APIResponse{data=domain.DepartmentMember}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments/{department_id}/members/{user_id} [put]
+func (h *DepartmentHandler) AssignMember(c echo.Context) error {
+	var id MemberIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var req AssignMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	m, created, err := h.usecase.AssignMember(ctx, id.CompanyID, id.DepartmentID, id.UserID, req.Role)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to assign department member")
+	}
+
+	if created {
+		return helper.CreatedResponse(c, m, "")
+	}
+	return helper.UpdatedResponse(c, m, "")
+}
+
+// RemoveMember godoc
+// @Summary 部署からの除外
+// @ID removeDepartmentMember
+// @Description ユーザーを部署から外します（会社・他の部署の所属は変わりません）
+// @Tags departments
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param department_id path int true "部署ID"
+// @Param user_id path int true "ユーザーID"
+// @Success 200 {object} helper.APIResponse
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/departments/{department_id}/members/{user_id} [delete]
+func (h *DepartmentHandler) RemoveMember(c echo.Context) error {
+	var req MemberIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if err := h.usecase.RemoveMember(ctx, req.CompanyID, req.DepartmentID, req.UserID); err != nil {
+		return h.errorResponse(c, err, "failed to remove department member")
+	}
+
+	return helper.DeletedResponse(c, "")
+}
+
+// errorResponse ユースケースのエラーをレスポンスに変換
+func (h *DepartmentHandler) errorResponse(c echo.Context, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		return helper.ForbiddenResponse(c)
+	case errors.Is(err, ErrParentNotFound):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "親部署が見つかりません", err.Error())
+	case errors.Is(err, ErrNotCompanyMember):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "会社に所属していないユーザーは部署に所属できません", err.Error())
+	case errors.Is(err, ErrInvalidMove):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "自分自身または配下の部署の下には移動できません", "")
+	case errors.Is(err, domain.ErrNotFound):
+		return helper.NotFoundResponse(c, "")
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return helper.ErrorResponse(c, http.StatusConflict, helper.ErrorCodeAlreadyExists, "同じ親部署の下に同じ名前の部署があります", err.Error())
+	case errors.Is(err, ErrHasChildren):
+		return helper.ErrorResponse(c, http.StatusConflict, helper.ErrorCodeConflict, "配下の部署があるため削除できません", "")
+	}
+
+	ctx := c.Request().Context()
+	logging.FromContext(ctx).ErrorContext(ctx, logMessage, slog.Any("error", err))
+	return helper.InternalErrorResponse(c, err.Error())
+}
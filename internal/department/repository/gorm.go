@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// departmentRepository GORM実装
+type departmentRepository struct {
+	db *gorm.DB
+}
+
+// NewDepartmentRepository 部署リポジトリのコンストラクタ
+func NewDepartmentRepository(db *gorm.DB) DepartmentRepository {
+	return &departmentRepository{db: db}
+}
+
+func (r *departmentRepository) GetByID(ctx context.Context, id uint) (*domain.Department, error) {
+	var d domain.Department
+
+	if err := infra.Conn(ctx, r.db).First(&d, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("department with id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get department by id %d: %w", id, err)
+	}
+
+	return &d, nil
+}
+
+func (r *departmentRepository) GetByCompanyID(ctx context.Context, companyID uint) ([]domain.Department, error) {
+	var departments []domain.Department
+
+	if err := infra.Conn(ctx, r.db).Where("company_id = ?", companyID).Order("id").Find(&departments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get departments by company id %d: %w", companyID, err)
+	}
+
+	return departments, nil
+}
+
+func (r *departmentRepository) IsDescendant(ctx context.Context, ancestorID, descendantID uint) (bool, error) {
+	var count int64
+
+	err := infra.Conn(ctx, r.db).Table("department_paths").
+		Where("ancestor_id = ? AND descendant_id = ?", ancestorID, descendantID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check department path: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *departmentRepository) HasChildren(ctx context.Context, id uint) (bool, error) {
+	var count int64
+
+	if err := infra.Conn(ctx, r.db).Model(&domain.Department{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check child departments: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *departmentRepository) ExistsByName(ctx context.Context, companyID uint, parentID *uint, name string, excludeID uint) (bool, error) {
+	var count int64
+
+	query := infra.Conn(ctx, r.db).Model(&domain.Department{}).Where("company_id = ? AND name = ? AND id <> ?", companyID, name, excludeID)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check department name: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *departmentRepository) LockCompany(ctx context.Context, companyID uint) error {
+	var company domain.Company
+
+	err := infra.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&company, companyID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("failed to lock company %d: %w", companyID, err)
+	}
+
+	return nil
+}
+
+func (r *departmentRepository) Create(ctx context.Context, d *domain.Department) error {
+	db := infra.Conn(ctx, r.db)
+
+	if err := db.Create(d).Error; err != nil {
+		return fmt.Errorf("failed to create department: %w", err)
+	}
+
+	// 自分自身（距離0）と、親のすべての祖先からの経路を追加する
+	err := db.Exec(`
+		INSERT INTO department_paths (ancestor_id, descendant_id, depth)
+		SELECT ancestor_id, @id, depth + 1 FROM department_paths WHERE descendant_id = @parent
+		UNION ALL
+		SELECT @id, @id, 0`,
+		map[string]any{"id": d.ID, "parent": d.ParentID},
+	).Error
+	if err != nil {
+		return fmt.Errorf("failed to create department paths: %w", err)
+	}
+
+	return nil
+}
+
+func (r *departmentRepository) Update(ctx context.Context, d *domain.Department) error {
+	result := infra.Conn(ctx, r.db).Model(d).Select("Name", "Description").Updates(d)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update department: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("department with id %d %w", d.ID, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *departmentRepository) Move(ctx context.Context, id uint, parentID *uint) error {
+	db := infra.Conn(ctx, r.db)
+
+	// 配下の部署（自分自身を含む）と、その外側の祖先との経路を削除する（配下の部署どうしの経路は残す）
+	err := db.Exec(`
+		DELETE FROM department_paths
+		WHERE descendant_id IN (SELECT descendant_id FROM department_paths WHERE ancestor_id = @id)
+		  AND ancestor_id NOT IN (SELECT descendant_id FROM department_paths WHERE ancestor_id = @id)`,
+		map[string]any{"id": id},
+	).Error
+	if err != nil {
+		return fmt.Errorf("failed to detach department subtree: %w", err)
+	}
+
+	if parentID != nil {
+		// 新しい親の祖先（親自身を含む）と配下の部署のすべての組の経路を追加する
+		err = db.Exec(`
+			INSERT INTO department_paths (ancestor_id, descendant_id, depth)
+			SELECT a.ancestor_id, s.descendant_id, a.depth + s.depth + 1
+			FROM department_paths a
+			CROSS JOIN department_paths s
+			WHERE a.descendant_id = @parent AND s.ancestor_id = @id`,
+			map[string]any{"id": id, "parent": *parentID},
+		).Error
+		if err != nil {
+			return fmt.Errorf("failed to attach department subtree: %w", err)
+		}
+	}
+
+	result := db.Model(&domain.Department{}).Where("id = ?", id).Update("parent_id", parentID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to move department: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("department with id %d %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *departmentRepository) Delete(ctx context.Context, id uint) error {
+	// 閉包テーブルの行・所属は外部キーのON DELETE CASCADEで削除される
+	result := infra.Conn(ctx, r.db).Delete(&domain.Department{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete department: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("department with id %d %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// departmentMemberRepository GORM実装
+type departmentMemberRepository struct {
+	db *gorm.DB
+}
+
+// NewDepartmentMemberRepository 部署の所属リポジトリのコンストラクタ
+func NewDepartmentMemberRepository(db *gorm.DB) DepartmentMemberRepository {
+	return &departmentMemberRepository{db: db}
+}
+
+func (r *departmentMemberRepository) Get(ctx context.Context, departmentID, userID uint) (*domain.DepartmentMember, error) {
+	var m domain.DepartmentMember
+
+	err := infra.Conn(ctx, r.db).Where("department_id = ? AND user_id = ?", departmentID, userID).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user %d in department %d %w", userID, departmentID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get department member: %w", err)
+	}
+
+	return &m, nil
+}
+
+// members 部署（recursiveの場合は配下の部署を含む）の所属のクエリ
+func (r *departmentMemberRepository) members(ctx context.Context, departmentID uint, recursive bool) *gorm.DB {
+	query := infra.Conn(ctx, r.db).Table("department_members m").
+		Joins("JOIN departments d ON d.id = m.department_id").
+		Joins("JOIN users u ON u.id = m.user_id")
+	if recursive {
+		return query.Joins("JOIN department_paths p ON p.descendant_id = m.department_id").Where("p.ancestor_id = ?", departmentID)
+	}
+	return query.Where("m.department_id = ?", departmentID)
+}
+
+func (r *departmentMemberRepository) GetPaginated(ctx context.Context, departmentID uint, recursive bool, offset, limit int) ([]domain.DepartmentMemberDetail, error) {
+	var members []domain.DepartmentMemberDetail
+
+	err := r.members(ctx, departmentID, recursive).
+		Select("m.department_id, d.name AS department_name, m.user_id, u.name AS user_name, u.email AS user_email, m.role, m.created_at").
+		Order("m.user_id, m.department_id").
+		Offset(offset).
+		Limit(limit).
+		Scan(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paginated department members: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *departmentMemberRepository) Count(ctx context.Context, departmentID uint, recursive bool) (int64, error) {
+	var count int64
+
+	if err := r.members(ctx, departmentID, recursive).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count department members: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *departmentMemberRepository) IsManager(ctx context.Context, userID, departmentID uint) (bool, error) {
+	var count int64
+
+	err := infra.Conn(ctx, r.db).Table("department_members m").
+		Joins("JOIN department_paths p ON p.ancestor_id = m.department_id").
+		Where("p.descendant_id = ? AND m.user_id = ? AND m.role = ?", departmentID, userID, domain.DepartmentRoleManager).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check department manager: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *departmentMemberRepository) Create(ctx context.Context, m *domain.DepartmentMember) error {
+	if err := infra.Conn(ctx, r.db).Create(m).Error; err != nil {
+		return fmt.Errorf("failed to create department member: %w", err)
+	}
+
+	return nil
+}
+
+func (r *departmentMemberRepository) UpdateRole(ctx context.Context, m *domain.DepartmentMember) error {
+	result := infra.Conn(ctx, r.db).Model(m).Select("Role").Updates(m)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update department member role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("department member with id %d %w", m.ID, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *departmentMemberRepository) Delete(ctx context.Context, departmentID, userID uint) error {
+	result := infra.Conn(ctx, r.db).Where("department_id = ? AND user_id = ?", departmentID, userID).Delete(&domain.DepartmentMember{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete department member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user %d in department %d %w", userID, departmentID, domain.ErrNotFound)
+	}
+
+	return nil
+}
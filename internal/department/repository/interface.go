@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"km-api-go/internal/domain"
+)
+
+// 部署リポジトリインターフェース
+// 木構造は閉包テーブル（department_paths）で持ち、作成・移動の際に更新する
+type DepartmentRepository interface {
+	GetByID(ctx context.Context, id uint) (*domain.Department, error)
+	// GetByCompanyID 会社のすべての部署を取得（木構造の組み立て用）
+	GetByCompanyID(ctx context.Context, companyID uint) ([]domain.Department, error)
+	// IsDescendant descendantIDがancestorIDの子孫（自分自身を含む）か
+	IsDescendant(ctx context.Context, ancestorID, descendantID uint) (bool, error)
+	HasChildren(ctx context.Context, id uint) (bool, error)
+	// ExistsByName 同じ親の下に同じ名前の部署があるか（excludeIDの部署は除く）
+	ExistsByName(ctx context.Context, companyID uint, parentID *uint, name string, excludeID uint) (bool, error)
+	// LockCompany 会社の行をロックし、同じ会社の木構造の変更を直列にする（トランザクション内で呼ぶ）
+	LockCompany(ctx context.Context, companyID uint) error
+	// Create 部署と閉包テーブルの行を作成（トランザクション内で呼ぶ）
+	Create(ctx context.Context, department *domain.Department) error
+	// Update 名前・説明を更新
+	Update(ctx context.Context, department *domain.Department) error
+	// Move 部署を配下の部署ごとparentIDの下（nilの場合は最上位）に移動する（トランザクション内で呼ぶ）
+	Move(ctx context.Context, id uint, parentID *uint) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// 部署の所属リポジトリインターフェース
+type DepartmentMemberRepository interface {
+	Get(ctx context.Context, departmentID, userID uint) (*domain.DepartmentMember, error)
+	// GetPaginated 部署（recursiveの場合は配下の部署を含む）の所属をユーザーID順に取得
+	GetPaginated(ctx context.Context, departmentID uint, recursive bool, offset, limit int) ([]domain.DepartmentMemberDetail, error)
+	Count(ctx context.Context, departmentID uint, recursive bool) (int64, error)
+	// IsManager ユーザーが部署またはその祖先の部署のmanagerか
+	IsManager(ctx context.Context, userID, departmentID uint) (bool, error)
+	Create(ctx context.Context, member *domain.DepartmentMember) error
+	UpdateRole(ctx context.Context, member *domain.DepartmentMember) error
+	Delete(ctx context.Context, departmentID, userID uint) error
+}
@@ -0,0 +1,313 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/department/repository/interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/department/repository/interface.go -destination=internal/department/repository/mocks/department_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "km-api-go/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDepartmentRepository is a mock of DepartmentRepository interface.
+type MockDepartmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepartmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDepartmentRepositoryMockRecorder is the mock recorder for MockDepartmentRepository.
+type MockDepartmentRepositoryMockRecorder struct {
+	mock *MockDepartmentRepository
+}
+
+// NewMockDepartmentRepository creates a new mock instance.
+func NewMockDepartmentRepository(ctrl *gomock.Controller) *MockDepartmentRepository {
+	mock := &MockDepartmentRepository{ctrl: ctrl}
+	mock.recorder = &MockDepartmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepartmentRepository) EXPECT() *MockDepartmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDepartmentRepository) Create(ctx context.Context, department *domain.Department) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, department)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDepartmentRepositoryMockRecorder) Create(ctx, department any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDepartmentRepository)(nil).Create), ctx, department)
+}
+
+// Delete mocks base method.
+func (m *MockDepartmentRepository) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDepartmentRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDepartmentRepository)(nil).Delete), ctx, id)
+}
+
+// ExistsByName mocks base method.
+func (m *MockDepartmentRepository) ExistsByName(ctx context.Context, companyID uint, parentID *uint, name string, excludeID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByName", ctx, companyID, parentID, name, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByName indicates an expected call of ExistsByName.
+func (mr *MockDepartmentRepositoryMockRecorder) ExistsByName(ctx, companyID, parentID, name, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByName", reflect.TypeOf((*MockDepartmentRepository)(nil).ExistsByName), ctx, companyID, parentID, name, excludeID)
+}
+
+// GetByCompanyID mocks base method.
+func (m *MockDepartmentRepository) GetByCompanyID(ctx context.Context, companyID uint) ([]domain.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompanyID", ctx, companyID)
+	ret0, _ := ret[0].([]domain.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompanyID indicates an expected call of GetByCompanyID.
+func (mr *MockDepartmentRepositoryMockRecorder) GetByCompanyID(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompanyID", reflect.TypeOf((*MockDepartmentRepository)(nil).GetByCompanyID), ctx, companyID)
+}
+
+// GetByID mocks base method.
+func (m *MockDepartmentRepository) GetByID(ctx context.Context, id uint) (*domain.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDepartmentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDepartmentRepository)(nil).GetByID), ctx, id)
+}
+
+// HasChildren mocks base method.
+func (m *MockDepartmentRepository) HasChildren(ctx context.Context, id uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChildren", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasChildren indicates an expected call of HasChildren.
+func (mr *MockDepartmentRepositoryMockRecorder) HasChildren(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChildren", reflect.TypeOf((*MockDepartmentRepository)(nil).HasChildren), ctx, id)
+}
+
+// IsDescendant mocks base method.
+func (m *MockDepartmentRepository) IsDescendant(ctx context.Context, ancestorID, descendantID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDescendant", ctx, ancestorID, descendantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDescendant indicates an expected call of IsDescendant.
+func (mr *MockDepartmentRepositoryMockRecorder) IsDescendant(ctx, ancestorID, descendantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDescendant", reflect.TypeOf((*MockDepartmentRepository)(nil).IsDescendant), ctx, ancestorID, descendantID)
+}
+
+// LockCompany mocks base method.
+func (m *MockDepartmentRepository) LockCompany(ctx context.Context, companyID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCompany", ctx, companyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockCompany indicates an expected call of LockCompany.
+func (mr *MockDepartmentRepositoryMockRecorder) LockCompany(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCompany", reflect.TypeOf((*MockDepartmentRepository)(nil).LockCompany), ctx, companyID)
+}
+
+// Move mocks base method.
+func (m *MockDepartmentRepository) Move(ctx context.Context, id uint, parentID *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, id, parentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockDepartmentRepositoryMockRecorder) Move(ctx, id, parentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockDepartmentRepository)(nil).Move), ctx, id, parentID)
+}
+
+// Update mocks base method.
+func (m *MockDepartmentRepository) Update(ctx context.Context, department *domain.Department) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, department)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDepartmentRepositoryMockRecorder) Update(ctx, department any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDepartmentRepository)(nil).Update), ctx, department)
+}
+
+// MockDepartmentMemberRepository is a mock of DepartmentMemberRepository interface.
+type MockDepartmentMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepartmentMemberRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDepartmentMemberRepositoryMockRecorder is the mock recorder for MockDepartmentMemberRepository.
+type MockDepartmentMemberRepositoryMockRecorder struct {
+	mock *MockDepartmentMemberRepository
+}
+
+// NewMockDepartmentMemberRepository creates a new mock instance.
+func NewMockDepartmentMemberRepository(ctrl *gomock.Controller) *MockDepartmentMemberRepository {
+	mock := &MockDepartmentMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockDepartmentMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepartmentMemberRepository) EXPECT() *MockDepartmentMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockDepartmentMemberRepository) Count(ctx context.Context, departmentID uint, recursive bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, departmentID, recursive)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockDepartmentMemberRepositoryMockRecorder) Count(ctx, departmentID, recursive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockDepartmentMemberRepository)(nil).Count), ctx, departmentID, recursive)
+}
+
+// Create mocks base method.
+func (m *MockDepartmentMemberRepository) Create(ctx context.Context, member *domain.DepartmentMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDepartmentMemberRepositoryMockRecorder) Create(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDepartmentMemberRepository)(nil).Create), ctx, member)
+}
+
+// Delete mocks base method.
+func (m *MockDepartmentMemberRepository) Delete(ctx context.Context, departmentID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, departmentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDepartmentMemberRepositoryMockRecorder) Delete(ctx, departmentID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDepartmentMemberRepository)(nil).Delete), ctx, departmentID, userID)
+}
+
+// Get mocks base method.
+func (m *MockDepartmentMemberRepository) Get(ctx context.Context, departmentID, userID uint) (*domain.DepartmentMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, departmentID, userID)
+	ret0, _ := ret[0].(*domain.DepartmentMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDepartmentMemberRepositoryMockRecorder) Get(ctx, departmentID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDepartmentMemberRepository)(nil).Get), ctx, departmentID, userID)
+}
+
+// GetPaginated mocks base method.
+func (m *MockDepartmentMemberRepository) GetPaginated(ctx context.Context, departmentID uint, recursive bool, offset, limit int) ([]domain.DepartmentMemberDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaginated", ctx, departmentID, recursive, offset, limit)
+	ret0, _ := ret[0].([]domain.DepartmentMemberDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaginated indicates an expected call of GetPaginated.
+func (mr *MockDepartmentMemberRepositoryMockRecorder) GetPaginated(ctx, departmentID, recursive, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginated", reflect.TypeOf((*MockDepartmentMemberRepository)(nil).GetPaginated), ctx, departmentID, recursive, offset, limit)
+}
+
+// IsManager mocks base method.
+func (m *MockDepartmentMemberRepository) IsManager(ctx context.Context, userID, departmentID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsManager", ctx, userID, departmentID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsManager indicates an expected call of IsManager.
+func (mr *MockDepartmentMemberRepositoryMockRecorder) IsManager(ctx, userID, departmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManager", reflect.TypeOf((*MockDepartmentMemberRepository)(nil).IsManager), ctx, userID, departmentID)
+}
+
+// UpdateRole mocks base method.
+func (m *MockDepartmentMemberRepository) UpdateRole(ctx context.Context, member *domain.DepartmentMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockDepartmentMemberRepositoryMockRecorder) UpdateRole(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockDepartmentMemberRepository)(nil).UpdateRole), ctx, member)
+}
@@ -0,0 +1,425 @@
+package department
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"km-api-go/internal/auth"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/department/repository"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/tracing"
+)
+
+var (
+	// ErrInvalidMove 部署を自分自身または配下の部署の下に移動しようとした
+	ErrInvalidMove = errors.New("department cannot be moved under itself or its descendants")
+	// ErrHasChildren 配下の部署があるため削除できない
+	ErrHasChildren = errors.New("department has child departments")
+	// ErrParentNotFound 親部署が会社にない
+	ErrParentNotFound = errors.New("parent department not found")
+	// ErrNotCompanyMember 会社に所属していないユーザーは部署に所属できない
+	ErrNotCompanyMember = errors.New("user is not a member of the company")
+)
+
+// DepartmentUsecase 会社の部署と所属に関するビジネスロジック
+// 一覧・取得は会社のメンバー、部署の作成・変更・移動・削除は会社の管理者とAPIキーのみ行える
+// 所属の追加・変更・削除は会社の管理者に加え、部署またはその祖先の部署のmanagerも行える
+type DepartmentUsecase interface {
+	ListDepartments(ctx context.Context, companyID uint) ([]domain.Department, error)
+	GetDepartment(ctx context.Context, companyID, id uint) (*domain.Department, error)
+	CreateDepartment(ctx context.Context, companyID uint, parentID *uint, name, description string) (*domain.Department, error)
+	UpdateDepartment(ctx context.Context, companyID, id uint, name, description string) (*domain.Department, error)
+	// MoveDepartment 部署を配下の部署・所属ごとparentIDの下（nilの場合は最上位）に移動する
+	MoveDepartment(ctx context.Context, companyID, id uint, parentID *uint) (*domain.Department, error)
+	// DeleteDepartment 配下の部署がない部署を所属ごと削除する
+	DeleteDepartment(ctx context.Context, companyID, id uint) error
+	// ListMembers 部署の所属を取得する（recursiveの場合は配下のすべての部署の所属を含む）
+	ListMembers(ctx context.Context, companyID, id uint, recursive bool, page, limit int) ([]domain.DepartmentMemberDetail, *helper.PaginationResponse, error)
+	// AssignMember 会社のメンバーを部署に所属させる（既に所属している場合は役割を変更する）。作成した場合はcreatedがtrue
+	AssignMember(ctx context.Context, companyID, id, userID uint, role string) (member *domain.DepartmentMember, created bool, err error)
+	RemoveMember(ctx context.Context, companyID, id, userID uint) error
+}
+
+type departmentUsecase struct {
+	departmentRepo  repository.DepartmentRepository
+	memberRepo      repository.DepartmentMemberRepository
+	companyRepo     companyRepo.CompanyRepository
+	companyUserRepo companyRepo.CompanyUserRepository
+	tx              infra.Transactor
+}
+
+// NewDepartmentUsecase 部署ユースケースのコンストラクタ
+func NewDepartmentUsecase(
+	departmentRepository repository.DepartmentRepository,
+	memberRepository repository.DepartmentMemberRepository,
+	companyRepository companyRepo.CompanyRepository,
+	companyUserRepository companyRepo.CompanyUserRepository,
+	tx infra.Transactor,
+) DepartmentUsecase {
+	return &departmentUsecase{
+		departmentRepo:  departmentRepository,
+		memberRepo:      memberRepository,
+		companyRepo:     companyRepository,
+		companyUserRepo: companyUserRepository,
+		tx:              tx,
+	}
+}
+
+// authorize 呼び出し元が会社のメンバーか確認し、会社との関係を返す（APIキーの場合はnil）
+func (uc *departmentUsecase) authorize(ctx context.Context, companyID uint) (*domain.CompanyUser, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrForbidden
+	}
+
+	if !principal.IsUser() {
+		exists, err := uc.companyRepo.Exists(ctx, companyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check company existence: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
+		}
+		return nil, nil
+	}
+
+	relation, err := uc.companyUserRepo.GetRelation(ctx, principal.UserID, companyID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrForbidden
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company relation: %w", err)
+	}
+	if !relation.IsMember() {
+		return nil, domain.ErrForbidden
+	}
+	return relation, nil
+}
+
+// authorizeAdmin 呼び出し元が会社の管理者またはAPIキーか確認
+func (uc *departmentUsecase) authorizeAdmin(ctx context.Context, companyID uint) error {
+	relation, err := uc.authorize(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if relation != nil && !relation.IsAdmin() {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeManager 呼び出し元が会社の管理者・APIキー、または部署かその祖先の部署のmanagerか確認
+func (uc *departmentUsecase) authorizeManager(ctx context.Context, companyID, id uint) error {
+	relation, err := uc.authorize(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if relation == nil || relation.IsAdmin() {
+		return nil
+	}
+	manager, err := uc.memberRepo.IsManager(ctx, relation.UserID, id)
+	if err != nil {
+		return err
+	}
+	if !manager {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// getDepartment 会社の部署を取得（他の会社の部署はErrNotFound）
+func (uc *departmentUsecase) getDepartment(ctx context.Context, companyID, id uint) (*domain.Department, error) {
+	d, err := uc.departmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.CompanyID != companyID {
+		return nil, fmt.Errorf("department with id %d %w", id, domain.ErrNotFound)
+	}
+	return d, nil
+}
+
+// checkParent 親部署が同じ会社にあるか確認
+func (uc *departmentUsecase) checkParent(ctx context.Context, companyID, parentID uint) error {
+	_, err := uc.getDepartment(ctx, companyID, parentID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("%w: %d", ErrParentNotFound, parentID)
+	}
+	return err
+}
+
+// checkName 同じ親の下に同じ名前の部署がないか確認
+func (uc *departmentUsecase) checkName(ctx context.Context, companyID uint, parentID *uint, name string, excludeID uint) error {
+	exists, err := uc.departmentRepo.ExistsByName(ctx, companyID, parentID, name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("department named %s %w", name, domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (uc *departmentUsecase) ListDepartments(ctx context.Context, companyID uint) (_ []domain.Department, err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.ListDepartments")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	return uc.departmentRepo.GetByCompanyID(ctx, companyID)
+}
+
+func (uc *departmentUsecase) GetDepartment(ctx context.Context, companyID, id uint) (_ *domain.Department, err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.GetDepartment")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	return uc.getDepartment(ctx, companyID, id)
+}
+
+func (uc *departmentUsecase) CreateDepartment(ctx context.Context, companyID uint, parentID *uint, name, description string) (_ *domain.Department, err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.CreateDepartment")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	d := &domain.Department{CompanyID: companyID, ParentID: parentID, Name: name, Description: description}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.departmentRepo.LockCompany(ctx, companyID); err != nil {
+			return err
+		}
+		if parentID != nil {
+			if err := uc.checkParent(ctx, companyID, *parentID); err != nil {
+				return err
+			}
+		}
+		if err := uc.checkName(ctx, companyID, parentID, name, 0); err != nil {
+			return err
+		}
+		return uc.departmentRepo.Create(ctx, d)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "department created",
+		slog.Uint64("department_id", uint64(d.ID)),
+		slog.Uint64("company_id", uint64(companyID)),
+	)
+	return d, nil
+}
+
+func (uc *departmentUsecase) UpdateDepartment(ctx context.Context, companyID, id uint, name, description string) (_ *domain.Department, err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.UpdateDepartment")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	var d *domain.Department
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.departmentRepo.LockCompany(ctx, companyID); err != nil {
+			return err
+		}
+		found, err := uc.getDepartment(ctx, companyID, id)
+		if err != nil {
+			return err
+		}
+		d = found
+		if err := uc.checkName(ctx, companyID, d.ParentID, name, id); err != nil {
+			return err
+		}
+		d.Name = name
+		d.Description = description
+		return uc.departmentRepo.Update(ctx, d)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (uc *departmentUsecase) MoveDepartment(ctx context.Context, companyID, id uint, parentID *uint) (_ *domain.Department, err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.MoveDepartment")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	var d *domain.Department
+	// 同じ会社の移動を直列にし、確認してから移動するまでの間に木構造が変わらないようにする
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.departmentRepo.LockCompany(ctx, companyID); err != nil {
+			return err
+		}
+		found, err := uc.getDepartment(ctx, companyID, id)
+		if err != nil {
+			return err
+		}
+		d = found
+		if parentID != nil {
+			if err := uc.checkParent(ctx, companyID, *parentID); err != nil {
+				return err
+			}
+			descendant, err := uc.departmentRepo.IsDescendant(ctx, id, *parentID)
+			if err != nil {
+				return err
+			}
+			if descendant {
+				return ErrInvalidMove
+			}
+		}
+		if sameParent(d.ParentID, parentID) {
+			return nil
+		}
+		if err := uc.checkName(ctx, companyID, parentID, d.Name, id); err != nil {
+			return err
+		}
+		if err := uc.departmentRepo.Move(ctx, id, parentID); err != nil {
+			return err
+		}
+		d.ParentID = parentID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "department moved",
+		slog.Uint64("department_id", uint64(id)),
+		slog.Uint64("company_id", uint64(companyID)),
+		slog.Any("parent_id", parentID),
+	)
+	return d, nil
+}
+
+// sameParent 親部署が同じか
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (uc *departmentUsecase) DeleteDepartment(ctx context.Context, companyID, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.DeleteDepartment")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeAdmin(ctx, companyID); err != nil {
+		return err
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.departmentRepo.LockCompany(ctx, companyID); err != nil {
+			return err
+		}
+		if _, err := uc.getDepartment(ctx, companyID, id); err != nil {
+			return err
+		}
+		// 配下の部署は先に移動・削除してもらう（まとめて削除すると所属が意図せず失われるため）
+		hasChildren, err := uc.departmentRepo.HasChildren(ctx, id)
+		if err != nil {
+			return err
+		}
+		if hasChildren {
+			return ErrHasChildren
+		}
+		return uc.departmentRepo.Delete(ctx, id)
+	})
+}
+
+func (uc *departmentUsecase) ListMembers(ctx context.Context, companyID, id uint, recursive bool, page, limit int) (_ []domain.DepartmentMemberDetail, _ *helper.PaginationResponse, err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.ListMembers")
+	defer tracing.End(span, &err)
+
+	if _, err := uc.authorize(ctx, companyID); err != nil {
+		return nil, nil, err
+	}
+	if _, err := uc.getDepartment(ctx, companyID, id); err != nil {
+		return nil, nil, err
+	}
+
+	paginationReq := &helper.PaginationRequest{Page: page, Limit: limit}
+	offset := paginationReq.GetOffset()
+	normalizedLimit := paginationReq.GetLimit()
+
+	total, err := uc.memberRepo.Count(ctx, id, recursive)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count department members: %w", err)
+	}
+	members, err := uc.memberRepo.GetPaginated(ctx, id, recursive, offset, normalizedLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get department members: %w", err)
+	}
+
+	return members, helper.NewPaginationResponse(paginationReq.Page, normalizedLimit, total), nil
+}
+
+func (uc *departmentUsecase) AssignMember(ctx context.Context, companyID, id, userID uint, role string) (_ *domain.DepartmentMember, created bool, err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.AssignMember")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeManager(ctx, companyID, id); err != nil {
+		return nil, false, err
+	}
+	if _, err := uc.getDepartment(ctx, companyID, id); err != nil {
+		return nil, false, err
+	}
+	inCompany, err := uc.companyUserRepo.Exists(ctx, userID, companyID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !inCompany {
+		return nil, false, fmt.Errorf("user %d: %w", userID, ErrNotCompanyMember)
+	}
+
+	m, err := uc.memberRepo.Get(ctx, id, userID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		m = &domain.DepartmentMember{DepartmentID: id, UserID: userID, Role: role}
+		if err := uc.memberRepo.Create(ctx, m); err != nil {
+			return nil, false, err
+		}
+		created = true
+	case err != nil:
+		return nil, false, err
+	case m.Role != role:
+		m.Role = role
+		if err := uc.memberRepo.UpdateRole(ctx, m); err != nil {
+			return nil, false, err
+		}
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "department member assigned",
+		slog.Uint64("department_id", uint64(id)),
+		slog.Uint64("user_id", uint64(userID)),
+		slog.String("role", role),
+	)
+	return m, created, nil
+}
+
+func (uc *departmentUsecase) RemoveMember(ctx context.Context, companyID, id, userID uint) (err error) {
+	ctx, span := tracing.Start(ctx, "DepartmentUsecase.RemoveMember")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeManager(ctx, companyID, id); err != nil {
+		return err
+	}
+	if _, err := uc.getDepartment(ctx, companyID, id); err != nil {
+		return err
+	}
+	return uc.memberRepo.Delete(ctx, id, userID)
+}
@@ -0,0 +1,258 @@
+package department
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/department/repository/mocks"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+)
+
+type testDeps struct {
+	departments  *mocks.MockDepartmentRepository
+	members      *mocks.MockDepartmentMemberRepository
+	companies    *companyMocks.MockCompanyRepository
+	companyUsers *companyMocks.MockCompanyUserRepository
+}
+
+func newTestUsecase(t *testing.T) (DepartmentUsecase, testDeps) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	deps := testDeps{
+		departments:  mocks.NewMockDepartmentRepository(ctrl),
+		members:      mocks.NewMockDepartmentMemberRepository(ctrl),
+		companies:    companyMocks.NewMockCompanyRepository(ctrl),
+		companyUsers: companyMocks.NewMockCompanyUserRepository(ctrl),
+	}
+	uc := NewDepartmentUsecase(deps.departments, deps.members, deps.companies, deps.companyUsers, infra.NoTx)
+	return uc, deps
+}
+
+// asUser 会社（ID=1）に指定した役割で所属するユーザーとして呼び出す
+func asUser(deps testDeps, userID uint, role string) context.Context {
+	deps.companyUsers.EXPECT().GetRelation(gomock.Any(), userID, uint(1)).
+		Return(&domain.CompanyUser{UserID: userID, CompanyID: 1, Role: role}, nil).AnyTimes()
+	return auth.WithPrincipal(context.Background(), &auth.Principal{UserID: userID})
+}
+
+func ptr(v uint) *uint { return &v }
+
+// expectDepartment 会社（ID=1）の部署を返す
+func expectDepartment(deps testDeps, id uint, parentID *uint, name string) {
+	deps.departments.EXPECT().GetByID(gomock.Any(), id).
+		Return(&domain.Department{ID: id, CompanyID: 1, ParentID: parentID, Name: name}, nil).AnyTimes()
+}
+
+func TestCreateDepartment_WithParent(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	expectDepartment(deps, 1, nil, "営業部")
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().ExistsByName(gomock.Any(), uint(1), ptr(1), "第一営業課", uint(0)).Return(false, nil)
+	deps.departments.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *domain.Department) error {
+		d.ID = 2
+		return nil
+	})
+
+	d, err := uc.CreateDepartment(ctx, 1, ptr(1), "第一営業課", "")
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), d.ID)
+	assert.Equal(t, uint(1), *d.ParentID)
+}
+
+func TestCreateDepartment_ParentInOtherCompany(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().GetByID(gomock.Any(), uint(5)).
+		Return(&domain.Department{ID: 5, CompanyID: 2, Name: "開発部"}, nil)
+
+	_, err := uc.CreateDepartment(ctx, 1, ptr(5), "第一営業課", "")
+	assert.ErrorIs(t, err, ErrParentNotFound)
+}
+
+func TestCreateDepartment_DuplicateName(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().ExistsByName(gomock.Any(), uint(1), (*uint)(nil), "営業部", uint(0)).Return(true, nil)
+
+	_, err := uc.CreateDepartment(ctx, 1, nil, "営業部", "")
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
+func TestCreateDepartment_ForbiddenForMember(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "member")
+
+	_, err := uc.CreateDepartment(ctx, 1, nil, "営業部", "")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestMoveDepartment(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	// 営業部(1) > 第一営業課(2) を 本社(3) の下へ
+	expectDepartment(deps, 2, ptr(1), "第一営業課")
+	expectDepartment(deps, 3, nil, "本社")
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().IsDescendant(gomock.Any(), uint(2), uint(3)).Return(false, nil)
+	deps.departments.EXPECT().ExistsByName(gomock.Any(), uint(1), ptr(3), "第一営業課", uint(2)).Return(false, nil)
+	deps.departments.EXPECT().Move(gomock.Any(), uint(2), ptr(3)).Return(nil)
+
+	d, err := uc.MoveDepartment(ctx, 1, 2, ptr(3))
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), *d.ParentID)
+}
+
+func TestMoveDepartment_ToRoot(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	expectDepartment(deps, 2, ptr(1), "第一営業課")
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().ExistsByName(gomock.Any(), uint(1), (*uint)(nil), "第一営業課", uint(2)).Return(false, nil)
+	deps.departments.EXPECT().Move(gomock.Any(), uint(2), (*uint)(nil)).Return(nil)
+
+	d, err := uc.MoveDepartment(ctx, 1, 2, nil)
+	require.NoError(t, err)
+	assert.Nil(t, d.ParentID)
+}
+
+func TestMoveDepartment_UnderDescendant(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	// 営業部(1) を配下の 第一営業課(2) の下へは移動できない
+	expectDepartment(deps, 1, nil, "営業部")
+	expectDepartment(deps, 2, ptr(1), "第一営業課")
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().IsDescendant(gomock.Any(), uint(1), uint(2)).Return(true, nil)
+
+	_, err := uc.MoveDepartment(ctx, 1, 1, ptr(2))
+	assert.ErrorIs(t, err, ErrInvalidMove)
+}
+
+func TestMoveDepartment_SameParentIsNoop(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	expectDepartment(deps, 1, nil, "営業部")
+	expectDepartment(deps, 2, ptr(1), "第一営業課")
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().IsDescendant(gomock.Any(), uint(2), uint(1)).Return(false, nil)
+
+	d, err := uc.MoveDepartment(ctx, 1, 2, ptr(1))
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), *d.ParentID)
+}
+
+func TestDeleteDepartment_HasChildren(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	expectDepartment(deps, 1, nil, "営業部")
+	deps.departments.EXPECT().LockCompany(gomock.Any(), uint(1)).Return(nil)
+	deps.departments.EXPECT().HasChildren(gomock.Any(), uint(1)).Return(true, nil)
+
+	err := uc.DeleteDepartment(ctx, 1, 1)
+	assert.ErrorIs(t, err, ErrHasChildren)
+}
+
+func TestListMembers_Recursive(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "member")
+
+	expectDepartment(deps, 1, nil, "営業部")
+	deps.members.EXPECT().Count(gomock.Any(), uint(1), true).Return(int64(2), nil)
+	deps.members.EXPECT().GetPaginated(gomock.Any(), uint(1), true, 0, 20).Return([]domain.DepartmentMemberDetail{
+		{DepartmentID: 1, DepartmentName: "営業部", UserID: 10, Role: domain.DepartmentRoleManager},
+		{DepartmentID: 2, DepartmentName: "第一営業課", UserID: 11, Role: domain.DepartmentRoleMember},
+	}, nil)
+
+	members, pagination, err := uc.ListMembers(ctx, 1, 1, true, 1, 20)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Equal(t, int64(2), pagination.Total)
+}
+
+func TestAssignMember_ByManager(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "member")
+
+	expectDepartment(deps, 2, ptr(1), "第一営業課")
+	deps.members.EXPECT().IsManager(gomock.Any(), uint(10), uint(2)).Return(true, nil)
+	deps.companyUsers.EXPECT().Exists(gomock.Any(), uint(11), uint(1)).Return(true, nil)
+	deps.members.EXPECT().Get(gomock.Any(), uint(2), uint(11)).Return(nil, domain.ErrNotFound)
+	deps.members.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	m, created, err := uc.AssignMember(ctx, 1, 2, 11, domain.DepartmentRoleMember)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, domain.DepartmentRoleMember, m.Role)
+}
+
+func TestAssignMember_ChangesRole(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	expectDepartment(deps, 2, ptr(1), "第一営業課")
+	deps.companyUsers.EXPECT().Exists(gomock.Any(), uint(11), uint(1)).Return(true, nil)
+	deps.members.EXPECT().Get(gomock.Any(), uint(2), uint(11)).
+		Return(&domain.DepartmentMember{ID: 3, DepartmentID: 2, UserID: 11, Role: domain.DepartmentRoleMember}, nil)
+	deps.members.EXPECT().UpdateRole(gomock.Any(), gomock.Any()).Return(nil)
+
+	m, created, err := uc.AssignMember(ctx, 1, 2, 11, domain.DepartmentRoleManager)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, domain.DepartmentRoleManager, m.Role)
+}
+
+func TestAssignMember_ForbiddenForNonManager(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "member")
+
+	deps.members.EXPECT().IsManager(gomock.Any(), uint(10), uint(2)).Return(false, nil)
+
+	_, _, err := uc.AssignMember(ctx, 1, 2, 11, domain.DepartmentRoleMember)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestAssignMember_NotCompanyMember(t *testing.T) {
+	uc, deps := newTestUsecase(t)
+	ctx := asUser(deps, 10, "admin")
+
+	expectDepartment(deps, 2, ptr(1), "第一営業課")
+	deps.companyUsers.EXPECT().Exists(gomock.Any(), uint(99), uint(1)).Return(false, nil)
+
+	_, _, err := uc.AssignMember(ctx, 1, 2, 99, domain.DepartmentRoleMember)
+	assert.ErrorIs(t, err, ErrNotCompanyMember)
+}
+
+func TestNewDepartmentTree(t *testing.T) {
+	tree := NewDepartmentTree([]domain.Department{
+		{ID: 1, Name: "営業部"},
+		{ID: 2, ParentID: ptr(1), Name: "第一営業課"},
+		{ID: 3, ParentID: ptr(2), Name: "東京チーム"},
+		{ID: 4, Name: "開発部"},
+	})
+
+	require.Len(t, tree, 2)
+	assert.Equal(t, "営業部", tree[0].Name)
+	require.Len(t, tree[0].Children, 1)
+	require.Len(t, tree[0].Children[0].Children, 1)
+	assert.Equal(t, "東京チーム", tree[0].Children[0].Children[0].Name)
+	assert.Equal(t, 2, tree[0].Children[0].Children[0].Depth)
+	assert.Empty(t, tree[1].Children)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// 添付ファイルの種類
+const (
+	AttachmentKindDocument = "document" // 契約書・資料など
+	AttachmentKindLogo     = "logo"     // 会社のロゴ（会社ごとに1つ）
+)
+
+// ウイルススキャンの結果
+const (
+	AttachmentScanClean   = "clean"   // スキャンして問題がなかった
+	AttachmentScanSkipped = "skipped" // スキャナーが設定されていない
+)
+
+// CompanyAttachment 会社の添付ファイルのメタデータ（ファイル本体はストレージに保存する）
+// @Description 会社の添付ファイル
+type CompanyAttachment struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement" example:"1"`                     // 添付ファイルID
+	CompanyID   uint      `json:"company_id" gorm:"not null;index" example:"1"`                       // 会社ID
+	Kind        string    `json:"kind" gorm:"size:20;not null;default:'document'" example:"document"` // 種類（document, logo）
+	Filename    string    `json:"filename" gorm:"size:255;not null" example:"契約書.pdf"`                // アップロード時のファイル名
+	ContentType string    `json:"content_type" gorm:"size:255;not null" example:"application/pdf"`    // 内容から判定したContent-Type
+	Size        int64     `json:"size" gorm:"not null" example:"102400"`                              // サイズ（バイト）
+	Checksum    string    `json:"checksum" gorm:"size:64;not null"`                                   // SHA-256（16進数）
+	StorageKey  string    `json:"-" gorm:"size:1024;not null"`                                        // ストレージのキー
+	ScanStatus  string    `json:"scan_status" gorm:"size:20;not null" example:"clean"`                // ウイルススキャンの結果（clean, skipped）
+	UploadedBy  *uint     `json:"uploaded_by,omitempty" example:"1"`                                  // アップロードしたユーザーID（APIキーの場合は空）
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`                                   // アップロード日時
+}
+
+// TableName テーブル名を指定
+func (CompanyAttachment) TableName() string {
+	return "company_attachments"
+}
@@ -14,6 +14,7 @@ type Company struct {
 	Address     string    `json:"address" gorm:"size:500" validate:"omitempty,max=500" example:"東京都渋谷区..."`                         // 住所
 	Website     string    `json:"website" gorm:"size:255" validate:"omitempty,url" example:"https://sample.co.jp"`                  // ウェブサイト
 	Description string    `json:"description" gorm:"type:text" validate:"omitempty,max=1000" example:"IT関連のサービスを提供しています"`           // 会社説明
+	Version     uint      `json:"version" gorm:"not null;default:1" example:"1"`                                                    // バージョン（楽観的排他制御用）
 	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`                                                                 // 作成日時
 	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`                                                                 // 更新日時
 }
@@ -54,6 +55,7 @@ func (c *Company) ToResponseCompany() Company {
 		Address:     c.Address,
 		Website:     c.Website,
 		Description: c.Description,
+		Version:     c.Version,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 	}
@@ -84,3 +86,30 @@ func (cu *CompanyUser) IsAdmin() bool {
 func (cu *CompanyUser) IsMember() bool {
 	return cu.Role == "member" || cu.Role == "admin"
 }
+
+// CompanyFilter 会社一覧・エクスポートの絞り込み条件
+type CompanyFilter struct {
+	Query     string // 会社名の部分一致
+	SortBy    string // ソート項目（id, name, email, created_at, updated_at）
+	SortDir   string // ソート方向（asc, desc）
+	VisibleTo uint   // 0以外の場合、このユーザーが所属する会社のみ
+}
+
+// MembershipFilter 所属一覧・エクスポートの絞り込み条件
+type MembershipFilter struct {
+	CompanyID uint   // 0以外の場合、この会社の所属のみ
+	Role      string // 役割（admin, member）
+	VisibleTo uint   // 0以外の場合、このユーザーが所属する会社の所属のみ
+}
+
+// MembershipDetail 会社・ユーザー情報を含む所属
+type MembershipDetail struct {
+	CompanyID    uint      `json:"company_id" example:"1"`                    // 会社ID
+	CompanyName  string    `json:"company_name" example:"株式会社サンプル"`           // 会社名
+	CompanyEmail string    `json:"company_email" example:"info@sample.co.jp"` // 会社メールアドレス
+	UserID       uint      `json:"user_id" example:"1"`                       // ユーザーID
+	UserName     string    `json:"user_name" example:"山田太郎"`                  // ユーザー名
+	UserEmail    string    `json:"user_email" example:"yamada@example.com"`   // ユーザーメールアドレス
+	Role         string    `json:"role" example:"admin"`                      // 役割
+	CreatedAt    time.Time `json:"created_at"`                                // 所属日時
+}
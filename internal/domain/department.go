@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// 部署での役割
+const (
+	DepartmentRoleManager = "manager" // 部署と配下の部署の所属を管理できる
+	DepartmentRoleMember  = "member"
+)
+
+// Department 部署エンティティ（会社ごとの木構造）
+// @Description 部署
+type Department struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement" example:"1"`                 // 部署ID
+	CompanyID   uint      `json:"company_id" gorm:"not null;index" example:"1"`                   // 会社ID
+	ParentID    *uint     `json:"parent_id" example:"1"`                                          // 親部署ID（最上位の部署はnull）
+	Name        string    `json:"name" gorm:"size:100;not null" example:"営業部"`                    // 部署名
+	Description string    `json:"description" gorm:"size:255;not null;default:''" example:"法人営業"` // 説明
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`                               // 作成日時
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`                               // 更新日時
+}
+
+// TableName テーブル名を指定
+func (Department) TableName() string {
+	return "departments"
+}
+
+// DepartmentMember 部署の所属（1人が複数の部署に所属できる）
+// @Description 部署の所属
+type DepartmentMember struct {
+	ID           uint      `json:"id" gorm:"primaryKey;autoIncrement"`                             // 所属ID
+	DepartmentID uint      `json:"department_id" gorm:"not null" example:"1"`                      // 部署ID
+	UserID       uint      `json:"user_id" gorm:"not null" example:"1"`                            // ユーザーID
+	Role         string    `json:"role" gorm:"size:20;not null;default:'member'" example:"member"` // 部署での役割（manager, member）
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`                               // 所属した日時
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`                               // 更新日時
+}
+
+// TableName テーブル名を指定
+func (DepartmentMember) TableName() string {
+	return "department_members"
+}
+
+// DepartmentMemberDetail 所属とユーザー・部署の名前（配下の部署を含む所属の一覧用）
+type DepartmentMemberDetail struct {
+	DepartmentID   uint      `json:"department_id" example:"2"`               // 部署ID
+	DepartmentName string    `json:"department_name" example:"第一営業課"`         // 部署名
+	UserID         uint      `json:"user_id" example:"1"`                     // ユーザーID
+	UserName       string    `json:"user_name" example:"山田太郎"`                // ユーザー名
+	UserEmail      string    `json:"user_email" example:"yamada@example.com"` // メールアドレス
+	Role           string    `json:"role" example:"member"`                   // 部署での役割（manager, member）
+	CreatedAt      time.Time `json:"created_at"`                              // 所属した日時
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+// ドメイン共通のエラー（errors.Isで判定する）
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrVersionConflict = errors.New("version conflict")
+	ErrForbidden       = errors.New("forbidden")
+)
@@ -0,0 +1,126 @@
+package domain
+
+// Event ドメインイベント（変更と同じトランザクションでアウトボックスに保存し、後から外部に配信する）
+type Event interface {
+	EventType() string     // イベントの種類（例: user.created）
+	AggregateType() string // 対象の種類（user, company）
+	AggregateID() uint     // 対象のID
+}
+
+// イベントの種類
+const (
+	EventUserCreated    = "user.created"
+	EventUserUpdated    = "user.updated"
+	EventUserDeleted    = "user.deleted"
+	EventCompanyCreated = "company.created"
+	EventCompanyUpdated = "company.updated"
+	EventCompanyDeleted = "company.deleted"
+	EventMemberAdded    = "company.member_added"
+	EventRoleChanged    = "company.member_role_changed"
+	EventMemberRemoved  = "company.member_removed"
+)
+
+// イベントの対象の種類
+const (
+	AggregateUser    = "user"
+	AggregateCompany = "company"
+)
+
+// UserCreated ユーザーが作成された
+type UserCreated struct {
+	UserID uint   `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+func (UserCreated) EventType() string     { return EventUserCreated }
+func (UserCreated) AggregateType() string { return AggregateUser }
+func (e UserCreated) AggregateID() uint   { return e.UserID }
+
+// UserUpdated ユーザーが更新された（Changedは変更されたフィールドのJSON名）
+type UserUpdated struct {
+	UserID  uint     `json:"user_id"`
+	Name    string   `json:"name"`
+	Email   string   `json:"email"`
+	Version uint     `json:"version"`
+	Changed []string `json:"changed"`
+}
+
+func (UserUpdated) EventType() string     { return EventUserUpdated }
+func (UserUpdated) AggregateType() string { return AggregateUser }
+func (e UserUpdated) AggregateID() uint   { return e.UserID }
+
+// UserDeleted ユーザーが削除された
+type UserDeleted struct {
+	UserID uint `json:"user_id"`
+}
+
+func (UserDeleted) EventType() string     { return EventUserDeleted }
+func (UserDeleted) AggregateType() string { return AggregateUser }
+func (e UserDeleted) AggregateID() uint   { return e.UserID }
+
+// CompanyCreated 会社が作成された
+type CompanyCreated struct {
+	CompanyID uint   `json:"company_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+}
+
+func (CompanyCreated) EventType() string     { return EventCompanyCreated }
+func (CompanyCreated) AggregateType() string { return AggregateCompany }
+func (e CompanyCreated) AggregateID() uint   { return e.CompanyID }
+
+// CompanyUpdated 会社が更新された（Changedは変更されたフィールドのJSON名）
+type CompanyUpdated struct {
+	CompanyID uint     `json:"company_id"`
+	Name      string   `json:"name"`
+	Email     string   `json:"email"`
+	Version   uint     `json:"version"`
+	Changed   []string `json:"changed"`
+}
+
+func (CompanyUpdated) EventType() string     { return EventCompanyUpdated }
+func (CompanyUpdated) AggregateType() string { return AggregateCompany }
+func (e CompanyUpdated) AggregateID() uint   { return e.CompanyID }
+
+// CompanyDeleted 会社が削除された
+type CompanyDeleted struct {
+	CompanyID uint `json:"company_id"`
+}
+
+func (CompanyDeleted) EventType() string     { return EventCompanyDeleted }
+func (CompanyDeleted) AggregateType() string { return AggregateCompany }
+func (e CompanyDeleted) AggregateID() uint   { return e.CompanyID }
+
+// MemberAdded ユーザーが会社に追加された
+type MemberAdded struct {
+	CompanyID uint   `json:"company_id"`
+	UserID    uint   `json:"user_id"`
+	Role      string `json:"role"`
+}
+
+func (MemberAdded) EventType() string     { return EventMemberAdded }
+func (MemberAdded) AggregateType() string { return AggregateCompany }
+func (e MemberAdded) AggregateID() uint   { return e.CompanyID }
+
+// RoleChanged 会社でのユーザーの役割が変更された
+type RoleChanged struct {
+	CompanyID uint   `json:"company_id"`
+	UserID    uint   `json:"user_id"`
+	OldRole   string `json:"old_role"`
+	NewRole   string `json:"new_role"`
+}
+
+func (RoleChanged) EventType() string     { return EventRoleChanged }
+func (RoleChanged) AggregateType() string { return AggregateCompany }
+func (e RoleChanged) AggregateID() uint   { return e.CompanyID }
+
+// MemberRemoved ユーザーが会社から外された
+type MemberRemoved struct {
+	CompanyID uint `json:"company_id"`
+	UserID    uint `json:"user_id"`
+}
+
+func (MemberRemoved) EventType() string     { return EventMemberRemoved }
+func (MemberRemoved) AggregateType() string { return AggregateCompany }
+func (e MemberRemoved) AggregateID() uint   { return e.CompanyID }
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// インポート対象
+const (
+	ImportKindUsers       = "users"
+	ImportKindCompanies   = "companies"
+	ImportKindMemberships = "memberships"
+)
+
+// インポートファイルの形式
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+)
+
+// インポートの状態
+const (
+	ImportStatusValidated  = "validated"  // ドライラン（検証のみ・保存しない）
+	ImportStatusInvalid    = "invalid"    // 検証エラーがあるため受け付けない
+	ImportStatusPending    = "pending"    // 処理待ち
+	ImportStatusProcessing = "processing" // 処理中
+	ImportStatusCompleted  = "completed"  // 完了（行ごとのエラーを含む場合がある）
+	ImportStatusFailed     = "failed"     // 処理を継続できないエラーで中断
+)
+
+// ImportRowError 行ごとのエラー
+type ImportRowError struct {
+	Line    int    `json:"line" example:"3"`                            // ファイル内の行番号（CSVはヘッダーが1行目）
+	Field   string `json:"field,omitempty" example:"email"`             // エラーのあるフィールド
+	Message string `json:"message" example:"emailは有効なメールアドレスを入力してください"` // エラーメッセージ
+}
+
+// ImportRowErrors 行ごとのエラー一覧（JSONBカラムとして保存）
+type ImportRowErrors []ImportRowError
+
+// Value driver.Valuerの実装
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装
+func (e *ImportRowErrors) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("unsupported type for ImportRowErrors: %T", value)
+	}
+}
+
+// Import 一括インポートエンティティ
+// @Description 一括インポートの状態と結果
+type Import struct {
+	ID            uint            `json:"id" gorm:"primaryKey;autoIncrement" example:"1"`         // インポートID
+	Kind          string          `json:"type" gorm:"size:20;not null" example:"users"`           // 対象（users, companies, memberships）
+	Format        string          `json:"format" gorm:"size:10;not null" example:"csv"`           // 形式（csv, jsonl）
+	Status        string          `json:"status" gorm:"size:20;not null" example:"completed"`     // 状態
+	TotalRows     int             `json:"total_rows" gorm:"not null;default:0" example:"100"`     // 総行数
+	ProcessedRows int             `json:"processed_rows" gorm:"not null;default:0" example:"100"` // 処理済み行数
+	CreatedRows   int             `json:"created_rows" gorm:"not null;default:0" example:"80"`    // 作成した行数
+	UpdatedRows   int             `json:"updated_rows" gorm:"not null;default:0" example:"18"`    // 更新した行数
+	FailedRows    int             `json:"failed_rows" gorm:"not null;default:0" example:"2"`      // 失敗した行数
+	Errors        ImportRowErrors `json:"errors" gorm:"type:jsonb;not null"`                      // 行ごとのエラー
+	Payload       []byte          `json:"-" gorm:"type:bytea"`                                    // アップロードされたファイル
+	CreatedBy     *uint           `json:"created_by,omitempty" example:"1"`                       // 作成したユーザーID
+	CreatedAt     time.Time       `json:"created_at" gorm:"autoCreateTime" format:"date-time"`    // 作成日時
+	UpdatedAt     time.Time       `json:"updated_at" gorm:"autoUpdateTime" format:"date-time"`    // 更新日時
+	CompletedAt   *time.Time      `json:"completed_at,omitempty" format:"date-time"`              // 完了日時
+}
+
+// TableName テーブル名を指定
+func (Import) TableName() string {
+	return "imports"
+}
+
+// IsFinished 処理が終了しているか確認
+func (i *Import) IsFinished() bool {
+	return i.Status == ImportStatusCompleted || i.Status == ImportStatusFailed
+}
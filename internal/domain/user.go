@@ -4,19 +4,35 @@ import (
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // User ユーザーエンティティ
 // @Description ユーザー情報
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement" example:"1"`                                                    // ユーザーID
-	Name      string    `json:"name" gorm:"size:50;not null" validate:"required,min=2,max=50" example:"山田太郎"`                      // ユーザー名
-	Email     string    `json:"email" gorm:"size:255;uniqueIndex;not null" validate:"required,email" example:"yamada@example.com"` // メールアドレス
-	Password  string    `json:"-" gorm:"size:255;not null" validate:"required,min=8"`                                              // パスワード（レスポンスに含めない）
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`                                                                  // 作成日時
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`                                                                  // 更新日時
+	ID       uint   `json:"id" gorm:"primaryKey;autoIncrement" example:"1"`                                                    // ユーザーID
+	Name     string `json:"name" gorm:"size:50;not null" validate:"required,min=2,max=50" example:"山田太郎"`                      // ユーザー名
+	Email    string `json:"email" gorm:"size:255;uniqueIndex;not null" validate:"required,email" example:"yamada@example.com"` // メールアドレス
+	Password string `json:"-" gorm:"size:255;not null" validate:"required,min=8"`                                              // パスワード（レスポンスに含めない）
+
+	DisplayName string `json:"display_name" gorm:"size:50;not null;default:''" validate:"omitempty,max=50" example:"やまだ"`            // 表示名
+	KanaName    string `json:"kana_name" gorm:"size:100;not null;default:''" validate:"omitempty,max=100,kana" example:"ヤマダ タロウ"`    // 氏名のふりがな
+	Locale      string `json:"locale" gorm:"size:35;not null;default:''" validate:"omitempty,bcp47_language_tag" example:"ja-JP"`    // ロケール（BCP 47）
+	Timezone    string `json:"timezone" gorm:"size:64;not null;default:''" validate:"omitempty,timezone" example:"Asia/Tokyo"`       // タイムゾーン（IANA）
+	Bio         string `json:"bio" gorm:"type:text;not null;default:''" validate:"omitempty,max=1000" example:"営業部でSaaSの導入支援をしています"` // 自己紹介
+
+	AvatarKey       string     `json:"-" gorm:"size:255;not null;default:''"` // アバター画像の保存先のキーのプレフィックス（未設定は空）
+	AvatarType      string     `json:"-" gorm:"size:50;not null;default:''"`  // アバター画像のContent-Type
+	AvatarUpdatedAt *time.Time `json:"-"`                                     // アバター画像の更新日時
+
+	Version   uint      `json:"version" gorm:"not null;default:1" example:"1"` // バージョン（楽観的排他制御用）
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`              // 作成日時
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`              // 更新日時
 }
 
+// UserAvatarFields アバター画像の列（Goのフィールド名）。レスポンスのavatarを返す場合に読み込む
+var UserAvatarFields = []string{"AvatarKey", "AvatarType", "AvatarUpdatedAt"}
+
 // TableName テーブル名を指定
 func (User) TableName() string {
 	return "users"
@@ -41,7 +57,8 @@ func (u *User) IsValidUser() bool {
 }
 
 // BeforeCreate GORM フック - 作成前にパスワードをハッシュ化
-func (u *User) BeforeCreate() error {
+// GORMは BeforeCreate(*gorm.DB) error のシグネチャのみ呼び出す
+func (u *User) BeforeCreate(_ *gorm.DB) error {
 	if u.Password != "" {
 		return u.HashPassword()
 	}
@@ -49,19 +66,56 @@ func (u *User) BeforeCreate() error {
 }
 
 func (u *User) GetDisplayName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
 	if u.Name != "" {
 		return u.Name
 	}
 	return u.Email
 }
 
+// HasAvatar アバター画像が設定されているか
+func (u *User) HasAvatar() bool {
+	return u.AvatarKey != ""
+}
+
+// AvatarURLs アバター画像（元画像とサムネイル）の署名付きURL
+type AvatarURLs struct {
+	Original  string
+	Small     string
+	Medium    string
+	Large     string
+	ExpiresAt time.Time  // 署名の有効期限
+	UpdatedAt *time.Time // 画像を更新した日時
+}
+
 // ToResponseUser レスポンス用にパスワードを除いたUserを返す
 func (u *User) ToResponseUser() User {
 	return User{
-		ID:        u.ID,
-		Name:      u.Name,
-		Email:     u.Email,
+		ID:    u.ID,
+		Name:  u.Name,
+		Email: u.Email,
+
+		DisplayName:     u.DisplayName,
+		KanaName:        u.KanaName,
+		Locale:          u.Locale,
+		Timezone:        u.Timezone,
+		Bio:             u.Bio,
+		AvatarKey:       u.AvatarKey,
+		AvatarType:      u.AvatarType,
+		AvatarUpdatedAt: u.AvatarUpdatedAt,
+
+		Version:   u.Version,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
 }
+
+// UserFilter ユーザー一覧・エクスポートの絞り込み条件
+type UserFilter struct {
+	Query     string // 名前・メールアドレスの部分一致
+	SortBy    string // ソート項目（id, name, email, created_at, updated_at）
+	SortDir   string // ソート方向（asc, desc）
+	VisibleTo uint   // 0以外の場合、このユーザー本人と同じ会社に所属するユーザーのみ
+}
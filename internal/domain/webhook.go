@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// WebhookEventTypes 会社のWebhookで購読できるイベントの種類
+var WebhookEventTypes = []string{
+	EventCompanyUpdated,
+	EventMemberAdded,
+	EventRoleChanged,
+	EventMemberRemoved,
+}
+
+// Webhookの配信状態
+const (
+	WebhookDeliveryPending   = "pending"   // 配信待ち・再試行待ち
+	WebhookDeliverySucceeded = "succeeded" // 配信成功（2xx）
+	WebhookDeliveryFailed    = "failed"    // 再試行の上限に達した、または配信先が無効
+)
+
+// WebhookEvents 購読するイベントの種類（JSONBカラムとして保存）
+type WebhookEvents []string
+
+// Value driver.Valuerの実装
+func (e WebhookEvents) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装
+func (e *WebhookEvents) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("unsupported type for WebhookEvents: %T", value)
+	}
+}
+
+// Webhook 会社が登録したWebhookの配信先エンティティ
+// @Description Webhookの配信先
+type Webhook struct {
+	ID                      uint          `json:"id" gorm:"primaryKey;autoIncrement" example:"1"`                                              // WebhookID
+	CompanyID               uint          `json:"company_id" gorm:"not null;index" example:"1"`                                                // 会社ID
+	URL                     string        `json:"url" gorm:"size:2048;not null" example:"https://example.com/hooks/km"`                        // 配信先URL
+	Events                  WebhookEvents `json:"events" gorm:"type:jsonb;not null" swaggertype:"array,string" example:"company.member_added"` // 購読するイベントの種類
+	Description             string        `json:"description" gorm:"size:255" example:"入退社の通知"`                                                // 説明
+	Active                  bool          `json:"active" gorm:"not null;default:true" example:"true"`                                          // 配信を有効にするか
+	Secret                  string        `json:"-" gorm:"size:100;not null"`                                                                  // 署名シークレット
+	PreviousSecret          string        `json:"-" gorm:"size:100;not null;default:''"`                                                       // ローテーション前の署名シークレット
+	PreviousSecretExpiresAt *time.Time    `json:"-"`                                                                                           // ローテーション前のシークレットで署名する期限
+	SecretRotatedAt         *time.Time    `json:"secret_rotated_at,omitempty"`                                                                 // シークレットのローテーション日時
+	CreatedBy               *uint         `json:"created_by,omitempty" example:"1"`                                                            // 作成したユーザーID
+	CreatedAt               time.Time     `json:"created_at" gorm:"autoCreateTime"`                                                            // 作成日時
+	UpdatedAt               time.Time     `json:"updated_at" gorm:"autoUpdateTime"`                                                            // 更新日時
+}
+
+// TableName テーブル名を指定
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Subscribes イベントの種類を購読しているか確認
+func (w *Webhook) Subscribes(eventType string) bool {
+	return slices.Contains(w.Events, eventType)
+}
+
+// SigningSecrets 署名に使うシークレット（ローテーション直後は期限まで以前のシークレットも含む）
+func (w *Webhook) SigningSecrets(now time.Time) []string {
+	secrets := []string{w.Secret}
+	if w.PreviousSecret != "" && w.PreviousSecretExpiresAt != nil && now.Before(*w.PreviousSecretExpiresAt) {
+		secrets = append(secrets, w.PreviousSecret)
+	}
+	return secrets
+}
+
+// WebhookDelivery Webhookの配信履歴エンティティ
+// @Description Webhookの配信履歴（再試行のたびに最後の結果で更新する）
+type WebhookDelivery struct {
+	ID             uint            `json:"id" gorm:"primaryKey;autoIncrement" example:"1"`                                  // 配信ID
+	WebhookID      uint            `json:"webhook_id" gorm:"not null" example:"1"`                                          // WebhookID
+	EventID        string          `json:"event_id" gorm:"size:36;not null" example:"0b6b0c1e-8f4a-4a43-9d1e-2b0c6f3e5a10"` // イベントID
+	EventType      string          `json:"event_type" gorm:"size:100;not null" example:"company.member_added"`              // イベントの種類
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb;not null" swaggertype:"object"`                         // 送信するボディ
+	Status         string          `json:"status" gorm:"size:20;not null" example:"succeeded"`                              // 状態（pending, succeeded, failed）
+	Attempts       int             `json:"attempts" gorm:"not null;default:0" example:"1"`                                  // 送信した回数
+	ResponseStatus *int            `json:"response_status,omitempty" example:"200"`                                         // 最後の応答のステータスコード
+	ResponseBody   string          `json:"response_body,omitempty" gorm:"type:text"`                                        // 最後の応答のボディ（先頭のみ）
+	Error          string          `json:"error,omitempty" gorm:"type:text"`                                                // 最後の失敗理由
+	DurationMs     int64           `json:"duration_ms" gorm:"not null;default:0" example:"120"`                             // 最後の送信にかかった時間（ミリ秒）
+	RedeliveryOf   *uint           `json:"redelivery_of,omitempty" example:"1"`                                             // 再配信元の配信ID
+	CreatedAt      time.Time       `json:"created_at" gorm:"autoCreateTime" format:"date-time"`                             // 作成日時
+	UpdatedAt      time.Time       `json:"updated_at" gorm:"autoUpdateTime" format:"date-time"`                             // 更新日時
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" format:"date-time"`                                       // 配信に成功した日時
+}
+
+// TableName テーブル名を指定
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// IsFinished 配信が終了しているか確認
+func (d *WebhookDelivery) IsFinished() bool {
+	return d.Status == WebhookDeliverySucceeded || d.Status == WebhookDeliveryFailed
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"km-api-go/internal/domain"
+)
+
+// ErrUnknownColumn 存在しない列が指定された
+var ErrUnknownColumn = errors.New("unknown export column")
+
+// Column エクスポートする列
+type Column[T any] struct {
+	Name  string
+	Value func(T) any
+}
+
+// Columns 列の一覧（定義順が既定の出力順）
+type Columns[T any] []Column[T]
+
+// Names 列名の一覧
+func (cs Columns[T]) Names() []string {
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Values 1件分の値を列の順序で取得
+func (cs Columns[T]) Values(item T) []any {
+	values := make([]any, len(cs))
+	for i, c := range cs {
+		values[i] = c.Value(item)
+	}
+	return values
+}
+
+// Select カンマ区切りの列名で列を選択する（空の場合は全列）
+// 指定された順序で出力し、重複は除く
+func (cs Columns[T]) Select(spec string) (Columns[T], error) {
+	if strings.TrimSpace(spec) == "" {
+		return cs, nil
+	}
+
+	byName := make(map[string]Column[T], len(cs))
+	for _, c := range cs {
+		byName[c.Name] = c
+	}
+
+	var selected Columns[T]
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s（指定可能: %s）", ErrUnknownColumn, name, strings.Join(cs.Names(), ", "))
+		}
+		seen[name] = true
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// UserColumns ユーザーのエクスポート列
+var UserColumns = Columns[domain.User]{
+	{Name: "id", Value: func(u domain.User) any { return u.ID }},
+	{Name: "name", Value: func(u domain.User) any { return u.Name }},
+	{Name: "email", Value: func(u domain.User) any { return u.Email }},
+	{Name: "version", Value: func(u domain.User) any { return u.Version }},
+	{Name: "created_at", Value: func(u domain.User) any { return u.CreatedAt }},
+	{Name: "updated_at", Value: func(u domain.User) any { return u.UpdatedAt }},
+}
+
+// CompanyColumns 会社のエクスポート列
+var CompanyColumns = Columns[domain.Company]{
+	{Name: "id", Value: func(c domain.Company) any { return c.ID }},
+	{Name: "name", Value: func(c domain.Company) any { return c.Name }},
+	{Name: "email", Value: func(c domain.Company) any { return c.Email }},
+	{Name: "phone", Value: func(c domain.Company) any { return c.Phone }},
+	{Name: "address", Value: func(c domain.Company) any { return c.Address }},
+	{Name: "website", Value: func(c domain.Company) any { return c.Website }},
+	{Name: "description", Value: func(c domain.Company) any { return c.Description }},
+	{Name: "version", Value: func(c domain.Company) any { return c.Version }},
+	{Name: "created_at", Value: func(c domain.Company) any { return c.CreatedAt }},
+	{Name: "updated_at", Value: func(c domain.Company) any { return c.UpdatedAt }},
+}
+
+// MembershipColumns 所属のエクスポート列
+var MembershipColumns = Columns[domain.MembershipDetail]{
+	{Name: "company_id", Value: func(m domain.MembershipDetail) any { return m.CompanyID }},
+	{Name: "company_name", Value: func(m domain.MembershipDetail) any { return m.CompanyName }},
+	{Name: "company_email", Value: func(m domain.MembershipDetail) any { return m.CompanyEmail }},
+	{Name: "user_id", Value: func(m domain.MembershipDetail) any { return m.UserID }},
+	{Name: "user_name", Value: func(m domain.MembershipDetail) any { return m.UserName }},
+	{Name: "user_email", Value: func(m domain.MembershipDetail) any { return m.UserEmail }},
+	{Name: "role", Value: func(m domain.MembershipDetail) any { return m.Role }},
+	{Name: "created_at", Value: func(m domain.MembershipDetail) any { return m.CreatedAt }},
+}
@@ -0,0 +1,34 @@
+package export
+
+// ExportRequest エクスポート共通のクエリパラメータ
+type ExportRequest struct {
+	Format  string `query:"format" validate:"omitempty,oneof=csv jsonl xlsx" example:"csv"`                              // 出力形式（既定はcsv）
+	Columns string `query:"columns" validate:"omitempty,max=500" example:"id,name,email"`                                // 出力する列（カンマ区切り、既定は全列）
+	Query   string `query:"q" validate:"omitempty,max=100" example:"search term"`                                        // 検索クエリ
+	SortBy  string `query:"sort_by" validate:"omitempty,oneof=id name email created_at updated_at" example:"created_at"` // ソート項目
+	SortDir string `query:"sort_dir" validate:"omitempty,oneof=asc desc" example:"desc"`                                 // ソート方向
+}
+
+// GetFormat 出力形式を取得（デフォルト値設定）
+func (r *ExportRequest) GetFormat() string {
+	if r.Format == "" {
+		return FormatCSV
+	}
+	return r.Format
+}
+
+// MembershipExportRequest 所属エクスポートのクエリパラメータ
+type MembershipExportRequest struct {
+	Format    string `query:"format" validate:"omitempty,oneof=csv jsonl xlsx" example:"csv"`         // 出力形式（既定はcsv）
+	Columns   string `query:"columns" validate:"omitempty,max=500" example:"company_name,user_email"` // 出力する列（カンマ区切り、既定は全列）
+	CompanyID uint   `query:"company_id" example:"1"`                                                 // 会社ID
+	Role      string `query:"role" validate:"omitempty,max=50" example:"admin"`                       // 役割
+}
+
+// GetFormat 出力形式を取得（デフォルト値設定）
+func (r *MembershipExportRequest) GetFormat() string {
+	if r.Format == "" {
+		return FormatCSV
+	}
+	return r.Format
+}
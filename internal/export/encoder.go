@@ -0,0 +1,228 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// エクスポート形式
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatXLSX  = "xlsx"
+)
+
+// ErrUnsupportedFormat 対応していないエクスポート形式
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// utf8BOM Excelで日本語を文字化けさせずに開くためにCSVの先頭に付与するBOM
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// xlsxSheet XLSXのシート名
+const xlsxSheet = "Sheet1"
+
+// Encoder 行を順に書き出すエンコーダー
+type Encoder interface {
+	// WriteHeader 列名を書き出す（最初に1回だけ呼ぶ）
+	WriteHeader(columns []string) error
+	// WriteRow 1行を書き出す。値の順序はWriteHeaderの列と同じ
+	WriteRow(values []any) error
+	// Flush バッファを書き出す
+	Flush() error
+	// Close 残りを書き出して終了する
+	Close() error
+}
+
+// ContentType 形式に対応するContent-Type
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// NewEncoder 形式に対応するエンコーダーを作成
+func NewEncoder(format string, w io.Writer) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		return &csvEncoder{w: w, csv: csv.NewWriter(w)}, nil
+	case FormatJSONL:
+		return &jsonlEncoder{w: w}, nil
+	case FormatXLSX:
+		return newXLSXEncoder(w)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+// formatValue CSV・XLSXのセルに書き出す文字列
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+type csvEncoder struct {
+	w   io.Writer
+	csv *csv.Writer
+}
+
+func (e *csvEncoder) WriteHeader(columns []string) error {
+	if _, err := e.w.Write(utf8BOM); err != nil {
+		return err
+	}
+	return e.csv.Write(columns)
+}
+
+func (e *csvEncoder) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = formatValue(v)
+	}
+	return e.csv.Write(record)
+}
+
+func (e *csvEncoder) Flush() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	return e.Flush()
+}
+
+// jsonlEncoder 1行1オブジェクトのJSON。キーは列の順序で出力する
+type jsonlEncoder struct {
+	w    io.Writer
+	keys [][]byte
+	buf  bytes.Buffer
+}
+
+func (e *jsonlEncoder) WriteHeader(columns []string) error {
+	e.keys = make([][]byte, len(columns))
+	for i, col := range columns {
+		key, err := json.Marshal(col)
+		if err != nil {
+			return err
+		}
+		e.keys[i] = key
+	}
+	return nil
+}
+
+func (e *jsonlEncoder) WriteRow(values []any) error {
+	e.buf.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(e.keys[i])
+		e.buf.WriteByte(':')
+		e.buf.Write(value)
+	}
+	e.buf.WriteString("}\n")
+	return nil
+}
+
+func (e *jsonlEncoder) Flush() error {
+	_, err := e.w.Write(e.buf.Bytes())
+	e.buf.Reset()
+	return err
+}
+
+func (e *jsonlEncoder) Close() error {
+	return e.Flush()
+}
+
+// xlsxEncoder excelizeのStreamWriterで行を書き出す
+// XLSXはzip形式のため、出力はCloseでまとめて行う（行データは一時ファイルに退避されメモリに載らない）
+type xlsxEncoder struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXEncoder(w io.Writer) (*xlsxEncoder, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to create xlsx stream writer: %w", err)
+	}
+	// 見出し行を固定する（SetRowより前に設定する必要がある）
+	if err := stream.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to set xlsx panes: %w", err)
+	}
+	return &xlsxEncoder{w: w, file: file, stream: stream}, nil
+}
+
+func (e *xlsxEncoder) WriteHeader(columns []string) error {
+	values := make([]any, len(columns))
+	for i, col := range columns {
+		values[i] = col
+	}
+	return e.writeRow(values)
+}
+
+func (e *xlsxEncoder) WriteRow(values []any) error {
+	cells := make([]any, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case uint, nil:
+			cells[i] = v
+		default:
+			cells[i] = formatValue(v)
+		}
+	}
+	return e.writeRow(cells)
+}
+
+func (e *xlsxEncoder) writeRow(values []any) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	return e.stream.SetRow(cell, values)
+}
+
+func (e *xlsxEncoder) Flush() error {
+	return nil
+}
+
+func (e *xlsxEncoder) Close() error {
+	defer e.file.Close()
+	if err := e.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to flush xlsx: %w", err)
+	}
+	if err := e.file.Write(e.w); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"km-api-go/internal/domain"
+)
+
+func encode(t *testing.T, format string, columns Columns[domain.User], users []domain.User) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc, err := NewEncoder(format, &buf)
+	require.NoError(t, err)
+	require.NoError(t, enc.WriteHeader(columns.Names()))
+	for _, u := range users {
+		require.NoError(t, enc.WriteRow(columns.Values(u)))
+	}
+	require.NoError(t, enc.Close())
+	return buf.Bytes()
+}
+
+func TestEncoder(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	users := []domain.User{
+		{ID: 1, Name: "山田太郎", Email: "yamada@example.com", CreatedAt: createdAt},
+		{ID: 2, Name: "Smith, John", Email: "smith@example.com", CreatedAt: createdAt},
+	}
+	columns, err := UserColumns.Select("id,name,created_at")
+	require.NoError(t, err)
+
+	t.Run("正常系: CSVはBOM付きで引用符が必要な値をエスケープする", func(t *testing.T) {
+		out := encode(t, FormatCSV, columns, users)
+
+		assert.Equal(t, "\xEF\xBB\xBFid,name,created_at\n"+
+			"1,山田太郎,2024-01-02T03:04:05Z\n"+
+			"2,\"Smith, John\",2024-01-02T03:04:05Z\n", string(out))
+	})
+
+	t.Run("正常系: JSONLは列の順序でキーを出力する", func(t *testing.T) {
+		out := encode(t, FormatJSONL, columns, users)
+
+		assert.Equal(t, `{"id":1,"name":"山田太郎","created_at":"2024-01-02T03:04:05Z"}`+"\n"+
+			`{"id":2,"name":"Smith, John","created_at":"2024-01-02T03:04:05Z"}`+"\n", string(out))
+	})
+
+	t.Run("正常系: XLSXは見出し行と値を書き出す", func(t *testing.T) {
+		out := encode(t, FormatXLSX, columns, users)
+
+		f, err := excelize.OpenReader(bytes.NewReader(out))
+		require.NoError(t, err)
+		defer f.Close()
+		rows, err := f.GetRows(xlsxSheet)
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "name", "created_at"},
+			{"1", "山田太郎", "2024-01-02T03:04:05Z"},
+			{"2", "Smith, John", "2024-01-02T03:04:05Z"},
+		}, rows)
+	})
+
+	t.Run("異常系: 対応していない形式", func(t *testing.T) {
+		_, err := NewEncoder("xml", &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
+
+func TestColumns_Select(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expectedNames []string
+		expectedErr   error
+	}{
+		{
+			name:          "正常系: 未指定の場合は全列",
+			spec:          "",
+			expectedNames: UserColumns.Names(),
+		},
+		{
+			name:          "正常系: 指定順で重複を除く",
+			spec:          "email, id,email",
+			expectedNames: []string{"email", "id"},
+		},
+		{
+			name:        "異常系: 存在しない列",
+			spec:        "id,password",
+			expectedErr: ErrUnknownColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := UserColumns.Select(tt.spec)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNames, columns.Names())
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+type ExportHandler struct {
+	usecase ExportUsecase
+	now     func() time.Time
+}
+
+func NewExportHandler(usecase ExportUsecase) *ExportHandler {
+	return &ExportHandler{usecase: usecase, now: time.Now}
+}
+
+// ExportUsers godoc
+// @Summary ユーザーエクスポート
+// @ID exportUsers
+// @Description ユーザーをCSV（UTF-8 BOM付き）・JSONL・XLSXでダウンロードします。ユーザー認証の場合は自分と同じ会社に所属するユーザーのみ出力します
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "出力形式" Enums(csv, jsonl, xlsx)
+// @Param columns query string false "出力する列（カンマ区切り）: id, name, email, version, created_at, updated_at"
+// @Param q query string false "名前・メールアドレスの部分一致"
+// @Param sort_by query string false "ソート項目" Enums(id, name, email, created_at, updated_at)
+// @Param sort_dir query string false "ソート方向" Enums(asc, desc)
+// @Success 200 {file} file
+// @Failure 400 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /exports/users [get]
+func (h *ExportHandler) ExportUsers(c echo.Context) error {
+	var req ExportRequest
+	if err := bindQuery(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	filter := domain.UserFilter{Query: req.Query, SortBy: req.SortBy, SortDir: req.SortDir}
+	return writeExport(c, h.filename("users", req.GetFormat()), req.GetFormat(), req.Columns, UserColumns,
+		func(ctx context.Context, fn func([]domain.User) error) error {
+			return h.usecase.StreamUsers(ctx, filter, fn)
+		})
+}
+
+// ExportCompanies godoc
+// @Summary 会社エクスポート
+// @ID exportCompanies
+// @Description 会社をCSV（UTF-8 BOM付き）・JSONL・XLSXでダウンロードします。ユーザー認証の場合は自分が所属する会社のみ出力します
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "出力形式" Enums(csv, jsonl, xlsx)
+// @Param columns query string false "出力する列（カンマ区切り）: id, name, email, phone, address, website, description, version, created_at, updated_at"
+// @Param q query string false "会社名の部分一致"
+// @Param sort_by query string false "ソート項目" Enums(id, name, email, created_at, updated_at)
+// @Param sort_dir query string false "ソート方向" Enums(asc, desc)
+// @Success 200 {file} file
+// @Failure 400 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /exports/companies [get]
+func (h *ExportHandler) ExportCompanies(c echo.Context) error {
+	var req ExportRequest
+	if err := bindQuery(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	filter := domain.CompanyFilter{Query: req.Query, SortBy: req.SortBy, SortDir: req.SortDir}
+	return writeExport(c, h.filename("companies", req.GetFormat()), req.GetFormat(), req.Columns, CompanyColumns,
+		func(ctx context.Context, fn func([]domain.Company) error) error {
+			return h.usecase.StreamCompanies(ctx, filter, fn)
+		})
+}
+
+// ExportMemberships godoc
+// @Summary 所属エクスポート
+// @ID exportMemberships
+// @Description 会社とユーザーの所属をCSV（UTF-8 BOM付き）・JSONL・XLSXでダウンロードします。ユーザー認証の場合は自分が所属する会社のみ出力します
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "出力形式" Enums(csv, jsonl, xlsx)
+// @Param columns query string false "出力する列（カンマ区切り）: company_id, company_name, company_email, user_id, user_name, user_email, role, created_at"
+// @Param company_id query int false "会社ID"
+// @Param role query string false "役割"
+// @Success 200 {file} file
+// @Failure 400 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /exports/memberships [get]
+func (h *ExportHandler) ExportMemberships(c echo.Context) error {
+	var req MembershipExportRequest
+	if err := bindQuery(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	filter := domain.MembershipFilter{CompanyID: req.CompanyID, Role: req.Role}
+	return writeExport(c, h.filename("memberships", req.GetFormat()), req.GetFormat(), req.Columns, MembershipColumns,
+		func(ctx context.Context, fn func([]domain.MembershipDetail) error) error {
+			return h.usecase.StreamMemberships(ctx, filter, fn)
+		})
+}
+
+func bindQuery(c echo.Context, req interface{}) error {
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return err
+	}
+	return c.Validate(req)
+}
+
+// filename ダウンロード時のファイル名（例: users-20240101.csv）
+func (h *ExportHandler) filename(resource, format string) string {
+	return fmt.Sprintf("%s-%s.%s", resource, h.now().Format("20060102"), format)
+}
+
+// writeExport 取得した行を順にエンコードしてレスポンスに書き出す
+// レスポンスは最初のバッチを受け取った時点で開始する。それ以前のエラーはエラーレスポンスとして返し、
+// 開始後のエラーはステータスを変更できないためログに記録して出力を打ち切る
+func writeExport[T any](
+	c echo.Context,
+	filename, format, columnSpec string,
+	columns Columns[T],
+	stream func(ctx context.Context, fn func([]T) error) error,
+) error {
+	selected, err := columns.Select(columnSpec)
+	if err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	res := c.Response()
+	var enc Encoder
+	start := func() error {
+		res.Header().Set(echo.HeaderContentType, ContentType(format))
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		res.WriteHeader(http.StatusOK)
+
+		var err error
+		if enc, err = NewEncoder(format, res); err != nil {
+			return err
+		}
+		return enc.WriteHeader(selected.Names())
+	}
+
+	ctx := c.Request().Context()
+	err = stream(ctx, func(batch []T) error {
+		if enc == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for _, item := range batch {
+			if err := enc.WriteRow(selected.Values(item)); err != nil {
+				return err
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	})
+	if err == nil && enc == nil {
+		// 該当なしの場合も見出し行のみのファイルを返す
+		err = start()
+	}
+	if err == nil {
+		err = enc.Close()
+	}
+	if err != nil {
+		if !res.Committed {
+			if errors.Is(err, ErrUnauthenticated) {
+				return helper.UnauthorizedResponse(c)
+			}
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to export", slog.Any("error", err))
+			return helper.InternalErrorResponse(c, err.Error())
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "export aborted", slog.String("filename", filename), slog.Any("error", err))
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/export/mocks"
+	"km-api-go/internal/helper"
+)
+
+func TestExportHandler_ExportUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockExportUsecase(ctrl)
+	handler := NewExportHandler(mockUsecase)
+	handler.now = func() time.Time { return time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC) }
+
+	users := []domain.User{{ID: 1, Name: "山田太郎", Email: "yamada@example.com"}}
+	streamUsers := func(batches ...[]domain.User) func(context.Context, domain.UserFilter, func([]domain.User) error) error {
+		return func(_ context.Context, _ domain.UserFilter, fn func([]domain.User) error) error {
+			for _, batch := range batches {
+				if err := fn(batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		setupMock           func()
+		expectedStatus      int
+		expectedBody        string
+		expectedHeader      string
+		expectedDisposition string
+	}{
+		{
+			name:  "正常系: 条件と列を指定してCSVを出力",
+			query: "?q=yamada&sort_by=name&sort_dir=asc&columns=name,email",
+			setupMock: func() {
+				mockUsecase.EXPECT().
+					StreamUsers(gomock.Any(), domain.UserFilter{Query: "yamada", SortBy: "name", SortDir: "asc"}, gomock.Any()).
+					DoAndReturn(streamUsers(users)).
+					Times(1)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "\xEF\xBB\xBFname,email\n山田太郎,yamada@example.com\n",
+			expectedHeader:      "text/csv; charset=utf-8",
+			expectedDisposition: `attachment; filename="users-20240102.csv"`,
+		},
+		{
+			name:  "正常系: 該当なしの場合は見出しのみ",
+			query: "?format=jsonl",
+			setupMock: func() {
+				mockUsecase.EXPECT().
+					StreamUsers(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(streamUsers()).
+					Times(1)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "",
+			expectedHeader:      "application/x-ndjson",
+			expectedDisposition: `attachment; filename="users-20240102.jsonl"`,
+		},
+		{
+			name:           "異常系: 存在しない列",
+			query:          "?columns=password",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 対応していない形式",
+			query:          "?format=xml",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 出力開始前のエラー",
+			query: "",
+			setupMock: func() {
+				mockUsecase.EXPECT().
+					StreamUsers(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("database error")).
+					Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/exports/users"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.ExportUsers(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedBody, rec.Body.String())
+				assert.Equal(t, tt.expectedHeader, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, tt.expectedDisposition, rec.Header().Get(echo.HeaderContentDisposition))
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/export/usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/export/usecase.go -destination=internal/export/mocks/export_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "km-api-go/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportUsecase is a mock of ExportUsecase interface.
+type MockExportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockExportUsecaseMockRecorder is the mock recorder for MockExportUsecase.
+type MockExportUsecaseMockRecorder struct {
+	mock *MockExportUsecase
+}
+
+// NewMockExportUsecase creates a new mock instance.
+func NewMockExportUsecase(ctrl *gomock.Controller) *MockExportUsecase {
+	mock := &MockExportUsecase{ctrl: ctrl}
+	mock.recorder = &MockExportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportUsecase) EXPECT() *MockExportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// StreamCompanies mocks base method.
+func (m *MockExportUsecase) StreamCompanies(ctx context.Context, filter domain.CompanyFilter, fn func([]domain.Company) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCompanies", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCompanies indicates an expected call of StreamCompanies.
+func (mr *MockExportUsecaseMockRecorder) StreamCompanies(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCompanies", reflect.TypeOf((*MockExportUsecase)(nil).StreamCompanies), ctx, filter, fn)
+}
+
+// StreamMemberships mocks base method.
+func (m *MockExportUsecase) StreamMemberships(ctx context.Context, filter domain.MembershipFilter, fn func([]domain.MembershipDetail) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamMemberships", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamMemberships indicates an expected call of StreamMemberships.
+func (mr *MockExportUsecaseMockRecorder) StreamMemberships(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamMemberships", reflect.TypeOf((*MockExportUsecase)(nil).StreamMemberships), ctx, filter, fn)
+}
+
+// StreamUsers mocks base method.
+func (m *MockExportUsecase) StreamUsers(ctx context.Context, filter domain.UserFilter, fn func([]domain.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamUsers", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamUsers indicates an expected call of StreamUsers.
+func (mr *MockExportUsecaseMockRecorder) StreamUsers(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamUsers", reflect.TypeOf((*MockExportUsecase)(nil).StreamUsers), ctx, filter, fn)
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"context"
+	"errors"
+
+	"km-api-go/internal/auth"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/tracing"
+	userRepo "km-api-go/internal/user/repository"
+)
+
+// ErrUnauthenticated 呼び出し元が認証されていない
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ExportUsecase エクスポートのビジネスロジック
+// ユーザーとして認証された呼び出し元には、自分が所属する会社の範囲のデータのみ返す（APIキーの場合は全件）
+type ExportUsecase interface {
+	StreamUsers(ctx context.Context, filter domain.UserFilter, fn func([]domain.User) error) error
+	StreamCompanies(ctx context.Context, filter domain.CompanyFilter, fn func([]domain.Company) error) error
+	StreamMemberships(ctx context.Context, filter domain.MembershipFilter, fn func([]domain.MembershipDetail) error) error
+}
+
+type exportUsecase struct {
+	userRepo        userRepo.UserRepository
+	companyRepo     companyRepo.CompanyRepository
+	companyUserRepo companyRepo.CompanyUserRepository
+}
+
+// NewExportUsecase エクスポートユースケースのコンストラクタ
+func NewExportUsecase(
+	userRepository userRepo.UserRepository,
+	companyRepository companyRepo.CompanyRepository,
+	companyUserRepository companyRepo.CompanyUserRepository,
+) ExportUsecase {
+	return &exportUsecase{
+		userRepo:        userRepository,
+		companyRepo:     companyRepository,
+		companyUserRepo: companyUserRepository,
+	}
+}
+
+// visibleTo 呼び出し元が参照できる範囲（0は全件）
+func visibleTo(ctx context.Context) (uint, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+	return principal.UserID, nil
+}
+
+func (uc *exportUsecase) StreamUsers(ctx context.Context, filter domain.UserFilter, fn func([]domain.User) error) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportUsecase.StreamUsers")
+	defer tracing.End(span, &err)
+
+	if filter.VisibleTo, err = visibleTo(ctx); err != nil {
+		return err
+	}
+	return uc.userRepo.Stream(ctx, filter, fn)
+}
+
+func (uc *exportUsecase) StreamCompanies(ctx context.Context, filter domain.CompanyFilter, fn func([]domain.Company) error) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportUsecase.StreamCompanies")
+	defer tracing.End(span, &err)
+
+	if filter.VisibleTo, err = visibleTo(ctx); err != nil {
+		return err
+	}
+	return uc.companyRepo.Stream(ctx, filter, fn)
+}
+
+func (uc *exportUsecase) StreamMemberships(ctx context.Context, filter domain.MembershipFilter, fn func([]domain.MembershipDetail) error) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportUsecase.StreamMemberships")
+	defer tracing.End(span, &err)
+
+	if filter.VisibleTo, err = visibleTo(ctx); err != nil {
+		return err
+	}
+	return uc.companyUserRepo.StreamDetails(ctx, filter, fn)
+}
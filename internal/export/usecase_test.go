@@ -0,0 +1,50 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/domain"
+	userMocks "km-api-go/internal/user/repository/mocks"
+)
+
+func TestExportUsecase_Scope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := userMocks.NewMockUserRepository(ctrl)
+	mockCompanyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := companyMocks.NewMockCompanyUserRepository(ctrl)
+	uc := NewExportUsecase(mockUserRepo, mockCompanyRepo, mockCompanyUserRepo)
+	noop := func([]domain.User) error { return nil }
+
+	t.Run("正常系: ユーザーは自分と同じ会社の範囲に限定される", func(t *testing.T) {
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 7})
+		mockUserRepo.EXPECT().
+			Stream(gomock.Any(), domain.UserFilter{Query: "a", VisibleTo: 7}, gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		assert.NoError(t, uc.StreamUsers(ctx, domain.UserFilter{Query: "a"}, noop))
+	})
+
+	t.Run("正常系: APIキーは全件", func(t *testing.T) {
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+		mockCompanyRepo.EXPECT().
+			Stream(gomock.Any(), domain.CompanyFilter{}, gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		assert.NoError(t, uc.StreamCompanies(ctx, domain.CompanyFilter{VisibleTo: 3}, func([]domain.Company) error { return nil }))
+	})
+
+	t.Run("異常系: 未認証", func(t *testing.T) {
+		err := uc.StreamMemberships(context.Background(), domain.MembershipFilter{}, func([]domain.MembershipDetail) error { return nil })
+		assert.ErrorIs(t, err, ErrUnauthenticated)
+	})
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+	// ErrTooDeep クエリのネストが上限を超えた
+	ErrTooDeep = errors.New("query is too deep")
+	// ErrTooComplex クエリの計算量が上限を超えた
+	ErrTooComplex = errors.New("query is too complex")
+)
+
+// cost 実行するオペレーションのネストと計算量
+type cost struct {
+	Depth      int
+	Complexity int
+}
+
+// analyze 実行するオペレーションのネストと計算量を求める
+// 各フィールドを1とし、コネクション（first引数を取るフィールド）の子は取得件数倍する。
+// イントロスペクションのフィールド（__で始まる）は数えない
+func analyze(doc *ast.Document, operation *ast.OperationDefinition, variables map[string]interface{}) cost {
+	a := &analyzer{fragments: fragmentsOf(doc), variables: variables}
+	depth, complexity := a.selectionSet(operation.SelectionSet, map[string]bool{})
+	return cost{Depth: depth, Complexity: complexity}
+}
+
+func fragmentsOf(doc *ast.Document) map[string]*ast.FragmentDefinition {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[def.Name.Value] = def
+		}
+	}
+	return fragments
+}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// selectionSet 選択セットのネストと計算量（visitingは展開中のフラグメント。循環は検証で弾かれるが念のため）
+func (a *analyzer) selectionSet(set *ast.SelectionSet, visiting map[string]bool) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			childDepth, childComplexity := a.selectionSet(s.SelectionSet, visiting)
+			d = childDepth + 1
+			c = 1 + childComplexity*a.multiplier(s)
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(s.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			fragment, ok := a.fragments[s.Name.Value]
+			if !ok || visiting[s.Name.Value] {
+				continue
+			}
+			visiting[s.Name.Value] = true
+			d, c = a.selectionSet(fragment.SelectionSet, visiting)
+			delete(visiting, s.Name.Value)
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+// multiplier 子フィールドが繰り返される回数（コネクションは取得件数）
+func (a *analyzer) multiplier(field *ast.Field) int {
+	if !connectionFields[field.Name.Value] {
+		return 1
+	}
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil {
+				return pageSize(n)
+			}
+		case *ast.Variable:
+			if n, ok := a.variables[v.Name.Value].(float64); ok {
+				return pageSize(int(n))
+			}
+			if n, ok := a.variables[v.Name.Value].(int); ok {
+				return pageSize(n)
+			}
+		}
+	}
+	return defaultPageSize
+}
@@ -0,0 +1,22 @@
+package graph
+
+import "km-api-go/internal/infra"
+
+// Config GraphQLエンドポイントの設定
+type Config struct {
+	Enabled       bool // /api/v1/graphql, /api/v2/graphql を公開するか
+	MaxDepth      int  // クエリのネストの上限
+	MaxComplexity int  // クエリの計算量の上限（フィールドごとに1、コネクションは取得件数倍）
+	Introspection bool // イントロスペクション（__schema, __type）を許可するか
+}
+
+// LoadConfig 環境変数からGraphQLの設定を読み込み
+// 本番環境ではGRAPHQL_INTROSPECTIONに関わらずイントロスペクションを無効化する
+func LoadConfig() Config {
+	return Config{
+		Enabled:       infra.GetEnvBool("GRAPHQL_ENABLED", true),
+		MaxDepth:      infra.GetEnvInt("GRAPHQL_MAX_DEPTH", 12),
+		MaxComplexity: infra.GetEnvInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+		Introspection: infra.GetEnvBool("GRAPHQL_INTROSPECTION", true) && !infra.IsProduction(),
+	}
+}
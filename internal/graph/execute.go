@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/tracing"
+)
+
+// Request GraphQLのリクエスト
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Result 実行結果（Executedがfalseの場合は構文・検証・制限のエラーで実行していない）
+type Result struct {
+	*graphql.Result
+	Executed bool
+}
+
+// Execute クエリを検証し、ネスト・計算量の上限を確認してから実行する
+// queryOnlyの場合はクエリ以外のオペレーション（ミューテーション）を拒否する（GETリクエスト用）
+func (s *Schema) Execute(ctx context.Context, req Request, queryOnly bool) (_ *Result, err error) {
+	ctx, span := tracing.Start(ctx, "GraphQL.Execute")
+	defer tracing.End(span, &err)
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return rejected(gqlerrors.FormatErrors(err)...), nil
+	}
+
+	validation := graphql.ValidateDocument(&s.schema, doc, nil)
+	if !validation.IsValid {
+		return rejected(validation.Errors...), nil
+	}
+
+	operation := findOperation(doc, req.OperationName)
+	if operation == nil {
+		return rejected(formatError(newError(helper.ErrorCodeValidation, "operation not found"))), nil
+	}
+	if queryOnly && operation.Operation != ast.OperationTypeQuery {
+		return rejected(formatError(newError(helper.ErrorCodeValidation, "only queries are allowed with GET"))), nil
+	}
+	if !s.cfg.Introspection && usesIntrospection(fragmentsOf(doc), operation.SelectionSet) {
+		return rejected(formatError(newError(helper.ErrorCodeForbidden, "introspection is disabled"))), nil
+	}
+
+	c := analyze(doc, operation, req.Variables)
+	if c.Depth > s.cfg.MaxDepth {
+		return rejected(formatError(newError(helper.ErrorCodeValidation,
+			fmt.Sprintf("%s: depth %d exceeds %d", ErrTooDeep, c.Depth, s.cfg.MaxDepth)))), nil
+	}
+	if c.Complexity > s.cfg.MaxComplexity {
+		return rejected(formatError(newError(helper.ErrorCodeValidation,
+			fmt.Sprintf("%s: complexity %d exceeds %d", ErrTooComplex, c.Complexity, s.cfg.MaxComplexity)))), nil
+	}
+
+	ctx = withLoaders(ctx, newLoaders(s.userRepo, s.companyRepo, s.companyUserRepo))
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+
+	// 想定外のエラーはログに残す（レスポンスには詳細を含めない）
+	for _, e := range result.Errors {
+		var internal *internalError
+		if errors.As(e.OriginalError(), &internal) {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to resolve graphql field",
+				slog.Any("path", e.Path),
+				slog.Any("error", internal.err),
+			)
+		}
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "graphql executed",
+		slog.String("operation", operation.Operation),
+		slog.String("operation_name", req.OperationName),
+		slog.Int("depth", c.Depth),
+		slog.Int("complexity", c.Complexity),
+		slog.Int("errors", len(result.Errors)),
+	)
+	return &Result{Result: result, Executed: true}, nil
+}
+
+func rejected(errs ...gqlerrors.FormattedError) *Result {
+	return &Result{Result: &graphql.Result{Errors: errs}}
+}
+
+func formatError(err *Error) gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{Message: err.Message, Extensions: err.Extensions()}
+}
+
+// findOperation 実行するオペレーション（名前の指定がない場合は唯一のオペレーション）
+func findOperation(doc *ast.Document, operationName string) *ast.OperationDefinition {
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			if operationName == "" || (op.Name != nil && op.Name.Value == operationName) {
+				return op
+			}
+		}
+	}
+	return nil
+}
+
+// usesIntrospection オペレーションの最上位で__schema・__typeを参照しているか
+// フラグメントの循環は検証で弾かれているため、そのまま展開する
+func usesIntrospection(fragments map[string]*ast.FragmentDefinition, set *ast.SelectionSet) bool {
+	if set == nil {
+		return false
+	}
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if name := s.Name.Value; strings.HasPrefix(name, "__") && name != "__typename" {
+				return true
+			}
+		case *ast.InlineFragment:
+			if usesIntrospection(fragments, s.SelectionSet) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if fragment, ok := fragments[s.Name.Value]; ok && usesIntrospection(fragments, fragment.SelectionSet) {
+				return true
+			}
+		}
+	}
+	return false
+}
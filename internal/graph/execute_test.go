@@ -0,0 +1,375 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/company"
+	companyMocks "km-api-go/internal/company/mocks"
+	companyRepoMocks "km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/outbox"
+	userRepoMocks "km-api-go/internal/user/repository/mocks"
+)
+
+func testConfig() Config {
+	return Config{Enabled: true, MaxDepth: 12, MaxComplexity: 5000, Introspection: true}
+}
+
+type testDeps struct {
+	companyUsecase  *companyMocks.MockCompanyUsecase
+	userRepo        *userRepoMocks.MockUserRepository
+	companyRepo     *companyRepoMocks.MockCompanyRepository
+	companyUserRepo *companyRepoMocks.MockCompanyUserRepository
+}
+
+func newTestSchema(t *testing.T, cfg Config) (*Schema, testDeps) {
+	ctrl := gomock.NewController(t)
+	deps := testDeps{
+		companyUsecase:  companyMocks.NewMockCompanyUsecase(ctrl),
+		userRepo:        userRepoMocks.NewMockUserRepository(ctrl),
+		companyRepo:     companyRepoMocks.NewMockCompanyRepository(ctrl),
+		companyUserRepo: companyRepoMocks.NewMockCompanyUserRepository(ctrl),
+	}
+	schema, err := NewSchema(deps.companyUsecase, deps.userRepo, deps.companyRepo, deps.companyUserRepo, cfg)
+	require.NoError(t, err)
+	return schema, deps
+}
+
+// resultJSON 実行結果をJSONに変換（比較用）
+func resultJSON(t *testing.T, result *Result) string {
+	b, err := json.Marshal(result.Result)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestSchema_Execute_Batching(t *testing.T) {
+	schema, deps := newTestSchema(t, testConfig())
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+
+	companies := []domain.Company{{ID: 1, Name: "会社A"}, {ID: 2, Name: "会社B"}, {ID: 3, Name: "会社C"}}
+	deps.companyRepo.EXPECT().
+		ListAfter(gomock.Any(), domain.CompanyFilter{VisibleTo: 1}, uint(0), 3).
+		Return(companies, nil)
+	// 会社ごとではなく、まとめて1回ずつ取得する
+	deps.companyUserRepo.EXPECT().
+		GetByCompanyIDs(gomock.Any(), []uint{1, 2}).
+		Return([]domain.CompanyUser{
+			{ID: 10, CompanyID: 1, UserID: 1, Role: "admin"},
+			{ID: 11, CompanyID: 2, UserID: 1, Role: "member"},
+			{ID: 12, CompanyID: 2, UserID: 2, Role: "admin"},
+		}, nil).
+		Times(1)
+	// 呼び出し元の所属は会社の所属ユーザーを返してよいかの判定に使い、リクエスト内で1回だけ取得する
+	deps.companyUserRepo.EXPECT().
+		GetByUserIDs(gomock.Any(), []uint{1}).
+		Return([]domain.CompanyUser{
+			{ID: 10, CompanyID: 1, UserID: 1, Role: "admin"},
+			{ID: 11, CompanyID: 2, UserID: 1, Role: "member"},
+		}, nil).
+		Times(1)
+	deps.userRepo.EXPECT().
+		GetByIDs(gomock.Any(), []uint{1, 2}).
+		Return([]domain.User{{ID: 1, Name: "山田太郎"}, {ID: 2, Name: "鈴木花子"}}, nil).
+		Times(1)
+
+	result, err := schema.Execute(ctx, Request{Query: `{
+		companies(first: 2) {
+			edges { node { name members { edges { node { role user { name } } } } } }
+			pageInfo { hasNextPage }
+		}
+	}`}, false)
+	require.NoError(t, err)
+	assert.True(t, result.Executed)
+	assert.JSONEq(t, `{"data":{"companies":{
+		"edges":[
+			{"node":{"name":"会社A","members":{"edges":[{"node":{"role":"ADMIN","user":{"name":"山田太郎"}}}]}}},
+			{"node":{"name":"会社B","members":{"edges":[
+				{"node":{"role":"MEMBER","user":{"name":"山田太郎"}}},
+				{"node":{"role":"ADMIN","user":{"name":"鈴木花子"}}}
+			]}}}
+		],
+		"pageInfo":{"hasNextPage":true}
+	}}}`, resultJSON(t, result))
+}
+
+func TestSchema_Execute_NestedVisibility(t *testing.T) {
+	schema, deps := newTestSchema(t, testConfig())
+	// ユーザー1は会社1のみに所属し、ユーザー2は会社1・会社2に所属している
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+
+	deps.companyUserRepo.EXPECT().SharesCompany(gomock.Any(), uint(1), uint(2)).Return(true, nil)
+	deps.userRepo.EXPECT().
+		GetByIDs(gomock.Any(), []uint{2}).
+		Return([]domain.User{{ID: 2, Name: "鈴木花子"}}, nil)
+	deps.companyUserRepo.EXPECT().
+		GetByUserIDs(gomock.Any(), []uint{1, 2}).
+		Return([]domain.CompanyUser{
+			{ID: 10, CompanyID: 1, UserID: 1, Role: "admin"},
+			{ID: 20, CompanyID: 1, UserID: 2, Role: "member"},
+			{ID: 21, CompanyID: 2, UserID: 2, Role: "admin"},
+		}, nil).
+		Times(1)
+	// 会社2は呼び出し元が所属していないため、所属・会社・所属ユーザーを取得しない
+	deps.companyRepo.EXPECT().
+		GetByIDs(gomock.Any(), []uint{1}).
+		Return([]domain.Company{{ID: 1, Name: "会社A"}}, nil)
+	deps.companyUserRepo.EXPECT().
+		GetByCompanyIDs(gomock.Any(), []uint{1}).
+		Return([]domain.CompanyUser{
+			{ID: 10, CompanyID: 1, UserID: 1, Role: "admin"},
+			{ID: 20, CompanyID: 1, UserID: 2, Role: "member"},
+		}, nil)
+	deps.userRepo.EXPECT().
+		GetByIDs(gomock.Any(), []uint{1}).
+		Return([]domain.User{{ID: 1, Name: "山田太郎"}}, nil)
+
+	result, err := schema.Execute(ctx, Request{Query: `{
+		user(id: "2") {
+			name
+			memberships { edges { node { role company { name members { edges { node { user { name } } } } } } } }
+		}
+	}`}, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"user":{
+		"name":"鈴木花子",
+		"memberships":{"edges":[
+			{"node":{"role":"MEMBER","company":{"name":"会社A","members":{"edges":[
+				{"node":{"user":{"name":"山田太郎"}}},
+				{"node":{"user":{"name":"鈴木花子"}}}
+			]}}}}
+		]}
+	}}}`, resultJSON(t, result))
+}
+
+func TestSchema_Execute_Pagination(t *testing.T) {
+	schema, deps := newTestSchema(t, testConfig())
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "dashboard"})
+
+	deps.userRepo.EXPECT().
+		ListAfter(gomock.Any(), domain.UserFilter{Query: "yamada"}, uint(5), 2).
+		Return([]domain.User{{ID: 6, Name: "山田太郎"}}, nil)
+
+	result, err := schema.Execute(ctx, Request{
+		Query:     `query Users($after: String) { users(first: 1, after: $after, query: "yamada") { edges { cursor node { id } } pageInfo { hasNextPage endCursor } } }`,
+		Variables: map[string]interface{}{"after": encodeCursor(5)},
+	}, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"data":{"users":{
+		"edges":[{"cursor":%[1]q,"node":{"id":"6"}}],
+		"pageInfo":{"hasNextPage":false,"endCursor":%[1]q}
+	}}}`, encodeCursor(6)), resultJSON(t, result))
+}
+
+func TestSchema_Execute_Mutation(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+	updateQuery := `mutation { updateCompany(input: {id: "1", version: 2, name: "会社A", email: "a@example.com"}) { id version } }`
+
+	tests := []struct {
+		name      string
+		query     string
+		queryOnly bool
+		setupMock func(deps testDeps)
+		expected  string
+		executed  bool
+	}{
+		{
+			name:  "正常系: 会社を更新",
+			query: updateQuery,
+			setupMock: func(deps testDeps) {
+				deps.companyUsecase.EXPECT().
+					UpdateCompany(gomock.Any(), uint(1), uint(2), "会社A", "a@example.com", "", "", "", "").
+					Return(&domain.Company{ID: 1, Version: 3}, nil)
+			},
+			expected: `{"data":{"updateCompany":{"id":"1","version":3}}}`,
+			executed: true,
+		},
+		{
+			name:  "異常系: バージョンが一致しない",
+			query: updateQuery,
+			setupMock: func(deps testDeps) {
+				deps.companyUsecase.EXPECT().
+					UpdateCompany(gomock.Any(), uint(1), uint(2), "会社A", "a@example.com", "", "", "", "").
+					Return(nil, fmt.Errorf("company with id 1 version 2: %w", domain.ErrVersionConflict))
+			},
+			expected: `{"data":null,"errors":[{"message":"company with id 1 version 2: version conflict","locations":[{"line":1,"column":12}],"path":["updateCompany"],"extensions":{"code":"PRECONDITION_FAILED"}}]}`,
+			executed: true,
+		},
+		{
+			name:      "異常系: バージョンは必須",
+			query:     `mutation { updateCompany(input: {id: "1", name: "会社A", email: "a@example.com"}) { id } }`,
+			setupMock: func(deps testDeps) {},
+			expected:  `{"data":null,"errors":[{"message":"Argument \"input\" has invalid value {id: \"1\", name: \"会社A\", email: \"a@example.com\"}.\nIn field \"version\": Expected \"Int!\", found null.","locations":[{"line":1,"column":33}]}]}`,
+		},
+		{
+			name:      "異常系: バージョンが不正",
+			query:     `mutation { updateCompany(input: {id: "1", version: 0, name: "会社A", email: "a@example.com"}) { id } }`,
+			setupMock: func(deps testDeps) {},
+			expected:  `{"data":null,"errors":[{"message":"invalid version","locations":[{"line":1,"column":12}],"path":["updateCompany"],"extensions":{"code":"VALIDATION_ERROR"}}]}`,
+			executed:  true,
+		},
+		{
+			name:      "異常系: 入力値が不正",
+			query:     `mutation { addMember(companyId: "1", userId: "abc", role: ADMIN) { id } }`,
+			setupMock: func(deps testDeps) {},
+			expected:  `{"data":null,"errors":[{"message":"invalid id: \"abc\"","locations":[{"line":1,"column":12}],"path":["addMember"],"extensions":{"code":"VALIDATION_ERROR"}}]}`,
+			executed:  true,
+		},
+		{
+			name:      "異常系: GETではミューテーションを実行しない",
+			query:     updateQuery,
+			queryOnly: true,
+			setupMock: func(deps testDeps) {},
+			expected:  `{"data":null,"errors":[{"message":"only queries are allowed with GET","locations":null,"extensions":{"code":"VALIDATION_ERROR"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, deps := newTestSchema(t, testConfig())
+			tt.setupMock(deps)
+
+			result, err := schema.Execute(ctx, Request{Query: tt.query}, tt.queryOnly)
+			require.NoError(t, err)
+			assert.Equal(t, tt.executed, result.Executed)
+			assert.JSONEq(t, tt.expected, resultJSON(t, result))
+		})
+	}
+}
+
+func TestSchema_Execute_Limits(t *testing.T) {
+	deep := `{ viewer { memberships { edges { node { company { members { edges { node { user { memberships { edges { node { role } } } } } } } } } } } } }`
+
+	tests := []struct {
+		name     string
+		cfg      func(cfg *Config)
+		query    string
+		expected string
+	}{
+		{
+			name:     "異常系: ネストが上限を超える",
+			query:    deep,
+			expected: "query is too deep: depth 13 exceeds 12",
+		},
+		{
+			name:     "異常系: 計算量が上限を超える",
+			cfg:      func(cfg *Config) { cfg.MaxComplexity = 100 },
+			query:    `{ companies(first: 50) { edges { node { name } } } }`,
+			expected: "query is too complex: complexity 151 exceeds 100",
+		},
+		{
+			name:     "異常系: イントロスペクションが無効",
+			cfg:      func(cfg *Config) { cfg.Introspection = false },
+			query:    `query { ...Schema } fragment Schema on Query { __schema { queryType { name } } }`,
+			expected: "introspection is disabled",
+		},
+		{
+			name:     "異常系: スキーマにないフィールド",
+			query:    `{ viewer { password } }`,
+			expected: `Cannot query field "password" on type "User".`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			if tt.cfg != nil {
+				tt.cfg(&cfg)
+			}
+			schema, _ := newTestSchema(t, cfg)
+
+			result, err := schema.Execute(context.Background(), Request{Query: tt.query}, false)
+			require.NoError(t, err)
+			assert.False(t, result.Executed)
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, tt.expected, result.Errors[0].Message)
+		})
+	}
+}
+
+// newTestSchemaWithUsecase モックではなく会社ユースケースを通すスキーマ（権限の確認用）
+func newTestSchemaWithUsecase(t *testing.T) (*Schema, testDeps) {
+	ctrl := gomock.NewController(t)
+	deps := testDeps{
+		userRepo:        userRepoMocks.NewMockUserRepository(ctrl),
+		companyRepo:     companyRepoMocks.NewMockCompanyRepository(ctrl),
+		companyUserRepo: companyRepoMocks.NewMockCompanyUserRepository(ctrl),
+	}
+	uc := company.NewCompanyUsecase(deps.companyRepo, deps.companyUserRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()))
+	schema, err := NewSchema(uc, deps.userRepo, deps.companyRepo, deps.companyUserRepo, testConfig())
+	require.NoError(t, err)
+	return schema, deps
+}
+
+func TestSchema_Execute_MutationForbidden(t *testing.T) {
+	tests := []struct {
+		name  string
+		role  string
+		query string
+	}{
+		{
+			name:  "異常系: 所属していない会社に自分を管理者として追加",
+			query: `mutation { addMember(companyId: "1", userId: "10", role: ADMIN) { id } }`,
+		},
+		{
+			name:  "異常系: メンバーが自分を管理者に変更",
+			role:  "member",
+			query: `mutation { updateMemberRole(companyId: "1", userId: "10", role: ADMIN) { id } }`,
+		},
+		{
+			name:  "異常系: メンバーが他のユーザーを外す",
+			role:  "member",
+			query: `mutation { removeMember(companyId: "1", userId: "11") }`,
+		},
+		{
+			name:  "異常系: メンバーが会社を更新",
+			role:  "member",
+			query: `mutation { updateCompany(input: {id: "1", version: 1, name: "会社A", email: "a@example.com"}) { id } }`,
+		},
+		{
+			name:  "異常系: メンバーが会社を削除",
+			role:  "member",
+			query: `mutation { deleteCompany(id: "1") }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, deps := newTestSchemaWithUsecase(t)
+			ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 10})
+			if tt.role == "" {
+				deps.companyUserRepo.EXPECT().GetRelation(gomock.Any(), uint(10), uint(1)).Return(nil, domain.ErrNotFound)
+			} else {
+				deps.companyUserRepo.EXPECT().GetRelation(gomock.Any(), uint(10), uint(1)).
+					Return(&domain.CompanyUser{UserID: 10, CompanyID: 1, Role: tt.role}, nil)
+			}
+
+			result, err := schema.Execute(ctx, Request{Query: tt.query}, false)
+			require.NoError(t, err)
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, "forbidden", result.Errors[0].Message)
+			assert.Equal(t, "FORBIDDEN", result.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func TestSchema_Execute_NotVisible(t *testing.T) {
+	schema, deps := newTestSchemaWithUsecase(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 10})
+
+	// 所属していない会社・同じ会社に所属していないユーザーはnullを返し、取得しない
+	deps.companyUserRepo.EXPECT().Exists(gomock.Any(), uint(10), uint(2)).Return(false, nil)
+	deps.companyUserRepo.EXPECT().SharesCompany(gomock.Any(), uint(10), uint(20)).Return(false, nil)
+
+	result, err := schema.Execute(ctx, Request{Query: `{ company(id: "2") { name } user(id: "20") { email } }`}, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"company":null,"user":null}}`, resultJSON(t, result))
+}
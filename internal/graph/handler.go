@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+type GraphQLHandler struct {
+	schema *Schema
+}
+
+func NewGraphQLHandler(schema *Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+// Query godoc
+// @Summary GraphQL
+// @Description ユーザー・会社・所属をGraphQLで取得・更新します。認証はRESTと同じです。
+// @Description GETはクエリのみ（query, operationName, variablesをクエリパラメータで指定）、POSTはミューテーションも実行できます。
+// @Description ネスト・計算量が上限を超えるクエリは実行せずに400を返します
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body Request false "GraphQLのリクエスト（POST）"
+// @Param query query string false "クエリ（GET）"
+// @Param operationName query string false "オペレーション名（GET）"
+// @Param variables query string false "変数のJSON（GET）"
+// @Success 200 {object} object "GraphQLのレスポンス（data, errors）"
+// @Failure 400 {object} object "GraphQLのレスポンス（errors）"
+// @Failure 401 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /graphql [get]
+// @Router /graphql [post]
+func (h *GraphQLHandler) Query(c echo.Context) error {
+	var req Request
+	queryOnly := c.Request().Method == http.MethodGet
+	if queryOnly {
+		req.Query = c.QueryParam("query")
+		req.OperationName = c.QueryParam("operationName")
+		if variables := c.QueryParam("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return helper.ValidationErrorResponse(c, "variablesはJSONオブジェクトで指定してください")
+			}
+		}
+	} else if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if req.Query == "" {
+		return helper.ValidationErrorResponse(c, "queryは必須項目です")
+	}
+
+	ctx := c.Request().Context()
+	result, err := h.schema.Execute(ctx, req, queryOnly)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to execute graphql", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+
+	status := http.StatusOK
+	if !result.Executed {
+		status = http.StatusBadRequest
+	}
+	return c.JSON(status, result.Result)
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+)
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		body           string
+		setupMock      func(deps testDeps)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "正常系: GETでクエリを実行",
+			method: http.MethodGet,
+			query:  "?" + url.Values{"query": {`query Company($id: ID!) { company(id: $id) { name } }`}, "variables": {`{"id":"1"}`}}.Encode(),
+			setupMock: func(deps testDeps) {
+				deps.companyUserRepo.EXPECT().Exists(gomock.Any(), uint(1), uint(1)).Return(true, nil)
+				deps.companyRepo.EXPECT().
+					GetByIDs(gomock.Any(), []uint{1}).
+					Return([]domain.Company{{ID: 1, Name: "会社A"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"company":{"name":"会社A"}}}`,
+		},
+		{
+			name:   "正常系: POSTでミューテーションを実行",
+			method: http.MethodPost,
+			body:   `{"query":"mutation { deleteCompany(id: \"1\") }"}`,
+			setupMock: func(deps testDeps) {
+				deps.companyUsecase.EXPECT().DeleteCompany(gomock.Any(), uint(1)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"deleteCompany":true}}`,
+		},
+		{
+			name:           "異常系: GETでミューテーション",
+			method:         http.MethodGet,
+			query:          "?" + url.Values{"query": {`mutation { deleteCompany(id: "1") }`}}.Encode(),
+			setupMock:      func(deps testDeps) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 構文エラー",
+			method:         http.MethodPost,
+			body:           `{"query":"{ company(id: "}`,
+			setupMock:      func(deps testDeps) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: queryがない",
+			method:         http.MethodPost,
+			body:           `{}`,
+			setupMock:      func(deps testDeps) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: variablesがJSONでない",
+			method:         http.MethodGet,
+			query:          "?" + url.Values{"query": {`{ viewer { id } }`}, "variables": {"id=1"}}.Encode(),
+			setupMock:      func(deps testDeps) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, deps := newTestSchema(t, testConfig())
+			tt.setupMock(deps)
+			handler := NewGraphQLHandler(schema)
+
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+			req := httptest.NewRequest(tt.method, "/api/v1/graphql"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{UserID: 1}))
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.Query(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
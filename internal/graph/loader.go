@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"km-api-go/internal/auth"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/domain"
+	userRepo "km-api-go/internal/user/repository"
+)
+
+// Loader 同じリクエスト内で要求されたキーをまとめて1回のクエリで取得する（dataloader）
+// Loadはキーを登録するだけで、返した関数を最初に呼んだ時点で登録済みのキーをまとめて取得する。
+// 実行エンジンは同じ階層のフィールドを全て解決してから値を取り出すため、兄弟のキーが1回にまとまる
+type Loader[K comparable, V any] struct {
+	fetch   func(ctx context.Context, keys []K) (map[K]V, error)
+	mu      sync.Mutex
+	pending []K
+	entries map[K]*loaderEntry[V]
+}
+
+type loaderEntry[V any] struct {
+	value V
+	err   error
+}
+
+// NewLoader Loaderのコンストラクタ
+// fetchは取得できなかったキーを結果に含めなくてよい（ゼロ値を返す）
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, entries: make(map[K]*loaderEntry[V])}
+}
+
+// Load キーを登録し、値を取り出す関数を返す（取得済みのキーはキャッシュを返す）
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &loaderEntry[V]{}
+		l.entries[key] = entry
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.dispatch(ctx)
+		return entry.value, entry.err
+	}
+}
+
+// dispatch 登録済みで未取得のキーをまとめて取得
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.pending) == 0 {
+		return
+	}
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		entry := l.entries[key]
+		if err != nil {
+			entry.err = err
+			continue
+		}
+		entry.value = values[key]
+	}
+}
+
+// loaders リクエストごとのLoader
+type loaders struct {
+	users             *Loader[uint, *domain.User]
+	companies         *Loader[uint, *domain.Company]
+	membersByCompany  *Loader[uint, []domain.CompanyUser]
+	membershipsByUser *Loader[uint, []domain.CompanyUser]
+}
+
+func newLoaders(users userRepo.UserRepository, companies companyRepo.CompanyRepository, companyUsers companyRepo.CompanyUserRepository) *loaders {
+	return &loaders{
+		users: NewLoader(func(ctx context.Context, ids []uint) (map[uint]*domain.User, error) {
+			found, err := users.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint]*domain.User, len(found))
+			for i := range found {
+				result[found[i].ID] = &found[i]
+			}
+			return result, nil
+		}),
+		companies: NewLoader(func(ctx context.Context, ids []uint) (map[uint]*domain.Company, error) {
+			found, err := companies.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint]*domain.Company, len(found))
+			for i := range found {
+				result[found[i].ID] = &found[i]
+			}
+			return result, nil
+		}),
+		membersByCompany: NewLoader(func(ctx context.Context, ids []uint) (map[uint][]domain.CompanyUser, error) {
+			found, err := companyUsers.GetByCompanyIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint][]domain.CompanyUser, len(ids))
+			for _, cu := range found {
+				result[cu.CompanyID] = append(result[cu.CompanyID], cu)
+			}
+			return result, nil
+		}),
+		membershipsByUser: NewLoader(func(ctx context.Context, ids []uint) (map[uint][]domain.CompanyUser, error) {
+			found, err := companyUsers.GetByUserIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint][]domain.CompanyUser, len(ids))
+			for _, cu := range found {
+				result[cu.UserID] = append(result[cu.UserID], cu)
+			}
+			return result, nil
+		}),
+	}
+}
+
+// viewerCompanies 呼び出し元のユーザーが会社に所属しているか判定する関数を返す
+// 所属はmembershipsByUserで他のキーとまとめて取得する。APIキーの場合は全ての会社を参照できる
+func viewerCompanies(ctx context.Context) func() (func(companyID uint) bool, error) {
+	viewerID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return func() (func(uint) bool, error) {
+			return func(uint) bool { return true }, nil
+		}
+	}
+
+	load := loadersFromContext(ctx).membershipsByUser.Load(ctx, viewerID)
+	return func() (func(uint) bool, error) {
+		own, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return func(companyID uint) bool {
+			return slices.ContainsFunc(own, func(cu domain.CompanyUser) bool { return cu.CompanyID == companyID })
+		}, nil
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
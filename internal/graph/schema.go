@@ -0,0 +1,741 @@
+package graph
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/company"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	userRepo "km-api-go/internal/user/repository"
+)
+
+// コネクションの取得件数
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// connectionFields first・after引数でページングするフィールド
+var connectionFields = map[string]bool{
+	"users":       true,
+	"companies":   true,
+	"members":     true,
+	"memberships": true,
+}
+
+// pageSize first引数を取得件数に変換（未指定・範囲外は既定値・上限に丸める）
+func pageSize(first int) int {
+	if first <= 0 {
+		return defaultPageSize
+	}
+	return min(first, maxPageSize)
+}
+
+// connection Relayのコネクション
+type connection struct {
+	Edges    []edge
+	PageInfo pageInfo
+}
+
+type edge struct {
+	Cursor string
+	Node   interface{}
+}
+
+type pageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+// encodeCursor IDを不透明なカーソルに変換
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("cursor:" + strconv.FormatUint(uint64(id), 10)))
+}
+
+// decodeCursor カーソルをIDに変換（空の場合は0）
+func decodeCursor(cursor string) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(b), "cursor:") {
+		return 0, newError(helper.ErrorCodeValidation, "invalid cursor")
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(string(b), "cursor:"), 10, 64)
+	if err != nil {
+		return 0, newError(helper.ErrorCodeValidation, "invalid cursor")
+	}
+	return uint(id), nil
+}
+
+// newConnection 取得件数+1件取得した結果からコネクションを作成
+func newConnection[T any](items []T, first int, id func(*T) uint) *connection {
+	conn := &connection{Edges: []edge{}}
+	if len(items) > first {
+		items = items[:first]
+		conn.PageInfo.HasNextPage = true
+	}
+	for i := range items {
+		conn.Edges = append(conn.Edges, edge{Cursor: encodeCursor(id(&items[i])), Node: &items[i]})
+	}
+	if n := len(conn.Edges); n > 0 {
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+	return conn
+}
+
+// parseID ID引数をuintに変換
+func parseID(value interface{}) (uint, error) {
+	s, _ := value.(string)
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || id == 0 {
+		return 0, newError(helper.ErrorCodeValidation, fmt.Sprintf("invalid id: %q", s))
+	}
+	return uint(id), nil
+}
+
+// pageArgs first・after引数
+func pageArgs(args map[string]interface{}) (first int, afterID uint, err error) {
+	n, _ := args["first"].(int)
+	after, _ := args["after"].(string)
+	afterID, err = decodeCursor(after)
+	return pageSize(n), afterID, err
+}
+
+// Schema GraphQLのスキーマとリゾルバー
+// 参照はリクエストごとのLoaderでまとめて取得し、更新は会社ユースケースを通す（権限の確認もユースケースで行う）
+type Schema struct {
+	schema          graphql.Schema
+	companyUsecase  company.CompanyUsecase
+	userRepo        userRepo.UserRepository
+	companyRepo     companyRepo.CompanyRepository
+	companyUserRepo companyRepo.CompanyUserRepository
+	cfg             Config
+}
+
+// NewSchema GraphQLスキーマのコンストラクタ
+func NewSchema(
+	companyUsecase company.CompanyUsecase,
+	userRepository userRepo.UserRepository,
+	companyRepository companyRepo.CompanyRepository,
+	companyUserRepository companyRepo.CompanyUserRepository,
+	cfg Config,
+) (*Schema, error) {
+	s := &Schema{
+		companyUsecase:  companyUsecase,
+		userRepo:        userRepository,
+		companyRepo:     companyRepository,
+		companyUserRepo: companyUserRepository,
+		cfg:             cfg,
+	}
+
+	schema, err := s.build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build graphql schema: %w", err)
+	}
+	s.schema = schema
+	return s, nil
+}
+
+func (s *Schema) build() (graphql.Schema, error) {
+	roleEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "MembershipRole",
+		Description: "会社での役割",
+		Values: graphql.EnumValueConfigMap{
+			"ADMIN":  &graphql.EnumValueConfig{Value: "admin", Description: "管理者"},
+			"MEMBER": &graphql.EnumValueConfig{Value: "member", Description: "メンバー"},
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	connectionArgs := func(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		args := graphql.FieldConfigArgument{
+			"first": &graphql.ArgumentConfig{Type: graphql.Int, Description: fmt.Sprintf("取得件数（既定%d、最大%d）", defaultPageSize, maxPageSize)},
+			"after": &graphql.ArgumentConfig{Type: graphql.String, Description: "このカーソルより後から取得"},
+		}
+		for name, arg := range extra {
+			args[name] = arg
+		}
+		return args
+	}
+	newConnectionType := func(name string, node graphql.Output) *graphql.Object {
+		edgeType := graphql.NewObject(graphql.ObjectConfig{
+			Name: name + "Edge",
+			Fields: graphql.Fields{
+				"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"node":   &graphql.Field{Type: graphql.NewNonNull(node)},
+			},
+		})
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name: name + "Connection",
+			Fields: graphql.Fields{
+				"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType)))},
+				"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+			},
+		})
+	}
+
+	var userType, companyType, membershipType *graphql.Object
+	var membershipConnection *graphql.Object
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "ユーザー",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"email":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"memberships": &graphql.Field{
+					Type:        graphql.NewNonNull(membershipConnection),
+					Description: "所属している会社",
+					Args:        connectionArgs(nil),
+					Resolve:     s.resolveUserMemberships,
+				},
+			}
+		}),
+	})
+
+	companyType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Company",
+		Description: "会社",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"email":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"phone":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"address":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"website":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"version":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"members": &graphql.Field{
+					Type:        graphql.NewNonNull(membershipConnection),
+					Description: "所属しているユーザー",
+					Args: connectionArgs(graphql.FieldConfigArgument{
+						"role": &graphql.ArgumentConfig{Type: roleEnum, Description: "役割で絞り込み"},
+					}),
+					Resolve: s.resolveCompanyMembers,
+				},
+			}
+		}),
+	})
+
+	membershipType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Membership",
+		Description: "ユーザーの会社への所属",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"role":      &graphql.Field{Type: graphql.NewNonNull(roleEnum)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"user": &graphql.Field{
+				Type:    userType,
+				Resolve: s.resolveMembershipUser,
+			},
+			"company": &graphql.Field{
+				Type:    companyType,
+				Resolve: s.resolveMembershipCompany,
+			},
+		},
+	})
+	membershipConnection = newConnectionType("Membership", membershipType)
+
+	idArgs := graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+	}
+	searchArgs := connectionArgs(graphql.FieldConfigArgument{
+		"query": &graphql.ArgumentConfig{Type: graphql.String, Description: "部分一致で絞り込み"},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"viewer": &graphql.Field{
+				Type:        userType,
+				Description: "認証済みのユーザー（APIキーの場合はnull）",
+				Resolve:     s.resolveViewer,
+			},
+			"user": &graphql.Field{
+				Type:        userType,
+				Description: "ユーザー（ユーザーの場合は本人と同じ会社に所属するユーザーのみ）",
+				Args:        idArgs,
+				Resolve:     s.resolveUser,
+			},
+			"company": &graphql.Field{
+				Type:        companyType,
+				Description: "会社（ユーザーの場合は所属する会社のみ）",
+				Args:        idArgs,
+				Resolve:     s.resolveCompany,
+			},
+			"users": &graphql.Field{
+				Type:        graphql.NewNonNull(newConnectionType("User", userType)),
+				Description: "ユーザー一覧（ユーザーの場合は本人と同じ会社に所属するユーザーのみ）",
+				Args:        searchArgs,
+				Resolve:     s.resolveUsers,
+			},
+			"companies": &graphql.Field{
+				Type:        graphql.NewNonNull(newConnectionType("Company", companyType)),
+				Description: "会社一覧（ユーザーの場合は所属する会社のみ）",
+				Args:        searchArgs,
+				Resolve:     s.resolveCompanies,
+			},
+		},
+	})
+
+	companyInputFields := func(withID bool) graphql.InputObjectConfigFieldMap {
+		fields := graphql.InputObjectConfigFieldMap{
+			"name":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"email":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"phone":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"address":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"website":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		}
+		if withID {
+			fields["id"] = &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)}
+			fields["version"] = &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int), Description: "取得したバージョン（一致するときのみ更新する。RESTのIf-Matchに相当）"}
+		}
+		return fields
+	}
+	membershipArgs := graphql.FieldConfigArgument{
+		"companyId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"userId":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+	}
+	membershipRoleArgs := graphql.FieldConfigArgument{
+		"companyId": membershipArgs["companyId"],
+		"userId":    membershipArgs["userId"],
+		"role":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(roleEnum)},
+	}
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createCompany": &graphql.Field{
+				Type: graphql.NewNonNull(companyType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name:   "CreateCompanyInput",
+						Fields: companyInputFields(false),
+					}))},
+				},
+				Resolve: s.resolveCreateCompany,
+			},
+			"updateCompany": &graphql.Field{
+				Type: graphql.NewNonNull(companyType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name:   "UpdateCompanyInput",
+						Fields: companyInputFields(true),
+					}))},
+				},
+				Resolve: s.resolveUpdateCompany,
+			},
+			"deleteCompany": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    idArgs,
+				Resolve: s.resolveDeleteCompany,
+			},
+			"addMember": &graphql.Field{
+				Type:    graphql.NewNonNull(membershipType),
+				Args:    membershipRoleArgs,
+				Resolve: s.resolveAddMember,
+			},
+			"updateMemberRole": &graphql.Field{
+				Type:    graphql.NewNonNull(membershipType),
+				Args:    membershipRoleArgs,
+				Resolve: s.resolveUpdateMemberRole,
+			},
+			"removeMember": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    membershipArgs,
+				Resolve: s.resolveRemoveMember,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// thunk Loaderの取り出し関数を実行エンジンが後から呼ぶ形式に変換
+func thunk[V any](load func() (V, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		v, err := load()
+		if err != nil {
+			return nil, toError(err)
+		}
+		return v, nil
+	}
+}
+
+func (s *Schema) resolveViewer(p graphql.ResolveParams) (interface{}, error) {
+	userID, ok := auth.UserIDFromContext(p.Context)
+	if !ok {
+		return nil, nil
+	}
+	return thunk(loadersFromContext(p.Context).users.Load(p.Context, userID)), nil
+}
+
+// resolveUser ユーザーの場合はusersと同じく本人と同じ会社に所属するユーザーのみ返す（それ以外はnull）
+func (s *Schema) resolveUser(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	if viewerID, ok := auth.UserIDFromContext(p.Context); ok && viewerID != id {
+		visible, err := s.companyUserRepo.SharesCompany(p.Context, viewerID, id)
+		if err != nil {
+			return nil, toError(err)
+		}
+		if !visible {
+			return nil, nil
+		}
+	}
+	return thunk(loadersFromContext(p.Context).users.Load(p.Context, id)), nil
+}
+
+// resolveCompany ユーザーの場合はcompaniesと同じく所属する会社のみ返す（それ以外はnull）
+func (s *Schema) resolveCompany(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	if viewerID, ok := auth.UserIDFromContext(p.Context); ok {
+		visible, err := s.companyUserRepo.Exists(p.Context, viewerID, id)
+		if err != nil {
+			return nil, toError(err)
+		}
+		if !visible {
+			return nil, nil
+		}
+	}
+	return thunk(loadersFromContext(p.Context).companies.Load(p.Context, id)), nil
+}
+
+func (s *Schema) resolveUsers(p graphql.ResolveParams) (interface{}, error) {
+	first, afterID, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	query, _ := p.Args["query"].(string)
+	userID, _ := auth.UserIDFromContext(p.Context)
+
+	users, err := s.userRepo.ListAfter(p.Context, domain.UserFilter{Query: query, VisibleTo: userID}, afterID, first+1)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return newConnection(users, first, func(u *domain.User) uint { return u.ID }), nil
+}
+
+func (s *Schema) resolveCompanies(p graphql.ResolveParams) (interface{}, error) {
+	first, afterID, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	query, _ := p.Args["query"].(string)
+	userID, _ := auth.UserIDFromContext(p.Context)
+
+	companies, err := s.companyRepo.ListAfter(p.Context, domain.CompanyFilter{Query: query, VisibleTo: userID}, afterID, first+1)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return newConnection(companies, first, func(c *domain.Company) uint { return c.ID }), nil
+}
+
+// resolveCompanyMembers ユーザーの場合は所属する会社のみ返す（それ以外は空）
+func (s *Schema) resolveCompanyMembers(p graphql.ResolveParams) (interface{}, error) {
+	c := p.Source.(*domain.Company)
+	first, afterID, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	role, _ := p.Args["role"].(string)
+
+	member := viewerCompanies(p.Context)
+	load := loadersFromContext(p.Context).membersByCompany.Load(p.Context, c.ID)
+	return thunk(func() (*connection, error) {
+		isMember, err := member()
+		if err != nil {
+			return nil, err
+		}
+		if !isMember(c.ID) {
+			return membershipPage(nil, first, afterID, role), nil
+		}
+		members, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return membershipPage(members, first, afterID, role), nil
+	}), nil
+}
+
+// resolveUserMemberships 本人以外のユーザーの場合は呼び出し元も所属している会社の所属のみ返す
+func (s *Schema) resolveUserMemberships(p graphql.ResolveParams) (interface{}, error) {
+	u := p.Source.(*domain.User)
+	first, afterID, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	viewerID, _ := auth.UserIDFromContext(p.Context)
+
+	member := viewerCompanies(p.Context)
+	load := loadersFromContext(p.Context).membershipsByUser.Load(p.Context, u.ID)
+	return thunk(func() (*connection, error) {
+		isMember, err := member()
+		if err != nil {
+			return nil, err
+		}
+		memberships, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if u.ID != viewerID {
+			memberships = slices.DeleteFunc(slices.Clone(memberships), func(cu domain.CompanyUser) bool {
+				return !isMember(cu.CompanyID)
+			})
+		}
+		return membershipPage(memberships, first, afterID, ""), nil
+	}), nil
+}
+
+// membershipPage まとめて取得した所属（ID順）からページを切り出す
+func membershipPage(memberships []domain.CompanyUser, first int, afterID uint, role string) *connection {
+	var page []domain.CompanyUser
+	for _, cu := range memberships {
+		if cu.ID <= afterID || (role != "" && cu.Role != role) {
+			continue
+		}
+		page = append(page, cu)
+		if len(page) > first {
+			break
+		}
+	}
+	return newConnection(page, first, func(cu *domain.CompanyUser) uint { return cu.ID })
+}
+
+// resolveMembershipUser 本人か、呼び出し元も所属している会社の所属の場合のみ返す（それ以外はnull）
+func (s *Schema) resolveMembershipUser(p graphql.ResolveParams) (interface{}, error) {
+	cu := p.Source.(*domain.CompanyUser)
+	viewerID, _ := auth.UserIDFromContext(p.Context)
+
+	member := viewerCompanies(p.Context)
+	load := loadersFromContext(p.Context).users.Load(p.Context, cu.UserID)
+	return thunk(func() (*domain.User, error) {
+		isMember, err := member()
+		if err != nil {
+			return nil, err
+		}
+		if cu.UserID != viewerID && !isMember(cu.CompanyID) {
+			return nil, nil
+		}
+		return load()
+	}), nil
+}
+
+// resolveMembershipCompany 呼び出し元も所属している会社の場合のみ返す（それ以外はnull）
+func (s *Schema) resolveMembershipCompany(p graphql.ResolveParams) (interface{}, error) {
+	cu := p.Source.(*domain.CompanyUser)
+
+	member := viewerCompanies(p.Context)
+	load := loadersFromContext(p.Context).companies.Load(p.Context, cu.CompanyID)
+	return thunk(func() (*domain.Company, error) {
+		isMember, err := member()
+		if err != nil {
+			return nil, err
+		}
+		if !isMember(cu.CompanyID) {
+			return nil, nil
+		}
+		return load()
+	}), nil
+}
+
+func (s *Schema) resolveCreateCompany(p graphql.ResolveParams) (interface{}, error) {
+	input, _ := p.Args["input"].(map[string]interface{})
+	req := company.CreateCompanyRequest{
+		Name:        stringArg(input, "name"),
+		Email:       stringArg(input, "email"),
+		Phone:       stringArg(input, "phone"),
+		Address:     stringArg(input, "address"),
+		Website:     stringArg(input, "website"),
+		Description: stringArg(input, "description"),
+	}
+	if err := helper.ValidateStruct(&req); err != nil {
+		return nil, toError(err)
+	}
+
+	c, err := s.companyUsecase.CreateCompany(p.Context, req.Name, req.Email, req.Phone, req.Address, req.Website, req.Description)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return c, nil
+}
+
+func (s *Schema) resolveUpdateCompany(p graphql.ResolveParams) (interface{}, error) {
+	input, _ := p.Args["input"].(map[string]interface{})
+	id, err := parseID(input["id"])
+	if err != nil {
+		return nil, err
+	}
+	version, _ := input["version"].(int)
+	if version < 1 {
+		return nil, newError(helper.ErrorCodeValidation, "invalid version")
+	}
+	req := company.UpdateCompanyRequest{
+		Name:        stringArg(input, "name"),
+		Email:       stringArg(input, "email"),
+		Phone:       stringArg(input, "phone"),
+		Address:     stringArg(input, "address"),
+		Website:     stringArg(input, "website"),
+		Description: stringArg(input, "description"),
+	}
+	if err := helper.ValidateStruct(&req); err != nil {
+		return nil, toError(err)
+	}
+
+	c, err := s.companyUsecase.UpdateCompany(p.Context, id, uint(version), req.Name, req.Email, req.Phone, req.Address, req.Website, req.Description)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return c, nil
+}
+
+func (s *Schema) resolveDeleteCompany(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	if err := s.companyUsecase.DeleteCompany(p.Context, id); err != nil {
+		return nil, toError(err)
+	}
+	return true, nil
+}
+
+func (s *Schema) resolveAddMember(p graphql.ResolveParams) (interface{}, error) {
+	companyID, userID, err := membershipIDs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	cu, err := s.companyUsecase.AddUserToCompany(p.Context, userID, companyID, stringArg(p.Args, "role"))
+	if err != nil {
+		return nil, toError(err)
+	}
+	return cu, nil
+}
+
+func (s *Schema) resolveUpdateMemberRole(p graphql.ResolveParams) (interface{}, error) {
+	companyID, userID, err := membershipIDs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	cu, err := s.companyUsecase.UpdateUserRole(p.Context, userID, companyID, stringArg(p.Args, "role"))
+	if err != nil {
+		return nil, toError(err)
+	}
+	return cu, nil
+}
+
+func (s *Schema) resolveRemoveMember(p graphql.ResolveParams) (interface{}, error) {
+	companyID, userID, err := membershipIDs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.companyUsecase.RemoveUserFromCompany(p.Context, userID, companyID); err != nil {
+		return nil, toError(err)
+	}
+	return true, nil
+}
+
+func membershipIDs(args map[string]interface{}) (companyID, userID uint, err error) {
+	if companyID, err = parseID(args["companyId"]); err != nil {
+		return 0, 0, err
+	}
+	if userID, err = parseID(args["userId"]); err != nil {
+		return 0, 0, err
+	}
+	return companyID, userID, nil
+}
+
+func stringArg(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+// Error 拡張（extensions.code）にエラーコードを含むGraphQLのエラー
+type Error struct {
+	Code    helper.ErrorCode
+	Message string
+}
+
+func newError(code helper.ErrorCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions gqlerrors.ExtendedErrorの実装
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code.String()}
+}
+
+// toError ユースケース・リポジトリのエラーをGraphQLのエラーに変換
+// 想定外のエラーは内部の詳細を返さない
+func toError(err error) error {
+	var gqlErr *Error
+	var validationErrs helper.ValidationErrors
+	switch {
+	case errors.As(err, &gqlErr):
+		return gqlErr
+	case errors.As(err, &validationErrs):
+		return newError(helper.ErrorCodeValidation, validationErrs.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return newError(helper.ErrorCodeNotFound, err.Error())
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return newError(helper.ErrorCodeAlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrVersionConflict):
+		return newError(helper.ErrorCodePreconditionFailed, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return newError(helper.ErrorCodeForbidden, "forbidden")
+	}
+	return &internalError{err: err}
+}
+
+// internalError 想定外のエラー（ログには元のエラーを出し、レスポンスには出さない）
+type internalError struct {
+	err error
+}
+
+func (e *internalError) Error() string {
+	return "internal error"
+}
+
+func (e *internalError) Unwrap() error {
+	return e.err
+}
+
+func (e *internalError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": helper.ErrorCodeInternalError.String()}
+}
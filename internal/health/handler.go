@@ -0,0 +1,38 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthHandler struct {
+	health *Health
+}
+
+func NewHealthHandler(health *Health) *HealthHandler {
+	return &HealthHandler{health: health}
+}
+
+// Livez ライブネスチェック（/livez）
+// プロセスが応答可能かを返す（依存先は確認しない）。APIのベースパスの外にあるため仕様には載せない
+func (h *HealthHandler) Livez(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.health.Liveness())
+}
+
+// Readyz godoc
+// @Summary レディネスチェック
+// @ID getHealth
+// @Description データベース・マイグレーション・シャットダウン状態を確認し、チェックごとの結果と所要時間を返します（/readyz と同じ）
+// @Tags health
+// @Produce json
+// @Success 200 {object} Report
+// @Failure 503 {object} Report
+// @Router /health [get]
+func (h *HealthHandler) Readyz(c echo.Context) error {
+	report := h.health.Readiness(c.Request().Context())
+	if report.Status != StatusOK {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,166 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"km-api-go/internal/infra"
+)
+
+// チェック結果のステータス
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ErrShuttingDown グレースフルシャットダウン中
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// CheckFunc 依存先の状態を確認する関数（異常時はエラーを返す）
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult 個別チェックの結果
+type CheckResult struct {
+	Name      string  `json:"name" example:"database"`   // チェック名
+	Status    string  `json:"status" example:"ok"`       // ok, fail
+	LatencyMs float64 `json:"latency_ms" example:"1.25"` // 所要時間（ミリ秒）
+	Error     string  `json:"error,omitempty"`           // 失敗理由
+}
+
+// Report ヘルスチェック全体の結果
+type Report struct {
+	Status string        `json:"status" example:"ok"` // ok, fail
+	Checks []CheckResult `json:"checks,omitempty"`    // 個別チェックの結果
+}
+
+type checker struct {
+	name  string
+	check CheckFunc
+}
+
+// Health 登録されたチェッカーでレディネスを判定する
+type Health struct {
+	mu           sync.RWMutex
+	checkers     []checker
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// New ヘルスチェッカーのコンストラクタ
+// timeoutは各チェックに与える最大時間
+func New(timeout time.Duration) *Health {
+	return &Health{timeout: timeout}
+}
+
+// Register レディネスチェックを登録
+func (h *Health) Register(name string, check CheckFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkers = append(h.checkers, checker{name: name, check: check})
+}
+
+// MarkShuttingDown シャットダウン開始を記録し、以降レディネスを失敗させる
+func (h *Health) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Liveness プロセスが応答可能かを返す（依存先は確認しない）
+func (h *Health) Liveness() Report {
+	return Report{Status: StatusOK}
+}
+
+// Readiness 登録されたチェックを並行実行し、結果を登録順に返す
+func (h *Health) Readiness(ctx context.Context) Report {
+	h.mu.RLock()
+	checkers := append([]checker{{name: "shutdown", check: h.checkShutdown}}, h.checkers...)
+	h.mu.RUnlock()
+
+	results := make([]CheckResult, len(checkers))
+	var wg sync.WaitGroup
+	for i, c := range checkers {
+		wg.Add(1)
+		go func(i int, c checker) {
+			defer wg.Done()
+			results[i] = h.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, r := range results {
+		if r.Status != StatusOK {
+			report.Status = StatusFail
+			break
+		}
+	}
+	return report
+}
+
+// run タイムアウト付きでチェックを実行
+func (h *Health) run(ctx context.Context, c checker) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		errCh <- c.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = fmt.Errorf("check timed out after %s", h.timeout)
+	}
+
+	result := CheckResult{
+		Name:      c.name,
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (h *Health) checkShutdown(context.Context) error {
+	if h.shuttingDown.Load() {
+		return ErrShuttingDown
+	}
+	return nil
+}
+
+// DatabaseCheck データベースへのPingチェック
+func DatabaseCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return infra.PingDatabase(ctx, db)
+	}
+}
+
+// MigrationCheck 未適用のマイグレーションがないかのチェック
+func MigrationCheck(db *gorm.DB, dir string) CheckFunc {
+	return func(ctx context.Context) error {
+		pending, err := infra.PendingMigrations(ctx, db, dir)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migrations (next: %s)", len(pending), pending[0].Version)
+		}
+		return nil
+	}
+}
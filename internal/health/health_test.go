@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth_Readiness(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(h *Health)
+		expectStatus string
+		expectFailed []string
+	}{
+		{
+			name: "正常系: すべてのチェックが成功",
+			setup: func(h *Health) {
+				h.Register("database", func(ctx context.Context) error { return nil })
+			},
+			expectStatus: StatusOK,
+		},
+		{
+			name: "異常系: チェックがエラーを返す",
+			setup: func(h *Health) {
+				h.Register("database", func(ctx context.Context) error { return errors.New("connection refused") })
+				h.Register("migrations", func(ctx context.Context) error { return nil })
+			},
+			expectStatus: StatusFail,
+			expectFailed: []string{"database"},
+		},
+		{
+			name: "異常系: チェックがタイムアウト",
+			setup: func(h *Health) {
+				h.Register("database", func(ctx context.Context) error {
+					<-ctx.Done()
+					time.Sleep(10 * time.Millisecond)
+					return nil
+				})
+			},
+			expectStatus: StatusFail,
+			expectFailed: []string{"database"},
+		},
+		{
+			name: "異常系: シャットダウン中はレディネスが失敗",
+			setup: func(h *Health) {
+				h.Register("database", func(ctx context.Context) error { return nil })
+				h.MarkShuttingDown()
+			},
+			expectStatus: StatusFail,
+			expectFailed: []string{"shutdown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(50 * time.Millisecond)
+			tt.setup(h)
+
+			report := h.Readiness(context.Background())
+
+			assert.Equal(t, tt.expectStatus, report.Status)
+			var failed []string
+			for _, c := range report.Checks {
+				if c.Status != StatusOK {
+					failed = append(failed, c.Name)
+					assert.NotEmpty(t, c.Error)
+				}
+			}
+			assert.Equal(t, tt.expectFailed, failed)
+		})
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	h := New(time.Second)
+	h.Register("database", func(ctx context.Context) error { return nil })
+	handler := NewHealthHandler(h)
+
+	e := echo.New()
+	e.GET("/livez", handler.Livez)
+	e.GET("/readyz", handler.Readyz)
+
+	serve := func(path string) (int, Report) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		var report Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		return rec.Code, report
+	}
+
+	code, report := serve("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, report.Checks, 2)
+
+	// シャットダウン開始後はレディネスのみ失敗し、ライブネスは成功のまま
+	h.MarkShuttingDown()
+
+	code, report = serve("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusFail, report.Status)
+
+	code, report = serve("/livez")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, report.Status)
+}
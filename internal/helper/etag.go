@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 条件付きリクエストのヘッダー
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// ETag バージョンからETagを作成
+func ETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// SetETag レスポンスにETagを設定
+func SetETag(c echo.Context, version uint) {
+	c.Response().Header().Set(HeaderETag, ETag(version))
+}
+
+// NotModified If-None-MatchがETagと一致するか確認（一致すれば304を返すべき）
+func NotModified(c echo.Context, version uint) bool {
+	header := c.Request().Header.Get(HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+	etag := ETag(version)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// NotModifiedResponse 304レスポンス（ETagのみ返す）
+func NotModifiedResponse(c echo.Context, version uint) error {
+	SetETag(c, version)
+	return c.NoContent(http.StatusNotModified)
+}
+
+// IfMatchVersion If-Matchヘッダーからバージョンを取得
+// 「*」の場合は0を返す。ヘッダーがない・形式が不正な場合はokがfalse
+func IfMatchVersion(c echo.Context) (version uint, ok bool) {
+	header := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if header == "*" {
+		return 0, true
+	}
+	// 弱いETagは更新の条件に使えない（RFC 9110 13.1.1）
+	if len(header) < 3 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(header[1:len(header)-1], 10, 0)
+	if err != nil || v == 0 {
+		return 0, false
+	}
+	return uint(v), true
+}
+
+// PreconditionRequiredResponse If-Matchがない更新リクエストへの428エラーレスポンス
+func PreconditionRequiredResponse(c echo.Context) error {
+	return ErrorResponse(c, http.StatusPreconditionRequired, ErrorCodePreconditionRequired,
+		"If-Matchヘッダーが必要です", "取得時のETagをIf-Matchヘッダーに指定してください")
+}
+
+// PreconditionFailedResponse バージョン不一致の412エラーレスポンス
+func PreconditionFailedResponse(c echo.Context) error {
+	return ErrorResponse(c, http.StatusPreconditionFailed, ErrorCodePreconditionFailed,
+		"リソースは他のリクエストで更新されています", "最新のリソースを取得してから再度更新してください")
+}
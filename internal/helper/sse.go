@@ -0,0 +1,4 @@
+package helper
+
+// HeaderLastEventID Server-Sent Eventsの再接続時にクライアントが最後に受け取ったイベントIDを渡すヘッダー
+const HeaderLastEventID = "Last-Event-ID"
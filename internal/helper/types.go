@@ -88,18 +88,37 @@ type IDRequest struct {
 	ID uint `param:"id" validate:"required,min=1" example:"1"` // エンティティID
 }
 
+// SparseRequest 取得するフィールドと展開する関連の指定
+type SparseRequest struct {
+	Fields  string `query:"fields" validate:"omitempty,max=500" example:"id,name"`          // 返すフィールド（カンマ区切り、既定は全フィールド）
+	Include string `query:"include" validate:"omitempty,max=200" example:"members.user"` // 展開する関連（カンマ区切り）
+}
+
 // ErrorCode エラーコード
 type ErrorCode string
 
 const (
-	ErrorCodeValidation      ErrorCode = "VALIDATION_ERROR"
-	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
-	ErrorCodeAlreadyExists   ErrorCode = "ALREADY_EXISTS"
-	ErrorCodeUnauthorized    ErrorCode = "UNAUTHORIZED"
-	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
-	ErrorCodeInternalError   ErrorCode = "INTERNAL_ERROR"
-	ErrorCodeDatabaseError   ErrorCode = "DATABASE_ERROR"
-	ErrorCodeExternalAPI     ErrorCode = "EXTERNAL_API_ERROR"
+	ErrorCodeValidation           ErrorCode = "VALIDATION_ERROR"
+	ErrorCodeNotFound             ErrorCode = "NOT_FOUND"
+	ErrorCodeAlreadyExists        ErrorCode = "ALREADY_EXISTS"
+	ErrorCodeConflict             ErrorCode = "CONFLICT"
+	ErrorCodeUnauthorized         ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden            ErrorCode = "FORBIDDEN"
+	ErrorCodeInternalError        ErrorCode = "INTERNAL_ERROR"
+	ErrorCodeDatabaseError        ErrorCode = "DATABASE_ERROR"
+	ErrorCodeExternalAPI          ErrorCode = "EXTERNAL_API_ERROR"
+	ErrorCodeRateLimited          ErrorCode = "RATE_LIMITED"
+	ErrorCodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeRequestInProgress    ErrorCode = "REQUEST_IN_PROGRESS"
+	ErrorCodePreconditionFailed   ErrorCode = "PRECONDITION_FAILED"
+	ErrorCodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+	ErrorCodeUnsupportedMedia     ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	ErrorCodeServiceUnavailable   ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrorCodeContractViolation    ErrorCode = "CONTRACT_VIOLATION"
+	ErrorCodeBatchFailed          ErrorCode = "BATCH_FAILED"
+	ErrorCodePayloadTooLarge      ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrorCodeQuotaExceeded        ErrorCode = "QUOTA_EXCEEDED"
+	ErrorCodeInfected             ErrorCode = "INFECTED_FILE"
 )
 
 // String ErrorCodeの文字列表現
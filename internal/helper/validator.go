@@ -14,11 +14,25 @@ type CustomValidator struct {
 
 // NewValidator バリデーターを初期化
 func NewValidator() *CustomValidator {
+	v := validator.New()
+	_ = v.RegisterValidation("kana", isKana)
 	return &CustomValidator{
-		validator: validator.New(),
+		validator: v,
 	}
 }
 
+// isKana カタカナ・ひらがな・長音符・空白のみか（ふりがな用）
+func isKana(fl validator.FieldLevel) bool {
+	for _, r := range fl.Field().String() {
+		switch {
+		case r >= 'ァ' && r <= 'ヺ', r >= 'ぁ' && r <= 'ゖ', r == 'ー', r == '・', r == ' ', r == '　':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // Validate バリデーションを実行
 func (cv *CustomValidator) Validate(i interface{}) error {
 	if err := cv.validator.Struct(i); err != nil {
@@ -27,6 +41,14 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	return nil
 }
 
+// ValidatePartial 指定したフィールドのみバリデーションを実行（部分更新用）
+func (cv *CustomValidator) ValidatePartial(i interface{}, fields ...string) error {
+	if err := cv.validator.StructPartial(i, fields...); err != nil {
+		return FormatValidationErrors(err)
+	}
+	return nil
+}
+
 // ValidationError カスタムバリデーションエラー
 type ValidationError struct {
 	Field   string `json:"field"`   // フィールド名
@@ -91,6 +113,12 @@ func generateErrorMessage(fe validator.FieldError) string {
 		return fmt.Sprintf("%sはアルファベットのみ入力可能です", field)
 	case "alphanum":
 		return fmt.Sprintf("%sは英数字のみ入力可能です", field)
+	case "kana":
+		return fmt.Sprintf("%sはカタカナまたはひらがなで入力してください", field)
+	case "bcp47_language_tag":
+		return fmt.Sprintf("%sは言語タグ（例: ja-JP）を指定してください", field)
+	case "timezone":
+		return fmt.Sprintf("%sはタイムゾーン名（例: Asia/Tokyo）を指定してください", field)
 	default:
 		return fmt.Sprintf("%sの入力値が正しくありません", field)
 	}
@@ -112,4 +140,10 @@ func GetValidationErrorDetails(err error) string {
 func ValidateStruct(s interface{}) error {
 	validator := NewValidator()
 	return validator.Validate(s)
-}
\ No newline at end of file
+}
+
+// ValidateStructPartial 構造体の指定したフィールドのみバリデーション
+func ValidateStructPartial(s interface{}, fields ...string) error {
+	validator := NewValidator()
+	return validator.ValidatePartial(s, fields...)
+}
@@ -0,0 +1,33 @@
+package idempotency
+
+import (
+	"strings"
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// 保存先の種別
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// Config Idempotency-Key設定
+type Config struct {
+	Enabled bool          // Idempotency-Keyを処理するか
+	Backend string        // memory, postgres
+	TTL     time.Duration // 保存したレスポンスの有効期間
+	// LockTimeout 処理中のまま放置されたキーを再試行で確保し直せるまでの時間（TTLより十分短く、最も長いリクエストより長くする）
+	LockTimeout time.Duration
+}
+
+// LoadConfig 環境変数からIdempotency-Key設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		Enabled:     infra.GetEnvBool("IDEMPOTENCY_ENABLED", true),
+		Backend:     strings.ToLower(infra.GetEnv("IDEMPOTENCY_BACKEND", BackendPostgres)),
+		TTL:         infra.GetEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		LockTimeout: infra.GetEnvDuration("IDEMPOTENCY_LOCK_TIMEOUT", time.Minute),
+	}
+}
@@ -0,0 +1,52 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Record Idempotency-Keyごとに保存するリクエストの指紋と最初のレスポンス
+type Record struct {
+	Key         string            // スコープ付きのキー
+	Fingerprint string            // リクエストの指紋（メソッド・パス・ボディのハッシュ）
+	Completed   bool              // レスポンスが保存済みか（falseは処理中）
+	StatusCode  int               // 保存したレスポンスのステータスコード
+	ContentType string            // 保存したレスポンスのContent-Type
+	Headers     map[string]string // 保存したレスポンスヘッダー（ETag・Location）
+	Body        []byte            // 保存したレスポンスボディ
+	CreatedAt   time.Time         // 確保した日時（処理中の場合は処理を開始した日時）
+	ExpiresAt   time.Time         // 有効期限
+}
+
+// Response 保存するレスポンス
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+}
+
+// Store Idempotency-Keyの保存先
+type Store interface {
+	// Acquire キーが未使用（または期限切れ）なら処理中として確保しtrueを返す
+	// 処理中のままlockTimeoutを過ぎたキー（処理したインスタンスが停止した等）も確保し直す
+	// 既に使われている場合は保存済みの記録とfalseを返す
+	Acquire(ctx context.Context, key, fingerprint string, ttl, lockTimeout time.Duration) (*Record, bool, error)
+	// Complete 処理結果のレスポンスを保存
+	Complete(ctx context.Context, key string, resp Response) error
+	// Release 確保したキーを解放し、再試行で処理し直せるようにする
+	Release(ctx context.Context, key string) error
+}
+
+// Fingerprint リクエストの指紋を計算
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
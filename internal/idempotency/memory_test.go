@@ -0,0 +1,48 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Acquire(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, acquired, err := store.Acquire(ctx, "k1", "fp", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// 処理中のキーはロックの期限までは確保できない
+	now = now.Add(59 * time.Second)
+	record, acquired, err := store.Acquire(ctx, "k1", "fp", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	assert.False(t, record.Completed)
+
+	// 処理中のままロックの期限を過ぎたキーは再試行で確保し直せる
+	now = now.Add(time.Second)
+	_, acquired, err = store.Acquire(ctx, "k1", "fp", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// 保存済みのレスポンスはロックの期限を過ぎても有効期限まで返す
+	require.NoError(t, store.Complete(ctx, "k1", Response{StatusCode: 201}))
+	now = now.Add(30 * time.Minute)
+	record, acquired, err = store.Acquire(ctx, "k1", "fp", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	assert.Equal(t, 201, record.StatusCode)
+
+	// 有効期限を過ぎた記録は確保し直せる
+	now = now.Add(30 * time.Minute)
+	_, acquired, err = store.Acquire(ctx, "k1", "fp", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
@@ -0,0 +1,140 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"km-api-go/internal/infra"
+)
+
+// acquireSQL 未使用・期限切れ・処理中のままlocked_beforeより前に確保されたキーのみ確保する
+// 有効な記録が既にある場合は更新せず、行を返さない
+const acquireSQL = `
+INSERT INTO idempotency_keys AS k (key, fingerprint, completed, status_code, content_type, headers, body, created_at, expires_at)
+VALUES (@key, @fingerprint, FALSE, 0, '', '{}', NULL, @now, @expires_at)
+ON CONFLICT (key) DO UPDATE SET
+    fingerprint = EXCLUDED.fingerprint,
+    completed = FALSE,
+    status_code = 0,
+    content_type = '',
+    headers = '{}',
+    body = NULL,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE k.expires_at <= EXCLUDED.created_at OR (NOT k.completed AND k.created_at <= @locked_before)
+RETURNING key`
+
+// idempotencyKeyRow idempotency_keysテーブルの行
+type idempotencyKeyRow struct {
+	Key         string
+	Fingerprint string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Headers     []byte
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (idempotencyKeyRow) TableName() string {
+	return "idempotency_keys"
+}
+
+// PostgresStore idempotency_keysテーブルを使う保存先（複数インスタンス向け）
+type PostgresStore struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewPostgresStore Postgres保存先のコンストラクタ
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db, now: time.Now}
+}
+
+func (s *PostgresStore) Acquire(ctx context.Context, key, fingerprint string, ttl, lockTimeout time.Duration) (*Record, bool, error) {
+	now := s.now()
+
+	var acquired []string
+	err := infra.Conn(ctx, s.db).Raw(acquireSQL,
+		sql.Named("key", key),
+		sql.Named("fingerprint", fingerprint),
+		sql.Named("now", now),
+		sql.Named("expires_at", now.Add(ttl)),
+		sql.Named("locked_before", now.Add(-lockTimeout)),
+	).Scan(&acquired).Error
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire idempotency key: %w", err)
+	}
+	if len(acquired) > 0 {
+		return nil, true, nil
+	}
+
+	var row idempotencyKeyRow
+	if err := infra.Conn(ctx, s.db).Where("key = ?", key).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 確保と取得の間に解放された
+			return nil, false, fmt.Errorf("idempotency key was released concurrently: %w", err)
+		}
+		return nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	var headers map[string]string
+	if len(row.Headers) > 0 {
+		if err := json.Unmarshal(row.Headers, &headers); err != nil {
+			return nil, false, fmt.Errorf("failed to decode idempotent response headers: %w", err)
+		}
+	}
+
+	return &Record{
+		Key:         row.Key,
+		Fingerprint: row.Fingerprint,
+		Completed:   row.Completed,
+		StatusCode:  row.StatusCode,
+		ContentType: row.ContentType,
+		Headers:     headers,
+		Body:        row.Body,
+		CreatedAt:   row.CreatedAt,
+		ExpiresAt:   row.ExpiresAt,
+	}, false, nil
+}
+
+func (s *PostgresStore) Complete(ctx context.Context, key string, resp Response) error {
+	headers, err := json.Marshal(resp.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode idempotent response headers: %w", err)
+	}
+	err = infra.Conn(ctx, s.db).Model(&idempotencyKeyRow{}).Where("key = ?", key).Updates(map[string]interface{}{
+		"completed":    true,
+		"status_code":  resp.StatusCode,
+		"content_type": resp.ContentType,
+		"headers":      string(headers),
+		"body":         resp.Body,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Release(ctx context.Context, key string) error {
+	if err := infra.Conn(ctx, s.db).Where("key = ?", key).Delete(&idempotencyKeyRow{}).Error; err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Cleanup 期限切れの記録を削除
+func (s *PostgresStore) Cleanup(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", s.now()).Delete(&idempotencyKeyRow{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to clean up idempotency keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
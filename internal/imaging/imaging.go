@@ -0,0 +1,158 @@
+// Package imaging アップロードされた画像の検証・向きの補正・縮小
+//
+// 外部コマンドやcgoに依存せず、標準ライブラリとgolang.org/x/imageだけで処理する
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // image.DecodeでWebPを読めるようにする
+)
+
+var (
+	// ErrUnsupportedType 対応していない形式（内容から判定する）
+	ErrUnsupportedType = errors.New("unsupported image type")
+	// ErrTooLarge 画像の縦横のピクセル数が上限を超えている
+	ErrTooLarge = errors.New("image dimensions too large")
+	// ErrInvalidImage 画像として読み込めない
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// 受け付ける画像の形式（Content-Type）
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeGIF  = "image/gif"
+	TypeWebP = "image/webp"
+)
+
+// Extension 保存する画像のContent-Typeの拡張子
+func Extension(contentType string) string {
+	switch contentType {
+	case TypeJPEG:
+		return ".jpg"
+	case TypePNG:
+		return ".png"
+	case TypeGIF:
+		return ".gif"
+	case TypeWebP:
+		return ".webp"
+	}
+	return ""
+}
+
+// Sniff 内容の先頭から画像の形式を判定する（クライアントが送ったContent-Typeは信用しない）
+func Sniff(data []byte) (string, error) {
+	switch contentType := http.DetectContentType(data); contentType {
+	case TypeJPEG, TypePNG, TypeGIF, TypeWebP:
+		return contentType, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+}
+
+// Decode 画像を検証して読み込み、EXIFの向き（JPEG）を補正する
+// 展開後のメモリを抑えるため、ピクセル数（幅×高さ）がmaxPixelsを超える画像は読み込まずにErrTooLargeを返す
+func Decode(data []byte, maxPixels int) (image.Image, string, error) {
+	contentType, err := Sniff(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, "", fmt.Errorf("%w: empty image", ErrInvalidImage)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, "", fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if contentType == TypeJPEG {
+		img = orient(img, exifOrientation(data))
+	}
+	return img, contentType, nil
+}
+
+// Fit 縦横比を保ってsize×sizeに収まるよう縮小する（小さい画像は拡大しない）
+func Fit(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return toRGBA(img, b)
+	}
+	if w >= h {
+		h, w = max(h*size/w, 1), size
+	} else {
+		w, h = max(w*size/h, 1), size
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// Square 中央を正方形に切り抜いてsize×sizeにする（サムネイル用）
+func Square(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x := b.Min.X + (b.Dx()-side)/2
+	y := b.Min.Y + (b.Dy()-side)/2
+	crop := image.Rect(x, y, x+side, y+side)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	return dst
+}
+
+func toRGBA(img image.Image, b image.Rectangle) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
+
+// OutputType 縮小した画像を保存する形式
+// 写真（JPEG）はJPEG、透過を含みうる形式（PNG, GIF, WebP）はPNGにする
+func OutputType(contentType string) string {
+	if contentType == TypeJPEG {
+		return TypeJPEG
+	}
+	return TypePNG
+}
+
+// Encode contentType（TypeJPEG, TypePNG）の形式で書き出す。メタデータ（EXIF等）は含めない
+func Encode(w io.Writer, img image.Image, contentType string) error {
+	switch contentType {
+	case TypeJPEG:
+		return jpeg.Encode(w, flatten(img), &jpeg.Options{Quality: 85})
+	case TypePNG:
+		return (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(w, img)
+	case TypeGIF:
+		return gif.Encode(w, img, nil)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+}
+
+// flatten 透過部分を白で塗る（JPEGは透過を表せないため）
+func flatten(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,105 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// encodeJPEG EXIFのOrientationタグ（リトルエンディアン）を付けたJPEG
+func encodeJPEG(t *testing.T, w, h, orientation int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h)), nil))
+	data := buf.Bytes()
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, uint16(orientation))
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	app1 := append([]byte("Exif\x00\x00"), tiff...)
+
+	out := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	out = binary.BigEndian.AppendUint16(out, uint16(len(app1)+2))
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func TestSniff(t *testing.T) {
+	contentType, err := Sniff(encodePNG(t, 2, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, TypePNG, contentType)
+
+	// 拡張子やContent-Typeを偽っても内容で判定する
+	_, err = Sniff([]byte("<html><script>alert(1)</script></html>"))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("正常系: PNG", func(t *testing.T) {
+		img, contentType, err := Decode(encodePNG(t, 40, 20), 1000)
+		require.NoError(t, err)
+		assert.Equal(t, TypePNG, contentType)
+		assert.Equal(t, image.Rect(0, 0, 40, 20), img.Bounds())
+	})
+
+	t.Run("異常系: ピクセル数の上限を超える", func(t *testing.T) {
+		_, _, err := Decode(encodePNG(t, 40, 30), 1000)
+		assert.ErrorIs(t, err, ErrTooLarge)
+	})
+
+	t.Run("異常系: 壊れた画像", func(t *testing.T) {
+		data := encodePNG(t, 40, 20)
+		_, _, err := Decode(data[:len(data)/2], 1000)
+		assert.ErrorIs(t, err, ErrInvalidImage)
+	})
+
+	t.Run("正常系: EXIFの向き（時計回りに90度）を補正する", func(t *testing.T) {
+		img, contentType, err := Decode(encodeJPEG(t, 40, 20, 6), 1000)
+		require.NoError(t, err)
+		assert.Equal(t, TypeJPEG, contentType)
+		assert.Equal(t, image.Rect(0, 0, 20, 40), img.Bounds())
+	})
+}
+
+func TestFitAndSquare(t *testing.T) {
+	img, _, err := Decode(encodePNG(t, 400, 200), 1<<20)
+	require.NoError(t, err)
+
+	assert.Equal(t, image.Rect(0, 0, 100, 50), Fit(img, 100).Bounds())
+	// 小さい画像は拡大しない
+	assert.Equal(t, image.Rect(0, 0, 400, 200), Fit(img, 1000).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 64, 64), Square(img, 64).Bounds())
+}
+
+func TestEncode(t *testing.T) {
+	img := Square(image.NewRGBA(image.Rect(0, 0, 10, 10)), 8)
+	for _, contentType := range []string{TypeJPEG, TypePNG} {
+		var buf bytes.Buffer
+		require.NoError(t, Encode(&buf, img, contentType))
+		sniffed, err := Sniff(buf.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, contentType, sniffed)
+	}
+}
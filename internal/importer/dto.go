@@ -0,0 +1,7 @@
+package importer
+
+// CreateImportRequest インポート作成のクエリパラメータ
+type CreateImportRequest struct {
+	Type   string `query:"type" validate:"required,oneof=users companies memberships" example:"users"` // インポート対象
+	DryRun bool   `query:"dry_run" example:"false"`                                                    // 検証のみ行うか
+}
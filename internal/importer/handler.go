@@ -0,0 +1,138 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+// インポートファイルのメディアタイプと形式の対応
+var importFormats = map[string]string{
+	"text/csv":             domain.ImportFormatCSV,
+	"application/jsonl":    domain.ImportFormatJSONL,
+	"application/x-ndjson": domain.ImportFormatJSONL,
+}
+
+type ImportHandler struct {
+	usecase ImportUsecase
+}
+
+func NewImportHandler(usecase ImportUsecase) *ImportHandler {
+	return &ImportHandler{usecase: usecase}
+}
+
+// CreateImport godoc
+// @Summary 一括インポート
+// @ID createImport
+// @Description CSV（text/csv）またはJSONL（application/x-ndjson）でユーザー・会社・所属を一括登録します。ファイル全体を検証し、エラーがなければ非同期で処理します。既存のデータはメールアドレスで照合して更新します。ユーザー・会社のインポートはAPIキーのみ、所属のインポートはファイル内のすべての会社の管理者またはAPIキーのみ実行できます
+// @Tags imports
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param type query string true "インポート対象" Enums(users, companies, memberships)
+// @Param dry_run query bool false "検証のみ行う"
+// @Param file body string true "インポートファイル"
+// @Success 200 {object} helper.APIResponse{data=domain.Import} "ドライランの検証結果"
+// @Success 202 {object} helper.APIResponse{data=domain.Import} "受け付け"
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 415 {object} helper.APIResponse
+// @Failure 422 {object} helper.APIResponse{data=domain.Import} "行ごとの検証エラー"
+// @Failure 500 {object} helper.APIResponse
+// @Router /imports [post]
+func (h *ImportHandler) CreateImport(c echo.Context) error {
+	var req CreateImportRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	format, ok := importFormats[mediaType]
+	if !ok {
+		return helper.ErrorResponse(c, http.StatusUnsupportedMediaType, helper.ErrorCodeUnsupportedMedia,
+			"text/csv または application/x-ndjson を指定してください", mediaType)
+	}
+
+	data, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	imp, err := h.usecase.CreateImport(ctx, req.Type, format, data, req.DryRun)
+	if err != nil {
+		if errors.Is(err, ErrInvalidFile) || errors.Is(err, ErrUnsupportedKind) {
+			return helper.ValidationErrorResponse(c, err.Error())
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return helper.ForbiddenResponse(c)
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to create import", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+
+	switch imp.Status {
+	case domain.ImportStatusValidated:
+		return helper.SuccessResponse(c, http.StatusOK, imp, "検証が完了しました")
+	case domain.ImportStatusInvalid:
+		return c.JSON(http.StatusUnprocessableEntity, helper.APIResponse{
+			Success: false,
+			Data:    imp,
+			Error: &helper.APIError{
+				Code:    helper.ErrorCodeValidation,
+				Message: "インポートファイルにエラーがあります",
+				Details: strconv.Itoa(len(imp.Errors)) + "行のエラー",
+			},
+		})
+	}
+
+	// 受け付けたAPIバージョンの状況取得のURL（/api/v1/imports/1 等）
+	c.Response().Header().Set(echo.HeaderLocation, c.Path()+"/"+strconv.FormatUint(uint64(imp.ID), 10))
+	return helper.SuccessResponse(c, http.StatusAccepted, imp, "インポートを受け付けました")
+}
+
+// GetImport godoc
+// @Summary インポート状況取得
+// @ID getImport
+// @Description インポートの処理状況と行ごとのエラーを取得します。ユーザーは自分が作成したインポートのみ取得できます
+// @Tags imports
+// @Produce json
+// @Param id path int true "インポートID"
+// @Success 200 {object} helper.APIResponse{data=domain.Import}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /imports/{id} [get]
+func (h *ImportHandler) GetImport(c echo.Context) error {
+	var req helper.IDRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	imp, err := h.usecase.GetImport(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return helper.NotFoundResponse(c, "インポート")
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to get import", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, imp, "")
+}
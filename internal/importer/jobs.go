@@ -0,0 +1,27 @@
+package importer
+
+import (
+	"context"
+	"errors"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/job"
+)
+
+// JobProcessImport 受け付けたインポートを処理するジョブ
+const JobProcessImport = "import.process"
+
+type processImportPayload struct {
+	ImportID uint `json:"import_id"`
+}
+
+// RegisterJobs インポートのジョブハンドラーを登録
+func RegisterJobs(registry *job.Registry, usecase ImportUsecase) {
+	job.Register(registry, JobProcessImport, func(ctx context.Context, p processImportPayload) error {
+		err := usecase.ProcessImport(ctx, p.ImportID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return job.Permanent(err)
+		}
+		return err
+	})
+}
@@ -0,0 +1,188 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+)
+
+// MaxRows 1回のインポートで受け付ける最大行数
+const MaxRows = 10000
+
+// maxLineSize JSONLの1行の最大サイズ
+const maxLineSize = 1 << 20
+
+var (
+	// ErrInvalidFile ファイル全体として解釈できない（ヘッダー不正・行数超過など）
+	ErrInvalidFile = errors.New("invalid import file")
+	// ErrUnsupportedKind 対象外のインポート種別・形式
+	ErrUnsupportedKind = errors.New("unsupported import kind")
+)
+
+// parsedRow 解析済みの1行（解析・検証エラーがあればerrに入る）
+type parsedRow[T importRow] struct {
+	line  int
+	value T
+	err   *domain.ImportRowError
+}
+
+// parseRows ファイルを解析し、各行を検証する
+func parseRows[T importRow](format string, data []byte) ([]parsedRow[T], error) {
+	var rows []parsedRow[T]
+	var err error
+	switch format {
+	case domain.ImportFormatCSV:
+		rows, err = parseCSV[T](data)
+	case domain.ImportFormatJSONL:
+		rows, err = parseJSONL[T](data)
+	default:
+		return nil, fmt.Errorf("%w: format %q", ErrUnsupportedKind, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	validator := helper.NewValidator()
+	seen := make(map[string]int, len(rows))
+	for i := range rows {
+		r := &rows[i]
+		if r.err != nil {
+			continue
+		}
+		if err := validator.Validate(&r.value); err != nil {
+			r.err = validationRowError(r.line, err)
+			continue
+		}
+		if first, ok := seen[r.value.key()]; ok {
+			r.err = &domain.ImportRowError{Line: r.line, Message: fmt.Sprintf("%d行目と重複しています", first)}
+			continue
+		}
+		seen[r.value.key()] = r.line
+	}
+
+	return rows, nil
+}
+
+func parseCSV[T importRow](data []byte) ([]parsedRow[T], error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: empty file", ErrInvalidFile)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	fields := csvFields(reflect.TypeOf((*T)(nil)).Elem())
+	columns := make([]int, len(header))
+	for i, name := range header {
+		index, ok := fields[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidFile, name)
+		}
+		columns[i] = index
+	}
+
+	var rows []parsedRow[T]
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			rows = append(rows, parsedRow[T]{line: parseErr.StartLine, err: &domain.ImportRowError{
+				Line: parseErr.StartLine, Message: fmt.Sprintf("列数がヘッダーと一致しません（%d列）", len(record)),
+			}})
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		} else {
+			var value T
+			v := reflect.ValueOf(&value).Elem()
+			for i, cell := range record {
+				v.Field(columns[i]).SetString(strings.TrimSpace(cell))
+			}
+			rows = append(rows, parsedRow[T]{line: line, value: value})
+		}
+
+		if len(rows) > MaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidFile, MaxRows)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no data rows", ErrInvalidFile)
+	}
+
+	return rows, nil
+}
+
+func parseJSONL[T importRow](data []byte) ([]parsedRow[T], error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var rows []parsedRow[T]
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var value T
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&value); err != nil {
+			rows = append(rows, parsedRow[T]{line: line, err: &domain.ImportRowError{
+				Line: line, Message: fmt.Sprintf("JSONとして解析できません: %v", err),
+			}})
+		} else {
+			rows = append(rows, parsedRow[T]{line: line, value: value})
+		}
+
+		if len(rows) > MaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidFile, MaxRows)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidFile)
+	}
+
+	return rows, nil
+}
+
+// csvFields csvタグの列名から構造体フィールドのインデックスへの対応
+func csvFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := t.Field(i).Tag.Get("csv"); name != "" {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+// validationRowError バリデーションエラーを行エラーに変換（最初のエラーのみ）
+func validationRowError(line int, err error) *domain.ImportRowError {
+	var validationErrs helper.ValidationErrors
+	if errors.As(err, &validationErrs) && len(validationErrs) > 0 {
+		return &domain.ImportRowError{Line: line, Field: validationErrs[0].Field, Message: validationErrs.Error()}
+	}
+	return &domain.ImportRowError{Line: line, Message: err.Error()}
+}
@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/domain"
+)
+
+func TestValidateImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        string
+		format      string
+		data        string
+		expectTotal int
+		expectLines []int
+		expectError error
+	}{
+		{
+			name:        "正常系: CSV（BOM・列順の入れ替えを許容）",
+			kind:        domain.ImportKindUsers,
+			format:      domain.ImportFormatCSV,
+			data:        "\xef\xbb\xbfEmail,Name,Password\nyamada@example.com,山田太郎,password123\nsato@example.com,佐藤花子,\n",
+			expectTotal: 2,
+		},
+		{
+			name:        "異常系: CSVの行ごとのエラー（バリデーション・列数・重複）",
+			kind:        domain.ImportKindUsers,
+			format:      domain.ImportFormatCSV,
+			data:        "name,email\n山田太郎,not-an-email\n佐藤花子\n田中一郎,tanaka@example.com\n田中二郎,tanaka@example.com\n",
+			expectTotal: 4,
+			expectLines: []int{2, 3, 5},
+		},
+		{
+			name:        "異常系: CSVの不明な列",
+			kind:        domain.ImportKindUsers,
+			format:      domain.ImportFormatCSV,
+			data:        "name,email,age\n山田太郎,yamada@example.com,30\n",
+			expectError: ErrInvalidFile,
+		},
+		{
+			name:        "正常系: JSONL（空行を無視）",
+			kind:        domain.ImportKindMemberships,
+			format:      domain.ImportFormatJSONL,
+			data:        "{\"user_email\":\"yamada@example.com\",\"company_email\":\"info@sample.co.jp\",\"role\":\"admin\"}\n\n{\"user_email\":\"sato@example.com\",\"company_email\":\"info@sample.co.jp\"}\n",
+			expectTotal: 2,
+		},
+		{
+			name:        "異常系: JSONLの行ごとのエラー（不正なJSON・不明なフィールド・役割）",
+			kind:        domain.ImportKindMemberships,
+			format:      domain.ImportFormatJSONL,
+			data:        "{\"user_email\":\n{\"user_email\":\"a@example.com\",\"company_email\":\"b@example.com\",\"team\":\"x\"}\n{\"user_email\":\"a@example.com\",\"company_email\":\"b@example.com\",\"role\":\"owner\"}\n",
+			expectTotal: 3,
+			expectLines: []int{1, 2, 3},
+		},
+		{
+			name:        "異常系: データ行なし",
+			kind:        domain.ImportKindCompanies,
+			format:      domain.ImportFormatCSV,
+			data:        "name,email\n",
+			expectError: ErrInvalidFile,
+		},
+		{
+			name:        "異常系: 対象外の種別",
+			kind:        "departments",
+			format:      domain.ImportFormatCSV,
+			data:        "name\nx\n",
+			expectError: ErrUnsupportedKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, rowErrors, err := validateImport(tt.kind, tt.format, []byte(tt.data))
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectTotal, total)
+
+			var lines []int
+			for _, e := range rowErrors {
+				lines = append(lines, e.Line)
+				assert.NotEmpty(t, e.Message)
+			}
+			assert.Equal(t, tt.expectLines, lines)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+
+	"gorm.io/gorm"
+)
+
+// importRepository GORM実装
+type importRepository struct {
+	db *gorm.DB
+}
+
+// NewImportRepository インポートリポジトリのコンストラクタ
+func NewImportRepository(db *gorm.DB) ImportRepository {
+	return &importRepository{db: db}
+}
+
+func (r *importRepository) Create(ctx context.Context, imp *domain.Import) error {
+	if err := infra.Conn(ctx, r.db).Create(imp).Error; err != nil {
+		return fmt.Errorf("failed to create import: %w", err)
+	}
+
+	return nil
+}
+
+func (r *importRepository) GetByID(ctx context.Context, id uint) (*domain.Import, error) {
+	var imp domain.Import
+
+	if err := infra.Conn(ctx, r.db).First(&imp, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("import with id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get import by id %d: %w", id, err)
+	}
+
+	return &imp, nil
+}
+
+func (r *importRepository) UpdateProgress(ctx context.Context, imp *domain.Import) error {
+	fields := []any{"TotalRows", "ProcessedRows", "CreatedRows", "UpdatedRows", "FailedRows", "Errors", "CompletedAt", "UpdatedAt"}
+	// 処理が終了したらアップロードされたファイルは不要なため削除する
+	if imp.IsFinished() {
+		imp.Payload = nil
+		fields = append(fields, "Payload")
+	}
+	err := infra.Conn(ctx, r.db).Model(imp).
+		Select("Status", fields...).
+		Updates(imp).Error
+	if err != nil {
+		return fmt.Errorf("failed to update import progress: %w", err)
+	}
+
+	return nil
+}
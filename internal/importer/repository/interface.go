@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"km-api-go/internal/domain"
+)
+
+type ImportRepository interface {
+	Create(ctx context.Context, imp *domain.Import) error
+	GetByID(ctx context.Context, id uint) (*domain.Import, error)
+	// UpdateProgress 状態・件数・エラーを更新（処理が終了した場合はペイロードを削除する）
+	UpdateProgress(ctx context.Context, imp *domain.Import) error
+}
@@ -0,0 +1,37 @@
+package importer
+
+// importRow インポート1行分の型（重複検出用のキーを返す）
+type importRow interface {
+	UserRow | CompanyRow | MembershipRow
+	key() string
+}
+
+// UserRow ユーザーのインポート行（メールアドレスで既存ユーザーを更新）
+type UserRow struct {
+	Name     string `json:"name" csv:"name" validate:"required,min=2,max=50"`
+	Email    string `json:"email" csv:"email" validate:"required,email"`
+	Password string `json:"password" csv:"password" validate:"omitempty,min=8"` // 新規作成時のみ使用
+}
+
+func (r UserRow) key() string { return r.Email }
+
+// CompanyRow 会社のインポート行（メールアドレスで既存の会社を更新）
+type CompanyRow struct {
+	Name        string `json:"name" csv:"name" validate:"required,min=2,max=100"`
+	Email       string `json:"email" csv:"email" validate:"required,email"`
+	Phone       string `json:"phone" csv:"phone" validate:"omitempty,min=10,max=20"`
+	Address     string `json:"address" csv:"address" validate:"omitempty,max=500"`
+	Website     string `json:"website" csv:"website" validate:"omitempty,url"`
+	Description string `json:"description" csv:"description" validate:"omitempty,max=1000"`
+}
+
+func (r CompanyRow) key() string { return r.Email }
+
+// MembershipRow 会社への所属のインポート行（既存の所属は役割を更新）
+type MembershipRow struct {
+	UserEmail    string `json:"user_email" csv:"user_email" validate:"required,email"`
+	CompanyEmail string `json:"company_email" csv:"company_email" validate:"required,email"`
+	Role         string `json:"role" csv:"role" validate:"omitempty,oneof=admin member"`
+}
+
+func (r MembershipRow) key() string { return r.UserEmail + "\x00" + r.CompanyEmail }
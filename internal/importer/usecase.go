@@ -0,0 +1,469 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"km-api-go/internal/auth"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/domain"
+	importRepo "km-api-go/internal/importer/repository"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/metrics"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/tracing"
+	userRepo "km-api-go/internal/user/repository"
+)
+
+// progressInterval 進捗を保存する行数の間隔
+const progressInterval = 100
+
+// ImportUsecase 一括インポートのビジネスロジック
+type ImportUsecase interface {
+	// CreateImport ファイルを検証し、問題がなければ非同期処理として受け付ける
+	// dryRunの場合は検証結果のみ返し、保存しない。検証エラーがある場合は状態invalidで返す
+	// ユーザー・会社のインポートはAPIキーのみ、所属のインポートはファイル内のすべての会社の管理者またはAPIキーのみ可能
+	CreateImport(ctx context.Context, kind, format string, data []byte, dryRun bool) (*domain.Import, error)
+	// GetImport インポートを取得する（ユーザーは自分が作成したインポートのみ取得できる）
+	GetImport(ctx context.Context, id uint) (*domain.Import, error)
+	// ProcessImport 受け付けたインポートを処理する（完了済みの場合は何もしない）
+	ProcessImport(ctx context.Context, id uint) error
+}
+
+type importUsecase struct {
+	importRepo      importRepo.ImportRepository
+	userRepo        userRepo.UserRepository
+	companyRepo     companyRepo.CompanyRepository
+	companyUserRepo companyRepo.CompanyUserRepository
+	tx              infra.Transactor
+	events          outbox.Recorder
+	dispatch        func(ctx context.Context, id uint) error
+	now             func() time.Time
+}
+
+// NewImportUsecase インポートユースケースのコンストラクタ
+// 受け付けたインポートはジョブとして登録し、ワーカーで処理する
+// 各行の変更とドメインイベントは同じトランザクションで保存する
+func NewImportUsecase(
+	importRepository importRepo.ImportRepository,
+	userRepository userRepo.UserRepository,
+	companyRepository companyRepo.CompanyRepository,
+	companyUserRepository companyRepo.CompanyUserRepository,
+	tx infra.Transactor,
+	events outbox.Recorder,
+	jobs job.Enqueuer,
+) ImportUsecase {
+	return &importUsecase{
+		importRepo:      importRepository,
+		userRepo:        userRepository,
+		companyRepo:     companyRepository,
+		companyUserRepo: companyUserRepository,
+		tx:              tx,
+		events:          events,
+		dispatch: func(ctx context.Context, id uint) error {
+			_, err := jobs.Enqueue(ctx, JobProcessImport, processImportPayload{ImportID: id})
+			return err
+		},
+		now: time.Now,
+	}
+}
+
+func (uc *importUsecase) CreateImport(ctx context.Context, kind, format string, data []byte, dryRun bool) (_ *domain.Import, err error) {
+	ctx, span := tracing.Start(ctx, "ImportUsecase.CreateImport")
+	defer tracing.End(span, &err)
+
+	total, rowErrors, err := validateImport(kind, format, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeImport(ctx, kind, format, data); err != nil {
+		return nil, err
+	}
+
+	imp := &domain.Import{
+		Kind:       kind,
+		Format:     format,
+		TotalRows:  total,
+		FailedRows: len(rowErrors),
+		Errors:     rowErrors,
+	}
+	switch {
+	case dryRun:
+		imp.Status = domain.ImportStatusValidated
+		return imp, nil
+	case len(rowErrors) > 0:
+		imp.Status = domain.ImportStatusInvalid
+		return imp, nil
+	}
+
+	imp.Status = domain.ImportStatusPending
+	imp.FailedRows = 0
+	imp.Payload = data
+	if createdBy, ok := auth.UserIDFromContext(ctx); ok {
+		imp.CreatedBy = &createdBy
+	}
+	if err := uc.importRepo.Create(ctx, imp); err != nil {
+		return nil, fmt.Errorf("failed to create import: %w", err)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "import accepted",
+		slog.Uint64("import_id", uint64(imp.ID)), slog.String("type", kind), slog.Int("rows", total))
+
+	if err := uc.dispatch(ctx, imp.ID); err != nil {
+		return nil, fmt.Errorf("failed to dispatch import: %w", err)
+	}
+
+	imp.Payload = nil
+	return imp, nil
+}
+
+func (uc *importUsecase) GetImport(ctx context.Context, id uint) (_ *domain.Import, err error) {
+	ctx, span := tracing.Start(ctx, "ImportUsecase.GetImport")
+	defer tracing.End(span, &err)
+
+	imp, err := uc.importRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import: %w", err)
+	}
+	// 他のユーザーのインポートは存在しないものとして扱う
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || (principal.IsUser() && (imp.CreatedBy == nil || *imp.CreatedBy != principal.UserID)) {
+		return nil, fmt.Errorf("import with id %d %w", id, domain.ErrNotFound)
+	}
+
+	imp.Payload = nil
+	return imp, nil
+}
+
+// authorizeImport 呼び出し元がインポートできるか確認
+// ユーザーは所属のインポートのみ可能で、ファイル内のすべての会社の管理者である必要がある
+func (uc *importUsecase) authorizeImport(ctx context.Context, kind, format string, data []byte) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrForbidden
+	}
+	if !principal.IsUser() {
+		return nil
+	}
+	if kind != domain.ImportKindMemberships {
+		return domain.ErrForbidden
+	}
+
+	rows, err := parseRows[MembershipRow](format, data)
+	if err != nil {
+		return err
+	}
+	checked := make(map[string]bool)
+	for _, r := range rows {
+		if r.err != nil || checked[r.value.CompanyEmail] {
+			continue
+		}
+		checked[r.value.CompanyEmail] = true
+
+		// 存在しない会社は管理者であることを確認できないため許可しない
+		company, err := uc.companyRepo.GetByEmail(ctx, r.value.CompanyEmail)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrForbidden
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get company: %w", err)
+		}
+		relation, err := uc.companyUserRepo.GetRelation(ctx, principal.UserID, company.ID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrForbidden
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get company relation: %w", err)
+		}
+		if !relation.IsAdmin() {
+			return domain.ErrForbidden
+		}
+	}
+	return nil
+}
+
+func (uc *importUsecase) ProcessImport(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "ImportUsecase.ProcessImport")
+	defer tracing.End(span, &err)
+
+	imp, err := uc.importRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get import: %w", err)
+	}
+	if imp.IsFinished() {
+		return nil
+	}
+
+	// 再試行の場合も先頭から処理し直すため、前回の件数・エラーは破棄する
+	imp.Status = domain.ImportStatusProcessing
+	imp.ProcessedRows, imp.CreatedRows, imp.UpdatedRows, imp.FailedRows = 0, 0, 0, 0
+	imp.Errors = nil
+	if err := uc.importRepo.UpdateProgress(ctx, imp); err != nil {
+		return err
+	}
+
+	switch imp.Kind {
+	case domain.ImportKindUsers:
+		err = processRows(ctx, uc, imp, uc.upsertUser)
+	case domain.ImportKindCompanies:
+		err = processRows(ctx, uc, imp, uc.upsertCompany)
+	case domain.ImportKindMemberships:
+		err = processRows(ctx, uc, imp, uc.upsertMembership)
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnsupportedKind, imp.Kind)
+	}
+
+	completedAt := uc.now()
+	imp.CompletedAt = &completedAt
+	imp.Status = domain.ImportStatusCompleted
+	if err != nil {
+		imp.Status = domain.ImportStatusFailed
+		imp.Errors = append(imp.Errors, domain.ImportRowError{Message: err.Error()})
+	}
+	// 処理の失敗も記録できるよう、キャンセルされたコンテキストは使わない
+	if updateErr := uc.importRepo.UpdateProgress(context.WithoutCancel(ctx), imp); updateErr != nil {
+		return errors.Join(err, updateErr)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "import finished",
+		slog.Uint64("import_id", uint64(imp.ID)), slog.String("status", imp.Status),
+		slog.Int("created", imp.CreatedRows), slog.Int("updated", imp.UpdatedRows), slog.Int("failed", imp.FailedRows))
+
+	return err
+}
+
+// validateImport ドライラン用の検証（行数と行ごとのエラーを返す）
+func validateImport(kind, format string, data []byte) (int, domain.ImportRowErrors, error) {
+	switch kind {
+	case domain.ImportKindUsers:
+		return validateRows[UserRow](format, data)
+	case domain.ImportKindCompanies:
+		return validateRows[CompanyRow](format, data)
+	case domain.ImportKindMemberships:
+		return validateRows[MembershipRow](format, data)
+	default:
+		return 0, nil, fmt.Errorf("%w: %q", ErrUnsupportedKind, kind)
+	}
+}
+
+func validateRows[T importRow](format string, data []byte) (int, domain.ImportRowErrors, error) {
+	rows, err := parseRows[T](format, data)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var rowErrors domain.ImportRowErrors
+	for _, r := range rows {
+		if r.err != nil {
+			rowErrors = append(rowErrors, *r.err)
+		}
+	}
+	return len(rows), rowErrors, nil
+}
+
+// processRows 各行を順に反映し、行ごとの結果を記録する
+// applyは作成した場合にtrueを返す
+func processRows[T importRow](ctx context.Context, uc *importUsecase, imp *domain.Import, apply func(context.Context, T) (bool, error)) error {
+	rows, err := parseRows[T](imp.Format, imp.Payload)
+	if err != nil {
+		return err
+	}
+	imp.TotalRows = len(rows)
+
+	for i, r := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rowErr := r.err
+		if rowErr == nil {
+			created, err := apply(ctx, r.value)
+			switch {
+			case err != nil:
+				rowErr = &domain.ImportRowError{Line: r.line, Message: err.Error()}
+			case created:
+				imp.CreatedRows++
+			default:
+				imp.UpdatedRows++
+			}
+		}
+		if rowErr != nil {
+			imp.FailedRows++
+			imp.Errors = append(imp.Errors, *rowErr)
+		}
+		imp.ProcessedRows++
+
+		if (i+1)%progressInterval == 0 {
+			if err := uc.importRepo.UpdateProgress(ctx, imp); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// upsertUser メールアドレスが一致するユーザーがいれば名前を更新、いなければ作成
+func (uc *importUsecase) upsertUser(ctx context.Context, row UserRow) (bool, error) {
+	exists, err := uc.userRepo.ExistsByEmail(ctx, row.Email)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		if row.Password == "" {
+			return false, fmt.Errorf("passwordは新規ユーザーの作成に必須です")
+		}
+		user := &domain.User{Name: row.Name, Email: row.Email, Password: row.Password}
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.userRepo.Create(ctx, user); err != nil {
+				return err
+			}
+			return uc.events.Record(ctx, domain.UserCreated{UserID: user.ID, Name: user.Name, Email: user.Email})
+		})
+		if err != nil {
+			return false, err
+		}
+		metrics.UsersCreated.Inc()
+		return true, nil
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, row.Email)
+	if err != nil {
+		return false, err
+	}
+	if user.Name == row.Name {
+		return false, nil
+	}
+	user.Name = row.Name
+	return false, uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.UpdateFields(ctx, user, "Name"); err != nil {
+			return err
+		}
+		return uc.events.Record(ctx, domain.UserUpdated{
+			UserID: user.ID, Name: user.Name, Email: user.Email, Version: user.Version, Changed: []string{"name"},
+		})
+	})
+}
+
+// upsertCompany メールアドレスが一致する会社があれば変更されたフィールドを更新、なければ作成
+func (uc *importUsecase) upsertCompany(ctx context.Context, row CompanyRow) (bool, error) {
+	exists, err := uc.companyRepo.ExistsByEmail(ctx, row.Email)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		company := &domain.Company{
+			Name:        row.Name,
+			Email:       row.Email,
+			Phone:       row.Phone,
+			Address:     row.Address,
+			Website:     row.Website,
+			Description: row.Description,
+		}
+		return true, uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.companyRepo.Create(ctx, company); err != nil {
+				return err
+			}
+			return uc.events.Record(ctx, domain.CompanyCreated{CompanyID: company.ID, Name: company.Name, Email: company.Email})
+		})
+	}
+
+	company, err := uc.companyRepo.GetByEmail(ctx, row.Email)
+	if err != nil {
+		return false, err
+	}
+
+	var changed, changedKeys []string
+	for _, f := range []struct {
+		name  string
+		key   string
+		field *string
+		value string
+	}{
+		{"Name", "name", &company.Name, row.Name},
+		{"Phone", "phone", &company.Phone, row.Phone},
+		{"Address", "address", &company.Address, row.Address},
+		{"Website", "website", &company.Website, row.Website},
+		{"Description", "description", &company.Description, row.Description},
+	} {
+		if *f.field != f.value {
+			*f.field = f.value
+			changed = append(changed, f.name)
+			changedKeys = append(changedKeys, f.key)
+		}
+	}
+	if len(changed) == 0 {
+		return false, nil
+	}
+	return false, uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyRepo.UpdateFields(ctx, company, changed...); err != nil {
+			return err
+		}
+		return uc.events.Record(ctx, domain.CompanyUpdated{
+			CompanyID: company.ID, Name: company.Name, Email: company.Email, Version: company.Version, Changed: changedKeys,
+		})
+	})
+}
+
+// upsertMembership ユーザーを会社に所属させる（所属済みの場合は役割を更新）
+func (uc *importUsecase) upsertMembership(ctx context.Context, row MembershipRow) (bool, error) {
+	user, err := uc.userRepo.GetByEmail(ctx, row.UserEmail)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, fmt.Errorf("ユーザー %s が見つかりません", row.UserEmail)
+		}
+		return false, err
+	}
+	company, err := uc.companyRepo.GetByEmail(ctx, row.CompanyEmail)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, fmt.Errorf("会社 %s が見つかりません", row.CompanyEmail)
+		}
+		return false, err
+	}
+
+	role := row.Role
+	if role == "" {
+		role = "member"
+	}
+	existing, err := uc.companyUserRepo.GetRelation(ctx, user.ID, company.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return false, err
+	}
+	if existing != nil {
+		if existing.Role == role {
+			return false, nil
+		}
+		oldRole := existing.Role
+		existing.Role = role
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.companyUserRepo.Update(ctx, existing); err != nil {
+				return err
+			}
+			return uc.events.Record(ctx, domain.RoleChanged{CompanyID: company.ID, UserID: user.ID, OldRole: oldRole, NewRole: role})
+		})
+		if err != nil {
+			return false, err
+		}
+		metrics.MembershipChanges.WithLabelValues(metrics.MembershipRoleChanged).Inc()
+		return false, nil
+	}
+
+	membership := &domain.CompanyUser{UserID: user.ID, CompanyID: company.ID, Role: role}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.companyUserRepo.Create(ctx, membership); err != nil {
+			return err
+		}
+		return uc.events.Record(ctx, domain.MemberAdded{CompanyID: company.ID, UserID: user.ID, Role: role})
+	})
+	if err != nil {
+		return false, err
+	}
+	metrics.MembershipChanges.WithLabelValues(metrics.MembershipAdded).Inc()
+	return true, nil
+}
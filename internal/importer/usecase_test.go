@@ -0,0 +1,300 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/domain"
+	importMocks "km-api-go/internal/importer/repository/mocks"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/outbox"
+	userMocks "km-api-go/internal/user/repository/mocks"
+)
+
+func TestImportUsecase_CreateImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportRepo := importMocks.NewMockImportRepository(ctrl)
+	uc := NewImportUsecase(mockImportRepo, nil, nil, nil, nil, nil, nil).(*importUsecase)
+	var dispatched []uint
+	uc.dispatch = func(_ context.Context, id uint) error {
+		dispatched = append(dispatched, id)
+		return nil
+	}
+
+	valid := "name,email,password\n山田太郎,yamada@example.com,password123\n"
+	invalid := "name,email\n山田太郎,not-an-email\n"
+
+	tests := []struct {
+		name             string
+		data             string
+		dryRun           bool
+		setupMock        func()
+		expectStatus     string
+		expectDispatched []uint
+	}{
+		{
+			name:         "正常系: ドライランは保存しない",
+			data:         valid,
+			dryRun:       true,
+			setupMock:    func() {},
+			expectStatus: domain.ImportStatusValidated,
+		},
+		{
+			name:         "異常系: 検証エラーがあれば受け付けない",
+			data:         invalid,
+			setupMock:    func() {},
+			expectStatus: domain.ImportStatusInvalid,
+		},
+		{
+			name: "正常系: 保存して非同期処理を開始",
+			data: valid,
+			setupMock: func() {
+				mockImportRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, imp *domain.Import) error {
+					assert.Equal(t, []byte(valid), imp.Payload)
+					assert.Nil(t, imp.CreatedBy)
+					imp.ID = 42
+					return nil
+				}).Times(1)
+			},
+			expectStatus:     domain.ImportStatusPending,
+			expectDispatched: []uint{42},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatched = nil
+			tt.setupMock()
+
+			ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+			imp, err := uc.CreateImport(ctx, domain.ImportKindUsers, domain.ImportFormatCSV, []byte(tt.data), tt.dryRun)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectStatus, imp.Status)
+			assert.Equal(t, 1, imp.TotalRows)
+			assert.Nil(t, imp.Payload)
+			assert.Equal(t, tt.expectDispatched, dispatched)
+		})
+	}
+}
+
+func TestImportUsecase_CreateImport_Authorization(t *testing.T) {
+	memberships := "user_email,company_email,role\n" +
+		"yamada@example.com,info@example.com,member\n" +
+		"sato@example.com,info@example.com,admin\n"
+
+	tests := []struct {
+		name      string
+		kind      string
+		data      string
+		setupMock func(companies *companyMocks.MockCompanyRepository, companyUsers *companyMocks.MockCompanyUserRepository)
+		wantErr   error
+	}{
+		{
+			name:      "異常系: ユーザーはユーザーをインポートできない",
+			kind:      domain.ImportKindUsers,
+			data:      "name,email,password\n山田太郎,yamada@example.com,password123\n",
+			setupMock: func(*companyMocks.MockCompanyRepository, *companyMocks.MockCompanyUserRepository) {},
+			wantErr:   domain.ErrForbidden,
+		},
+		{
+			name: "正常系: 会社の管理者は所属をインポートできる",
+			kind: domain.ImportKindMemberships,
+			data: memberships,
+			setupMock: func(companies *companyMocks.MockCompanyRepository, companyUsers *companyMocks.MockCompanyUserRepository) {
+				companies.EXPECT().GetByEmail(gomock.Any(), "info@example.com").Return(&domain.Company{ID: 1}, nil).Times(1)
+				companyUsers.EXPECT().GetRelation(gomock.Any(), uint(7), uint(1)).
+					Return(&domain.CompanyUser{UserID: 7, CompanyID: 1, Role: "admin"}, nil).Times(1)
+			},
+		},
+		{
+			name: "異常系: 会社のメンバーは所属をインポートできない",
+			kind: domain.ImportKindMemberships,
+			data: memberships,
+			setupMock: func(companies *companyMocks.MockCompanyRepository, companyUsers *companyMocks.MockCompanyUserRepository) {
+				companies.EXPECT().GetByEmail(gomock.Any(), "info@example.com").Return(&domain.Company{ID: 1}, nil).Times(1)
+				companyUsers.EXPECT().GetRelation(gomock.Any(), uint(7), uint(1)).
+					Return(&domain.CompanyUser{UserID: 7, CompanyID: 1, Role: "member"}, nil).Times(1)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "異常系: 存在しない会社への所属はインポートできない",
+			kind: domain.ImportKindMemberships,
+			data: memberships,
+			setupMock: func(companies *companyMocks.MockCompanyRepository, _ *companyMocks.MockCompanyUserRepository) {
+				companies.EXPECT().GetByEmail(gomock.Any(), "info@example.com").Return(nil, domain.ErrNotFound).Times(1)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCompanyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+			mockCompanyUserRepo := companyMocks.NewMockCompanyUserRepository(ctrl)
+			tt.setupMock(mockCompanyRepo, mockCompanyUserRepo)
+			uc := NewImportUsecase(importMocks.NewMockImportRepository(ctrl), nil, mockCompanyRepo, mockCompanyUserRepo, nil, nil, nil)
+
+			ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 7})
+			imp, err := uc.CreateImport(ctx, tt.kind, domain.ImportFormatCSV, []byte(tt.data), true)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domain.ImportStatusValidated, imp.Status)
+		})
+	}
+}
+
+func TestImportUsecase_GetImport(t *testing.T) {
+	owner := uint(7)
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		wantErr   error
+	}{
+		{name: "正常系: 作成したユーザーは取得できる", principal: &auth.Principal{UserID: owner}},
+		{name: "正常系: APIキーは取得できる", principal: &auth.Principal{APIKey: "batch"}},
+		{name: "異常系: 他のユーザーのインポートは見つからない", principal: &auth.Principal{UserID: 8}, wantErr: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockImportRepo := importMocks.NewMockImportRepository(ctrl)
+			mockImportRepo.EXPECT().GetByID(gomock.Any(), uint(1)).
+				Return(&domain.Import{ID: 1, Status: domain.ImportStatusPending, Payload: []byte("name,email\n"), CreatedBy: &owner}, nil).Times(1)
+			uc := NewImportUsecase(mockImportRepo, nil, nil, nil, nil, nil, nil)
+
+			imp, err := uc.GetImport(auth.WithPrincipal(context.Background(), tt.principal), 1)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, imp.Payload)
+		})
+	}
+}
+
+func TestImportUsecase_ProcessImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportRepo := importMocks.NewMockImportRepository(ctrl)
+	mockUserRepo := userMocks.NewMockUserRepository(ctrl)
+	mockCompanyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+	mockCompanyUserRepo := companyMocks.NewMockCompanyUserRepository(ctrl)
+	events := outbox.NewMemoryStore()
+	uc := NewImportUsecase(mockImportRepo, mockUserRepo, mockCompanyRepo, mockCompanyUserRepo, infra.NoTx, outbox.New(events), nil)
+
+	imp := &domain.Import{
+		ID:     1,
+		Kind:   domain.ImportKindUsers,
+		Format: domain.ImportFormatCSV,
+		Status: domain.ImportStatusPending,
+		Payload: []byte("name,email,password\n" +
+			"新規ユーザー,new@example.com,password123\n" +
+			"山田太郎（更新）,yamada@example.com,\n" +
+			"パスワードなし,nopass@example.com,\n"),
+	}
+
+	mockImportRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(imp, nil).Times(1)
+	mockImportRepo.EXPECT().UpdateProgress(gomock.Any(), imp).Return(nil).Times(2)
+
+	// 新規作成
+	mockUserRepo.EXPECT().ExistsByEmail(gomock.Any(), "new@example.com").Return(false, nil).Times(1)
+	mockUserRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	// 既存ユーザーの更新（名前のみ）
+	mockUserRepo.EXPECT().ExistsByEmail(gomock.Any(), "yamada@example.com").Return(true, nil).Times(1)
+	mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "yamada@example.com").
+		Return(&domain.User{ID: 1, Name: "山田太郎", Email: "yamada@example.com", Version: 1}, nil).Times(1)
+	mockUserRepo.EXPECT().UpdateFields(gomock.Any(), gomock.Any(), "Name").Return(nil).Times(1)
+	// 新規作成にはパスワードが必要
+	mockUserRepo.EXPECT().ExistsByEmail(gomock.Any(), "nopass@example.com").Return(false, nil).Times(1)
+
+	err := uc.ProcessImport(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ImportStatusCompleted, imp.Status)
+	assert.Equal(t, 3, imp.TotalRows)
+	assert.Equal(t, 3, imp.ProcessedRows)
+	assert.Equal(t, 1, imp.CreatedRows)
+	assert.Equal(t, 1, imp.UpdatedRows)
+	assert.Equal(t, 1, imp.FailedRows)
+	require.Len(t, imp.Errors, 1)
+	assert.Equal(t, 4, imp.Errors[0].Line)
+	assert.NotNil(t, imp.CompletedAt)
+
+	messages := events.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, domain.EventUserCreated, messages[0].Type)
+	assert.Equal(t, domain.EventUserUpdated, messages[1].Type)
+}
+
+func TestImportUsecase_ProcessImport_Retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportRepo := importMocks.NewMockImportRepository(ctrl)
+	mockUserRepo := userMocks.NewMockUserRepository(ctrl)
+	uc := NewImportUsecase(mockImportRepo, mockUserRepo, nil, nil, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil)
+
+	// 途中まで処理して中断したインポート
+	imp := &domain.Import{
+		ID:            1,
+		Kind:          domain.ImportKindUsers,
+		Format:        domain.ImportFormatCSV,
+		Status:        domain.ImportStatusProcessing,
+		ProcessedRows: 1,
+		UpdatedRows:   1,
+		Errors:        domain.ImportRowErrors{{Message: "context canceled"}},
+		Payload:       []byte("name,email,password\n山田太郎,yamada@example.com,\n"),
+	}
+
+	mockImportRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(imp, nil).Times(1)
+	mockImportRepo.EXPECT().UpdateProgress(gomock.Any(), imp).Return(nil).Times(2)
+	mockUserRepo.EXPECT().ExistsByEmail(gomock.Any(), "yamada@example.com").Return(true, nil).Times(1)
+	mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "yamada@example.com").
+		Return(&domain.User{ID: 1, Name: "山田太郎", Email: "yamada@example.com"}, nil).Times(1)
+
+	require.NoError(t, uc.ProcessImport(context.Background(), 1))
+
+	// 前回の件数・エラーは数え直す
+	assert.Equal(t, domain.ImportStatusCompleted, imp.Status)
+	assert.Equal(t, 1, imp.ProcessedRows)
+	assert.Equal(t, 1, imp.UpdatedRows)
+	assert.Empty(t, imp.Errors)
+}
+
+func TestImportUsecase_ProcessImport_Finished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportRepo := importMocks.NewMockImportRepository(ctrl)
+	uc := NewImportUsecase(mockImportRepo, nil, nil, nil, nil, nil, nil)
+
+	// 完了済みのインポートは再処理しない
+	mockImportRepo.EXPECT().GetByID(gomock.Any(), uint(1)).
+		Return(&domain.Import{ID: 1, Status: domain.ImportStatusCompleted}, nil).Times(1)
+	assert.NoError(t, uc.ProcessImport(context.Background(), 1))
+
+	mockImportRepo.EXPECT().GetByID(gomock.Any(), uint(2)).
+		Return(nil, fmt.Errorf("import with id 2 %w", domain.ErrNotFound)).Times(1)
+	assert.ErrorIs(t, uc.ProcessImport(context.Background(), 2), domain.ErrNotFound)
+}
@@ -0,0 +1,51 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// DefaultCursorBatchSize サーバーサイドカーソルから1回に取得する件数
+const DefaultCursorBatchSize = 500
+
+// StreamCursor クエリをサーバーサイドカーソルで実行し、batchSize件ずつfnに渡す
+// 全件をメモリに載せずに大量の行を処理するために使う（読み取り専用トランザクション内で実行）
+// queryはプレースホルダー「?」を使ったSELECT文
+func StreamCursor[T any](ctx context.Context, db *gorm.DB, batchSize int, query string, args []interface{}, fn func([]T) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultCursorBatchSize
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION READ ONLY").Error; err != nil {
+			return fmt.Errorf("failed to start read-only transaction: %w", err)
+		}
+		if err := tx.Exec("DECLARE stream_cursor NO SCROLL CURSOR FOR "+query, args...).Error; err != nil {
+			return fmt.Errorf("failed to declare cursor: %w", err)
+		}
+
+		fetch := fmt.Sprintf("FETCH FORWARD %d FROM stream_cursor", batchSize)
+		for {
+			var batch []T
+			if err := tx.Raw(fetch).Scan(&batch).Error; err != nil {
+				return fmt.Errorf("failed to fetch from cursor: %w", err)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			if err := fn(batch); err != nil {
+				return err
+			}
+			if len(batch) < batchSize {
+				break
+			}
+		}
+
+		if err := tx.Exec("CLOSE stream_cursor").Error; err != nil {
+			return fmt.Errorf("failed to close cursor: %w", err)
+		}
+		return nil
+	})
+}
@@ -1,14 +1,16 @@
 package infra
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+
+	"km-api-go/internal/logging"
 )
 
 // DatabaseConfig データベース接続設定
@@ -24,21 +26,13 @@ type DatabaseConfig struct {
 // LoadDatabaseConfig 環境変数からデータベース設定を読み込み
 func LoadDatabaseConfig() *DatabaseConfig {
 	return &DatabaseConfig{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnv("DB_PORT", "5432"),
-		User:     getEnv("DB_USER", "postgres"),
-		Password: getEnv("DB_PASSWORD", "postgres"),
-		DBName:   getEnv("DB_NAME", "km_api"),
-		SSLMode:  getEnv("DB_SSLMODE", "disable"),
-	}
-}
-
-// getEnv 環境変数取得のヘルパー関数
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+		Host:     GetEnv("DB_HOST", "localhost"),
+		Port:     GetEnv("DB_PORT", "5432"),
+		User:     GetEnv("DB_USER", "postgres"),
+		Password: GetEnv("DB_PASSWORD", "postgres"),
+		DBName:   GetEnv("DB_NAME", "km_api"),
+		SSLMode:  GetEnv("DB_SSLMODE", "disable"),
 	}
-	return defaultValue
 }
 
 // NewDatabase データベース接続を初期化
@@ -55,13 +49,13 @@ func NewDatabase(config *DatabaseConfig) (*gorm.DB, error) {
 
 	// ログレベル設定
 	logLevel := logger.Info
-	if getEnv("GO_ENV", "development") == "production" {
+	if IsProduction() {
 		logLevel = logger.Error
 	}
 
 	// GORM設定
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
+		Logger: logging.NewGormLogger(logLevel, GetEnvDuration("DB_SLOW_THRESHOLD", 200*time.Millisecond)),
 		NowFunc: func() time.Time {
 			return time.Now().Local()
 		},
@@ -84,7 +78,7 @@ func NewDatabase(config *DatabaseConfig) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(100)               // 最大接続数
 	sqlDB.SetConnMaxLifetime(time.Hour * 1)  // 接続最大生存時間
 
-	log.Println("Database connected successfully")
+	slog.Info("Database connected successfully")
 	return db, nil
 }
 
@@ -105,18 +99,18 @@ func CloseDatabase(db *gorm.DB) error {
 		return fmt.Errorf("failed to close database connection: %w", err)
 	}
 
-	log.Println("Database connection closed")
+	slog.Info("Database connection closed")
 	return nil
 }
 
-// PingDatabase データベース接続確認
-func PingDatabase(db *gorm.DB) error {
+// PingDatabase データベース接続確認（ctxのタイムアウトに従う）
+func PingDatabase(ctx context.Context, db *gorm.DB) error {
 	sqlDB, err := db.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get database instance: %w", err)
 	}
 
-	if err := sqlDB.Ping(); err != nil {
+	if err := sqlDB.PingContext(ctx); err != nil {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
 
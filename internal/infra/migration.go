@@ -0,0 +1,140 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Migration マイグレーションファイル
+type Migration struct {
+	Version string // ファイル名先頭の番号（例: 001）
+	Path    string // ファイルパス
+}
+
+// schemaMigrationsDDL 適用済みマイグレーションを記録するテーブル
+const schemaMigrationsDDL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version VARCHAR(50) PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+)`
+
+// baselineMigrations schema_migrationsの導入前から実行していたマイグレーションと、作成するテーブル
+// 導入前のマイグレーションは毎回すべてのファイルを実行していたため、既存のスキーマには記録がない
+var baselineMigrations = []struct {
+	Version string
+	Table   string
+}{
+	{Version: "001", Table: "users"},
+	{Version: "002", Table: "companies"},
+}
+
+// MigrationsDir マイグレーションファイルのディレクトリ
+func MigrationsDir() string {
+	return GetEnv("MIGRATIONS_DIR", "migrations")
+}
+
+// ListMigrations ディレクトリ内のマイグレーションファイルをバージョン順に取得
+func ListMigrations(dir string) ([]Migration, error) {
+	var migrations []Migration
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".sql") {
+			return nil
+		}
+
+		version, _, _ := strings.Cut(d.Name(), "_")
+		migrations = append(migrations, Migration{Version: version, Path: path})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Path < migrations[j].Path })
+	return migrations, nil
+}
+
+// EnsureMigrationTable schema_migrationsテーブルを作成
+// 新しく作成した場合、既存のスキーマ（usersテーブルがある）ではテーブルのあるベースラインのマイグレーションを適用済みとして記録する
+func EnsureMigrationTable(ctx context.Context, db *gorm.DB) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		exists := tx.Migrator().HasTable("schema_migrations")
+		if err := tx.Exec(schemaMigrationsDDL).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations table: %w", err)
+		}
+		if exists || !tx.Migrator().HasTable("users") {
+			return nil
+		}
+		return adoptBaseline(tx)
+	})
+}
+
+// adoptBaseline 既存のスキーマにあるベースラインのマイグレーションを適用済みとして記録
+func adoptBaseline(tx *gorm.DB) error {
+	for _, b := range baselineMigrations {
+		if !tx.Migrator().HasTable(b.Table) {
+			continue
+		}
+		if err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?) ON CONFLICT DO NOTHING", b.Version).Error; err != nil {
+			return fmt.Errorf("failed to record baseline migration %s: %w", b.Version, err)
+		}
+		slog.Info("Adopted existing schema", slog.String("version", b.Version), slog.String("table", b.Table))
+	}
+	return nil
+}
+
+// AppliedMigrations 適用済みのマイグレーションバージョンを取得
+func AppliedMigrations(ctx context.Context, db *gorm.DB) (map[string]bool, error) {
+	var versions []string
+	if err := db.WithContext(ctx).Table("schema_migrations").Pluck("version", &versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	applied := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+	return applied, nil
+}
+
+// ApplyMigration マイグレーションを実行し、同一トランザクションで適用済みとして記録
+func ApplyMigration(ctx context.Context, db *gorm.DB, sql string, m Migration) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to execute migration file %s: %w", m.Path, err)
+		}
+		if err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", m.Version).Error; err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", m.Version, err)
+		}
+		return nil
+	})
+}
+
+// PendingMigrations 未適用のマイグレーションを取得
+func PendingMigrations(ctx context.Context, db *gorm.DB, dir string) ([]Migration, error) {
+	migrations, err := ListMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := AppliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		if !applied[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,20 @@
+package infra
+
+import (
+	"fmt"
+	"slices"
+)
+
+// OrderBy 一覧のソート条件のORDER BY句
+// sortByがcolumnsに含まれない場合はcreated_at、sortDirがasc以外の場合は降順とする
+// 同じ値の行の順序を安定させるため、最後にidで並べる
+func OrderBy(sortBy, sortDir string, columns []string) string {
+	if !slices.Contains(columns, sortBy) {
+		sortBy = "created_at"
+	}
+	dir := "DESC"
+	if sortDir == "asc" {
+		dir = "ASC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, dir, dir)
+}
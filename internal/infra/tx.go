@@ -0,0 +1,53 @@
+package infra
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transactor 複数のリポジトリ操作を1つのトランザクションで実行する
+type Transactor interface {
+	// WithinTx fnをトランザクション内で実行する（fnがエラーを返した場合はロールバック）
+	// fnに渡すコンテキストを使ったリポジトリ操作は同じトランザクションで実行される
+	// 既にトランザクション内の場合はそのトランザクションをそのまま使う
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormTransactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor GORMのトランザクションを使うTransactorのコンストラクタ
+func NewTransactor(db *gorm.DB) Transactor {
+	return &gormTransactor{db: db}
+}
+
+func (t *gormTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn コンテキストにトランザクションがあればそれを、なければdbを返す
+// リポジトリはdb.WithContextの代わりにこれを使い、WithinTxのトランザクションに参加する
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// NoTx トランザクションを使わずにfnを実行するTransactor（テスト用）
+var NoTx Transactor = noTx{}
+
+type noTx struct{}
+
+func (noTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,81 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Enqueuer ジョブを登録する
+type Enqueuer interface {
+	// Enqueue payloadをJSONに変換してジョブを登録する
+	Enqueue(ctx context.Context, jobType string, payload any, opts ...Option) (*Job, error)
+}
+
+// Option ジョブ登録時のオプション
+type Option func(*Job)
+
+// WithQueue 登録するキューを指定
+func WithQueue(queue string) Option {
+	return func(j *Job) { j.Queue = queue }
+}
+
+// WithRunAt 指定日時以降に実行する
+func WithRunAt(t time.Time) Option {
+	return func(j *Job) { j.RunAt = t }
+}
+
+// WithDelay 指定時間後に実行する
+func WithDelay(d time.Duration) Option {
+	return func(j *Job) { j.RunAt = j.RunAt.Add(d) }
+}
+
+// WithMaxAttempts 実行回数の上限を指定
+func WithMaxAttempts(n int) Option {
+	return func(j *Job) { j.MaxAttempts = n }
+}
+
+// WithUniqueKey 同じキーのジョブを重複して登録しない（重複時はErrDuplicateJob）
+func WithUniqueKey(key string) Option {
+	return func(j *Job) { j.UniqueKey = &key }
+}
+
+// Client Storeにジョブを登録するEnqueuer
+type Client struct {
+	store Store
+	cfg   Config
+	now   func() time.Time
+}
+
+// NewClient ジョブ登録クライアントのコンストラクタ
+func NewClient(store Store, cfg Config) *Client {
+	return &Client{store: store, cfg: cfg, now: time.Now}
+}
+
+func (c *Client) Enqueue(ctx context.Context, jobType string, payload any, opts ...Option) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload of %s: %w", jobType, err)
+	}
+
+	job := &Job{
+		Queue:       DefaultQueue,
+		Type:        jobType,
+		Payload:     data,
+		Status:      StatusPending,
+		MaxAttempts: c.cfg.MaxAttempts,
+		RunAt:       c.now(),
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = 1
+	}
+
+	if err := c.store.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
@@ -0,0 +1,51 @@
+package job
+
+import (
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// Config ジョブの実行設定
+type Config struct {
+	Embedded        bool          // APIサーバーのプロセス内でワーカーを動かすか（falseの場合はcmd/workerを別途起動する）
+	Queues          []string      // 処理するキュー
+	Concurrency     int           // 同時に実行するジョブ数
+	PollInterval    time.Duration // 実行待ちのジョブを確認する間隔
+	Timeout         time.Duration // 1回の実行時間の上限
+	LockTimeout     time.Duration // 実行中のまま放置されたジョブを実行待ちに戻すまでの時間
+	MaxAttempts     int           // 実行回数の上限（既定値）
+	RetryBase       time.Duration // 最初の再試行までの待機時間（以降は倍々に増やす）
+	RetryMax        time.Duration // 再試行までの待機時間の上限
+	Retention       time.Duration // 完了したジョブを保持する期間
+	ShutdownTimeout time.Duration // シャットダウン時に実行中のジョブの終了を待つ時間
+}
+
+// LoadConfig 環境変数からジョブの実行設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		Embedded:        infra.GetEnvBool("JOB_WORKER_EMBEDDED", true),
+		Queues:          infra.GetEnvList("JOB_QUEUES", []string{DefaultQueue}),
+		Concurrency:     infra.GetEnvInt("JOB_CONCURRENCY", 4),
+		PollInterval:    infra.GetEnvDuration("JOB_POLL_INTERVAL", time.Second),
+		Timeout:         infra.GetEnvDuration("JOB_TIMEOUT", 5*time.Minute),
+		LockTimeout:     infra.GetEnvDuration("JOB_LOCK_TIMEOUT", 15*time.Minute),
+		MaxAttempts:     infra.GetEnvInt("JOB_MAX_ATTEMPTS", 5),
+		RetryBase:       infra.GetEnvDuration("JOB_RETRY_BASE", 10*time.Second),
+		RetryMax:        infra.GetEnvDuration("JOB_RETRY_MAX", time.Hour),
+		Retention:       infra.GetEnvDuration("JOB_RETENTION", 7*24*time.Hour),
+		ShutdownTimeout: infra.GetEnvDuration("JOB_SHUTDOWN_TIMEOUT", 30*time.Second),
+	}
+}
+
+// Backoff attempts回目の実行が失敗した後、再試行までの待機時間
+func (c Config) Backoff(attempts int) time.Duration {
+	d := c.RetryBase
+	for i := 1; i < attempts && d < c.RetryMax; i++ {
+		d *= 2
+	}
+	if d > c.RetryMax {
+		d = c.RetryMax
+	}
+	return d
+}
@@ -0,0 +1,82 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ジョブの状態
+const (
+	StatusPending   = "pending"   // 実行待ち（RunAt以降に実行）
+	StatusRunning   = "running"   // 実行中
+	StatusCompleted = "completed" // 完了
+	StatusDead      = "dead"      // 再試行の上限に達した、または再試行しても成功しない失敗
+)
+
+// DefaultQueue キューを指定しない場合のキュー名
+const DefaultQueue = "default"
+
+// ErrDuplicateJob 同じUniqueKeyのジョブが既に登録されている
+var ErrDuplicateJob = errors.New("duplicate job")
+
+// Job キューに登録されたジョブ
+type Job struct {
+	ID          uint            `json:"id"`
+	Queue       string          `json:"queue"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	UniqueKey   *string         `json:"unique_key,omitempty"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`     // 実行した回数
+	MaxAttempts int             `json:"max_attempts"` // 実行回数の上限
+	RunAt       time.Time       `json:"run_at"`       // この日時以降に実行する
+	LockedAt    *time.Time      `json:"locked_at,omitempty"`
+	LockedBy    string          `json:"locked_by,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// Store ジョブの保存先
+type Store interface {
+	// Enqueue ジョブを登録する（UniqueKeyが重複する場合はErrDuplicateJob。コンテキストのトランザクションに参加する）
+	Enqueue(ctx context.Context, job *Job) error
+	// Claim 実行可能なジョブを最大limit件取得して実行中にする（他のワーカーがロック中のジョブは飛ばす）
+	Claim(ctx context.Context, queues []string, workerID string, limit int) ([]*Job, error)
+	// Complete ジョブを完了にする
+	Complete(ctx context.Context, id uint) error
+	// Retry ジョブをrunAtに再実行する
+	Retry(ctx context.Context, id uint, runAt time.Time, lastErr string) error
+	// Kill ジョブをデッドレターにする（再実行しない）
+	Kill(ctx context.Context, id uint, lastErr string) error
+	// RescueStale lockedBefore以前から実行中のままのジョブを実行待ちに戻す（ワーカーの異常終了対策）
+	// 実行回数の上限に達しているジョブはデッドレターにする
+	RescueStale(ctx context.Context, lockedBefore time.Time) (int64, error)
+	// Cleanup completedBefore以前に完了したジョブを削除
+	Cleanup(ctx context.Context, completedBefore time.Time) (int64, error)
+}
+
+// permanentError 再試行しない失敗
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 再試行しても成功しない失敗としてerrをラップする（ジョブはすぐにデッドレターになる）
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent Permanentでラップされた失敗か
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
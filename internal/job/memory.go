@@ -0,0 +1,168 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore プロセス内の保存先（テスト・単一プロセスの開発環境向け。再起動でジョブは失われる）
+type MemoryStore struct {
+	mu     sync.Mutex
+	jobs   map[uint]*Job
+	nextID uint
+	now    func() time.Time
+}
+
+// NewMemoryStore メモリ保存先のコンストラクタ
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[uint]*Job), now: time.Now}
+}
+
+func (s *MemoryStore) Enqueue(_ context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job.UniqueKey != nil {
+		for _, j := range s.jobs {
+			if j.UniqueKey != nil && *j.UniqueKey == *job.UniqueKey {
+				return fmt.Errorf("job %s with key %s: %w", job.Type, *job.UniqueKey, ErrDuplicateJob)
+			}
+		}
+	}
+
+	s.nextID++
+	now := s.now()
+	job.ID = s.nextID
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	copied := *job
+	s.jobs[job.ID] = &copied
+	return nil
+}
+
+func (s *MemoryStore) Claim(_ context.Context, queues []string, workerID string, limit int) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var ready []*Job
+	for _, j := range s.jobs {
+		if j.Status == StatusPending && slices.Contains(queues, j.Queue) && !j.RunAt.After(now) {
+			ready = append(ready, j)
+		}
+	}
+	sort.Slice(ready, func(a, b int) bool {
+		if !ready[a].RunAt.Equal(ready[b].RunAt) {
+			return ready[a].RunAt.Before(ready[b].RunAt)
+		}
+		return ready[a].ID < ready[b].ID
+	})
+	if len(ready) > limit {
+		ready = ready[:limit]
+	}
+
+	claimed := make([]*Job, len(ready))
+	for i, j := range ready {
+		j.Status = StatusRunning
+		j.Attempts++
+		j.LockedAt = &now
+		j.LockedBy = workerID
+		j.UpdatedAt = now
+		copied := *j
+		claimed[i] = &copied
+	}
+	return claimed, nil
+}
+
+func (s *MemoryStore) Complete(_ context.Context, id uint) error {
+	s.update(id, func(j *Job, now time.Time) {
+		j.Status = StatusCompleted
+		j.CompletedAt = &now
+	})
+	return nil
+}
+
+func (s *MemoryStore) Retry(_ context.Context, id uint, runAt time.Time, lastErr string) error {
+	s.update(id, func(j *Job, _ time.Time) {
+		j.Status = StatusPending
+		j.RunAt = runAt
+		j.LastError = lastErr
+	})
+	return nil
+}
+
+func (s *MemoryStore) Kill(_ context.Context, id uint, lastErr string) error {
+	s.update(id, func(j *Job, _ time.Time) {
+		j.Status = StatusDead
+		j.LastError = lastErr
+	})
+	return nil
+}
+
+// update 実行中のジョブの状態を更新
+func (s *MemoryStore) update(id uint, fn func(j *Job, now time.Time)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok || j.Status != StatusRunning {
+		return
+	}
+	now := s.now()
+	fn(j, now)
+	j.LockedAt = nil
+	j.LockedBy = ""
+	j.UpdatedAt = now
+}
+
+func (s *MemoryStore) RescueStale(_ context.Context, lockedBefore time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rescued int64
+	for _, j := range s.jobs {
+		if j.Status != StatusRunning || j.LockedAt == nil || j.LockedAt.After(lockedBefore) {
+			continue
+		}
+		j.Status = StatusPending
+		if j.Attempts >= j.MaxAttempts {
+			j.Status = StatusDead
+		}
+		j.LockedAt = nil
+		j.LockedBy = ""
+		j.LastError = "lock timed out"
+		rescued++
+	}
+	return rescued, nil
+}
+
+func (s *MemoryStore) Cleanup(_ context.Context, completedBefore time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for id, j := range s.jobs {
+		if j.Status == StatusCompleted && j.CompletedAt != nil && !j.CompletedAt.After(completedBefore) {
+			delete(s.jobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Get 登録されたジョブを取得（テスト用）
+func (s *MemoryStore) Get(id uint) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	copied := *j
+	return &copied, true
+}
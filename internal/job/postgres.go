@@ -0,0 +1,150 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"km-api-go/internal/infra"
+)
+
+// enqueueSQL UniqueKeyが重複する場合は登録せず、行を返さない
+const enqueueSQL = `
+INSERT INTO jobs (queue, type, payload, unique_key, status, attempts, max_attempts, run_at, created_at, updated_at)
+VALUES (@queue, @type, @payload, @unique_key, @status, 0, @max_attempts, @run_at, @now, @now)
+ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL DO NOTHING
+RETURNING id, created_at, updated_at`
+
+// claimSQL 実行可能なジョブをロックして実行中にする
+// SKIP LOCKEDにより、複数のワーカーが同時に取得しても同じジョブを重複して取得しない
+const claimSQL = `
+UPDATE jobs SET status = 'running', attempts = attempts + 1, locked_at = @now, locked_by = @worker, updated_at = @now
+WHERE id IN (
+    SELECT id FROM jobs
+    WHERE status = 'pending' AND queue IN @queues AND run_at <= @now
+    ORDER BY run_at, id
+    LIMIT @limit
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// PostgresStore jobsテーブルを使う保存先
+type PostgresStore struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewPostgresStore Postgres保存先のコンストラクタ
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db, now: time.Now}
+}
+
+func (s *PostgresStore) Enqueue(ctx context.Context, job *Job) error {
+	var inserted []Job
+	err := infra.Conn(ctx, s.db).Raw(enqueueSQL,
+		sql.Named("queue", job.Queue),
+		sql.Named("type", job.Type),
+		sql.Named("payload", string(job.Payload)),
+		sql.Named("unique_key", job.UniqueKey),
+		sql.Named("status", job.Status),
+		sql.Named("max_attempts", job.MaxAttempts),
+		sql.Named("run_at", job.RunAt),
+		sql.Named("now", s.now()),
+	).Scan(&inserted).Error
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job %s: %w", job.Type, err)
+	}
+	if len(inserted) == 0 {
+		return fmt.Errorf("job %s with key %s: %w", job.Type, *job.UniqueKey, ErrDuplicateJob)
+	}
+
+	job.ID = inserted[0].ID
+	job.CreatedAt = inserted[0].CreatedAt
+	job.UpdatedAt = inserted[0].UpdatedAt
+	return nil
+}
+
+func (s *PostgresStore) Claim(ctx context.Context, queues []string, workerID string, limit int) ([]*Job, error) {
+	var jobs []*Job
+	err := s.db.WithContext(ctx).Raw(claimSQL,
+		sql.Named("now", s.now()),
+		sql.Named("worker", workerID),
+		sql.Named("queues", queues),
+		sql.Named("limit", limit),
+	).Scan(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (s *PostgresStore) Complete(ctx context.Context, id uint) error {
+	now := s.now()
+	return s.finish(ctx, id, map[string]interface{}{
+		"status":       StatusCompleted,
+		"locked_at":    nil,
+		"locked_by":    "",
+		"completed_at": now,
+		"updated_at":   now,
+	})
+}
+
+func (s *PostgresStore) Retry(ctx context.Context, id uint, runAt time.Time, lastErr string) error {
+	return s.finish(ctx, id, map[string]interface{}{
+		"status":     StatusPending,
+		"run_at":     runAt,
+		"locked_at":  nil,
+		"locked_by":  "",
+		"last_error": lastErr,
+		"updated_at": s.now(),
+	})
+}
+
+func (s *PostgresStore) Kill(ctx context.Context, id uint, lastErr string) error {
+	return s.finish(ctx, id, map[string]interface{}{
+		"status":     StatusDead,
+		"locked_at":  nil,
+		"locked_by":  "",
+		"last_error": lastErr,
+		"updated_at": s.now(),
+	})
+}
+
+// finish 実行中のジョブの状態を更新（既に実行待ちに戻されたジョブは更新しない）
+func (s *PostgresStore) finish(ctx context.Context, id uint, values map[string]interface{}) error {
+	err := s.db.WithContext(ctx).Table("jobs").
+		Where("id = ? AND status = ?", id, StatusRunning).
+		Updates(values).Error
+	if err != nil {
+		return fmt.Errorf("failed to update job %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) RescueStale(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Table("jobs").
+		Where("status = ? AND locked_at <= ?", StatusRunning, lockedBefore).
+		Updates(map[string]interface{}{
+			// 実行回数の上限に達している場合は再実行しない
+			"status":     gorm.Expr("CASE WHEN attempts >= max_attempts THEN ? ELSE ? END", StatusDead, StatusPending),
+			"locked_at":  nil,
+			"locked_by":  "",
+			"last_error": "lock timed out",
+			"updated_at": s.now(),
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to rescue stale jobs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (s *PostgresStore) Cleanup(ctx context.Context, completedBefore time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Exec("DELETE FROM jobs WHERE status = ? AND completed_at <= ?", StatusCompleted, completedBefore)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to clean up jobs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,58 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// HandlerFunc ジョブを処理する関数
+type HandlerFunc func(ctx context.Context, job *Job) error
+
+// schedule 定期実行するジョブ
+type schedule struct {
+	jobType  string
+	interval time.Duration
+	payload  any
+	opts     []Option
+}
+
+// Registry ジョブの種類ごとのハンドラーと定期実行の登録先
+type Registry struct {
+	handlers  map[string]HandlerFunc
+	schedules []schedule
+}
+
+// NewRegistry 登録先のコンストラクタ
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]HandlerFunc)}
+}
+
+// Handle ジョブの種類にハンドラーを登録する
+func (r *Registry) Handle(jobType string, fn HandlerFunc) {
+	r.handlers[jobType] = fn
+}
+
+// Register ペイロードをTとして受け取る型付きハンドラーを登録する
+// ペイロードを変換できないジョブは再試行せずにデッドレターにする
+func Register[T any](r *Registry, jobType string, fn func(ctx context.Context, payload T) error) {
+	r.Handle(jobType, func(ctx context.Context, job *Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("failed to decode payload of %s: %w", jobType, err))
+		}
+		return fn(ctx, payload)
+	})
+}
+
+// Schedule ジョブをinterval間隔で定期実行する
+// 登録は間隔の区切りごとに一意なキーで行うため、複数のワーカーが動いていても1回だけ実行される
+func (r *Registry) Schedule(jobType string, interval time.Duration, payload any, opts ...Option) {
+	r.schedules = append(r.schedules, schedule{jobType: jobType, interval: interval, payload: payload, opts: opts})
+}
+
+func (r *Registry) handler(jobType string) (HandlerFunc, bool) {
+	fn, ok := r.handlers[jobType]
+	return fn, ok
+}
@@ -0,0 +1,241 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"km-api-go/internal/logging"
+	"km-api-go/internal/metrics"
+	"km-api-go/internal/tracing"
+)
+
+// Worker キューからジョブを取得して実行する
+type Worker struct {
+	store    Store
+	registry *Registry
+	cfg      Config
+	id       string
+	now      func() time.Time
+
+	slots  chan struct{} // 実行中のジョブ（容量が同時実行数）
+	wake   chan struct{} // ジョブの終了を通知して次の取得を早める
+	stop   chan struct{}
+	done   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWorker ワーカーのコンストラクタ
+func NewWorker(store Store, registry *Registry, cfg Config) *Worker {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	hostname, _ := os.Hostname()
+	return &Worker{
+		store:    store,
+		registry: registry,
+		cfg:      cfg,
+		id:       fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		now:      time.Now,
+		slots:    make(chan struct{}, cfg.Concurrency),
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start バックグラウンドでジョブの取得と実行を開始する
+// ctxの値（ロガーなど）はジョブに引き継ぐが、キャンセルは引き継がない（停止はShutdownで行う）
+func (w *Worker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	go w.run(ctx)
+
+	logging.FromContext(ctx).InfoContext(ctx, "job worker started",
+		slog.String("worker_id", w.id),
+		slog.Any("queues", w.cfg.Queues),
+		slog.Int("concurrency", w.cfg.Concurrency),
+	)
+}
+
+// Shutdown 新しいジョブの取得を止め、実行中のジョブの終了を待つ
+// ctxの期限までに終わらないジョブは中断する（中断したジョブは再試行される）
+func (w *Worker) Shutdown(ctx context.Context) error {
+	close(w.stop)
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		<-w.done
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	defer close(w.done)
+	defer w.cancel()
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var lastMaintenance time.Time
+	for {
+		if now := w.now(); now.Sub(lastMaintenance) >= w.maintenanceInterval() {
+			w.maintain(ctx, now)
+			lastMaintenance = now
+		}
+		w.poll(ctx)
+
+		select {
+		case <-w.stop:
+			w.wg.Wait()
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// maintenanceInterval 放置されたジョブの回収や定期実行の登録を行う間隔
+func (w *Worker) maintenanceInterval() time.Duration {
+	interval := w.cfg.LockTimeout / 2
+	for _, s := range w.registry.schedules {
+		interval = min(interval, s.interval)
+	}
+	return max(interval, w.cfg.PollInterval)
+}
+
+// poll 空いている枠の数だけジョブを取得して実行する
+func (w *Worker) poll(ctx context.Context) {
+	free := cap(w.slots) - len(w.slots)
+	if free == 0 {
+		return
+	}
+
+	jobs, err := w.store.Claim(ctx, w.cfg.Queues, w.id, free)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to claim jobs", slog.Any("error", err))
+		return
+	}
+
+	for _, job := range jobs {
+		w.slots <- struct{}{}
+		w.wg.Add(1)
+		metrics.JobsInFlight.Inc()
+		go func() {
+			defer func() {
+				metrics.JobsInFlight.Dec()
+				<-w.slots
+				w.wg.Done()
+				select {
+				case w.wake <- struct{}{}:
+				default:
+				}
+			}()
+			w.process(ctx, job)
+		}()
+	}
+}
+
+// process ジョブを実行し、結果に応じて完了・再試行・デッドレターにする
+func (w *Worker) process(ctx context.Context, job *Job) {
+	logger := logging.FromContext(ctx).With(
+		slog.Uint64("job_id", uint64(job.ID)),
+		slog.String("job_type", job.Type),
+		slog.Int("attempt", job.Attempts),
+	)
+	ctx = logging.WithLogger(ctx, logger)
+
+	start := w.now()
+	err := w.execute(ctx, job)
+	metrics.JobDuration.WithLabelValues(job.Type).Observe(time.Since(start).Seconds())
+
+	// 中断された場合も結果は保存する
+	storeCtx := context.WithoutCancel(ctx)
+	switch {
+	case err == nil:
+		metrics.JobsProcessed.WithLabelValues(job.Type, metrics.JobCompleted).Inc()
+		if err := w.store.Complete(storeCtx, job.ID); err != nil {
+			logger.ErrorContext(ctx, "failed to complete job", slog.Any("error", err))
+		}
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		metrics.JobsProcessed.WithLabelValues(job.Type, metrics.JobDead).Inc()
+		logger.ErrorContext(ctx, "job failed permanently", slog.Any("error", err))
+		if err := w.store.Kill(storeCtx, job.ID, err.Error()); err != nil {
+			logger.ErrorContext(ctx, "failed to dead-letter job", slog.Any("error", err))
+		}
+	default:
+		metrics.JobsProcessed.WithLabelValues(job.Type, metrics.JobRetried).Inc()
+		runAt := w.now().Add(w.cfg.Backoff(job.Attempts))
+		logger.WarnContext(ctx, "job failed, will retry", slog.Any("error", err), slog.Time("run_at", runAt))
+		if err := w.store.Retry(storeCtx, job.ID, runAt, err.Error()); err != nil {
+			logger.ErrorContext(ctx, "failed to reschedule job", slog.Any("error", err))
+		}
+	}
+}
+
+// execute ハンドラーを実行時間の上限付きで実行する（panicはエラーとして扱う）
+func (w *Worker) execute(ctx context.Context, job *Job) (err error) {
+	ctx, span := tracing.Start(ctx, "job "+job.Type,
+		attribute.Int64("job.id", int64(job.ID)),
+		attribute.Int("job.attempt", job.Attempts),
+	)
+	defer tracing.End(span, &err)
+
+	handler, ok := w.registry.handler(job.Type)
+	if !ok {
+		return Permanent(fmt.Errorf("no handler registered for job type %s", job.Type))
+	}
+
+	if w.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.cfg.Timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// maintain 放置されたジョブの回収、古いジョブの削除、定期実行ジョブの登録
+func (w *Worker) maintain(ctx context.Context, now time.Time) {
+	logger := logging.FromContext(ctx)
+
+	if rescued, err := w.store.RescueStale(ctx, now.Add(-w.cfg.LockTimeout)); err != nil {
+		logger.ErrorContext(ctx, "failed to rescue stale jobs", slog.Any("error", err))
+	} else if rescued > 0 {
+		logger.WarnContext(ctx, "rescued stale jobs", slog.Int64("count", rescued))
+	}
+
+	if w.cfg.Retention > 0 {
+		if _, err := w.store.Cleanup(ctx, now.Add(-w.cfg.Retention)); err != nil {
+			logger.ErrorContext(ctx, "failed to clean up jobs", slog.Any("error", err))
+		}
+	}
+
+	client := &Client{store: w.store, cfg: w.cfg, now: w.now}
+	for _, s := range w.registry.schedules {
+		slot := now.Truncate(s.interval)
+		opts := append([]Option{
+			WithRunAt(slot),
+			WithUniqueKey(fmt.Sprintf("%s@%d", s.jobType, slot.Unix())),
+		}, s.opts...)
+		if _, err := client.Enqueue(ctx, s.jobType, s.payload, opts...); err != nil && !errors.Is(err, ErrDuplicateJob) {
+			logger.ErrorContext(ctx, "failed to schedule job", slog.String("job_type", s.jobType), slog.Any("error", err))
+		}
+	}
+}
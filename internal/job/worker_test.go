@@ -0,0 +1,284 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPayload struct {
+	Name string `json:"name"`
+}
+
+func testConfig() Config {
+	return Config{
+		Queues:       []string{DefaultQueue},
+		Concurrency:  2,
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      time.Second,
+		LockTimeout:  time.Minute,
+		MaxAttempts:  3,
+		RetryBase:    10 * time.Second,
+		RetryMax:     time.Minute,
+	}
+}
+
+func TestConfig_Backoff(t *testing.T) {
+	cfg := testConfig()
+
+	assert.Equal(t, 10*time.Second, cfg.Backoff(1))
+	assert.Equal(t, 20*time.Second, cfg.Backoff(2))
+	assert.Equal(t, 40*time.Second, cfg.Backoff(3))
+	assert.Equal(t, time.Minute, cfg.Backoff(4))
+	assert.Equal(t, time.Minute, cfg.Backoff(100))
+}
+
+func TestWorker_Process(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name           string
+		jobType        string
+		payload        any
+		maxAttempts    int
+		handler        func(ctx context.Context, p testPayload) error
+		expectedStatus string
+		expectedRunAt  time.Time
+		expectedError  string
+	}{
+		{
+			name:           "正常系: 成功したジョブは完了",
+			jobType:        "test",
+			payload:        testPayload{Name: "山田"},
+			handler:        func(_ context.Context, p testPayload) error { return nil },
+			expectedStatus: StatusCompleted,
+		},
+		{
+			name:           "異常系: 失敗したジョブは待機時間を空けて再試行",
+			jobType:        "test",
+			payload:        testPayload{},
+			handler:        func(context.Context, testPayload) error { return errFailed },
+			expectedStatus: StatusPending,
+			expectedRunAt:  now.Add(10 * time.Second),
+			expectedError:  "failed",
+		},
+		{
+			name:           "異常系: 実行回数の上限に達したジョブはデッドレター",
+			jobType:        "test",
+			payload:        testPayload{},
+			maxAttempts:    1,
+			handler:        func(context.Context, testPayload) error { return errFailed },
+			expectedStatus: StatusDead,
+			expectedError:  "failed",
+		},
+		{
+			name:           "異常系: Permanentな失敗は再試行しない",
+			jobType:        "test",
+			payload:        testPayload{},
+			handler:        func(context.Context, testPayload) error { return Permanent(errFailed) },
+			expectedStatus: StatusDead,
+			expectedError:  "failed",
+		},
+		{
+			name:           "異常系: 変換できないペイロードは再試行しない",
+			jobType:        "test",
+			payload:        "not an object",
+			handler:        func(context.Context, testPayload) error { return nil },
+			expectedStatus: StatusDead,
+			expectedError:  "failed to decode payload of test: json: cannot unmarshal string into Go value of type job.testPayload",
+		},
+		{
+			name:           "異常系: ハンドラーが登録されていない",
+			jobType:        "unknown",
+			payload:        testPayload{},
+			handler:        func(context.Context, testPayload) error { return nil },
+			expectedStatus: StatusDead,
+			expectedError:  "no handler registered for job type unknown",
+		},
+		{
+			name:           "異常系: panicは失敗として再試行",
+			jobType:        "test",
+			payload:        testPayload{},
+			handler:        func(context.Context, testPayload) error { panic("boom") },
+			expectedStatus: StatusPending,
+			expectedRunAt:  now.Add(10 * time.Second),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewMemoryStore()
+			store.now = func() time.Time { return now }
+			registry := NewRegistry()
+			Register(registry, "test", tt.handler)
+			worker := NewWorker(store, registry, testConfig())
+			worker.now = store.now
+
+			client := NewClient(store, testConfig())
+			client.now = store.now
+			var opts []Option
+			if tt.maxAttempts > 0 {
+				opts = append(opts, WithMaxAttempts(tt.maxAttempts))
+			}
+			enqueued, err := client.Enqueue(ctx, tt.jobType, tt.payload, opts...)
+			require.NoError(t, err)
+
+			claimed, err := store.Claim(ctx, []string{DefaultQueue}, "test-worker", 10)
+			require.NoError(t, err)
+			require.Len(t, claimed, 1)
+			worker.process(ctx, claimed[0])
+
+			job, ok := store.Get(enqueued.ID)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedStatus, job.Status)
+			assert.Equal(t, 1, job.Attempts)
+			assert.Nil(t, job.LockedAt)
+			if !tt.expectedRunAt.IsZero() {
+				assert.Equal(t, tt.expectedRunAt, job.RunAt)
+			}
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, job.LastError)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_Claim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	client := NewClient(store, testConfig())
+	client.now = store.now
+
+	later, err := client.Enqueue(ctx, "test", nil, WithDelay(time.Minute))
+	require.NoError(t, err)
+	first, err := client.Enqueue(ctx, "test", nil)
+	require.NoError(t, err)
+	_, err = client.Enqueue(ctx, "test", nil, WithQueue("other"))
+	require.NoError(t, err)
+
+	t.Run("正常系: 実行日時を過ぎた対象キューのジョブのみ取得", func(t *testing.T) {
+		claimed, err := store.Claim(ctx, []string{DefaultQueue}, "w1", 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, first.ID, claimed[0].ID)
+		assert.Equal(t, "w1", claimed[0].LockedBy)
+
+		claimed, err = store.Claim(ctx, []string{DefaultQueue}, "w2", 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+	})
+
+	t.Run("正常系: 遅延したジョブは実行日時以降に取得", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		claimed, err := store.Claim(ctx, []string{DefaultQueue}, "w2", 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, later.ID, claimed[0].ID)
+	})
+
+	t.Run("正常系: ロックが切れたジョブは実行待ちに戻す", func(t *testing.T) {
+		rescued, err := store.RescueStale(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), rescued)
+
+		job, _ := store.Get(first.ID)
+		assert.Equal(t, StatusPending, job.Status)
+	})
+
+	t.Run("異常系: 同じUniqueKeyは重複して登録しない", func(t *testing.T) {
+		_, err := client.Enqueue(ctx, "test", nil, WithUniqueKey("k"))
+		require.NoError(t, err)
+		_, err = client.Enqueue(ctx, "test", nil, WithUniqueKey("k"))
+		assert.ErrorIs(t, err, ErrDuplicateJob)
+	})
+}
+
+func TestWorker_Schedule(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	registry := NewRegistry()
+	registry.Schedule("cleanup", time.Hour, nil)
+	worker := NewWorker(store, registry, testConfig())
+	worker.now = store.now
+
+	// 同じ区切りの間は何回呼んでも1件のみ
+	worker.maintain(ctx, now)
+	worker.maintain(ctx, now.Add(10*time.Minute))
+	claimed, err := store.Claim(ctx, []string{DefaultQueue}, "w1", 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "cleanup", claimed[0].Type)
+	require.NoError(t, store.Complete(ctx, claimed[0].ID))
+
+	// 次の区切りで新たに登録
+	now = now.Add(time.Hour)
+	worker.maintain(ctx, now)
+	claimed, err = store.Claim(ctx, []string{DefaultQueue}, "w1", 10)
+	require.NoError(t, err)
+	assert.Len(t, claimed, 1)
+}
+
+func TestWorker_Shutdown(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	registry := NewRegistry()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	Register(registry, "slow", func(ctx context.Context, _ testPayload) error {
+		close(started)
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	job, err := NewClient(store, testConfig()).Enqueue(ctx, "slow", testPayload{})
+	require.NoError(t, err)
+
+	t.Run("正常系: 実行中のジョブの終了を待つ", func(t *testing.T) {
+		worker := NewWorker(store, registry, testConfig())
+		worker.Start(ctx)
+		<-started
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+		}()
+		require.NoError(t, worker.Shutdown(ctx))
+
+		got, _ := store.Get(job.ID)
+		assert.Equal(t, StatusCompleted, got.Status)
+	})
+
+	t.Run("異常系: 期限までに終わらないジョブは中断して再試行", func(t *testing.T) {
+		started = make(chan struct{})
+		release = make(chan struct{})
+		job, err := NewClient(store, testConfig()).Enqueue(ctx, "slow", testPayload{})
+		require.NoError(t, err)
+
+		worker := NewWorker(store, registry, testConfig())
+		worker.Start(ctx)
+		<-started
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, worker.Shutdown(shutdownCtx), context.DeadlineExceeded)
+
+		got, _ := store.Get(job.ID)
+		assert.Equal(t, StatusPending, got.Status)
+		assert.Equal(t, context.Canceled.Error(), got.LastError)
+	})
+}
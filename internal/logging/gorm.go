@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// GormLogger GORMのログをslogに出力するアダプター
+// コンテキストのロガーを使うため、WithContext付きのクエリにはリクエストIDが付与される
+type GormLogger struct {
+	level         gormLogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGormLogger GORMロガーアダプターのコンストラクタ
+func NewGormLogger(level gormLogger.LogLevel, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{level: level, slowThreshold: slowThreshold}
+}
+
+// LogMode ログレベルを変更したロガーを返す
+func (l *GormLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Info {
+		FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Warn {
+		FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Error {
+		FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace SQLの実行結果を所要時間・影響行数とともに出力
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	logger := FromContext(ctx)
+
+	attrs := func() []any {
+		sql, rows := fc()
+		return []any{
+			slog.String("sql", sql),
+			slog.Int64("rows", rows),
+			slog.Duration("duration", elapsed),
+		}
+	}
+
+	switch {
+	case err != nil && l.level >= gormLogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		logger.ErrorContext(ctx, "sql error", append(attrs(), slog.String("error", err.Error()))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormLogger.Warn:
+		logger.WarnContext(ctx, "slow sql", append(attrs(), slog.Duration("threshold", l.slowThreshold))...)
+	case l.level >= gormLogger.Info:
+		logger.DebugContext(ctx, "sql", attrs()...)
+	}
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Config ロガー設定
+type Config struct {
+	Level  slog.Level // 出力する最小ログレベル
+	Format string     // 出力形式（json, text）
+}
+
+// LoadConfig 環境変数からロガー設定を読み込み
+// LOG_FORMAT未指定時は本番環境でjson、それ以外でtextを使う
+// infraパッケージがこのパッケージに依存するため、環境変数は直接参照する
+func LoadConfig() Config {
+	format := strings.ToLower(os.Getenv("LOG_FORMAT"))
+	if format == "" {
+		format = "text"
+		if os.Getenv("GO_ENV") == "production" {
+			format = "json"
+		}
+	}
+
+	return Config{
+		Level:  ParseLevel(os.Getenv("LOG_LEVEL")),
+		Format: format,
+	}
+}
+
+// ParseLevel ログレベル文字列をslog.Levelに変換（不明な値はinfo）
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// New 設定に基づいてロガーを作成
+func New(cfg Config, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.Level}
+
+	var handler slog.Handler
+	if cfg.Format == "json" {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+
+	return slog.New(handler)
+}
+
+// Init 環境変数の設定でロガーを作成し、デフォルトロガーとして登録
+func Init() *slog.Logger {
+	logger := New(LoadConfig(), os.Stdout)
+	slog.SetDefault(logger)
+	return logger
+}
+
+type loggerKey struct{}
+type requestIDKey struct{}
+
+// WithLogger コンテキストにロガーを格納
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext コンテキストからロガーを取得（未設定の場合はデフォルトロガー）
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+// WithRequestID コンテキストにリクエストIDを格納し、ロガーにも付与
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return WithLogger(ctx, FromContext(ctx).With(slog.String("request_id", requestID)))
+}
+
+// RequestIDFromContext コンテキストからリクエストIDを取得
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
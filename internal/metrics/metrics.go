@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// namespace メトリクス名のプレフィックス
+const namespace = "km_api"
+
+// HTTPメトリクス
+var (
+	// HTTPRequestDuration HTTPリクエストの処理時間（ルートテンプレート・ステータス別）
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestsInFlight 処理中のHTTPリクエスト数
+	HTTPRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+)
+
+// データベースメトリクス
+var (
+	// DBQueryDuration GORMクエリの実行時間（操作・テーブル別）
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "GORM query latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+)
+
+// ジョブメトリクス
+var (
+	// JobsProcessed 処理したジョブ数（ジョブの種類・結果別）
+	JobsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "processed_total",
+		Help:      "Number of background jobs processed by type and result.",
+	}, []string{"type", "result"})
+
+	// JobDuration ジョブの実行時間（ジョブの種類別）
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "duration_seconds",
+		Help:      "Background job execution time by type.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"type"})
+
+	// JobsInFlight 実行中のジョブ数
+	JobsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "in_flight",
+		Help:      "Number of background jobs currently running in this process.",
+	})
+)
+
+// ドメインメトリクス
+var (
+	// UsersCreated 作成されたユーザー数
+	UsersCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_created_total",
+		Help:      "Number of users created.",
+	})
+
+	// Logins ログイン試行数（result: succeeded, failed）
+	Logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Number of login attempts by result.",
+	}, []string{"result"})
+
+	// MembershipChanges 会社メンバーシップの変更数（action: added, role_changed, removed）
+	MembershipChanges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "membership_changes_total",
+		Help:      "Number of company membership changes by action.",
+	}, []string{"action"})
+)
+
+// Logins の result ラベル値
+const (
+	LoginSucceeded = "succeeded"
+	LoginFailed    = "failed"
+)
+
+// MembershipChanges の action ラベル値
+const (
+	MembershipAdded       = "added"
+	MembershipRoleChanged = "role_changed"
+	MembershipRemoved     = "removed"
+)
+
+// JobsProcessed の result ラベル値
+const (
+	JobCompleted = "completed"
+	JobRetried   = "retried"
+	JobDead      = "dead"
+)
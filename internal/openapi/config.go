@@ -0,0 +1,18 @@
+package openapi
+
+import "km-api-go/internal/infra"
+
+// Config OpenAPI仕様によるリクエスト・レスポンス検証の設定
+type Config struct {
+	Enabled           bool // 仕様に合わないリクエストを400で拒否するか
+	ValidateResponses bool // レスポンスも検証し、仕様違反を500にするか（テスト・開発用）
+}
+
+// LoadConfig 環境変数からOpenAPI検証の設定を読み込み
+// 本番環境ではOPENAPI_VALIDATE_RESPONSESに関わらずレスポンスを検証しない
+func LoadConfig() Config {
+	return Config{
+		Enabled:           infra.GetEnvBool("OPENAPI_VALIDATION_ENABLED", true),
+		ValidateResponses: infra.GetEnvBool("OPENAPI_VALIDATE_RESPONSES", false) && !infra.IsProduction(),
+	}
+}
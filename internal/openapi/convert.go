@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OpenAPI 3.1の出力に付けるバージョン・JSON Schemaの方言
+const (
+	Version31         = "3.1.0"
+	JSONSchemaDialect = "https://spec.openapis.org/oas/3.1/dialect/base"
+)
+
+// ToV3 swagで生成した仕様（Swagger 2.0のJSON）をOpenAPI 3に変換する
+func ToV3(spec []byte) (*openapi3.T, error) {
+	var doc2 openapi2.T
+	if err := json.Unmarshal(spec, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+	doc, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert openapi spec: %w", err)
+	}
+	return doc, nil
+}
+
+// MarshalV31 OpenAPI 3.1のドキュメント（JSONとして扱える値）に変換する
+// kin-openapiは3.0の形式で出力するため、3.1で変わったスキーマのキーワードを書き換える
+//   - nullable: true → typeに"null"を追加
+//   - exclusiveMinimum/exclusiveMaximum: true → minimum/maximumの値を移す
+//   - example → examples（配列）
+func MarshalV31(doc *openapi3.T) (map[string]any, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal openapi spec: %w", err)
+	}
+	var v31 map[string]any
+	if err := json.Unmarshal(b, &v31); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal openapi spec: %w", err)
+	}
+
+	v31["openapi"] = Version31
+	v31["jsonSchemaDialect"] = JSONSchemaDialect
+	upgradeDocument(v31)
+	return v31, nil
+}
+
+// EncodeJSON ドキュメントを整形したJSONにする（swagの出力と同じインデント）
+func EncodeJSON(doc map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode openapi spec: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// upgradeDocument ドキュメント内のスキーマ（components.schemasと各schema）を3.1の形式にする
+func upgradeDocument(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			switch key {
+			case "schema":
+				upgradeSchema(child)
+			case "schemas":
+				if schemas, ok := child.(map[string]any); ok {
+					for _, schema := range schemas {
+						upgradeSchema(schema)
+					}
+				}
+			default:
+				upgradeDocument(child)
+			}
+		}
+	case []any:
+		for _, child := range v {
+			upgradeDocument(child)
+		}
+	}
+}
+
+// upgradeSchema スキーマと入れ子のスキーマを3.1（JSON Schema 2020-12）の形式にする
+func upgradeSchema(v any) {
+	schema, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		switch typ := schema["type"].(type) {
+		case string:
+			schema["type"] = []any{typ, "null"}
+		case []any:
+			schema["type"] = append(typ, "null")
+		}
+	}
+	delete(schema, "nullable")
+
+	for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		if flag, ok := schema[exclusive].(bool); ok {
+			delete(schema, exclusive)
+			if value, ok := schema[bound]; ok && flag {
+				schema[exclusive] = value
+				delete(schema, bound)
+			}
+		}
+	}
+
+	if example, ok := schema["example"]; ok {
+		schema["examples"] = []any{example}
+		delete(schema, "example")
+	}
+
+	for _, key := range []string{"items", "not", "additionalProperties"} {
+		upgradeSchema(schema[key])
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := schema[key].([]any); ok {
+			for _, child := range list {
+				upgradeSchema(child)
+			}
+		}
+	}
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		for _, child := range properties {
+			upgradeSchema(child)
+		}
+	}
+}
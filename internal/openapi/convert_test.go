@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/swagger/src"
+	v2docs "km-api-go/swagger/v2"
+)
+
+func TestMarshalV31(t *testing.T) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: "test", Version: "1.0"},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{
+			"user.UserResponse": openapi3.NewSchemaRef("", &openapi3.Schema{
+				Type: &openapi3.Types{openapi3.TypeObject},
+				Properties: openapi3.Schemas{
+					"name":  openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Nullable: true, Example: "山田太郎"}),
+					"age":   openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeInteger}, Min: openapi3.Float64Ptr(0), ExclusiveMin: true}),
+					"score": openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNumber}, Max: openapi3.Float64Ptr(100)}),
+					"tags": openapi3.NewSchemaRef("", &openapi3.Schema{
+						Type:  &openapi3.Types{openapi3.TypeArray},
+						Items: openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Nullable: true}),
+					}),
+				},
+			}),
+		}},
+	}
+
+	v31, err := MarshalV31(doc)
+	require.NoError(t, err)
+	b, err := EncodeJSON(v31)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"openapi": "3.1.0",
+		"jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+		"info": {"title": "test", "version": "1.0"},
+		"paths": {},
+		"components": {"schemas": {"user.UserResponse": {
+			"type": "object",
+			"properties": {
+				"name": {"type": ["string", "null"], "examples": ["山田太郎"]},
+				"age": {"type": "integer", "exclusiveMinimum": 0},
+				"score": {"type": "number", "maximum": 100},
+				"tags": {"type": "array", "items": {"type": ["string", "null"]}}
+			}
+		}}}
+	}`, string(b))
+}
+
+// swagger/src/openapi.json がswagの仕様から再生成されているか（task docs の実行漏れを検出する）
+func TestMarshalV31_GeneratedSpecIsUpToDate(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		path string
+	}{
+		{name: "v1", spec: src.SwaggerInfo.ReadDoc(), path: "../../swagger/src/openapi.json"},
+		{name: "v2", spec: v2docs.SwaggerInfov2.ReadDoc(), path: "../../swagger/v2/openapi.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ToV3([]byte(tt.spec))
+			require.NoError(t, err)
+			v31, err := MarshalV31(doc)
+			require.NoError(t, err)
+			expected, err := EncodeJSON(v31)
+			require.NoError(t, err)
+
+			actual, err := os.ReadFile(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual), "%s is stale; run `task docs`", tt.path)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ErrRouteNotFound 仕様に定義されていないルート
+var ErrRouteNotFound = errors.New("route not found in openapi spec")
+
+func init() {
+	// PATCHのリクエストボディ（RFC 7396 / RFC 6902）もJSONとして検証する
+	openapi3filter.RegisterBodyDecoder("application/merge-patch+json", openapi3filter.JSONBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/json-patch+json", openapi3filter.JSONBodyDecoder)
+}
+
+// Issue 仕様に合わない箇所
+type Issue struct {
+	In      string `json:"in" example:"body"`              // 場所（path, query, body, response）
+	Name    string `json:"name,omitempty" example:"email"` // パラメーター名・ボディ内のフィールドのパス
+	Message string `json:"message" example:"property \"email\" is missing"`
+}
+
+// ValidationError リクエスト・レスポンスが仕様に合わない
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.Name != "" {
+			messages = append(messages, fmt.Sprintf("%s %s: %s", issue.In, issue.Name, issue.Message))
+		} else {
+			messages = append(messages, fmt.Sprintf("%s: %s", issue.In, issue.Message))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validator swagで生成したOpenAPI仕様（Swagger 2.0）でリクエスト・レスポンスを検証する
+type Validator struct {
+	doc      *openapi3.T
+	basePath string
+}
+
+// NewValidator 仕様（Swagger 2.0のJSON）を読み込んでOpenAPI 3に変換する
+// ヘッダーのパラメーター（If-Match, Idempotency-Key等）はハンドラー・ミドルウェアが
+// 専用のステータス（428等）で検証するため対象外にする
+func NewValidator(spec []byte) (*Validator, error) {
+	doc, err := ToV3(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range doc.Paths.Map() {
+		for _, op := range item.Operations() {
+			params := op.Parameters[:0]
+			for _, p := range op.Parameters {
+				if p.Value != nil && (p.Value.In == openapi3.ParameterInHeader || p.Value.In == openapi3.ParameterInCookie) {
+					continue
+				}
+				params = append(params, p)
+			}
+			op.Parameters = params
+		}
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+	// hostのない仕様はserversに変換されないため、ベースパスは元の仕様から読む
+	var base struct {
+		BasePath string `json:"basePath"`
+	}
+	if err := json.Unmarshal(spec, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+	return &Validator{doc: doc, basePath: strings.TrimSuffix(base.BasePath, "/")}, nil
+}
+
+// echoParam Echoのルートのパラメーター（:id）
+var echoParam = regexp.MustCompile(`:([^/]+)`)
+
+// Route 仕様のルート
+// pathはEchoのルート（/api/v1/companies/:id）で、ベースパスを除いて仕様のパス（/companies/{id}）と照合する
+func (v *Validator) Route(method, path string) (*routers.Route, error) {
+	rel, ok := strings.CutPrefix(path, v.basePath)
+	if !ok {
+		return nil, ErrRouteNotFound
+	}
+	rel = echoParam.ReplaceAllString(rel, "{$1}")
+
+	item := v.doc.Paths.Value(rel)
+	if item == nil {
+		return nil, ErrRouteNotFound
+	}
+	op := item.GetOperation(method)
+	if op == nil {
+		return nil, ErrRouteNotFound
+	}
+	return &routers.Route{Spec: v.doc, Path: rel, PathItem: item, Method: method, Operation: op}, nil
+}
+
+// ValidateRequest パス・クエリ・ボディを検証する
+// ボディはJSONで、仕様がそのメディアタイプを受け付ける場合のみ検証する（CSV等はハンドラーに任せる）
+func (v *Validator) ValidateRequest(ctx context.Context, req *http.Request, route *routers.Route, pathParams map[string]string) error {
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			ExcludeRequestBody:  !validatesBody(route.Operation, req.Header.Get("Content-Type")),
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+	if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+		return &ValidationError{Issues: issuesOf(err)}
+	}
+	return nil
+}
+
+// ValidateResponse ステータス・ボディを検証する（仕様にないステータスは検証しない）
+func (v *Validator) ValidateResponse(ctx context.Context, req *http.Request, route *routers.Route, pathParams map[string]string, status int, header http.Header, body []byte) error {
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: status,
+		Header: header,
+		Options: &openapi3filter.Options{
+			MultiError:         true,
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+	input.SetBodyBytes(body)
+	if err := openapi3filter.ValidateResponse(ctx, input); err != nil {
+		issues := issuesOf(err)
+		for i := range issues {
+			issues[i].In = "response"
+		}
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// validatesBody リクエストボディを検証するか
+func validatesBody(op *openapi3.Operation, contentType string) bool {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !isJSON(mediaType) {
+		return false
+	}
+	return op.RequestBody.Value.Content.Get(mediaType) != nil
+}
+
+// IsJSON JSON（application/json, application/*+json）のメディアタイプか
+func IsJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && isJSON(mediaType)
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// issuesOf 検証エラーを場所・名前ごとの指摘に分解する
+// 各エラーはUnwrapで内側のエラーを返すため、errors.Asではなく外側から順に型で判定する
+func issuesOf(err error) []Issue {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var issues []Issue
+		for _, inner := range e {
+			issues = append(issues, issuesOf(inner)...)
+		}
+		return issues
+
+	case *openapi3filter.RequestError:
+		in, name := "body", ""
+		if p := e.Parameter; p != nil {
+			in, name = p.In, p.Name
+		}
+		if e.Err == nil {
+			return []Issue{{In: in, Name: name, Message: e.Reason}}
+		}
+		issues := issuesOf(e.Err)
+		for i := range issues {
+			issues[i].In = in
+			if e.Parameter != nil {
+				issues[i].Name = name
+			}
+		}
+		return issues
+
+	case *openapi3filter.ResponseError:
+		if e.Err == nil {
+			return []Issue{{Message: e.Reason}}
+		}
+		return issuesOf(e.Err)
+
+	case *openapi3.SchemaError:
+		return []Issue{{Name: strings.Join(e.JSONPointer(), "."), Message: e.Reason}}
+	}
+
+	return []Issue{{Message: err.Error()}}
+}
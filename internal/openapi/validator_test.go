@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/swagger/src"
+)
+
+// 生成済みの仕様（swagger/src）を読み込めることも確認する
+func newGeneratedValidator(t *testing.T) *Validator {
+	v, err := NewValidator([]byte(src.SwaggerInfo.ReadDoc()))
+	require.NoError(t, err)
+	return v
+}
+
+func TestValidator_Route(t *testing.T) {
+	v := newGeneratedValidator(t)
+
+	route, err := v.Route(http.MethodPut, "/api/v1/companies/:id/webhooks/:webhook_id")
+	require.NoError(t, err)
+	assert.Equal(t, "/companies/{id}/webhooks/{webhook_id}", route.Path)
+
+	_, err = v.Route(http.MethodDelete, "/api/v1/users/:id")
+	assert.ErrorIs(t, err, ErrRouteNotFound)
+	_, err = v.Route(http.MethodGet, "/metrics")
+	assert.ErrorIs(t, err, ErrRouteNotFound)
+}
+
+func TestValidator_ValidateRequest(t *testing.T) {
+	v := newGeneratedValidator(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		route       string
+		target      string
+		contentType string
+		body        string
+		pathParams  map[string]string
+		expected    []Issue
+	}{
+		{
+			name:        "正常系: ユーザー作成",
+			method:      http.MethodPost,
+			route:       "/api/v1/users",
+			target:      "/api/v1/users",
+			contentType: "application/json",
+			body:        `{"name":"山田太郎","email":"yamada@example.com","password":"password123"}`,
+		},
+		{
+			name:        "正常系: If-Matchの有無は検証しない（ハンドラーが428を返す）",
+			method:      http.MethodPut,
+			route:       "/api/v1/companies/:id",
+			target:      "/api/v1/companies/1",
+			contentType: "application/json",
+			body:        `{"name":"会社A","email":"a@example.com"}`,
+			pathParams:  map[string]string{"id": "1"},
+		},
+		{
+			name:        "正常系: JSON以外のボディはハンドラーに任せる",
+			method:      http.MethodPost,
+			route:       "/api/v1/imports",
+			target:      "/api/v1/imports?type=users",
+			contentType: "text/csv",
+			body:        "name,email\n",
+		},
+		{
+			name:        "正常系: JSON Patchの配列",
+			method:      http.MethodPatch,
+			route:       "/api/v1/users/:id",
+			target:      "/api/v1/users/1",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"replace","path":"/name","value":"山田花子"}]`,
+			pathParams:  map[string]string{"id": "1"},
+		},
+		{
+			name:        "異常系: 必須項目がない・型が違う",
+			method:      http.MethodPost,
+			route:       "/api/v1/users",
+			target:      "/api/v1/users",
+			contentType: "application/json",
+			body:        `{"name":1,"password":"password123"}`,
+			expected: []Issue{
+				{In: "body", Name: "name", Message: `value must be a string`},
+				{In: "body", Name: "email", Message: `property "email" is missing`},
+			},
+		},
+		{
+			name:        "異常系: パスパラメーターが整数でない",
+			method:      http.MethodPut,
+			route:       "/api/v1/companies/:id",
+			target:      "/api/v1/companies/abc",
+			contentType: "application/json",
+			body:        `{"name":"会社A","email":"a@example.com"}`,
+			pathParams:  map[string]string{"id": "abc"},
+			expected:    []Issue{{In: "path", Name: "id", Message: `value abc: an invalid integer: invalid syntax`}},
+		},
+		{
+			name:     "異常系: 列挙にないクエリ",
+			method:   http.MethodGet,
+			route:    "/api/v1/exports/users",
+			target:   "/api/v1/exports/users?format=xml",
+			expected: []Issue{{In: "query", Name: "format", Message: `value is not one of the allowed values ["csv","jsonl","xlsx"]`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := v.Route(tt.method, tt.route)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			err = v.ValidateRequest(context.Background(), req, route, tt.pathParams)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.ElementsMatch(t, tt.expected, validationErr.Issues)
+		})
+	}
+}
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	v := newGeneratedValidator(t)
+	route, err := v.Route(http.MethodGet, "/api/v1/users/:id")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	header := http.Header{"Content-Type": {"application/json"}}
+	pathParams := map[string]string{"id": "1"}
+
+	err = v.ValidateResponse(context.Background(), req, route, pathParams, http.StatusOK, header,
+		[]byte(`{"success":true,"data":{"id":1,"name":"山田太郎","email":"yamada@example.com","version":1}}`))
+	assert.NoError(t, err)
+
+	err = v.ValidateResponse(context.Background(), req, route, pathParams, http.StatusOK, header,
+		[]byte(`{"success":"yes","data":{"id":"1"}}`))
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	for _, issue := range validationErr.Issues {
+		assert.Equal(t, "response", issue.In)
+	}
+	assert.NotEmpty(t, validationErr.Issues)
+}
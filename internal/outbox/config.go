@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// 配信先の種別
+const (
+	SinkLog     = "log"
+	SinkWebhook = "webhook"
+)
+
+// Config アウトボックスの配信設定
+type Config struct {
+	Sinks          []string      // 配信先（log, webhook）
+	PollInterval   time.Duration // 未配信のメッセージを確認する間隔
+	BatchSize      int           // 1回に処理するメッセージ数
+	RetryBase      time.Duration // 最初の再配信までの待機時間（以降は倍々に増やす）
+	RetryMax       time.Duration // 再配信までの待機時間の上限
+	Retention      time.Duration // 配信済みのメッセージを保持する期間
+	WebhookURL     string        // webhook配信先のURL
+	WebhookSecret  string        // webhookの署名に使うシークレット
+	WebhookTimeout time.Duration // webhookのタイムアウト
+}
+
+// LoadConfig 環境変数からアウトボックスの配信設定を読み込み
+func LoadConfig() Config {
+	sinks := infra.GetEnvList("OUTBOX_SINKS", []string{SinkLog})
+	for i, s := range sinks {
+		sinks[i] = strings.ToLower(s)
+	}
+	return Config{
+		Sinks:          sinks,
+		PollInterval:   infra.GetEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		BatchSize:      infra.GetEnvInt("OUTBOX_BATCH_SIZE", 100),
+		RetryBase:      infra.GetEnvDuration("OUTBOX_RETRY_BASE", 5*time.Second),
+		RetryMax:       infra.GetEnvDuration("OUTBOX_RETRY_MAX", 30*time.Minute),
+		Retention:      infra.GetEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+		WebhookURL:     infra.GetEnv("OUTBOX_WEBHOOK_URL", ""),
+		WebhookSecret:  infra.GetEnv("OUTBOX_WEBHOOK_SECRET", ""),
+		WebhookTimeout: infra.GetEnvDuration("OUTBOX_WEBHOOK_TIMEOUT", 10*time.Second),
+	}
+}
+
+// Backoff attempts回目の配信が失敗した後、再配信までの待機時間
+func (c Config) Backoff(attempts int) time.Duration {
+	d := c.RetryBase
+	for i := 1; i < attempts && d < c.RetryMax; i++ {
+		d *= 2
+	}
+	if d > c.RetryMax {
+		d = c.RetryMax
+	}
+	return d
+}
+
+// NewSinks 設定に応じた配信先を作成
+func NewSinks(cfg Config) ([]Sink, error) {
+	var sinks []Sink
+	for _, name := range cfg.Sinks {
+		switch name {
+		case SinkLog:
+			sinks = append(sinks, LogSink{})
+		case SinkWebhook:
+			if cfg.WebhookURL == "" {
+				return nil, fmt.Errorf("OUTBOX_WEBHOOK_URL is required for the webhook sink")
+			}
+			sinks = append(sinks, NewWebhookSink(cfg.WebhookURL, cfg.WebhookSecret, cfg.WebhookTimeout))
+		default:
+			return nil, fmt.Errorf("unknown outbox sink: %s", name)
+		}
+	}
+	return sinks, nil
+}
@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore プロセス内の保存先（テスト向け。トランザクションには参加しない）
+type MemoryStore struct {
+	mu     sync.Mutex
+	msgs   []*Message
+	nextID uint
+	now    func() time.Time
+}
+
+// NewMemoryStore メモリ保存先のコンストラクタ
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now}
+}
+
+func (s *MemoryStore) Append(_ context.Context, msgs ...*Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, msg := range msgs {
+		s.nextID++
+		msg.ID = s.nextID
+		copied := *msg
+		s.msgs = append(s.msgs, &copied)
+	}
+	return nil
+}
+
+func (s *MemoryStore) Dispatch(ctx context.Context, limit int, fn func(ctx context.Context, msg *Message)) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var processed int
+	for _, msg := range s.msgs {
+		if processed >= limit {
+			break
+		}
+		if msg.PublishedAt != nil || msg.NextAttemptAt.After(now) {
+			continue
+		}
+		fn(ctx, msg)
+		processed++
+	}
+	return processed, nil
+}
+
+func (s *MemoryStore) Cleanup(_ context.Context, publishedBefore time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []*Message
+	for _, msg := range s.msgs {
+		if msg.PublishedAt == nil || msg.PublishedAt.After(publishedBefore) {
+			kept = append(kept, msg)
+		}
+	}
+	deleted := int64(len(s.msgs) - len(kept))
+	s.msgs = kept
+	return deleted, nil
+}
+
+func (s *MemoryStore) LatestID(_ context.Context) (uint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.msgs) == 0 {
+		return 0, nil
+	}
+	return s.msgs[len(s.msgs)-1].ID, nil
+}
+
+func (s *MemoryStore) OldestID(_ context.Context) (uint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.msgs) == 0 {
+		return 0, nil
+	}
+	return s.msgs[0].ID, nil
+}
+
+func (s *MemoryStore) List(_ context.Context, filter ListFilter) ([]Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var msgs []Message
+	for _, msg := range s.msgs {
+		if len(msgs) >= filter.Limit {
+			break
+		}
+		if msg.ID <= filter.AfterID ||
+			(filter.AggregateType != "" && msg.AggregateType != filter.AggregateType) ||
+			(filter.AggregateID != 0 && msg.AggregateID != filter.AggregateID) {
+			continue
+		}
+		msgs = append(msgs, *msg)
+	}
+	return msgs, nil
+}
+
+// Messages 保存したメッセージ（テスト用）
+func (s *MemoryStore) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msgs := make([]Message, len(s.msgs))
+	for i, msg := range s.msgs {
+		msgs[i] = *msg
+	}
+	return msgs
+}
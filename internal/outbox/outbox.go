@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/logging"
+)
+
+// Message アウトボックスに保存したイベント
+// 配信先にはJSONの各フィールドを封筒（エンベロープ）として送る
+type Message struct {
+	ID            uint            `json:"-" gorm:"primaryKey"`
+	EventID       string          `json:"id"`             // イベントID（UUID。受信側の重複排除に使う）
+	Type          string          `json:"type"`           // イベントの種類（例: user.created）
+	AggregateType string          `json:"aggregate_type"` // 対象の種類
+	AggregateID   uint            `json:"aggregate_id"`   // 対象のID
+	Payload       json.RawMessage `json:"data"`           // イベントの内容
+	RequestID     string          `json:"request_id,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Attempts      int             `json:"-"`
+	LastError     string          `json:"-"`
+	NextAttemptAt time.Time       `json:"-"`
+	PublishedAt   *time.Time      `json:"-"`
+}
+
+// TableName テーブル名を指定
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// Recorder ドメインイベントを記録する
+type Recorder interface {
+	// Record イベントをアウトボックスに保存する
+	// infra.Transactor.WithinTx のコンテキストを渡すと、変更と同じトランザクションで保存される
+	Record(ctx context.Context, events ...domain.Event) error
+}
+
+// Store アウトボックスの保存先
+type Store interface {
+	// Append メッセージを保存する（コンテキストのトランザクションに参加する）
+	Append(ctx context.Context, msgs ...*Message) error
+	// Dispatch 配信予定日時を過ぎた未配信のメッセージを古い順に最大limit件ロックしてfnに渡し、
+	// fnが更新したPublishedAt・Attempts・LastError・NextAttemptAtを保存する。処理した件数を返す
+	Dispatch(ctx context.Context, limit int, fn func(ctx context.Context, msg *Message)) (int, error)
+	// Cleanup publishedBefore以前に配信済みのメッセージを削除
+	Cleanup(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
+
+// ListFilter 配信状態に関わらずメッセージを参照する条件
+type ListFilter struct {
+	AggregateType string // 対象の種類（空の場合は全て）
+	AggregateID   uint   // 0以外の場合、この対象のみ
+	AfterID       uint   // このIDより後のメッセージのみ
+	Limit         int    // 取得する件数の上限
+}
+
+// Reader 保存したメッセージを参照する（イベントの購読・履歴の取得用）
+type Reader interface {
+	// LatestID 最新のメッセージID（メッセージがない場合は0）
+	LatestID(ctx context.Context) (uint, error)
+	// OldestID 残っている最古のメッセージID（メッセージがない場合は0）
+	OldestID(ctx context.Context) (uint, error)
+	// List 条件に一致するメッセージをID順に取得
+	List(ctx context.Context, filter ListFilter) ([]Message, error)
+}
+
+// Outbox イベントをメッセージに変換してStoreに保存するRecorder
+type Outbox struct {
+	store Store
+	now   func() time.Time
+}
+
+// New Outboxのコンストラクタ
+func New(store Store) *Outbox {
+	return &Outbox{store: store, now: time.Now}
+}
+
+func (o *Outbox) Record(ctx context.Context, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := o.now()
+	msgs := make([]*Message, len(events))
+	for i, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s: %w", e.EventType(), err)
+		}
+		msgs[i] = &Message{
+			EventID:       uuid.NewString(),
+			Type:          e.EventType(),
+			AggregateType: e.AggregateType(),
+			AggregateID:   e.AggregateID(),
+			Payload:       payload,
+			RequestID:     logging.RequestIDFromContext(ctx),
+			OccurredAt:    now,
+			NextAttemptAt: now,
+		}
+	}
+
+	if err := o.store.Append(ctx, msgs...); err != nil {
+		return fmt.Errorf("failed to record events: %w", err)
+	}
+	return nil
+}
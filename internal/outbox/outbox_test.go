@@ -0,0 +1,217 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/logging"
+)
+
+func testConfig() Config {
+	return Config{
+		BatchSize: 10,
+		RetryBase: 5 * time.Second,
+		RetryMax:  time.Minute,
+	}
+}
+
+func TestOutbox_Record(t *testing.T) {
+	store := NewMemoryStore()
+	ob := New(store)
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+
+	err := ob.Record(ctx,
+		domain.UserCreated{UserID: 1, Name: "山田太郎", Email: "yamada@example.com"},
+		domain.MemberAdded{CompanyID: 2, UserID: 1, Role: "admin"},
+	)
+
+	require.NoError(t, err)
+	msgs := store.Messages()
+	require.Len(t, msgs, 2)
+
+	assert.Equal(t, domain.EventUserCreated, msgs[0].Type)
+	assert.Equal(t, domain.AggregateUser, msgs[0].AggregateType)
+	assert.Equal(t, uint(1), msgs[0].AggregateID)
+	assert.Equal(t, "req-1", msgs[0].RequestID)
+	assert.NotEmpty(t, msgs[0].EventID)
+	assert.JSONEq(t, `{"user_id":1,"name":"山田太郎","email":"yamada@example.com"}`, string(msgs[0].Payload))
+
+	assert.Equal(t, domain.EventMemberAdded, msgs[1].Type)
+	assert.Equal(t, domain.AggregateCompany, msgs[1].AggregateType)
+	assert.Equal(t, uint(2), msgs[1].AggregateID)
+	assert.NotEqual(t, msgs[0].EventID, msgs[1].EventID)
+}
+
+func TestRelay_RelayBatch(t *testing.T) {
+	now := time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	ob := New(store)
+	ob.now = func() time.Time { return now }
+	require.NoError(t, ob.Record(context.Background(),
+		domain.UserCreated{UserID: 1},
+		domain.CompanyCreated{CompanyID: 2},
+	))
+
+	fail := true
+	var received []string
+	bus := NewBus()
+	bus.Subscribe(domain.EventCompanyCreated, func(_ context.Context, msg Message) error {
+		if fail {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	bus.Subscribe(AllEvents, func(_ context.Context, msg Message) error {
+		received = append(received, msg.Type)
+		return nil
+	})
+
+	relay := NewRelay(store, []Sink{bus}, testConfig())
+	relay.now = func() time.Time { return now }
+
+	// 1回目: company.createdの配信に失敗
+	n, err := relay.RelayBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{domain.EventUserCreated}, received)
+
+	msgs := store.Messages()
+	require.NotNil(t, msgs[0].PublishedAt)
+	assert.Nil(t, msgs[1].PublishedAt)
+	assert.Equal(t, 1, msgs[1].Attempts)
+	assert.Contains(t, msgs[1].LastError, "unavailable")
+	assert.Equal(t, now.Add(5*time.Second), msgs[1].NextAttemptAt)
+
+	// 再配信予定日時の前は配信しない
+	n, err = relay.RelayBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// 再配信予定日時を過ぎると配信する
+	fail = false
+	now = now.Add(5 * time.Second)
+	n, err = relay.RelayBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{domain.EventUserCreated, domain.EventCompanyCreated}, received)
+
+	msgs = store.Messages()
+	require.NotNil(t, msgs[1].PublishedAt)
+	assert.Empty(t, msgs[1].LastError)
+
+	// 配信済みのメッセージを削除
+	deleted, err := store.Cleanup(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Empty(t, store.Messages())
+}
+
+func TestWebhookSink_Publish(t *testing.T) {
+	msg := Message{
+		EventID:       "0b6b0c1e-8f4a-4a43-9d1e-2b0c6f3e5a10",
+		Type:          domain.EventUserUpdated,
+		AggregateType: domain.AggregateUser,
+		AggregateID:   1,
+		Payload:       json.RawMessage(`{"user_id":1}`),
+		OccurredAt:    time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{
+			name:   "正常系: 2xxは配信成功",
+			status: http.StatusNoContent,
+		},
+		{
+			name:        "異常系: 2xx以外は配信失敗",
+			status:      http.StatusInternalServerError,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Clone()
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			sink := NewWebhookSink(server.URL, "secret", time.Second)
+			sink.now = func() time.Time { return time.Unix(1757246400, 0) }
+
+			err := sink.Publish(context.Background(), msg)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, msg.EventID, header.Get(HeaderEventID))
+			assert.Equal(t, msg.Type, header.Get(HeaderEventType))
+			assert.Equal(t, "1757246400", header.Get(HeaderSignatureTimestamp))
+			assert.Equal(t, "sha256="+Sign([]byte("secret"), "1757246400", body), header.Get(HeaderSignature))
+
+			var envelope map[string]any
+			require.NoError(t, json.Unmarshal(body, &envelope))
+			assert.Equal(t, msg.EventID, envelope["id"])
+			assert.Equal(t, map[string]any{"user_id": float64(1)}, envelope["data"])
+			assert.NotContains(t, envelope, "attempts")
+		})
+	}
+}
+
+func TestNewSinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectLen   int
+		expectError bool
+	}{
+		{
+			name:      "正常系: logとwebhook",
+			cfg:       Config{Sinks: []string{SinkLog, SinkWebhook}, WebhookURL: "http://localhost/hook"},
+			expectLen: 2,
+		},
+		{
+			name:        "異常系: webhookのURLが未設定",
+			cfg:         Config{Sinks: []string{SinkWebhook}},
+			expectError: true,
+		},
+		{
+			name:        "異常系: 未対応の配信先",
+			cfg:         Config{Sinks: []string{"kafka"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinks, err := NewSinks(tt.cfg)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, sinks, tt.expectLen)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"km-api-go/internal/infra"
+)
+
+// PostgresStore outbox_messagesテーブルを使う保存先
+type PostgresStore struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewPostgresStore Postgres保存先のコンストラクタ
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db, now: time.Now}
+}
+
+func (s *PostgresStore) Append(ctx context.Context, msgs ...*Message) error {
+	if err := infra.Conn(ctx, s.db).Create(msgs).Error; err != nil {
+		return fmt.Errorf("failed to append outbox messages: %w", err)
+	}
+	return nil
+}
+
+// Dispatch SKIP LOCKEDでロックするため、複数のリレーが同時に動いても同じメッセージを重複して処理しない
+func (s *PostgresStore) Dispatch(ctx context.Context, limit int, fn func(ctx context.Context, msg *Message)) (int, error) {
+	var processed int
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var msgs []*Message
+		err := tx.Where("published_at IS NULL AND next_attempt_at <= ?", s.now()).
+			Order("id").
+			Limit(limit).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Find(&msgs).Error
+		if err != nil {
+			return fmt.Errorf("failed to lock outbox messages: %w", err)
+		}
+
+		for _, msg := range msgs {
+			fn(ctx, msg)
+			err := tx.Model(msg).Updates(map[string]interface{}{
+				"published_at":    msg.PublishedAt,
+				"attempts":        msg.Attempts,
+				"last_error":      msg.LastError,
+				"next_attempt_at": msg.NextAttemptAt,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("failed to update outbox message %d: %w", msg.ID, err)
+			}
+			processed++
+		}
+		return nil
+	})
+	return processed, err
+}
+
+func (s *PostgresStore) Cleanup(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("published_at <= ?", publishedBefore).Delete(&Message{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to clean up outbox messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (s *PostgresStore) LatestID(ctx context.Context) (uint, error) {
+	var id uint
+	if err := s.db.WithContext(ctx).Model(&Message{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error; err != nil {
+		return 0, fmt.Errorf("failed to get latest outbox message id: %w", err)
+	}
+	return id, nil
+}
+
+func (s *PostgresStore) OldestID(ctx context.Context) (uint, error) {
+	var id uint
+	if err := s.db.WithContext(ctx).Model(&Message{}).Select("COALESCE(MIN(id), 0)").Scan(&id).Error; err != nil {
+		return 0, fmt.Errorf("failed to get oldest outbox message id: %w", err)
+	}
+	return id, nil
+}
+
+func (s *PostgresStore) List(ctx context.Context, filter ListFilter) ([]Message, error) {
+	query := s.db.WithContext(ctx).Where("id > ?", filter.AfterID)
+	if filter.AggregateType != "" {
+		query = query.Where("aggregate_type = ?", filter.AggregateType)
+	}
+	if filter.AggregateID != 0 {
+		query = query.Where("aggregate_id = ?", filter.AggregateID)
+	}
+
+	var msgs []Message
+	if err := query.Order("id").Limit(filter.Limit).Find(&msgs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+	return msgs, nil
+}
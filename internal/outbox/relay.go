@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"km-api-go/internal/logging"
+)
+
+// Relay 未配信のメッセージを配信先に送る
+// 全ての配信先に送れた場合のみ配信済みにし、失敗した場合は待機時間を延ばしながら再配信する（at-least-once）
+type Relay struct {
+	store Store
+	sinks []Sink
+	cfg   Config
+	now   func() time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRelay リレーのコンストラクタ
+func NewRelay(store Store, sinks []Sink, cfg Config) *Relay {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &Relay{
+		store: store,
+		sinks: sinks,
+		cfg:   cfg,
+		now:   time.Now,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Start バックグラウンドで配信を開始する（停止はShutdownで行う）
+func (r *Relay) Start(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	go r.run(ctx)
+	logging.FromContext(ctx).InfoContext(ctx, "outbox relay started", slog.Int("sinks", len(r.sinks)))
+}
+
+// Shutdown 配信を止め、処理中のバッチの終了を待つ
+func (r *Relay) Shutdown(ctx context.Context) error {
+	close(r.stop)
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Relay) run(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.RelayBatch(ctx)
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to relay outbox messages", slog.Any("error", err))
+		}
+
+		// バッチが埋まった場合は待たずに続ける
+		if err == nil && n >= r.cfg.BatchSize {
+			select {
+			case <-r.stop:
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch 未配信のメッセージを1バッチ分配信し、処理した件数を返す
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	return r.store.Dispatch(ctx, r.cfg.BatchSize, func(ctx context.Context, msg *Message) {
+		err := r.publish(ctx, *msg)
+		now := r.now()
+		if err == nil {
+			msg.PublishedAt = &now
+			msg.LastError = ""
+			return
+		}
+
+		msg.Attempts++
+		msg.LastError = err.Error()
+		msg.NextAttemptAt = now.Add(r.cfg.Backoff(msg.Attempts))
+		logging.FromContext(ctx).WarnContext(ctx, "failed to publish outbox message",
+			slog.String("event_id", msg.EventID),
+			slog.String("event_type", msg.Type),
+			slog.Int("attempts", msg.Attempts),
+			slog.Time("next_attempt_at", msg.NextAttemptAt),
+			slog.Any("error", err),
+		)
+	})
+}
+
+func (r *Relay) publish(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", sink, err))
+		}
+	}
+	return errors.Join(errs...)
+}
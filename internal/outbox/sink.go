@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"km-api-go/internal/logging"
+)
+
+// Sink メッセージの配信先
+type Sink interface {
+	// Publish メッセージを配信する（エラーの場合は後で再配信する。同じメッセージが複数回届くことがある）
+	Publish(ctx context.Context, msg Message) error
+}
+
+// LogSink メッセージをログに出力する配信先（開発・動作確認用）
+type LogSink struct{}
+
+func (LogSink) Publish(ctx context.Context, msg Message) error {
+	logging.FromContext(ctx).InfoContext(ctx, "domain event",
+		slog.String("event_id", msg.EventID),
+		slog.String("event_type", msg.Type),
+		slog.String("aggregate_type", msg.AggregateType),
+		slog.Uint64("aggregate_id", uint64(msg.AggregateID)),
+		slog.String("data", string(msg.Payload)),
+	)
+	return nil
+}
+
+// Webhookのリクエストヘッダー
+const (
+	HeaderEventID            = "X-Event-ID"
+	HeaderEventType          = "X-Event-Type"
+	HeaderSignature          = "X-Signature"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp"
+)
+
+// WebhookSink メッセージをJSONでPOSTする配信先
+// シークレットを設定した場合、「タイムスタンプ.ボディ」のHMAC-SHA256を X-Signature ヘッダーに付与する
+type WebhookSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewWebhookSink Webhook配信先のコンストラクタ
+func NewWebhookSink(url, secret string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+		now:    time.Now,
+	}
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, msg.EventID)
+	req.Header.Set(HeaderEventType, msg.Type)
+	if len(s.secret) > 0 {
+		timestamp := strconv.FormatInt(s.now().Unix(), 10)
+		req.Header.Set(HeaderSignatureTimestamp, timestamp)
+		req.Header.Set(HeaderSignature, "sha256="+Sign(s.secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign 「タイムスタンプ.ボディ」のHMAC-SHA256（16進数）。受信側の署名検証にも使う
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Handler プロセス内でメッセージを受け取る関数
+type Handler func(ctx context.Context, msg Message) error
+
+// AllEvents Subscribeで全ての種類のイベントを受け取る場合に指定する
+const AllEvents = "*"
+
+// Bus プロセス内の購読者にメッセージを配る配信先（テストや同一プロセス内の後処理向け）
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus Busのコンストラクタ
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe イベントの種類（AllEventsは全て）にハンドラーを登録する
+func (b *Bus) Subscribe(eventType string, fn Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], fn)
+}
+
+func (b *Bus) Publish(ctx context.Context, msg Message) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[msg.Type]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	for _, fn := range handlers {
+		if err := fn(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
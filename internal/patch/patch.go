@@ -0,0 +1,172 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"slices"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// パッチのメディアタイプ
+const (
+	MIMEMergePatch = "application/merge-patch+json" // RFC 7396
+	MIMEJSONPatch  = "application/json-patch+json"  // RFC 6902
+)
+
+var (
+	// ErrUnsupportedMediaType パッチとして扱えないContent-Type
+	ErrUnsupportedMediaType = errors.New("unsupported patch media type")
+	// ErrInvalidPatch パッチの形式が不正、または適用できない
+	ErrInvalidPatch = errors.New("invalid patch")
+)
+
+// Patch JSONドキュメントに適用できるパッチ
+type Patch interface {
+	Apply(doc []byte) ([]byte, error)
+}
+
+type mergePatch []byte
+
+func (p mergePatch) Apply(doc []byte) ([]byte, error) {
+	return jsonpatch.MergePatch(doc, p)
+}
+
+type jsonPatch struct {
+	ops jsonpatch.Patch
+}
+
+func (p jsonPatch) Apply(doc []byte) ([]byte, error) {
+	return p.ops.Apply(doc)
+}
+
+// New Content-Typeに応じてリクエストボディをパッチとして解釈
+func New(contentType string, body []byte) (Patch, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, contentType)
+	}
+
+	switch mediaType {
+	case MIMEMergePatch:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return nil, fmt.Errorf("%w: merge patch must be a JSON object", ErrInvalidPatch)
+		}
+		return mergePatch(body), nil
+	case MIMEJSONPatch:
+		ops, err := jsonpatch.DecodePatch(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		return jsonPatch{ops: ops}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+	}
+}
+
+// Apply targetのJSON表現にパッチを適用し、変更されたフィールドだけをtargetに反映する
+// 変更されたフィールドのGoのフィールド名を返す。allowedに含まれないフィールドの変更はErrInvalidPatch
+// targetは構造体へのポインタ（トップレベルのフィールドのみ比較する）
+func Apply(p Patch, target interface{}, allowed ...string) ([]string, error) {
+	doc, err := json.Marshal(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch target: %w", err)
+	}
+
+	patched, err := p.Apply(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &before); err != nil {
+		return nil, fmt.Errorf("failed to decode patch target: %w", err)
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return nil, fmt.Errorf("%w: patched document must be a JSON object", ErrInvalidPatch)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, key)
+		}
+	}
+
+	v := reflect.ValueOf(target).Elem()
+	fields := jsonFields(v.Type())
+
+	var changed []string
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if !ok {
+			// マージパッチのnull・JSON Patchのremoveはゼロ値として扱う
+			newValue = json.RawMessage("null")
+		}
+		if equalJSON(oldValue, newValue) {
+			continue
+		}
+
+		name, ok := fields[key]
+		if !ok || !slices.Contains(allowed, name) {
+			return nil, fmt.Errorf("%w: field %q is read-only", ErrInvalidPatch, key)
+		}
+
+		field := v.FieldByName(name)
+		field.Set(reflect.Zero(field.Type()))
+		if err := json.Unmarshal(newValue, field.Addr().Interface()); err != nil {
+			return nil, fmt.Errorf("%w: field %q: %v", ErrInvalidPatch, key, err)
+		}
+		changed = append(changed, name)
+	}
+
+	slices.Sort(changed)
+	return changed, nil
+}
+
+// JSONNames Applyが返したGoのフィールド名をJSONのキーに変換（変更内容を外部に伝える用）
+func JSONNames(target interface{}, fields []string) []string {
+	v := reflect.Indirect(reflect.ValueOf(target))
+	keys := make(map[string]string)
+	for key, field := range jsonFields(v.Type()) {
+		keys[field] = key
+	}
+
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = keys[f]
+	}
+	return names
+}
+
+// jsonFields JSONのキーからGoのフィールド名への対応
+func jsonFields(t reflect.Type) map[string]string {
+	fields := make(map[string]string, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Name
+	}
+	return fields
+}
+
+func equalJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
@@ -0,0 +1,115 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type document struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+	Password string `json:"-"`
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		body          string
+		expectDoc     document
+		expectChanged []string
+		expectError   error
+	}{
+		{
+			name:          "正常系: マージパッチで一部のフィールドを変更",
+			contentType:   MIMEMergePatch,
+			body:          `{"name":"New Name"}`,
+			expectDoc:     document{ID: 1, Name: "New Name", Phone: "03-1234-5678", Password: "secret"},
+			expectChanged: []string{"Name"},
+		},
+		{
+			name:          "正常系: マージパッチのnullはゼロ値",
+			contentType:   MIMEMergePatch + "; charset=utf-8",
+			body:          `{"phone":null}`,
+			expectDoc:     document{ID: 1, Name: "Old Name", Password: "secret"},
+			expectChanged: []string{"Phone"},
+		},
+		{
+			name:          "正常系: 同じ値は変更なし",
+			contentType:   MIMEMergePatch,
+			body:          `{"name":"Old Name"}`,
+			expectDoc:     document{ID: 1, Name: "Old Name", Phone: "03-1234-5678", Password: "secret"},
+			expectChanged: nil,
+		},
+		{
+			name:          "正常系: JSON Patchでreplaceとremove",
+			contentType:   MIMEJSONPatch,
+			body:          `[{"op":"test","path":"/name","value":"Old Name"},{"op":"replace","path":"/name","value":"New Name"},{"op":"remove","path":"/phone"}]`,
+			expectDoc:     document{ID: 1, Name: "New Name", Password: "secret"},
+			expectChanged: []string{"Name", "Phone"},
+		},
+		{
+			name:        "異常系: JSON Patchのtestが失敗",
+			contentType: MIMEJSONPatch,
+			body:        `[{"op":"test","path":"/name","value":"Other"},{"op":"replace","path":"/name","value":"New Name"}]`,
+			expectError: ErrInvalidPatch,
+		},
+		{
+			name:        "異常系: 読み取り専用フィールドの変更",
+			contentType: MIMEMergePatch,
+			body:        `{"id":2}`,
+			expectError: ErrInvalidPatch,
+		},
+		{
+			name:        "異常系: 存在しないフィールドの追加",
+			contentType: MIMEMergePatch,
+			body:        `{"password":"hacked"}`,
+			expectError: ErrInvalidPatch,
+		},
+		{
+			name:        "異常系: 型が異なる値",
+			contentType: MIMEMergePatch,
+			body:        `{"name":123}`,
+			expectError: ErrInvalidPatch,
+		},
+		{
+			name:        "異常系: マージパッチがオブジェクトでない",
+			contentType: MIMEMergePatch,
+			body:        `["name"]`,
+			expectError: ErrInvalidPatch,
+		},
+		{
+			name:        "異常系: 未対応のメディアタイプ",
+			contentType: "application/json",
+			body:        `{"name":"New Name"}`,
+			expectError: ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := document{ID: 1, Name: "Old Name", Phone: "03-1234-5678", Password: "secret"}
+
+			p, err := New(tt.contentType, []byte(tt.body))
+			var changed []string
+			if err == nil {
+				changed, err = Apply(p, &doc, "Name", "Phone")
+			}
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectDoc, doc)
+			assert.Equal(t, tt.expectChanged, changed)
+		})
+	}
+}
+
+func TestJSONNames(t *testing.T) {
+	assert.Equal(t, []string{"name", "phone"}, JSONNames(&document{}, []string{"Name", "Phone"}))
+}
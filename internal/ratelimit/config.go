@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"strings"
+
+	"km-api-go/internal/infra"
+)
+
+// バックエンド種別
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// Config レート制限設定
+type Config struct {
+	Enabled bool   // レート制限を有効にするか
+	Backend string // memory, postgres
+	Default Policy // API全体のポリシー
+	Auth    Policy // /auth/* のポリシー（ログイン試行の総当たり対策）
+}
+
+// LoadConfig 環境変数からレート制限設定を読み込み
+// ポリシーの書式が不正な場合はデフォルト値を使う
+func LoadConfig() Config {
+	return Config{
+		Enabled: infra.GetEnvBool("RATE_LIMIT_ENABLED", true),
+		Backend: strings.ToLower(infra.GetEnv("RATE_LIMIT_BACKEND", BackendMemory)),
+		Default: loadPolicy("default", "RATE_LIMIT_DEFAULT", "300/1m"),
+		Auth:    loadPolicy("auth", "RATE_LIMIT_AUTH", "10/1m"),
+	}
+}
+
+func loadPolicy(name, key, defaultSpec string) Policy {
+	policy, err := ParsePolicy(name, infra.GetEnv(key, defaultSpec))
+	if err != nil {
+		slog.Warn("Invalid rate limit policy, using default", slog.String("key", key), slog.Any("error", err))
+		policy, _ = ParsePolicy(name, defaultSpec)
+	}
+	return policy
+}
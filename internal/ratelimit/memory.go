@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryStore プロセス内のトークンバケット（単一インスタンス向け）
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	idleTTL   time.Duration
+	lastSweep time.Time
+}
+
+// NewMemoryStore メモリバックエンドのコンストラクタ
+// idleTTLより長く使われていないバケットは定期的に破棄する
+func NewMemoryStore(idleTTL time.Duration) *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+		idleTTL: idleTTL,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(policy.Limit)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	// 経過時間分のトークンを補充
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = math.Min(capacity, b.tokens+elapsed*policy.Rate())
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return newResult(policy, allowed, b.tokens), nil
+}
+
+// sweep 使われていないバケットを破棄（idleTTLごとに1回）
+func (s *MemoryStore) sweep(now time.Time) {
+	if s.idleTTL <= 0 || now.Sub(s.lastSweep) < s.idleTTL {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updatedAt) > s.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		expect      Policy
+		expectError bool
+	}{
+		{name: "正常系: 回数/期間", spec: "100/1m", expect: Policy{Name: "p", Limit: 100, Period: time.Minute}},
+		{name: "正常系: 空白を許容", spec: " 10 / 30s ", expect: Policy{Name: "p", Limit: 10, Period: 30 * time.Second}},
+		{name: "異常系: 区切りなし", spec: "100", expectError: true},
+		{name: "異常系: 回数が0", spec: "0/1m", expectError: true},
+		{name: "異常系: 期間が不正", spec: "10/minute", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePolicy("p", tt.spec)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, policy)
+		})
+	}
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	policy := Policy{Name: "test", Limit: 3, Period: 3 * time.Second} // 1トークン/秒
+	ctx := context.Background()
+
+	// バースト分（3回）は許可される
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(ctx, "ip:1.2.3.4", policy)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	// 4回目は拒否され、次のトークンまで1秒
+	result, err := store.Take(ctx, "ip:1.2.3.4", policy)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.Reset)
+
+	// 別のキーは独立したバケット
+	result, err = store.Take(ctx, "ip:5.6.7.8", policy)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// 1秒経過で1トークン補充される
+	now = now.Add(time.Second)
+	result, err = store.Take(ctx, "ip:1.2.3.4", policy)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	now := time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	policy := Policy{Name: "test", Limit: 1, Period: time.Second}
+	_, err := store.Take(context.Background(), "ip:1.2.3.4", policy)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	_, err = store.Take(context.Background(), "ip:5.6.7.8", policy)
+	require.NoError(t, err)
+
+	assert.NotContains(t, store.buckets, "ip:1.2.3.4")
+	assert.Contains(t, store.buckets, "ip:5.6.7.8")
+}
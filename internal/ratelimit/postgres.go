@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"km-api-go/internal/infra"
+)
+
+// takeSQL 補充と消費を1文で行い、複数インスタンス間でも原子的に判定する
+// refilledは前回更新からの経過時間分を補充したトークン数
+// 時刻はインスタンスの時計のずれに影響されないようDBの時計を使う
+// （一括リクエストのトランザクション内でも進むよう、now()ではなくclock_timestamp()）
+const takeSQL = `
+WITH p AS (
+    SELECT CAST(@capacity AS DOUBLE PRECISION) AS capacity,
+           CAST(@rate AS DOUBLE PRECISION) AS rate,
+           clock_timestamp() AS now
+)
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+SELECT @key, p.capacity - 1, TRUE, p.now FROM p
+ON CONFLICT (key) DO UPDATE SET
+    tokens = (
+        SELECT CASE WHEN r.refilled >= 1 THEN r.refilled - 1 ELSE r.refilled END
+        FROM (SELECT LEAST(p.capacity, b.tokens + EXTRACT(EPOCH FROM (p.now - b.updated_at)) * p.rate) AS refilled FROM p) r
+    ),
+    allowed = (
+        SELECT LEAST(p.capacity, b.tokens + EXTRACT(EPOCH FROM (p.now - b.updated_at)) * p.rate) >= 1 FROM p
+    ),
+    updated_at = EXCLUDED.updated_at
+RETURNING tokens, allowed`
+
+// PostgresStore rate_limit_bucketsテーブルを使うバックエンド（複数インスタンス向け）
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresStore Postgresバックエンドのコンストラクタ
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	var row struct {
+		Tokens  float64
+		Allowed bool
+	}
+
+	err := infra.Conn(ctx, s.db).Raw(takeSQL,
+		sql.Named("key", key),
+		sql.Named("capacity", float64(policy.Limit)),
+		sql.Named("rate", policy.Rate()),
+	).Scan(&row).Error
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	return newResult(policy, row.Allowed, row.Tokens), nil
+}
+
+// Cleanup 指定時間より長く使われていないバケットを削除
+func (s *PostgresStore) Cleanup(ctx context.Context, idle time.Duration) (int64, error) {
+	result := s.db.WithContext(ctx).Exec(
+		"DELETE FROM rate_limit_buckets WHERE updated_at < clock_timestamp() - make_interval(secs => ?)", idle.Seconds())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to clean up rate limit buckets: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
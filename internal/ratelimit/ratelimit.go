@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy トークンバケットのレート制限ポリシー
+// Periodあたり Limit 回まで許可し、バケット容量（バースト）も Limit とする
+type Policy struct {
+	Name   string        // ポリシー名（バケットのキーに含める）
+	Limit  int           // 期間あたりの最大リクエスト数
+	Period time.Duration // 期間
+}
+
+// ParsePolicy 「回数/期間」形式（例: 100/1m, 10/30s）のポリシーを解析
+func ParsePolicy(name, spec string) (Policy, error) {
+	limitStr, periodStr, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid rate limit %q: expected <limit>/<period>", spec)
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+	if err != nil || limit <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit %q: limit must be a positive integer", spec)
+	}
+
+	period, err := time.ParseDuration(strings.TrimSpace(periodStr))
+	if err != nil || period <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration", spec)
+	}
+
+	return Policy{Name: name, Limit: limit, Period: period}, nil
+}
+
+// Rate 1秒あたりのトークン補充量
+func (p Policy) Rate() float64 {
+	return float64(p.Limit) / p.Period.Seconds()
+}
+
+// String RateLimit-Policyヘッダー形式（例: 100;w=60）
+func (p Policy) String() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, int(math.Ceil(p.Period.Seconds())))
+}
+
+// Result レート制限の判定結果
+type Result struct {
+	Allowed    bool          // リクエストを許可するか
+	Limit      int           // バケット容量
+	Remaining  int           // 残りトークン数
+	Reset      time.Duration // バケットが満杯に戻るまでの時間
+	RetryAfter time.Duration // 拒否時、次のトークンが補充されるまでの時間
+}
+
+// Store レート制限の状態を保持するバックエンド
+type Store interface {
+	// Take キーのバケットからトークンを1つ消費する
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+// newResult 消費後のトークン数から判定結果を組み立てる
+func newResult(policy Policy, allowed bool, tokens float64) Result {
+	rate := policy.Rate()
+	result := Result{
+		Allowed:   allowed,
+		Limit:     policy.Limit,
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     secondsToDuration((float64(policy.Limit) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	return result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,228 @@
+package relation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/company"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/user"
+)
+
+// include=に指定する関連の名前（レスポンスのフィールド名）
+const (
+	includeMembers     = "members"
+	includeMemberUsers = "members.user"
+	includeCompanies   = "companies"
+)
+
+// Member 会社に所属するユーザー（include=members）
+type Member struct {
+	UserID   uint        `json:"user_id" example:"1"`          // ユーザーID
+	Role     string      `json:"role" example:"admin"`         // 役割（admin, member）
+	JoinedAt time.Time   `json:"joined_at" format:"date-time"` // 所属日時（UTC）
+	User     *MemberUser `json:"user,omitempty"`               // ユーザー（include=members.userの場合のみ）
+}
+
+// MemberUser 所属するユーザーの情報（include=members.user）
+type MemberUser struct {
+	ID    uint   `json:"id" example:"1"`                     // ユーザーID
+	Name  string `json:"name" example:"山田太郎"`                // ユーザー名
+	Email string `json:"email" example:"yamada@example.com"` // メールアドレス
+}
+
+// UserCompany ユーザーが所属する会社（include=companies）
+type UserCompany struct {
+	ID       uint      `json:"id" example:"1"`                    // 会社ID
+	Name     string    `json:"name" example:"株式会社サンプル"`           // 会社名
+	Email    string    `json:"email" example:"info@sample.co.jp"` // 会社メールアドレス
+	Role     string    `json:"role" example:"admin"`              // 役割（admin, member）
+	JoinedAt time.Time `json:"joined_at" format:"date-time"`      // 所属日時（UTC）
+}
+
+// Expander ユーザー・会社の関連を展開する（user.Expander, company.Expander）
+// 所属・ユーザー・会社はそれぞれ1回のクエリでまとめて取得する
+type Expander struct {
+	users     user.UserUsecase
+	companies company.CompanyUsecase
+}
+
+// NewExpander Expanderのコンストラクタ
+func NewExpander(users user.UserUsecase, companies company.CompanyUsecase) *Expander {
+	return &Expander{users: users, companies: companies}
+}
+
+// ExpandCompany include=members, members.user
+// 所属するユーザーは会社に所属している場合のみ展開できる（それ以外はdomain.ErrForbidden）
+func (e *Expander) ExpandCompany(ctx context.Context, c *domain.Company, include []string) (map[string]any, error) {
+	related := make(map[string]any)
+	if slices.Contains(include, includeMembers) {
+		visible, err := e.companies.CanView(ctx, c.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand members: %w", err)
+		}
+		if !visible {
+			return nil, domain.ErrForbidden
+		}
+		members, err := e.Members(ctx, []uint{c.ID}, slices.Contains(include, includeMemberUsers))
+		if err != nil {
+			return nil, err
+		}
+		related[includeMembers] = members[c.ID]
+	}
+	return related, nil
+}
+
+// ExpandUser include=companies
+// 本人・同じ会社に所属するユーザーのみ展開でき（それ以外はdomain.ErrForbidden）、
+// 本人以外には呼び出し元も所属している会社だけを返す
+func (e *Expander) ExpandUser(ctx context.Context, u *domain.User, include []string) (map[string]any, error) {
+	related := make(map[string]any)
+	if slices.Contains(include, includeCompanies) {
+		visible, err := e.users.CanView(ctx, u.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand companies: %w", err)
+		}
+		if !visible {
+			return nil, domain.ErrForbidden
+		}
+		companies, err := e.Companies(ctx, []uint{u.ID})
+		if err != nil {
+			return nil, err
+		}
+		related[includeCompanies] = companies[u.ID]
+	}
+	return related, nil
+}
+
+// Members 会社ごとの所属するユーザー（所属した順）
+// withUsersがtrueの場合はユーザーの情報も含める（所属の取得とは別に1回のクエリで取得する）
+func (e *Expander) Members(ctx context.Context, companyIDs []uint, withUsers bool) (map[uint][]Member, error) {
+	companyUsers, err := e.companies.GetMembersByCompanyIDs(ctx, companyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand members: %w", err)
+	}
+
+	var users map[uint]*MemberUser
+	if withUsers {
+		users, err = e.memberUsers(ctx, companyUsers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	members := make(map[uint][]Member, len(companyIDs))
+	for _, id := range companyIDs {
+		members[id] = []Member{}
+	}
+	for _, cu := range companyUsers {
+		members[cu.CompanyID] = append(members[cu.CompanyID], Member{
+			UserID:   cu.UserID,
+			Role:     cu.Role,
+			JoinedAt: cu.CreatedAt.UTC(),
+			User:     users[cu.UserID],
+		})
+	}
+	return members, nil
+}
+
+// Companies ユーザーごとの所属する会社（所属した順）
+// 呼び出し元以外のユーザーについては、呼び出し元も所属している会社のみ含める
+func (e *Expander) Companies(ctx context.Context, userIDs []uint) (map[uint][]UserCompany, error) {
+	companyUsers, err := e.companies.GetMembershipsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand companies: %w", err)
+	}
+	companyUsers, err = e.visibleMemberships(ctx, companyUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(companyUsers))
+	for _, cu := range companyUsers {
+		if !slices.Contains(ids, cu.CompanyID) {
+			ids = append(ids, cu.CompanyID)
+		}
+	}
+	found, err := e.companies.GetCompaniesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand companies: %w", err)
+	}
+	byID := make(map[uint]domain.Company, len(found))
+	for _, c := range found {
+		byID[c.ID] = c
+	}
+
+	companies := make(map[uint][]UserCompany, len(userIDs))
+	for _, id := range userIDs {
+		companies[id] = []UserCompany{}
+	}
+	for _, cu := range companyUsers {
+		c, ok := byID[cu.CompanyID]
+		if !ok {
+			continue
+		}
+		companies[cu.UserID] = append(companies[cu.UserID], UserCompany{
+			ID:       c.ID,
+			Name:     c.Name,
+			Email:    c.Email,
+			Role:     cu.Role,
+			JoinedAt: cu.CreatedAt.UTC(),
+		})
+	}
+	return companies, nil
+}
+
+// visibleMemberships 呼び出し元が参照できる所属のみ残す
+// APIキーと本人の所属はすべて、それ以外は呼び出し元も所属している会社の所属のみ
+func (e *Expander) visibleMemberships(ctx context.Context, companyUsers []domain.CompanyUser) ([]domain.CompanyUser, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	if !principal.IsUser() || !slices.ContainsFunc(companyUsers, func(cu domain.CompanyUser) bool {
+		return cu.UserID != principal.UserID
+	}) {
+		return companyUsers, nil
+	}
+
+	own, err := e.companies.GetMembershipsByUserIDs(ctx, []uint{principal.UserID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get own memberships: %w", err)
+	}
+	shared := make(map[uint]bool, len(own))
+	for _, cu := range own {
+		shared[cu.CompanyID] = true
+	}
+
+	visible := make([]domain.CompanyUser, 0, len(companyUsers))
+	for _, cu := range companyUsers {
+		if cu.UserID == principal.UserID || shared[cu.CompanyID] {
+			visible = append(visible, cu)
+		}
+	}
+	return visible, nil
+}
+
+// memberUsers 所属するユーザーの情報をまとめて取得
+func (e *Expander) memberUsers(ctx context.Context, companyUsers []domain.CompanyUser) (map[uint]*MemberUser, error) {
+	ids := make([]uint, 0, len(companyUsers))
+	for _, cu := range companyUsers {
+		if !slices.Contains(ids, cu.UserID) {
+			ids = append(ids, cu.UserID)
+		}
+	}
+	found, err := e.users.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand member users: %w", err)
+	}
+
+	users := make(map[uint]*MemberUser, len(found))
+	for _, u := range found {
+		users[u.ID] = &MemberUser{ID: u.ID, Name: u.Name, Email: u.Email}
+	}
+	return users, nil
+}
@@ -0,0 +1,165 @@
+package relation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/mocks"
+	"km-api-go/internal/domain"
+	userMocks "km-api-go/internal/user/mocks"
+)
+
+func TestExpander_Members(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	joined := time.Date(2026, 1, 1, 9, 0, 0, 0, jst)
+	companyUsers := []domain.CompanyUser{
+		{CompanyID: 1, UserID: 5, Role: "admin", CreatedAt: joined},
+		{CompanyID: 2, UserID: 5, Role: "member", CreatedAt: joined},
+		{CompanyID: 1, UserID: 6, Role: "member", CreatedAt: joined},
+	}
+
+	tests := []struct {
+		name      string
+		withUsers bool
+		setupMock func(users *userMocks.MockUserUsecase, companies *companyMocks.MockCompanyUsecase)
+		expected  map[uint][]Member
+		wantErr   bool
+	}{
+		{
+			name: "正常系: 会社ごとにまとめ、所属がない会社は空",
+			setupMock: func(_ *userMocks.MockUserUsecase, companies *companyMocks.MockCompanyUsecase) {
+				companies.EXPECT().GetMembersByCompanyIDs(gomock.Any(), []uint{1, 2, 3}).Return(companyUsers, nil)
+			},
+			expected: map[uint][]Member{
+				1: {
+					{UserID: 5, Role: "admin", JoinedAt: joined.UTC()},
+					{UserID: 6, Role: "member", JoinedAt: joined.UTC()},
+				},
+				2: {{UserID: 5, Role: "member", JoinedAt: joined.UTC()}},
+				3: {},
+			},
+		},
+		{
+			name:      "正常系: ユーザーは重複を除いて1回で取得（見つからないユーザーは含めない）",
+			withUsers: true,
+			setupMock: func(users *userMocks.MockUserUsecase, companies *companyMocks.MockCompanyUsecase) {
+				companies.EXPECT().GetMembersByCompanyIDs(gomock.Any(), []uint{1, 2, 3}).Return(companyUsers, nil)
+				users.EXPECT().GetUsersByIDs(gomock.Any(), []uint{5, 6}).Return([]domain.User{
+					{ID: 5, Name: "山田太郎", Email: "yamada@example.com"},
+				}, nil).Times(1)
+			},
+			expected: map[uint][]Member{
+				1: {
+					{UserID: 5, Role: "admin", JoinedAt: joined.UTC(), User: &MemberUser{ID: 5, Name: "山田太郎", Email: "yamada@example.com"}},
+					{UserID: 6, Role: "member", JoinedAt: joined.UTC()},
+				},
+				2: {{UserID: 5, Role: "member", JoinedAt: joined.UTC(), User: &MemberUser{ID: 5, Name: "山田太郎", Email: "yamada@example.com"}}},
+				3: {},
+			},
+		},
+		{
+			name:      "異常系: ユーザーの取得に失敗",
+			withUsers: true,
+			setupMock: func(users *userMocks.MockUserUsecase, companies *companyMocks.MockCompanyUsecase) {
+				companies.EXPECT().GetMembersByCompanyIDs(gomock.Any(), []uint{1, 2, 3}).Return(companyUsers, nil)
+				users.EXPECT().GetUsersByIDs(gomock.Any(), []uint{5, 6}).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			users := userMocks.NewMockUserUsecase(ctrl)
+			companies := companyMocks.NewMockCompanyUsecase(ctrl)
+			tt.setupMock(users, companies)
+
+			members, err := NewExpander(users, companies).Members(context.Background(), []uint{1, 2, 3}, tt.withUsers)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, members)
+		})
+	}
+}
+
+func TestExpander_Companies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := userMocks.NewMockUserUsecase(ctrl)
+	companies := companyMocks.NewMockCompanyUsecase(ctrl)
+
+	joined := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	companies.EXPECT().GetMembershipsByUserIDs(gomock.Any(), []uint{5, 6}).Return([]domain.CompanyUser{
+		{CompanyID: 1, UserID: 5, Role: "admin", CreatedAt: joined},
+		{CompanyID: 2, UserID: 5, Role: "member", CreatedAt: joined},
+		{CompanyID: 1, UserID: 6, Role: "member", CreatedAt: joined},
+	}, nil)
+	companies.EXPECT().GetCompaniesByIDs(gomock.Any(), []uint{1, 2}).Return([]domain.Company{
+		{ID: 1, Name: "株式会社サンプル", Email: "info@sample.co.jp"},
+	}, nil).Times(1)
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+	result, err := NewExpander(users, companies).Companies(ctx, []uint{5, 6})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint][]UserCompany{
+		5: {{ID: 1, Name: "株式会社サンプル", Email: "info@sample.co.jp", Role: "admin", JoinedAt: joined}},
+		6: {{ID: 1, Name: "株式会社サンプル", Email: "info@sample.co.jp", Role: "member", JoinedAt: joined}},
+	}, result)
+}
+
+func TestExpander_Companies_OnlySharedCompanies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := userMocks.NewMockUserUsecase(ctrl)
+	companies := companyMocks.NewMockCompanyUsecase(ctrl)
+
+	// ユーザー6は会社1のみに所属しているため、ユーザー5の会社2は含めない
+	joined := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	companies.EXPECT().GetMembershipsByUserIDs(gomock.Any(), []uint{5, 6}).Return([]domain.CompanyUser{
+		{CompanyID: 1, UserID: 5, Role: "admin", CreatedAt: joined},
+		{CompanyID: 2, UserID: 5, Role: "member", CreatedAt: joined},
+		{CompanyID: 1, UserID: 6, Role: "member", CreatedAt: joined},
+	}, nil)
+	companies.EXPECT().GetMembershipsByUserIDs(gomock.Any(), []uint{6}).Return([]domain.CompanyUser{
+		{CompanyID: 1, UserID: 6, Role: "member", CreatedAt: joined},
+	}, nil)
+	companies.EXPECT().GetCompaniesByIDs(gomock.Any(), []uint{1}).Return([]domain.Company{
+		{ID: 1, Name: "株式会社サンプル", Email: "info@sample.co.jp"},
+	}, nil).Times(1)
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 6})
+	result, err := NewExpander(users, companies).Companies(ctx, []uint{5, 6})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint][]UserCompany{
+		5: {{ID: 1, Name: "株式会社サンプル", Email: "info@sample.co.jp", Role: "admin", JoinedAt: joined}},
+		6: {{ID: 1, Name: "株式会社サンプル", Email: "info@sample.co.jp", Role: "member", JoinedAt: joined}},
+	}, result)
+}
+
+func TestExpander_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := userMocks.NewMockUserUsecase(ctrl)
+	companies := companyMocks.NewMockCompanyUsecase(ctrl)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 9})
+
+	// 所属していない会社の所属ユーザー・参照できないユーザーの会社は展開しない（所属を取得しない）
+	companies.EXPECT().CanView(gomock.Any(), uint(1)).Return(false, nil)
+	users.EXPECT().CanView(gomock.Any(), uint(5)).Return(false, nil)
+
+	expander := NewExpander(users, companies)
+	_, err := expander.ExpandCompany(ctx, &domain.Company{ID: 1}, []string{includeMembers})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = expander.ExpandUser(ctx, &domain.User{ID: 5}, []string{includeCompanies})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
@@ -0,0 +1,251 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"km-api-go/internal/company"
+	"km-api-go/internal/domain"
+	kmv1 "km-api-go/pkg/pb/km/v1"
+)
+
+// CompanyServer CompanyServiceの実装（CompanyUsecaseをそのまま呼び出す）
+type CompanyServer struct {
+	kmv1.UnimplementedCompanyServiceServer
+	usecase company.CompanyUsecase
+}
+
+// NewCompanyServer CompanyServiceのコンストラクタ
+func NewCompanyServer(usecase company.CompanyUsecase) *CompanyServer {
+	return &CompanyServer{usecase: usecase}
+}
+
+func (s *CompanyServer) CreateCompany(ctx context.Context, req *kmv1.CreateCompanyRequest) (*kmv1.Company, error) {
+	if err := validate(&company.CreateCompanyRequest{
+		Name:        req.GetName(),
+		Email:       req.GetEmail(),
+		Phone:       req.GetPhone(),
+		Address:     req.GetAddress(),
+		Website:     req.GetWebsite(),
+		Description: req.GetDescription(),
+	}); err != nil {
+		return nil, err
+	}
+
+	c, err := s.usecase.CreateCompany(ctx, req.GetName(), req.GetEmail(), req.GetPhone(), req.GetAddress(), req.GetWebsite(), req.GetDescription())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toCompany(c), nil
+}
+
+func (s *CompanyServer) GetCompany(ctx context.Context, req *kmv1.GetCompanyRequest) (*kmv1.Company, error) {
+	if err := requireID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	c, err := s.usecase.GetCompanyByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toCompany(c), nil
+}
+
+func (s *CompanyServer) ListCompanies(ctx context.Context, req *kmv1.ListCompaniesRequest) (*kmv1.ListCompaniesResponse, error) {
+	if err := validatePage(req.GetPage(), req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	companies, pagination, err := s.usecase.GetCompaniesPaginated(ctx, int(req.GetPage()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &kmv1.ListCompaniesResponse{
+		Companies:  toCompanies(companies),
+		Pagination: toPagination(pagination),
+	}, nil
+}
+
+func (s *CompanyServer) SearchCompanies(ctx context.Context, req *kmv1.SearchCompaniesRequest) (*kmv1.SearchCompaniesResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	companies, err := s.usecase.SearchCompanies(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &kmv1.SearchCompaniesResponse{Companies: toCompanies(companies)}, nil
+}
+
+func (s *CompanyServer) UpdateCompany(ctx context.Context, req *kmv1.UpdateCompanyRequest) (*kmv1.Company, error) {
+	if err := requireID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := requireID("version", req.GetVersion()); err != nil {
+		return nil, err
+	}
+	if err := validate(&company.UpdateCompanyRequest{
+		Name:        req.GetName(),
+		Email:       req.GetEmail(),
+		Phone:       req.GetPhone(),
+		Address:     req.GetAddress(),
+		Website:     req.GetWebsite(),
+		Description: req.GetDescription(),
+	}); err != nil {
+		return nil, err
+	}
+
+	c, err := s.usecase.UpdateCompany(ctx, uint(req.GetId()), uint(req.GetVersion()),
+		req.GetName(), req.GetEmail(), req.GetPhone(), req.GetAddress(), req.GetWebsite(), req.GetDescription())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toCompany(c), nil
+}
+
+func (s *CompanyServer) DeleteCompany(ctx context.Context, req *kmv1.DeleteCompanyRequest) (*emptypb.Empty, error) {
+	if err := requireID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.usecase.DeleteCompany(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CompanyServer) AddMember(ctx context.Context, req *kmv1.AddMemberRequest) (*kmv1.Membership, error) {
+	if err := requireMember(req.GetCompanyId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	// 省略時はユースケースの既定（member）に任せる
+	role := fromRole(req.GetRole())
+	m, err := s.usecase.AddUserToCompany(ctx, uint(req.GetUserId()), uint(req.GetCompanyId()), role)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toMembership(m), nil
+}
+
+func (s *CompanyServer) UpdateMemberRole(ctx context.Context, req *kmv1.UpdateMemberRoleRequest) (*kmv1.Membership, error) {
+	if err := requireMember(req.GetCompanyId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+	role := fromRole(req.GetRole())
+	if role == "" {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	m, err := s.usecase.UpdateUserRole(ctx, uint(req.GetUserId()), uint(req.GetCompanyId()), role)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toMembership(m), nil
+}
+
+func (s *CompanyServer) RemoveMember(ctx context.Context, req *kmv1.RemoveMemberRequest) (*emptypb.Empty, error) {
+	if err := requireMember(req.GetCompanyId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.usecase.RemoveUserFromCompany(ctx, uint(req.GetUserId()), uint(req.GetCompanyId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CompanyServer) ListMembers(ctx context.Context, req *kmv1.ListMembersRequest) (*kmv1.ListMembershipsResponse, error) {
+	if err := requireID("company_id", req.GetCompanyId()); err != nil {
+		return nil, err
+	}
+
+	members, err := s.usecase.GetUsersByCompany(ctx, uint(req.GetCompanyId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toMemberships(members), nil
+}
+
+func (s *CompanyServer) ListUserCompanies(ctx context.Context, req *kmv1.ListUserCompaniesRequest) (*kmv1.ListMembershipsResponse, error) {
+	if err := requireID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.usecase.GetCompaniesByUser(ctx, uint(req.GetUserId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toMemberships(memberships), nil
+}
+
+func requireMember(companyID, userID uint64) error {
+	if err := requireID("company_id", companyID); err != nil {
+		return err
+	}
+	return requireID("user_id", userID)
+}
+
+// 役割の変換（domain.CompanyUser.Roleの値）
+var (
+	roleNames = map[kmv1.Role]string{
+		kmv1.Role_ROLE_ADMIN:  "admin",
+		kmv1.Role_ROLE_MEMBER: "member",
+	}
+	roleValues = map[string]kmv1.Role{
+		"admin":  kmv1.Role_ROLE_ADMIN,
+		"member": kmv1.Role_ROLE_MEMBER,
+	}
+)
+
+// fromRole 未指定・未知の値は空文字
+func fromRole(role kmv1.Role) string {
+	return roleNames[role]
+}
+
+func toCompany(c *domain.Company) *kmv1.Company {
+	return &kmv1.Company{
+		Id:          uint64(c.ID),
+		Name:        c.Name,
+		Email:       c.Email,
+		Phone:       c.Phone,
+		Address:     c.Address,
+		Website:     c.Website,
+		Description: c.Description,
+		Version:     uint64(c.Version),
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+}
+
+func toCompanies(companies []domain.Company) []*kmv1.Company {
+	res := make([]*kmv1.Company, 0, len(companies))
+	for i := range companies {
+		res = append(res, toCompany(&companies[i]))
+	}
+	return res
+}
+
+func toMembership(m *domain.CompanyUser) *kmv1.Membership {
+	return &kmv1.Membership{
+		Id:        uint64(m.ID),
+		UserId:    uint64(m.UserID),
+		CompanyId: uint64(m.CompanyID),
+		Role:      roleValues[m.Role],
+		CreatedAt: timestamppb.New(m.CreatedAt),
+		UpdatedAt: timestamppb.New(m.UpdatedAt),
+	}
+}
+
+func toMemberships(memberships []domain.CompanyUser) *kmv1.ListMembershipsResponse {
+	res := &kmv1.ListMembershipsResponse{Memberships: make([]*kmv1.Membership, 0, len(memberships))}
+	for i := range memberships {
+		res.Memberships = append(res.Memberships, toMembership(&memberships[i]))
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// Config gRPCサーバーの設定
+type Config struct {
+	Enabled         bool          // HTTPとは別のポートでgRPCサーバーを起動するか
+	Port            string        // 待ち受けるポート
+	Reflection      bool          // リフレクションサービスを公開するか（grpcurl等での確認用）
+	ShutdownTimeout time.Duration // 処理中のRPCの完了を待つ時間（超えた場合は強制的に切断する）
+}
+
+// LoadConfig 環境変数からgRPCサーバーの設定を読み込み
+// 本番環境ではGRPC_REFLECTIONに関わらずリフレクションを無効化する
+func LoadConfig() Config {
+	return Config{
+		Enabled:         infra.GetEnvBool("GRPC_ENABLED", true),
+		Port:            infra.GetEnv("GRPC_PORT", "9090"),
+		Reflection:      infra.GetEnvBool("GRPC_REFLECTION", true) && !infra.IsProduction(),
+		ShutdownTimeout: infra.GetEnvDuration("GRPC_SHUTDOWN_TIMEOUT", 10*time.Second),
+	}
+}
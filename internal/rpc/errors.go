@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+// toStatus ドメインエラーをgRPCのステータスに変換する
+// 想定外のエラーはログに残し、詳細を含めずにINTERNALを返す
+func toStatus(ctx context.Context, err error) error {
+	var validationErrs helper.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		return status.Error(codes.InvalidArgument, validationErrs.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrVersionConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "forbidden")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	logging.FromContext(ctx).ErrorContext(ctx, "failed to handle rpc", slog.Any("error", err))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// validate リクエストをRESTと同じ規則で検証する
+func validate(s interface{}) error {
+	if err := helper.ValidateStruct(s); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// requireID IDの指定を確認する
+func requireID(field string, id uint64) error {
+	if id == 0 {
+		return status.Errorf(codes.InvalidArgument, "%s is required", field)
+	}
+	return nil
+}
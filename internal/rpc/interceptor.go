@@ -0,0 +1,234 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/tracing"
+)
+
+// メタデータのキー（gRPCのメタデータのキーは小文字）
+const (
+	MetadataAuthorization = "authorization"
+	MetadataAPIKey        = "x-api-key"
+	MetadataRequestID     = "x-request-id"
+)
+
+// maxRequestIDLength 受け入れるx-request-idの最大長
+const maxRequestIDLength = 128
+
+// publicMethods 認証なしで呼び出せるRPC（RESTのユーザー登録と同じ）
+var publicMethods = map[string]bool{
+	"/km.v1.UserService/CreateUser": true,
+}
+
+// isPublic 認証なしで呼び出せるか（ヘルスチェック・リフレクションを含む）
+func isPublic(fullMethod string) bool {
+	return publicMethods[fullMethod] ||
+		strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") ||
+		strings.HasPrefix(fullMethod, "/grpc.reflection.")
+}
+
+// UnaryRequestID x-request-idを受け入れ（なければ生成し）、レスポンスヘッダーとコンテキストのロガーに設定する
+func UnaryRequestID(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := firstMetadata(ctx, MetadataRequestID)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = generateRequestID()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, requestID))
+
+		ctx = logging.WithLogger(ctx, logger)
+		ctx = logging.WithRequestID(ctx, requestID)
+		return handler(ctx, req)
+	}
+}
+
+// UnaryTracing RPCごとのスパンを生成する
+// メタデータのW3C Trace Context（traceparent）を引き継ぎ、ログにもtrace_idを付与する
+func UnaryTracing() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		service, method := splitMethod(info.FullMethod)
+		ctx, span := tracing.Tracer().Start(ctx, strings.TrimPrefix(info.FullMethod, "/"),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(service),
+				semconv.RPCMethod(method),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With(slog.String("trace_id", sc.TraceID().String())))
+		}
+
+		resp, err := handler(ctx, req)
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if isServerError(code) {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, code.String())
+		}
+		return resp, err
+	}
+}
+
+// UnaryLogger アクセスログをslogで出力する
+// UnaryRequestIDより後に登録し、コンテキストのロガーを利用する
+func UnaryLogger() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		attrs := []any{
+			slog.String("method", info.FullMethod),
+			slog.String("code", code.String()),
+			slog.Duration("latency", time.Since(start)),
+		}
+
+		logger := logging.FromContext(ctx)
+		switch {
+		case isServerError(code):
+			attrs = append(attrs, slog.String("error", err.Error()))
+			logger.ErrorContext(ctx, "rpc", attrs...)
+		case code != codes.OK:
+			logger.WarnContext(ctx, "rpc", attrs...)
+		default:
+			logger.InfoContext(ctx, "rpc", attrs...)
+		}
+		return resp, err
+	}
+}
+
+// UnaryRecovery ハンドラーのpanicを回復し、INTERNALを返す
+func UnaryRecovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "panic recovered",
+					slog.Any("error", r), slog.String("stack", string(debug.Stack())))
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// UnaryAuth メタデータのBearerトークン・APIキーをRESTと同じ規則で検証し、呼び出し元をコンテキストに設定する
+// 不正な認証情報と、公開RPC以外への認証なしの呼び出しはUNAUTHENTICATEDで拒否する
+// gRPCはサービス間の連携用のため、公開RPC以外はAPIキーのみ受け付け、ユーザーのトークンはPERMISSION_DENIEDで拒否する
+func UnaryAuth(tokens *auth.TokenService, cfg auth.Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, tokens, cfg, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth ストリーミングRPC用のUnaryAuth（現在はヘルスチェックのWatchとリフレクションのみ）
+func StreamAuth(tokens *auth.TokenService, cfg auth.Config) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, err := authenticate(ss.Context(), tokens, cfg, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func authenticate(ctx context.Context, tokens *auth.TokenService, cfg auth.Config, fullMethod string) (context.Context, error) {
+	principal, err := auth.Authenticate(tokens, cfg, firstMetadata(ctx, MetadataAuthorization), firstMetadata(ctx, MetadataAPIKey))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	if principal == nil {
+		if !isPublic(fullMethod) {
+			return nil, status.Error(codes.Unauthenticated, "authentication required")
+		}
+		return ctx, nil
+	}
+	if principal.IsUser() && !isPublic(fullMethod) {
+		return nil, status.Error(codes.PermissionDenied, "api key required")
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// firstMetadata 受信メタデータの最初の値
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// isServerError サーバー側の問題を表すステータスか
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		return true
+	}
+	return false
+}
+
+// splitMethod "/package.Service/Method" をサービス名とメソッド名に分ける
+func splitMethod(fullMethod string) (service, method string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "", fullMethod
+	}
+	return service, method
+}
+
+// generateRequestID ランダムなリクエストIDを生成
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// metadataCarrier トレースコンテキストの伝播用にメタデータを読み書きする
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
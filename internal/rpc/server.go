@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/company"
+	"km-api-go/internal/user"
+	kmv1 "km-api-go/pkg/pb/km/v1"
+)
+
+// Server ユーザー・会社のgRPCサービスと、ヘルスチェック・リフレクションを公開するサーバー
+type Server struct {
+	grpc   *grpc.Server
+	health *health.Server
+}
+
+// NewServer gRPCサーバーのコンストラクタ
+// 認証はRESTと同じトークン・APIキーの検証を使い、公開RPC以外はAPIキーのみ受け付ける
+func NewServer(
+	cfg Config,
+	logger *slog.Logger,
+	tokens *auth.TokenService,
+	authCfg auth.Config,
+	userUsecase user.UserUsecase,
+	companyUsecase company.CompanyUsecase,
+) *Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			UnaryRequestID(logger),
+			UnaryTracing(),
+			UnaryLogger(),
+			UnaryRecovery(),
+			UnaryAuth(tokens, authCfg),
+		),
+		grpc.ChainStreamInterceptor(StreamAuth(tokens, authCfg)),
+	)
+
+	kmv1.RegisterUserServiceServer(s, NewUserServer(userUsecase))
+	kmv1.RegisterCompanyServiceServer(s, NewCompanyServer(companyUsecase))
+
+	healthServer := health.NewServer()
+	for service := range s.GetServiceInfo() {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	if cfg.Reflection {
+		reflection.Register(s)
+	}
+
+	return &Server{grpc: s, health: healthServer}
+}
+
+// Serve 接続の受け付けを開始する（Shutdownまで戻らない）
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// MarkShuttingDown ヘルスチェックをNOT_SERVINGにし、クライアントに振り分けを止めさせる
+func (s *Server) MarkShuttingDown() {
+	s.health.Shutdown()
+}
+
+// Shutdown 新しいRPCの受け付けを止め、処理中のRPCの完了を待つ
+// ctxの期限までに終わらない場合は接続を強制的に切断する
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,323 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/mocks"
+	"km-api-go/internal/domain"
+	userMocks "km-api-go/internal/user/mocks"
+	kmv1 "km-api-go/pkg/pb/km/v1"
+)
+
+type testServer struct {
+	server         *Server
+	conn           *grpc.ClientConn
+	tokens         *auth.TokenService
+	userUsecase    *userMocks.MockUserUsecase
+	companyUsecase *companyMocks.MockCompanyUsecase
+}
+
+// newTestServer メモリ上の接続でgRPCサーバーを起動する
+func newTestServer(t *testing.T) *testServer {
+	ctrl := gomock.NewController(t)
+	ts := &testServer{
+		tokens:         auth.NewTokenService("test-secret", time.Hour),
+		userUsecase:    userMocks.NewMockUserUsecase(ctrl),
+		companyUsecase: companyMocks.NewMockCompanyUsecase(ctrl),
+	}
+	authCfg := auth.Config{APIKeys: []auth.APIKey{{Name: "dashboard", Key: "secret-key"}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ts.server = NewServer(Config{Reflection: true}, logger, ts.tokens, authCfg, ts.userUsecase, ts.companyUsecase)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = ts.server.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	ts.conn = conn
+
+	t.Cleanup(func() {
+		conn.Close()
+		ts.server.grpc.Stop()
+	})
+	return ts
+}
+
+// apiKeyContext APIキーで呼び出すコンテキスト
+func (ts *testServer) apiKeyContext() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), MetadataAPIKey, "secret-key")
+}
+
+func (ts *testServer) userContext(t *testing.T, userID uint) context.Context {
+	token, _, err := ts.tokens.Issue(userID)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), MetadataAuthorization, "Bearer "+token)
+}
+
+func TestServer_Auth(t *testing.T) {
+	ts := newTestServer(t)
+	client := kmv1.NewUserServiceClient(ts.conn)
+
+	tests := []struct {
+		name         string
+		ctx          func() context.Context
+		setupMock    func()
+		call         func(ctx context.Context) error
+		expectedCode codes.Code
+	}{
+		{
+			name:      "異常系: ユーザーのトークンは受け付けない",
+			ctx:       func() context.Context { return ts.userContext(t, 7) },
+			setupMock: func() {},
+			call: func(ctx context.Context) error {
+				_, err := client.DeleteUser(ctx, &kmv1.DeleteUserRequest{Id: 1})
+				return err
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:      "異常系: ユーザーのトークンで会社の所属を変更",
+			ctx:       func() context.Context { return ts.userContext(t, 7) },
+			setupMock: func() {},
+			call: func(ctx context.Context) error {
+				_, err := kmv1.NewCompanyServiceClient(ts.conn).AddMember(ctx, &kmv1.AddMemberRequest{CompanyId: 1, UserId: 7, Role: kmv1.Role_ROLE_ADMIN})
+				return err
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "正常系: APIキー",
+			ctx: func() context.Context {
+				return metadata.AppendToOutgoingContext(context.Background(), MetadataAPIKey, "secret-key")
+			},
+			setupMock: func() {
+				ts.userUsecase.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(&domain.User{ID: 1}, nil)
+			},
+			call: func(ctx context.Context) error {
+				_, err := client.GetUser(ctx, &kmv1.GetUserRequest{Id: 1})
+				return err
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "正常系: ユーザー作成は認証不要",
+			ctx:  context.Background,
+			setupMock: func() {
+				ts.userUsecase.EXPECT().
+					Create(gomock.Any(), "山田太郎", "yamada@example.com", "password123").
+					Return(&domain.User{ID: 1, Name: "山田太郎", Email: "yamada@example.com", Version: 1}, nil)
+			},
+			call: func(ctx context.Context) error {
+				_, err := client.CreateUser(ctx, &kmv1.CreateUserRequest{Name: "山田太郎", Email: "yamada@example.com", Password: "password123"})
+				return err
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:      "異常系: 認証情報なし",
+			ctx:       context.Background,
+			setupMock: func() {},
+			call: func(ctx context.Context) error {
+				_, err := client.GetUser(ctx, &kmv1.GetUserRequest{Id: 1})
+				return err
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name: "異常系: 不正なトークン",
+			ctx: func() context.Context {
+				return metadata.AppendToOutgoingContext(context.Background(), MetadataAuthorization, "Bearer invalid")
+			},
+			setupMock: func() {},
+			call: func(ctx context.Context) error {
+				_, err := client.CreateUser(ctx, &kmv1.CreateUserRequest{Name: "山田太郎", Email: "yamada@example.com", Password: "password123"})
+				return err
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := tt.call(tt.ctx())
+			assert.Equal(t, tt.expectedCode, status.Code(err), "error: %v", err)
+		})
+	}
+}
+
+func TestCompanyServer_Errors(t *testing.T) {
+	ts := newTestServer(t)
+	client := kmv1.NewCompanyServiceClient(ts.conn)
+	ctx := ts.apiKeyContext()
+	updateReq := &kmv1.UpdateCompanyRequest{Id: 1, Version: 2, Name: "会社A", Email: "a@example.com"}
+
+	tests := []struct {
+		name            string
+		req             *kmv1.UpdateCompanyRequest
+		setupMock       func()
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{
+			name: "正常系: 会社を更新",
+			req:  updateReq,
+			setupMock: func() {
+				ts.companyUsecase.EXPECT().
+					UpdateCompany(gomock.Any(), uint(1), uint(2), "会社A", "a@example.com", "", "", "", "").
+					Return(&domain.Company{ID: 1, Name: "会社A", Version: 3}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "異常系: 存在しない",
+			req:  updateReq,
+			setupMock: func() {
+				ts.companyUsecase.EXPECT().
+					UpdateCompany(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("company with id 1 %w", domain.ErrNotFound))
+			},
+			expectedCode:    codes.NotFound,
+			expectedMessage: "company with id 1 not found",
+		},
+		{
+			name: "異常系: バージョンが一致しない",
+			req:  updateReq,
+			setupMock: func() {
+				ts.companyUsecase.EXPECT().
+					UpdateCompany(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("company with id 1 version 2: %w", domain.ErrVersionConflict))
+			},
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: "company with id 1 version 2: version conflict",
+		},
+		{
+			name: "異常系: 想定外のエラーは詳細を返さない",
+			req:  updateReq,
+			setupMock: func() {
+				ts.companyUsecase.EXPECT().
+					UpdateCompany(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:    codes.Internal,
+			expectedMessage: "internal error",
+		},
+		{
+			name:         "異常系: バージョンの指定なし",
+			req:          &kmv1.UpdateCompanyRequest{Id: 1, Name: "会社A", Email: "a@example.com"},
+			setupMock:    func() {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "異常系: RESTと同じ入力検証",
+			req:          &kmv1.UpdateCompanyRequest{Id: 1, Version: 2, Name: "会社A", Email: "not-an-email"},
+			setupMock:    func() {},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			res, err := client.UpdateCompany(ctx, tt.req)
+			assert.Equal(t, tt.expectedCode, status.Code(err), "error: %v", err)
+			if tt.expectedMessage != "" {
+				assert.Equal(t, tt.expectedMessage, status.Convert(err).Message())
+			}
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, uint64(3), res.GetVersion())
+			}
+		})
+	}
+}
+
+func TestCompanyServer_AddMember(t *testing.T) {
+	ts := newTestServer(t)
+	client := kmv1.NewCompanyServiceClient(ts.conn)
+	ctx := ts.apiKeyContext()
+
+	ts.companyUsecase.EXPECT().
+		AddUserToCompany(gomock.Any(), uint(2), uint(1), "admin").
+		Return(&domain.CompanyUser{ID: 10, UserID: 2, CompanyID: 1, Role: "admin"}, nil)
+
+	res, err := client.AddMember(ctx, &kmv1.AddMemberRequest{CompanyId: 1, UserId: 2, Role: kmv1.Role_ROLE_ADMIN})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), res.GetId())
+	assert.Equal(t, kmv1.Role_ROLE_ADMIN, res.GetRole())
+}
+
+func TestServer_Health(t *testing.T) {
+	ts := newTestServer(t)
+	client := healthpb.NewHealthClient(ts.conn)
+
+	// ヘルスチェックは認証不要
+	for _, service := range []string{"", "km.v1.UserService", "km.v1.CompanyService"} {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus(), service)
+	}
+
+	ts.server.MarkShuttingDown()
+	res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	ts := newTestServer(t)
+	client := kmv1.NewUserServiceClient(ts.conn)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ts.userUsecase.EXPECT().
+		GetUserByID(gomock.Any(), uint(1)).
+		DoAndReturn(func(context.Context, uint) (*domain.User, error) {
+			close(started)
+			<-release
+			return &domain.User{ID: 1}, nil
+		})
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.GetUser(ts.apiKeyContext(), &kmv1.GetUserRequest{Id: 1})
+		result <- err
+	}()
+	<-started
+
+	// 処理中のRPCが終わるまで待つ
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- ts.server.Shutdown(context.Background()) }()
+	select {
+	case <-shutdown:
+		t.Fatal("shutdown returned before the running rpc finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-result)
+	assert.NoError(t, <-shutdown)
+}
@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/user"
+	kmv1 "km-api-go/pkg/pb/km/v1"
+)
+
+// UserServer UserServiceの実装（UserUsecaseをそのまま呼び出す）
+type UserServer struct {
+	kmv1.UnimplementedUserServiceServer
+	usecase user.UserUsecase
+}
+
+// NewUserServer UserServiceのコンストラクタ
+func NewUserServer(usecase user.UserUsecase) *UserServer {
+	return &UserServer{usecase: usecase}
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *kmv1.CreateUserRequest) (*kmv1.User, error) {
+	if err := validate(&user.CreateUserRequest{Name: req.GetName(), Email: req.GetEmail(), Password: req.GetPassword()}); err != nil {
+		return nil, err
+	}
+
+	u, err := s.usecase.Create(ctx, req.GetName(), req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toUser(u), nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *kmv1.GetUserRequest) (*kmv1.User, error) {
+	if err := requireID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	u, err := s.usecase.GetUserByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toUser(u), nil
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, req *kmv1.ListUsersRequest) (*kmv1.ListUsersResponse, error) {
+	if err := validatePage(req.GetPage(), req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	users, pagination, err := s.usecase.GetUsersPaginated(ctx, int(req.GetPage()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	res := &kmv1.ListUsersResponse{
+		Users:      make([]*kmv1.User, 0, len(users)),
+		Pagination: toPagination(pagination),
+	}
+	for i := range users {
+		res.Users = append(res.Users, toUser(&users[i]))
+	}
+	return res, nil
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *kmv1.UpdateUserRequest) (*kmv1.User, error) {
+	if err := requireID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := requireID("version", req.GetVersion()); err != nil {
+		return nil, err
+	}
+	if err := validate(&user.UpdateUserRequest{Name: req.GetName(), Email: req.GetEmail()}); err != nil {
+		return nil, err
+	}
+
+	u, err := s.usecase.UpdateUser(ctx, uint(req.GetId()), uint(req.GetVersion()), req.GetName(), req.GetEmail())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toUser(u), nil
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, req *kmv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := requireID("id", req.GetId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.usecase.DeleteUser(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func toUser(u *domain.User) *kmv1.User {
+	return &kmv1.User{
+		Id:        uint64(u.ID),
+		Name:      u.Name,
+		Email:     u.Email,
+		Version:   uint64(u.Version),
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+}
+
+// validatePage ページ番号・件数を検証する（0は省略として既定値を使う）
+func validatePage(page, limit int32) error {
+	if page < 0 || limit < 0 || limit > 100 {
+		return status.Error(codes.InvalidArgument, "page must be >= 1 and limit must be between 1 and 100")
+	}
+	return nil
+}
+
+func toPagination(p *helper.PaginationResponse) *kmv1.Pagination {
+	if p == nil {
+		return nil
+	}
+	return &kmv1.Pagination{
+		Page:       int32(p.Page),
+		Limit:      int32(p.Limit),
+		Total:      p.Total,
+		TotalPages: int32(p.TotalPages),
+	}
+}
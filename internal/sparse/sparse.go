@@ -0,0 +1,178 @@
+package sparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownField レスポンスに存在しないフィールドが指定された
+var ErrUnknownField = errors.New("unknown field")
+
+// ErrUnknownInclude 展開できない関連が指定された
+var ErrUnknownInclude = errors.New("unknown include")
+
+// 常にレスポンスに含めるフィールド
+const idField = "id"
+
+// Parse カンマ区切りの指定を一覧にする（空白・重複は除き、指定された順序を保つ）
+func Parse(spec string) []string {
+	var names []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(names, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// ParseInclude include=の指定を解釈する（allowedに含まれない関連はErrUnknownInclude）
+// "members.user" のようにネストした関連を指定した場合は、親の関連（"members"）も含める
+func ParseInclude(spec string, allowed ...string) ([]string, error) {
+	var include []string
+	for _, name := range Parse(spec) {
+		if !slices.Contains(allowed, name) {
+			return nil, fmt.Errorf("%w: %s（指定可能: %s）", ErrUnknownInclude, name, strings.Join(allowed, ", "))
+		}
+		for i := range name {
+			if name[i] == '.' && !slices.Contains(include, name[:i]) {
+				include = append(include, name[:i])
+			}
+		}
+		if !slices.Contains(include, name) {
+			include = append(include, name)
+		}
+	}
+	return include, nil
+}
+
+// GoFields JSONのキーをmodel（構造体）のGoのフィールド名に変換（リポジトリでSELECTするカラムの指定用）
+// 対応するフィールドがないキー（レスポンスで算出するフィールド）と json:"-" のフィールドは除く
+func GoFields(model any, names []string) []string {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if slices.Contains(names, name) {
+			fields = append(fields, f.Name)
+		}
+	}
+	return fields
+}
+
+// Object キーの順序を保つJSONオブジェクト
+type Object struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// Project dataのJSON表現（オブジェクト）から指定したフィールドだけを残す
+// fieldsが空の場合はすべて残し、idは常に残す。dataに存在しないフィールドはErrUnknownField
+func Project(data any, fields []string) (*Object, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := decodeObject(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return obj, nil
+	}
+
+	for _, name := range fields {
+		if _, ok := obj.values[name]; !ok {
+			return nil, fmt.Errorf("%w: %s（指定可能: %s）", ErrUnknownField, name, strings.Join(obj.keys, ", "))
+		}
+	}
+	keys := obj.keys[:0]
+	for _, key := range obj.keys {
+		if key == idField || slices.Contains(fields, key) {
+			keys = append(keys, key)
+			continue
+		}
+		delete(obj.values, key)
+	}
+	obj.keys = keys
+	return obj, nil
+}
+
+// Set フィールドを追加する（既にある場合は値を置き換え、位置は変えない）
+func (o *Object) Set(key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = raw
+	return nil
+}
+
+// MarshalJSON json.Marshaler
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(o.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeObject JSONオブジェクトをキーの順序を保って読み込む
+func decodeObject(body []byte) (*Object, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("response is not a JSON object")
+	}
+
+	obj := &Object{values: make(map[string]json.RawMessage)}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if _, ok := obj.values[key]; !ok {
+			obj.keys = append(obj.keys, key)
+		}
+		obj.values[key] = value
+	}
+	return obj, nil
+}
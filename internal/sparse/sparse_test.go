@@ -0,0 +1,106 @@
+package sparse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/domain"
+)
+
+func TestParseInclude(t *testing.T) {
+	allowed := []string{"members", "members.user", "companies"}
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "正常系: 未指定", spec: "", expected: nil},
+		{name: "正常系: 空白と重複を除く", spec: " companies , companies,", expected: []string{"companies"}},
+		{name: "正常系: ネストした関連は親も含める", spec: "members.user", expected: []string{"members", "members.user"}},
+		{name: "正常系: 親を後から指定しても重複しない", spec: "members.user,members", expected: []string{"members", "members.user"}},
+		{name: "異常系: 展開できない関連", spec: "members,webhooks", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			include, err := ParseInclude(tt.spec, allowed...)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownInclude)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, include)
+		})
+	}
+}
+
+func TestGoFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{name: "正常系: 構造体の定義順で返す", names: []string{"updated_at", "name", "id"}, expected: []string{"ID", "Name", "UpdatedAt"}},
+		{name: "正常系: レスポンスで算出するフィールドは除く", names: []string{"email", "memberships"}, expected: []string{"Email"}},
+		{name: "正常系: json:\"-\" のフィールドは選択できない", names: []string{"password", "Password"}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GoFields(&domain.User{}, tt.names))
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	data := struct {
+		ID      uint     `json:"id"`
+		Name    string   `json:"name"`
+		Email   string   `json:"email"`
+		Version uint     `json:"version"`
+		Tags    []string `json:"tags"`
+	}{ID: 1, Name: "山田太郎", Email: "yamada@example.com", Version: 2, Tags: []string{"a"}}
+
+	tests := []struct {
+		name     string
+		fields   []string
+		expected string
+		wantErr  bool
+	}{
+		{name: "正常系: 未指定はすべて返す", fields: nil, expected: `{"id":1,"name":"山田太郎","email":"yamada@example.com","version":2,"tags":["a"]}`},
+		{name: "正常系: 元の順序でidは常に返す", fields: []string{"tags", "name"}, expected: `{"id":1,"name":"山田太郎","tags":["a"]}`},
+		{name: "異常系: 存在しないフィールド", fields: []string{"name", "password"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := Project(data, tt.fields)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownField)
+				return
+			}
+			require.NoError(t, err)
+			body, err := json.Marshal(obj)
+			require.NoError(t, err)
+			// キーの順序も確認する
+			assert.Equal(t, tt.expected, string(body))
+		})
+	}
+}
+
+func TestObject_Set(t *testing.T) {
+	obj, err := Project(map[string]any{"id": 1, "members": []int{}}, []string{"members"})
+	require.NoError(t, err)
+
+	require.NoError(t, obj.Set("members", []map[string]any{{"user_id": 5}}))
+	require.NoError(t, obj.Set("companies", []any{}))
+
+	body, err := json.Marshal(obj)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1,"members":[{"user_id":5}],"companies":[]}`, string(body))
+}
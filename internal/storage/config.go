@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"km-api-go/internal/infra"
+)
+
+// バックエンド種別
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// defaultLocalURLSecret 開発用の既定の署名鍵（公開されているため本番環境では使えない）
+const defaultLocalURLSecret = "your-storage-url-secret-change-in-production"
+
+// Config ファイルの保存先の設定
+type Config struct {
+	Backend string // local, s3
+
+	LocalDir       string // ローカルの保存先ディレクトリ
+	LocalURL       string // ローカルのファイルを配信するURL（このサーバーの /files）
+	LocalURLSecret string // ローカルの署名付きURLの署名鍵
+
+	S3Endpoint  string // S3互換ストレージのエンドポイント（例: https://s3.ap-northeast-1.amazonaws.com, http://localhost:9000）
+	S3Region    string // リージョン
+	S3Bucket    string // バケット
+	S3AccessKey string // アクセスキーID
+	S3SecretKey string // シークレットアクセスキー
+	S3PathStyle bool   // パス形式（endpoint/bucket/key）でアクセスするか（MinIO等）
+}
+
+// LoadConfig 環境変数からファイルの保存先の設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		Backend:        strings.ToLower(infra.GetEnv("STORAGE_BACKEND", BackendLocal)),
+		LocalDir:       infra.GetEnv("STORAGE_LOCAL_DIR", "./data/storage"),
+		LocalURL:       infra.GetEnv("STORAGE_LOCAL_URL", "http://localhost:8080"+FilesPath),
+		LocalURLSecret: infra.GetEnv("STORAGE_LOCAL_URL_SECRET", defaultLocalURLSecret),
+		S3Endpoint:     infra.GetEnv("STORAGE_S3_ENDPOINT", ""),
+		S3Region:       infra.GetEnv("STORAGE_S3_REGION", "ap-northeast-1"),
+		S3Bucket:       infra.GetEnv("STORAGE_S3_BUCKET", ""),
+		S3AccessKey:    infra.GetEnv("STORAGE_S3_ACCESS_KEY", ""),
+		S3SecretKey:    infra.GetEnv("STORAGE_S3_SECRET_KEY", ""),
+		S3PathStyle:    infra.GetEnvBool("STORAGE_S3_PATH_STYLE", false),
+	}
+}
+
+// Validate 本番環境でローカルの署名鍵が未設定・既定値のままの場合はエラー（誰でも署名付きURLを作れてしまうため起動しない）
+func (c Config) Validate() error {
+	if c.Backend == BackendLocal && infra.IsProduction() &&
+		(c.LocalURLSecret == "" || c.LocalURLSecret == defaultLocalURLSecret) {
+		return errors.New("STORAGE_LOCAL_URL_SECRET must be set to a non-default value in production")
+	}
+	return nil
+}
+
+// New 設定に応じた保存先を作成
+func New(cfg Config) (BlobStore, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Backend {
+	case BackendLocal:
+		return NewLocalStore(cfg.LocalDir, cfg.LocalURL, []byte(cfg.LocalURLSecret))
+	case BackendS3:
+		return NewS3Store(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		})
+	}
+	return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+// FileHandler LocalStoreのファイルを署名付きURLで配信するハンドラー
+// 署名で認可するため、認証・APIのベースパスの外（FilesPath）に登録する
+type FileHandler struct {
+	store *LocalStore
+}
+
+func NewFileHandler(store *LocalStore) *FileHandler {
+	return &FileHandler{store: store}
+}
+
+// Serve 署名と期限を検証してファイルを返す（Range・If-Modified-Sinceに対応する）
+func (h *FileHandler) Serve(c echo.Context) error {
+	key, err := url.PathUnescape(c.Param("*"))
+	if err != nil || !ValidKey(key) {
+		return helper.NotFoundResponse(c, "ファイル")
+	}
+	expires := c.QueryParam("expires")
+	if err := h.store.Verify(key, expires, c.QueryParam("signature")); err != nil {
+		return helper.ErrorResponse(c, http.StatusForbidden, helper.ErrorCodeForbidden, "URLの署名が正しくないか、期限が切れています", "")
+	}
+
+	ctx := c.Request().Context()
+	f, obj, err := h.store.Open(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return helper.NotFoundResponse(c, "ファイル")
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to open stored file", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+	defer f.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, obj.ContentType)
+	// URLの期限を超えてキャッシュさせない
+	header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge(expires, h.store.now())))
+	http.ServeContent(c.Response(), c.Request(), "", obj.ModTime, f)
+	return nil
+}
+
+// maxAge 署名付きURLの期限までの秒数
+func maxAge(expires string, now time.Time) int64 {
+	var unix int64
+	if _, err := fmt.Sscan(expires, &unix); err != nil {
+		return 0
+	}
+	return max(unix-now.Unix(), 0)
+}
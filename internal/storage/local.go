@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"km-api-go/internal/domain"
+)
+
+// FilesPath ローカルに保存したファイルを署名付きURLで配信するパス
+const FilesPath = "/files"
+
+// ErrInvalidSignature 署名付きURLの署名が一致しないか、期限が切れている
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// LocalStore ローカルファイルシステムの保存先（開発・単一インスタンス用）
+// 内容は dir/objects、Content-Typeは dir/meta に保存し、署名付きURLはこのサーバーの FilesPath で配信する
+type LocalStore struct {
+	dir     string
+	baseURL string
+	secret  []byte
+	now     func() time.Time
+}
+
+// localMeta ファイルと別に保存するメタデータ
+type localMeta struct {
+	ContentType string `json:"content_type"`
+}
+
+// NewLocalStore ローカルの保存先を作成（ディレクトリがなければ作成する）
+func NewLocalStore(dir, baseURL string, secret []byte) (*LocalStore, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("storage url secret is required")
+	}
+	for _, sub := range []string{"objects", "meta"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+	return &LocalStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/"), secret: secret, now: time.Now}, nil
+}
+
+func (s *LocalStore) objectPath(key string) string {
+	return filepath.Join(s.dir, "objects", filepath.FromSlash(key))
+}
+
+func (s *LocalStore) metaPath(key string) string {
+	return filepath.Join(s.dir, "meta", filepath.FromSlash(key)+".json")
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	meta, err := json.Marshal(localMeta{ContentType: contentType})
+	if err != nil {
+		return err
+	}
+	if err := writeFile(s.metaPath(key), bytes.NewReader(meta)); err != nil {
+		return fmt.Errorf("failed to write metadata of %s: %w", key, err)
+	}
+	if err := writeFile(s.objectPath(key), io.LimitReader(r, size)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// writeFile 一時ファイルに書き込んでから置き換える（書き込み途中の内容を読ませない）
+func writeFile(name string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	f, obj, err := s.Open(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, obj, nil
+}
+
+// Open ファイルを開く（Rangeリクエストに応じられるよう*os.Fileを返す）
+func (s *LocalStore) Open(key string) (*os.File, *Object, error) {
+	if err := checkKey(key); err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(s.objectPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("object %s %w", key, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("failed to stat %s: %w", key, err)
+	}
+
+	obj := &Object{Key: key, Size: info.Size(), ContentType: "application/octet-stream", ModTime: info.ModTime()}
+	if b, err := os.ReadFile(s.metaPath(key)); err == nil {
+		var meta localMeta
+		if json.Unmarshal(b, &meta) == nil && meta.ContentType != "" {
+			obj.ContentType = meta.ContentType
+		}
+	}
+	return f, obj, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	for _, name := range []string{s.objectPath(key), s.metaPath(key)} {
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// SignedURL baseURL/key?expires=期限（Unix秒）&signature=署名 を返す
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	q := url.Values{"expires": {expires}, "signature": {s.sign(key, expires)}}
+	return s.baseURL + "/" + strings.Join(segments, "/") + "?" + q.Encode(), nil
+}
+
+// Verify 署名付きURLのキー・期限・署名を検証する
+func (s *LocalStore) Verify(key, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/domain"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(t.TempDir(), "http://localhost:8080/files", []byte("secret"))
+	require.NoError(t, err)
+	return store
+}
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "users/1/avatar/a.png", strings.NewReader("png"), 3, "image/png"))
+
+	r, obj, err := store.Get(ctx, "users/1/avatar/a.png")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(r)
+	_ = r.Close()
+	assert.Equal(t, "png", string(body))
+	assert.Equal(t, int64(3), obj.Size)
+	assert.Equal(t, "image/png", obj.ContentType)
+
+	require.NoError(t, store.Delete(ctx, "users/1/avatar/a.png"))
+	_, _, err = store.Get(ctx, "users/1/avatar/a.png")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// 存在しないキーの削除はエラーにしない
+	assert.NoError(t, store.Delete(ctx, "users/1/avatar/a.png"))
+}
+
+func TestLocalStore_RejectsInvalidKeys(t *testing.T) {
+	store := newTestLocalStore(t)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "users/../../secret", "users//a", `users\a`} {
+		t.Run(key, func(t *testing.T) {
+			assert.Error(t, store.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain"))
+		})
+	}
+}
+
+func TestFileHandler_Serve(t *testing.T) {
+	store := newTestLocalStore(t)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	require.NoError(t, store.Put(context.Background(), "users/1/avatar/a.png", strings.NewReader("png"), 3, "image/png"))
+
+	signed, err := store.SignedURL(context.Background(), "users/1/avatar/a.png", 10*time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/files/users/1/avatar/a.png", u.Path)
+
+	e := echo.New()
+	e.GET(FilesPath+"/*", NewFileHandler(store).Serve)
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("正常系: 署名付きURLで取得できる", func(t *testing.T) {
+		rec := serve(u.RequestURI())
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "png", rec.Body.String())
+		assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "private, max-age=600", rec.Header().Get("Cache-Control"))
+	})
+
+	t.Run("異常系: 別のキーには使えない", func(t *testing.T) {
+		rec := serve("/files/users/2/avatar/a.png?" + u.RawQuery)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("異常系: 期限切れ", func(t *testing.T) {
+		now = now.Add(11 * time.Minute)
+		defer func() { now = now.Add(-11 * time.Minute) }()
+		rec := serve(u.RequestURI())
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		cfg         Config
+		expectError bool
+	}{
+		{name: "正常系: 開発環境は既定の署名鍵で起動できる", env: "development", cfg: Config{Backend: BackendLocal, LocalURLSecret: defaultLocalURLSecret}},
+		{name: "正常系: 本番環境で署名鍵を設定", env: "production", cfg: Config{Backend: BackendLocal, LocalURLSecret: "a-long-random-secret"}},
+		{name: "正常系: 本番環境でもS3は署名鍵を使わない", env: "production", cfg: Config{Backend: BackendS3}},
+		{name: "異常系: 本番環境で既定の署名鍵", env: "production", cfg: Config{Backend: BackendLocal, LocalURLSecret: defaultLocalURLSecret}, expectError: true},
+		{name: "異常系: 本番環境で署名鍵が空", env: "production", cfg: Config{Backend: BackendLocal}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GO_ENV", tt.env)
+
+			err := tt.cfg.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
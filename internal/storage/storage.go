@@ -0,0 +1,50 @@
+// Package storage アップロードしたファイル（アバター画像・添付ファイル等）の保存先
+//
+// 保存先はBlobStoreで抽象化し、ローカルファイルシステム（開発用）とS3互換のオブジェクトストレージを切り替える。
+// クライアントにはファイルの内容ではなく、期限付きの署名付きURLを返す
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// BlobStore ファイルの保存先
+// キーは "users/1/avatar/xxx.png" のようなスラッシュ区切りのパスで、存在しないキーはdomain.ErrNotFoundを返す
+type BlobStore interface {
+	// Put 内容を保存する（同じキーは上書きする）。sizeは内容のバイト数
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get 内容とメタデータを取得する（呼び出し元が閉じる）
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// Delete 削除する（存在しないキーはエラーにしない）
+	Delete(ctx context.Context, key string) error
+	// SignedURL ttlの間だけ認証なしで取得できるURLを返す
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// Object 保存したファイルのメタデータ
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// ValidKey キーがスラッシュ区切りの相対パスで、親ディレクトリ（..）を含まないか
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	return path.Clean(key) == key && !strings.HasPrefix(key, "../") && key != ".."
+}
+
+func checkKey(key string) error {
+	if !ValidKey(key) {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"km-api-go/internal/infra"
+)
+
+// instrumentationName トレーサー名
+const instrumentationName = "km-api-go"
+
+// エクスポーター種別
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// Config トレーシング設定
+type Config struct {
+	Exporter     string  // エクスポーター（otlp, stdout, none）
+	ServiceName  string  // service.name リソース属性
+	OTLPEndpoint string  // OTLP/HTTPの送信先（host:port）。空ならOTEL_EXPORTER_OTLP_*に従う
+	OTLPInsecure bool    // TLSを使わずに送信するか
+	SampleRatio  float64 // 親スパンがない場合のサンプリング率（0.0〜1.0）
+
+	ShutdownTimeout time.Duration // 終了時にバッファ済みスパンを送信する時間の上限
+}
+
+// LoadConfig 環境変数からトレーシング設定を読み込み
+func LoadConfig() Config {
+	ratio := float64(infra.GetEnvInt("TRACING_SAMPLE_PERCENT", 100)) / 100
+
+	return Config{
+		Exporter:     strings.ToLower(infra.GetEnv("TRACING_EXPORTER", ExporterNone)),
+		ServiceName:  infra.GetEnv("OTEL_SERVICE_NAME", "km-api-go"),
+		OTLPEndpoint: infra.GetEnv("OTLP_ENDPOINT", ""),
+		OTLPInsecure: infra.GetEnvBool("OTLP_INSECURE", true),
+		SampleRatio:  ratio,
+
+		ShutdownTimeout: infra.GetEnvDuration("TRACING_SHUTDOWN_TIMEOUT", 5*time.Second),
+	}
+}
+
+// Init 設定に基づいてトレーサープロバイダーとW3C Trace Contextプロパゲーターを登録
+// 戻り値のshutdownでバッファ済みスパンを送信して終了する
+func Init(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterNone, "":
+		// スパンは生成されずプロパゲーションのみ有効
+		return func(context.Context) error { return nil }, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// Tracer アプリケーション共通のトレーサーを取得
+// グローバルプロバイダーから都度取得するため、テストではtracetest.NewInMemoryExporterを
+// 使ったプロバイダーをotel.SetTracerProviderで差し替えられる
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start 内部処理用のスパンを開始
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End エラーがあればスパンに記録して終了する
+// 名前付き戻り値のerrをdeferで渡す: defer tracing.End(span, &err)
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
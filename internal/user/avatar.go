@@ -0,0 +1,206 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/imaging"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/tracing"
+)
+
+// ErrAvatarTooLarge アップロードされた画像がAvatarMaxBytesを超えている
+var ErrAvatarTooLarge = errors.New("avatar file too large")
+
+// アバター画像の保存する大きさ
+const (
+	avatarOriginal     = "original"
+	avatarOriginalSize = 1024 // 元画像は縦横比を保ってこの大きさに収める
+)
+
+// avatarThumbnails 正方形に切り抜いたサムネイルの名前と一辺のピクセル数
+var avatarThumbnails = []struct {
+	name string
+	size int
+}{
+	{"small", 64},
+	{"medium", 128},
+	{"large", 256},
+}
+
+// avatarKey 大きさごとの画像のキー（users/1/avatar/<uuid>/small.png）
+func avatarKey(prefix, name, contentType string) string {
+	return prefix + "/" + name + imaging.Extension(contentType)
+}
+
+// avatarKeys ユーザーのアバター画像のすべてのキー
+func avatarKeys(u *domain.User) []string {
+	keys := []string{avatarKey(u.AvatarKey, avatarOriginal, u.AvatarType)}
+	for _, t := range avatarThumbnails {
+		keys = append(keys, avatarKey(u.AvatarKey, t.name, u.AvatarType))
+	}
+	return keys
+}
+
+// UploadAvatar validates the image, stores it with its thumbnails and replaces the current avatar.
+// version is the version the client last read; 0 skips the check.
+func (uc *userUsecase) UploadAvatar(ctx context.Context, id, version uint, data []byte) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.UploadAvatar")
+	defer tracing.End(span, &err)
+
+	if err := authorizeSelf(ctx, id); err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > uc.cfg.AvatarMaxBytes {
+		return nil, fmt.Errorf("%w: %d bytes", ErrAvatarTooLarge, len(data))
+	}
+	img, contentType, err := imaging.Decode(data, uc.cfg.AvatarMaxPixels)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for avatar: %w", err)
+	}
+	if version != 0 && user.Version != version {
+		return nil, fmt.Errorf("user with id %d version %d: %w", id, version, domain.ErrVersionConflict)
+	}
+	previous := *user
+
+	// 毎回新しいキーに保存し、古い画像はURLをキャッシュしたクライアントのため更新後に削除する
+	now := time.Now()
+	user.AvatarKey = fmt.Sprintf("users/%d/avatar/%s", id, uuid.NewString())
+	user.AvatarType = imaging.OutputType(contentType)
+	user.AvatarUpdatedAt = &now
+	if err := uc.storeAvatar(ctx, user, img); err != nil {
+		uc.deleteAvatarFiles(ctx, user)
+		return nil, err
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.UpdateFields(ctx, user, domain.UserAvatarFields...); err != nil {
+			return fmt.Errorf("failed to update avatar: %w", err)
+		}
+		return uc.events.Record(ctx, userUpdated(user, []string{"avatar"}))
+	})
+	if err != nil {
+		uc.deleteAvatarFiles(ctx, user)
+		return nil, err
+	}
+	if previous.HasAvatar() {
+		uc.deleteAvatarFiles(ctx, &previous)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "avatar uploaded",
+		slog.Uint64("user_id", uint64(id)), slog.String("content_type", contentType), slog.Int("bytes", len(data)))
+
+	user.Password = ""
+	return user, nil
+}
+
+// storeAvatar 元画像（縮小のみ）とサムネイルを保存する
+func (uc *userUsecase) storeAvatar(ctx context.Context, u *domain.User, img image.Image) error {
+	put := func(name string, rendition image.Image) error {
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, rendition, u.AvatarType); err != nil {
+			return fmt.Errorf("failed to encode avatar %s: %w", name, err)
+		}
+		key := avatarKey(u.AvatarKey, name, u.AvatarType)
+		if err := uc.blobs.Put(ctx, key, &buf, int64(buf.Len()), u.AvatarType); err != nil {
+			return fmt.Errorf("failed to store avatar %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := put(avatarOriginal, imaging.Fit(img, avatarOriginalSize)); err != nil {
+		return err
+	}
+	for _, t := range avatarThumbnails {
+		if err := put(t.name, imaging.Square(img, t.size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAvatarFiles アバター画像を削除する（失敗しても更新は取り消さず、ログに残す）
+func (uc *userUsecase) deleteAvatarFiles(ctx context.Context, u *domain.User) {
+	for _, key := range avatarKeys(u) {
+		if err := uc.blobs.Delete(ctx, key); err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "failed to delete avatar file",
+				slog.String("key", key), slog.Any("error", err))
+		}
+	}
+}
+
+// DeleteAvatar removes the avatar; users without one are returned unchanged.
+// version is the version the client last read; 0 skips the check.
+func (uc *userUsecase) DeleteAvatar(ctx context.Context, id, version uint) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.DeleteAvatar")
+	defer tracing.End(span, &err)
+
+	if err := authorizeSelf(ctx, id); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for avatar: %w", err)
+	}
+	if version != 0 && user.Version != version {
+		return nil, fmt.Errorf("user with id %d version %d: %w", id, version, domain.ErrVersionConflict)
+	}
+	if !user.HasAvatar() {
+		user.Password = ""
+		return user, nil
+	}
+
+	previous := *user
+	user.AvatarKey = ""
+	user.AvatarType = ""
+	user.AvatarUpdatedAt = nil
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.UpdateFields(ctx, user, domain.UserAvatarFields...); err != nil {
+			return fmt.Errorf("failed to delete avatar: %w", err)
+		}
+		return uc.events.Record(ctx, userUpdated(user, []string{"avatar"}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.deleteAvatarFiles(ctx, &previous)
+	logging.FromContext(ctx).InfoContext(ctx, "avatar deleted", slog.Uint64("user_id", uint64(id)))
+
+	user.Password = ""
+	return user, nil
+}
+
+// AvatarURLs returns signed URLs for the user's avatar, or nil when none is set.
+func (uc *userUsecase) AvatarURLs(ctx context.Context, u *domain.User) (*domain.AvatarURLs, error) {
+	if !u.HasAvatar() {
+		return nil, nil
+	}
+
+	urls := &domain.AvatarURLs{ExpiresAt: time.Now().Add(uc.cfg.AvatarURLTTL).UTC(), UpdatedAt: u.AvatarUpdatedAt}
+	targets := map[string]*string{
+		avatarOriginal: &urls.Original,
+		"small":        &urls.Small,
+		"medium":       &urls.Medium,
+		"large":        &urls.Large,
+	}
+	for name, target := range targets {
+		url, err := uc.blobs.SignedURL(ctx, avatarKey(u.AvatarKey, name, u.AvatarType), uc.cfg.AvatarURLTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign avatar url: %w", err)
+		}
+		*target = url
+	}
+	return urls, nil
+}
@@ -0,0 +1,154 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/imaging"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/storage"
+	"km-api-go/internal/user/repository/mocks"
+)
+
+func encodeAvatarPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))))
+	return buf.Bytes()
+}
+
+func newAvatarUsecase(t *testing.T, mockRepo *mocks.MockUserRepository) (UserUsecase, *storage.LocalStore) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files", []byte("secret"))
+	require.NoError(t, err)
+	cfg := Config{AvatarMaxBytes: 1 << 20, AvatarMaxPixels: 4 << 20, AvatarURLTTL: time.Hour}
+	return NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), store, cfg), store
+}
+
+func assertStored(t *testing.T, store *storage.LocalStore, key string) image.Image {
+	t.Helper()
+	r, obj, err := store.Get(context.Background(), key)
+	require.NoError(t, err, key)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, imaging.TypePNG, obj.ContentType)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img
+}
+
+func TestUserUsecase_UploadAvatar(t *testing.T) {
+	self := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+
+	t.Run("正常系: 元画像とサムネイルを保存し、古い画像を削除する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		usecase, store := newAvatarUsecase(t, mockRepo)
+
+		old := &domain.User{ID: 1, Version: 2, AvatarKey: "users/1/avatar/old", AvatarType: imaging.TypePNG}
+		for _, key := range avatarKeys(old) {
+			require.NoError(t, store.Put(context.Background(), key, strings.NewReader("old"), 3, imaging.TypePNG))
+		}
+		mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(old, nil)
+		mockRepo.EXPECT().UpdateFields(gomock.Any(), gomock.Any(), domain.UserAvatarFields).Return(nil)
+
+		user, err := usecase.UploadAvatar(self, 1, 2, encodeAvatarPNG(t, 2000, 1000))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(user.AvatarKey, "users/1/avatar/"))
+		assert.Equal(t, imaging.TypePNG, user.AvatarType)
+		assert.NotNil(t, user.AvatarUpdatedAt)
+
+		assert.Equal(t, image.Rect(0, 0, 1024, 512), assertStored(t, store, user.AvatarKey+"/original.png").Bounds())
+		assert.Equal(t, image.Rect(0, 0, 64, 64), assertStored(t, store, user.AvatarKey+"/small.png").Bounds())
+		assert.Equal(t, image.Rect(0, 0, 256, 256), assertStored(t, store, user.AvatarKey+"/large.png").Bounds())
+		for _, key := range avatarKeys(&domain.User{AvatarKey: "users/1/avatar/old", AvatarType: imaging.TypePNG}) {
+			_, _, err := store.Get(context.Background(), key)
+			assert.ErrorIs(t, err, domain.ErrNotFound, key)
+		}
+	})
+
+	t.Run("異常系: 他のユーザーのアバター画像は変更できない", func(t *testing.T) {
+		usecase, _ := newAvatarUsecase(t, mocks.NewMockUserRepository(gomock.NewController(t)))
+		other := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 2})
+
+		_, err := usecase.UploadAvatar(other, 1, 0, encodeAvatarPNG(t, 10, 10))
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("異常系: 画像以外のファイル", func(t *testing.T) {
+		usecase, _ := newAvatarUsecase(t, mocks.NewMockUserRepository(gomock.NewController(t)))
+
+		_, err := usecase.UploadAvatar(self, 1, 0, []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"))
+		assert.ErrorIs(t, err, imaging.ErrUnsupportedType)
+	})
+
+	t.Run("異常系: サイズの上限を超える", func(t *testing.T) {
+		usecase, _ := newAvatarUsecase(t, mocks.NewMockUserRepository(gomock.NewController(t)))
+
+		_, err := usecase.UploadAvatar(self, 1, 0, make([]byte, 1<<20+1))
+		assert.ErrorIs(t, err, ErrAvatarTooLarge)
+	})
+
+	t.Run("異常系: バージョンの不一致では保存しない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		usecase, _ := newAvatarUsecase(t, mockRepo)
+		mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&domain.User{ID: 1, Version: 3}, nil)
+
+		_, err := usecase.UploadAvatar(self, 1, 2, encodeAvatarPNG(t, 10, 10))
+		assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	})
+}
+
+func TestUserUsecase_DeleteAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase, store := newAvatarUsecase(t, mockRepo)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+
+	current := &domain.User{ID: 1, Version: 2, AvatarKey: "users/1/avatar/abc", AvatarType: imaging.TypeJPEG}
+	keys := avatarKeys(current)
+	for _, key := range keys {
+		require.NoError(t, store.Put(context.Background(), key, strings.NewReader("x"), 1, imaging.TypeJPEG))
+	}
+	mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(current, nil)
+	mockRepo.EXPECT().UpdateFields(gomock.Any(), gomock.Any(), domain.UserAvatarFields).Return(nil)
+
+	user, err := usecase.DeleteAvatar(ctx, 1, 0)
+	require.NoError(t, err)
+	assert.False(t, user.HasAvatar())
+	for _, key := range keys {
+		_, _, err := store.Get(context.Background(), key)
+		assert.ErrorIs(t, err, domain.ErrNotFound, key)
+	}
+}
+
+func TestUserUsecase_AvatarURLs(t *testing.T) {
+	usecase, _ := newAvatarUsecase(t, mocks.NewMockUserRepository(gomock.NewController(t)))
+
+	urls, err := usecase.AvatarURLs(context.Background(), &domain.User{ID: 1})
+	assert.NoError(t, err)
+	assert.Nil(t, urls)
+
+	urls, err = usecase.AvatarURLs(context.Background(), &domain.User{ID: 1, AvatarKey: "users/1/avatar/abc", AvatarType: imaging.TypeJPEG})
+	require.NoError(t, err)
+	assert.Contains(t, urls.Original, "/files/users/1/avatar/abc/original.jpg?expires=")
+	assert.Contains(t, urls.Small, "/small.jpg?")
+	assert.Contains(t, urls.Medium, "/medium.jpg?")
+	assert.Contains(t, urls.Large, "/large.jpg?")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), urls.ExpiresAt, time.Minute)
+}
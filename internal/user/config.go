@@ -0,0 +1,23 @@
+package user
+
+import (
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// Config ユーザーのアバター画像の設定
+type Config struct {
+	AvatarMaxBytes  int64         // アップロードできる画像の最大サイズ（バイト）
+	AvatarMaxPixels int           // 画像の最大ピクセル数（幅×高さ。展開後のメモリを抑える）
+	AvatarURLTTL    time.Duration // レスポンスに含める署名付きURLの有効期間
+}
+
+// LoadConfig 環境変数からアバター画像の設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		AvatarMaxBytes:  int64(infra.GetEnvInt("AVATAR_MAX_BYTES", 5<<20)),
+		AvatarMaxPixels: infra.GetEnvInt("AVATAR_MAX_PIXELS", 25_000_000),
+		AvatarURLTTL:    infra.GetEnvDuration("AVATAR_URL_TTL", time.Hour),
+	}
+}
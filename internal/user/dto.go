@@ -1,13 +1,84 @@
 package user
 
+import (
+	"time"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+)
+
 type CreateUserRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
 }
 
+type UpdateUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+// GetUserRequest ユーザー取得のパス・クエリパラメータ
+type GetUserRequest struct {
+	helper.IDRequest
+	helper.SparseRequest
+}
+
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID          uint            `json:"id"`
+	Name        string          `json:"name"`
+	Email       string          `json:"email"`
+	DisplayName string          `json:"display_name"`
+	KanaName    string          `json:"kana_name"`
+	Locale      string          `json:"locale"`
+	Timezone    string          `json:"timezone"`
+	Bio         string          `json:"bio"`
+	Avatar      *AvatarResponse `json:"avatar"` // 未設定の場合はnull
+	Version     uint            `json:"version"`
+}
+
+// AvatarResponse アバター画像の署名付きURL（v1・v2で共通）
+// URLは期限付きのため、期限が切れたらユーザーを取得し直す
+type AvatarResponse struct {
+	Original  string     `json:"original"`                      // 元画像（最大1024px）
+	Small     string     `json:"small"`                         // 64x64
+	Medium    string     `json:"medium"`                        // 128x128
+	Large     string     `json:"large"`                         // 256x256
+	ExpiresAt time.Time  `json:"expires_at" format:"date-time"` // URLの有効期限
+	UpdatedAt *time.Time `json:"updated_at" format:"date-time"` // 画像の更新日時
+}
+
+// NewUserResponse ドメインモデルからレスポンスを作成
+func NewUserResponse(u *domain.User, avatar *domain.AvatarURLs) UserResponse {
+	return UserResponse{
+		ID:          u.ID,
+		Name:        u.Name,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		KanaName:    u.KanaName,
+		Locale:      u.Locale,
+		Timezone:    u.Timezone,
+		Bio:         u.Bio,
+		Avatar:      NewAvatarResponse(avatar),
+		Version:     u.Version,
+	}
+}
+
+// NewAvatarResponse 署名付きURLからレスポンスを作成（未設定の場合はnil）
+func NewAvatarResponse(a *domain.AvatarURLs) *AvatarResponse {
+	if a == nil {
+		return nil
+	}
+	res := &AvatarResponse{
+		Original:  a.Original,
+		Small:     a.Small,
+		Medium:    a.Medium,
+		Large:     a.Large,
+		ExpiresAt: a.ExpiresAt.UTC(),
+	}
+	if a.UpdatedAt != nil {
+		updatedAt := a.UpdatedAt.UTC()
+		res.UpdatedAt = &updatedAt
+	}
+	return res
 }
\ No newline at end of file
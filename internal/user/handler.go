@@ -1,32 +1,64 @@
 package user
 
 import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"maps"
 	"net/http"
+	"slices"
 
 	"github.com/labstack/echo/v4"
 
+	"km-api-go/internal/domain"
 	"km-api-go/internal/helper"
+	"km-api-go/internal/imaging"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/patch"
+	"km-api-go/internal/sparse"
 )
 
+// UserHandler ユーザーのハンドラー（各APIバージョンで共通の処理）
+// バージョンごとのレスポンスと仕様（swagのアノテーション）は internal/apiv1, internal/apiv2 で定義する
 type UserHandler struct {
 	usecase UserUsecase
+	present Presenter
+	expand  Expander
 }
 
-func NewUserHandler(usecase UserUsecase) *UserHandler {
-	return &UserHandler{usecase: usecase}
+// Presenter ドメインモデルをAPIバージョンごとのレスポンスに変換する
+type Presenter func(ctx context.Context, u *domain.User) (any, error)
+
+// Includes GetUserのinclude=に指定できる関連
+var Includes = []string{"companies"}
+
+// Expander include=で指定された関連を取得する
+type Expander interface {
+	// ExpandUser レスポンスに追加するフィールド名ごとの値を返す
+	ExpandUser(ctx context.Context, u *domain.User, include []string) (map[string]any, error)
+}
+
+// NewUserHandler v1のレスポンス（UserResponse）を返すハンドラーを作成
+func NewUserHandler(usecase UserUsecase, expand Expander) *UserHandler {
+	return NewUserHandlerWithPresenter(usecase, expand, func(ctx context.Context, u *domain.User) (any, error) {
+		var avatar *domain.AvatarURLs
+		if u.HasAvatar() {
+			var err error
+			if avatar, err = usecase.AvatarURLs(ctx, u); err != nil {
+				return nil, err
+			}
+		}
+		return NewUserResponse(u, avatar), nil
+	})
 }
 
-// CreateUser godoc
-// @Summary ユーザー作成
-// @Description 新しいユーザーを作成します
-// @Tags users
-// @Accept json
-// @Produce json
-// @Param user body CreateUserRequest true "ユーザー情報"
-// @Success 201 {object} helper.APIResponse{data=UserResponse}
-// @Failure 400 {object} helper.APIResponse
-// @Failure 500 {object} helper.APIResponse
-// @Router /users [post]
+// NewUserHandlerWithPresenter レスポンスの形式だけが異なるAPIバージョン（/api/v2等）用のハンドラーを作成
+func NewUserHandlerWithPresenter(usecase UserUsecase, expand Expander, present Presenter) *UserHandler {
+	return &UserHandler{usecase: usecase, present: present, expand: expand}
+}
+
+// CreateUser ユーザー作成
 func (h *UserHandler) CreateUser(c echo.Context) error {
 	var req CreateUserRequest
 	if err := c.Bind(&req); err != nil {
@@ -37,19 +69,286 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 		return helper.ValidationErrorResponse(c, err.Error())
 	}
 
-	user, err := h.usecase.Create(c.Request().Context(), req.Name, req.Email, req.Password)
+	ctx := c.Request().Context()
+	user, err := h.usecase.Create(ctx, req.Name, req.Email, req.Password)
 	if err != nil {
-		// TODO: エラーの種類に応じて、AlreadyExistsResponseなども返す
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return helper.AlreadyExistsResponse(c, "ユーザー")
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to create user", slog.Any("error", err))
 		return helper.InternalErrorResponse(c, err.Error())
 	}
 
-	res := UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+	return h.respond(c, user, func(data any) error {
+		return helper.SuccessResponse(c, http.StatusCreated, data, "User created successfully")
+	})
+}
+
+// GetUser ユーザー取得
+// fields=で返すフィールドを選び（選んだカラムのみSELECTする）、include=で関連を展開する
+func (h *UserHandler) GetUser(c echo.Context) error {
+	var req GetUserRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	include, err := sparse.ParseInclude(req.Include, Includes...)
+	if err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	fields := sparse.Parse(req.Fields)
+
+	ctx := c.Request().Context()
+	var user *domain.User
+	if len(fields) == 0 {
+		user, err = h.usecase.GetUserByID(ctx, req.ID)
+	} else {
+		columns := sparse.GoFields(domain.User{}, fields)
+		// avatarはアバター画像の列から署名付きURLを作成する
+		if slices.Contains(fields, "avatar") {
+			columns = append(columns, domain.UserAvatarFields...)
+		}
+		user, err = h.usecase.GetUserByIDWithFields(ctx, req.ID, columns)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return helper.NotFoundResponse(c, "ユーザー")
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to get user", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+
+	// 展開した関連の変更はバージョンに反映されないため、関連を含む場合は304を返さない
+	if len(include) == 0 && helper.NotModified(c, user.Version) {
+		return helper.NotModifiedResponse(c, user.Version)
 	}
 
-	return helper.SuccessResponse(c, http.StatusCreated, res, "User created successfully")
+	data, err := h.presentSparse(ctx, user, fields, include)
+	if err != nil {
+		if errors.Is(err, sparse.ErrUnknownField) {
+			return helper.ValidationErrorResponse(c, err.Error())
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return helper.ForbiddenResponse(c)
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to build user response", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+	helper.SetETag(c, user.Version)
+	return helper.SuccessResponse(c, http.StatusOK, data, "")
 }
 
-// TODO: GetUser, UpdateUser, DeleteUser などのハンドラーメソッドを実装
+// UpdateUser ユーザー更新
+func (h *UserHandler) UpdateUser(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	version, ok := helper.IfMatchVersion(c)
+	if !ok {
+		return helper.PreconditionRequiredResponse(c)
+	}
+
+	var req UpdateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.usecase.UpdateUser(ctx, id.ID, version, req.Name, req.Email)
+	if err != nil {
+		return h.updateErrorResponse(c, err)
+	}
+
+	return h.respond(c, user, func(data any) error {
+		return helper.UpdatedResponse(c, data, "")
+	})
+}
+
+// PatchUser ユーザー部分更新
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	version, ok := helper.IfMatchVersion(c)
+	if !ok {
+		return helper.PreconditionRequiredResponse(c)
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+	p, err := patch.New(c.Request().Header.Get(echo.HeaderContentType), body)
+	if err != nil {
+		return h.updateErrorResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.usecase.PatchUser(ctx, id.ID, version, p)
+	if err != nil {
+		return h.updateErrorResponse(c, err)
+	}
+
+	return h.respond(c, user, func(data any) error {
+		return helper.UpdatedResponse(c, data, "")
+	})
+}
+
+// AvatarField アバター画像をアップロードするmultipart/form-dataのフィールド名
+const AvatarField = "avatar"
+
+// UploadAvatar アバター画像のアップロード
+// 形式は内容から判定し、縮小・サムネイルを作成して保存する。If-Matchは任意
+func (h *UserHandler) UploadAvatar(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	version, _ := helper.IfMatchVersion(c)
+
+	file, err := c.FormFile(AvatarField)
+	if err != nil {
+		return helper.ValidationErrorResponse(c, "multipart/form-dataのavatarフィールドに画像を指定してください")
+	}
+	src, err := file.Open()
+	if err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.usecase.UploadAvatar(ctx, id.ID, version, data)
+	if err != nil {
+		return h.avatarErrorResponse(c, err)
+	}
+
+	return h.respond(c, user, func(data any) error {
+		return helper.UpdatedResponse(c, data, "")
+	})
+}
+
+// DeleteAvatar アバター画像の削除
+func (h *UserHandler) DeleteAvatar(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	version, _ := helper.IfMatchVersion(c)
+
+	ctx := c.Request().Context()
+	user, err := h.usecase.DeleteAvatar(ctx, id.ID, version)
+	if err != nil {
+		return h.avatarErrorResponse(c, err)
+	}
+
+	return h.respond(c, user, func(data any) error {
+		return helper.UpdatedResponse(c, data, "")
+	})
+}
+
+// avatarErrorResponse アバター画像のエラーをレスポンスに変換
+func (h *UserHandler) avatarErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrAvatarTooLarge):
+		return helper.ErrorResponse(c, http.StatusRequestEntityTooLarge, helper.ErrorCodePayloadTooLarge, "画像のサイズが大きすぎます", err.Error())
+	case errors.Is(err, imaging.ErrUnsupportedType):
+		return helper.ErrorResponse(c, http.StatusUnsupportedMediaType, helper.ErrorCodeUnsupportedMedia,
+			"JPEG, PNG, GIF, WebP の画像を指定してください", err.Error())
+	case errors.Is(err, imaging.ErrTooLarge):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "画像の縦横のピクセル数が大きすぎます", err.Error())
+	case errors.Is(err, imaging.ErrInvalidImage):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "画像を読み込めません", err.Error())
+	}
+	return h.updateErrorResponse(c, err)
+}
+
+// respond ETagを付け、バージョンごとのレスポンスに変換して返す
+func (h *UserHandler) respond(c echo.Context, user *domain.User, write func(data any) error) error {
+	ctx := c.Request().Context()
+	data, err := h.present(ctx, user)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to build user response", slog.Any("error", err))
+		return helper.InternalErrorResponse(c, err.Error())
+	}
+	helper.SetETag(c, user.Version)
+	return write(data)
+}
+
+// presentSparse バージョンごとのレスポンスから指定したフィールドだけを残し、関連を展開する
+func (h *UserHandler) presentSparse(ctx context.Context, user *domain.User, fields, include []string) (any, error) {
+	data, err := h.present(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 && len(include) == 0 {
+		return data, nil
+	}
+
+	obj, err := sparse.Project(data, fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(include) == 0 {
+		return obj, nil
+	}
+	related, err := h.expand.ExpandUser(ctx, user, include)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(related)) {
+		if err := obj.Set(name, related[name]); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+// updateErrorResponse 更新系のエラーをレスポンスに変換
+func (h *UserHandler) updateErrorResponse(c echo.Context, err error) error {
+	var validationErrs helper.ValidationErrors
+	switch {
+	case errors.Is(err, patch.ErrUnsupportedMediaType):
+		return helper.ErrorResponse(c, http.StatusUnsupportedMediaType, helper.ErrorCodeUnsupportedMedia,
+			"application/merge-patch+json または application/json-patch+json を指定してください", err.Error())
+	case errors.Is(err, patch.ErrInvalidPatch):
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeValidation, "パッチを適用できません", err.Error())
+	case errors.As(err, &validationErrs):
+		return helper.ValidationErrorResponse(c, helper.GetValidationErrorDetails(validationErrs))
+	case errors.Is(err, domain.ErrForbidden):
+		return helper.ForbiddenResponse(c)
+	case errors.Is(err, domain.ErrNotFound):
+		return helper.NotFoundResponse(c, "ユーザー")
+	case errors.Is(err, domain.ErrVersionConflict):
+		return helper.PreconditionFailedResponse(c)
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return helper.AlreadyExistsResponse(c, "メールアドレス")
+	}
+	ctx := c.Request().Context()
+	logging.FromContext(ctx).ErrorContext(ctx, "failed to update user", slog.Any("error", err))
+	return helper.InternalErrorResponse(c, err.Error())
+}
@@ -2,8 +2,11 @@ package user
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,6 +17,7 @@ import (
 
 	"km-api-go/internal/domain"
 	"km-api-go/internal/helper"
+	"km-api-go/internal/imaging"
 	"km-api-go/internal/user/mocks"
 )
 
@@ -22,7 +26,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUsecase := mocks.NewMockUserUsecase(ctrl)
-	handler := NewUserHandler(mockUsecase)
+	handler := NewUserHandler(mockUsecase, nil)
 
 	tests := []struct {
 		name           string
@@ -150,4 +154,370 @@ func TestUserHandler_CreateUser(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestUserHandler_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecase(ctrl)
+	handler := NewUserHandler(mockUsecase, nil)
+
+	tests := []struct {
+		name           string
+		id             string
+		ifNoneMatch    string
+		setupMock      func()
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name: "正常系: ETag付きで取得",
+			id:   "1",
+			setupMock: func() {
+				mockUsecase.EXPECT().GetUserByID(gomock.Any(), uint(1)).
+					Return(&domain.User{ID: 1, Name: "Test User", Email: "test@example.com", Version: 2}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+		},
+		{
+			name:        "正常系: If-None-Matchが一致すれば304",
+			id:          "1",
+			ifNoneMatch: `"1", "2"`,
+			setupMock: func() {
+				mockUsecase.EXPECT().GetUserByID(gomock.Any(), uint(1)).
+					Return(&domain.User{ID: 1, Name: "Test User", Email: "test@example.com", Version: 2}, nil).Times(1)
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"2"`,
+		},
+		{
+			name: "異常系: ユーザーが見つからない",
+			id:   "999",
+			setupMock: func() {
+				mockUsecase.EXPECT().GetUserByID(gomock.Any(), uint(999)).
+					Return(nil, fmt.Errorf("user with id 999 %w", domain.ErrNotFound)).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "異常系: 不正なID",
+			id:             "abc",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+tt.id, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set(helper.HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			tt.setupMock()
+
+			err := handler.GetUser(c)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				assert.Equal(t, tt.expectedETag, rec.Header().Get(helper.HeaderETag))
+				if tt.expectedStatus == http.StatusNotModified {
+					assert.Empty(t, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+// expanderFunc user.Expanderのテスト用実装
+type expanderFunc func(ctx context.Context, u *domain.User, include []string) (map[string]any, error)
+
+func (f expanderFunc) ExpandUser(ctx context.Context, u *domain.User, include []string) (map[string]any, error) {
+	return f(ctx, u, include)
+}
+
+func TestUserHandler_GetUserSparse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecase(ctrl)
+	handler := NewUserHandler(mockUsecase, expanderFunc(func(_ context.Context, u *domain.User, include []string) (map[string]any, error) {
+		if u.ID == 2 {
+			return nil, errors.New("db error")
+		}
+		return map[string]any{"companies": []map[string]any{{"id": 10, "role": "admin"}}}, nil
+	}))
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "正常系: 指定したフィールドとidのみ返す",
+			query: "?fields=email,%20name,email",
+			setupMock: func() {
+				mockUsecase.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"Name", "Email"}).
+					Return(&domain.User{ID: 1, Name: "Test User", Email: "test@example.com", Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"success":true,"data":{"id":1,"name":"Test User","email":"test@example.com"}}`,
+		},
+		{
+			name:  "正常系: 関連を展開",
+			query: "?fields=name&include=companies",
+			setupMock: func() {
+				mockUsecase.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"Name"}).
+					Return(&domain.User{ID: 1, Name: "Test User", Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"success":true,"data":{"id":1,"name":"Test User","companies":[{"id":10,"role":"admin"}]}}`,
+		},
+		{
+			name:  "異常系: v1のレスポンスにないフィールド",
+			query: "?fields=created_at",
+			setupMock: func() {
+				mockUsecase.EXPECT().GetUserByIDWithFields(gomock.Any(), uint(1), []string{"CreatedAt"}).
+					Return(&domain.User{ID: 1, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 展開できない関連",
+			query:          "?include=companies.members",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 関連の取得に失敗",
+			query: "?include=companies",
+			setupMock: func() {
+				mockUsecase.EXPECT().GetUserByID(gomock.Any(), uint(1)).
+					Return(&domain.User{ID: 2, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+
+			req := httptest.NewRequest(http.MethodGet, "/users/1"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.setupMock()
+
+			err := handler.GetUser(c)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.expectedBody != "" {
+					assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_UpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecase(ctrl)
+	handler := NewUserHandler(mockUsecase, nil)
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		setupMock      func()
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:    "正常系: If-Matchが一致して更新",
+			ifMatch: `"2"`,
+			setupMock: func() {
+				mockUsecase.EXPECT().UpdateUser(gomock.Any(), uint(1), uint(2), "New Name", "new@example.com").
+					Return(&domain.User{ID: 1, Name: "New Name", Email: "new@example.com", Version: 3}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "異常系: If-Matchなしは428",
+			setupMock:      func() {},
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:           "異常系: 弱いETagは428",
+			ifMatch:        `W/"2"`,
+			setupMock:      func() {},
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:    "異常系: バージョン不一致は412",
+			ifMatch: `"1"`,
+			setupMock: func() {
+				mockUsecase.EXPECT().UpdateUser(gomock.Any(), uint(1), uint(1), "New Name", "new@example.com").
+					Return(nil, fmt.Errorf("user with id 1 version 1: %w", domain.ErrVersionConflict)).Times(1)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "異常系: メールアドレス重複は409",
+			ifMatch: `"2"`,
+			setupMock: func() {
+				mockUsecase.EXPECT().UpdateUser(gomock.Any(), uint(1), uint(2), "New Name", "new@example.com").
+					Return(nil, fmt.Errorf("user with email new@example.com %w", domain.ErrAlreadyExists)).Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+
+			body, err := json.Marshal(UpdateUserRequest{Name: "New Name", Email: "new@example.com"})
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/users/1", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.ifMatch != "" {
+				req.Header.Set(helper.HeaderIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.setupMock()
+
+			err = handler.UpdateUser(c)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				assert.Equal(t, tt.expectedETag, rec.Header().Get(helper.HeaderETag))
+			}
+		})
+	}
+}
+
+func TestUserHandler_UploadAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecase(ctrl)
+	handler := NewUserHandler(mockUsecase, nil)
+
+	// multipartBody field（空の場合はファイルなし）に内容を入れたmultipart/form-data
+	multipartBody := func(field string, content []byte) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		if field != "" {
+			part, _ := w.CreateFormFile(field, "avatar.png")
+			part.Write(content)
+		}
+		w.Close()
+		return &body, w.FormDataContentType()
+	}
+
+	tests := []struct {
+		name           string
+		field          string
+		ifMatch        string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:    "正常系: アップロードした画像の署名付きURLを返す",
+			field:   AvatarField,
+			ifMatch: `"2"`,
+			setupMock: func() {
+				user := &domain.User{ID: 1, Name: "Test User", Version: 3, AvatarKey: "users/1/avatar/abc", AvatarType: "image/png"}
+				mockUsecase.EXPECT().UploadAvatar(gomock.Any(), uint(1), uint(2), []byte("image")).Return(user, nil)
+				mockUsecase.EXPECT().AvatarURLs(gomock.Any(), user).Return(&domain.AvatarURLs{
+					Original: "http://localhost:8080/files/users/1/avatar/abc/original.png?signature=x",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: avatarフィールドがない",
+			field:          "file",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: 画像以外のファイル",
+			field: AvatarField,
+			setupMock: func() {
+				mockUsecase.EXPECT().UploadAvatar(gomock.Any(), uint(1), uint(0), []byte("image")).
+					Return(nil, fmt.Errorf("%w: text/plain", imaging.ErrUnsupportedType))
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:  "異常系: サイズの上限を超える",
+			field: AvatarField,
+			setupMock: func() {
+				mockUsecase.EXPECT().UploadAvatar(gomock.Any(), uint(1), uint(0), []byte("image")).Return(nil, ErrAvatarTooLarge)
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "異常系: 他のユーザー",
+			field: AvatarField,
+			setupMock: func() {
+				mockUsecase.EXPECT().UploadAvatar(gomock.Any(), uint(1), uint(0), []byte("image")).Return(nil, domain.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = helper.NewValidator()
+
+			body, contentType := multipartBody(tt.field, []byte("image"))
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/users/1/avatar", body)
+			req.Header.Set(echo.HeaderContentType, contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set(helper.HeaderIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.setupMock()
+
+			err := handler.UploadAvatar(c)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.expectedStatus == http.StatusOK {
+					assert.Contains(t, rec.Body.String(), `"original":"http://localhost:8080/files/users/1/avatar/abc/original.png?signature=x"`)
+					assert.Equal(t, `"3"`, rec.Header().Get(helper.HeaderETag))
+				}
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	domain "km-api-go/internal/domain"
 	helper "km-api-go/internal/helper"
+	patch "km-api-go/internal/patch"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -57,6 +58,36 @@ func (mr *MockUserUsecaseMockRecorder) AuthenticateUser(ctx, email, password any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateUser", reflect.TypeOf((*MockUserUsecase)(nil).AuthenticateUser), ctx, email, password)
 }
 
+// AvatarURLs mocks base method.
+func (m *MockUserUsecase) AvatarURLs(ctx context.Context, u *domain.User) (*domain.AvatarURLs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvatarURLs", ctx, u)
+	ret0, _ := ret[0].(*domain.AvatarURLs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AvatarURLs indicates an expected call of AvatarURLs.
+func (mr *MockUserUsecaseMockRecorder) AvatarURLs(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvatarURLs", reflect.TypeOf((*MockUserUsecase)(nil).AvatarURLs), ctx, u)
+}
+
+// CanView mocks base method.
+func (m *MockUserUsecase) CanView(ctx context.Context, id uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanView", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanView indicates an expected call of CanView.
+func (mr *MockUserUsecaseMockRecorder) CanView(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanView", reflect.TypeOf((*MockUserUsecase)(nil).CanView), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockUserUsecase) Create(ctx context.Context, name, email, password string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +103,21 @@ func (mr *MockUserUsecaseMockRecorder) Create(ctx, name, email, password any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserUsecase)(nil).Create), ctx, name, email, password)
 }
 
+// DeleteAvatar mocks base method.
+func (m *MockUserUsecase) DeleteAvatar(ctx context.Context, id, version uint) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatar", ctx, id, version)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAvatar indicates an expected call of DeleteAvatar.
+func (mr *MockUserUsecaseMockRecorder) DeleteAvatar(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatar", reflect.TypeOf((*MockUserUsecase)(nil).DeleteAvatar), ctx, id, version)
+}
+
 // DeleteUser mocks base method.
 func (m *MockUserUsecase) DeleteUser(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
@@ -116,6 +162,36 @@ func (mr *MockUserUsecaseMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserUsecase)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserByIDWithFields mocks base method.
+func (m *MockUserUsecase) GetUserByIDWithFields(ctx context.Context, id uint, fields []string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIDWithFields", ctx, id, fields)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIDWithFields indicates an expected call of GetUserByIDWithFields.
+func (mr *MockUserUsecaseMockRecorder) GetUserByIDWithFields(ctx, id, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIDWithFields", reflect.TypeOf((*MockUserUsecase)(nil).GetUserByIDWithFields), ctx, id, fields)
+}
+
+// GetUsersByIDs mocks base method.
+func (m *MockUserUsecase) GetUsersByIDs(ctx context.Context, ids []uint) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", ctx, ids)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockUserUsecaseMockRecorder) GetUsersByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockUserUsecase)(nil).GetUsersByIDs), ctx, ids)
+}
+
 // GetUsersPaginated mocks base method.
 func (m *MockUserUsecase) GetUsersPaginated(ctx context.Context, page, limit int) ([]domain.User, *helper.PaginationResponse, error) {
 	m.ctrl.T.Helper()
@@ -132,17 +208,47 @@ func (mr *MockUserUsecaseMockRecorder) GetUsersPaginated(ctx, page, limit any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersPaginated", reflect.TypeOf((*MockUserUsecase)(nil).GetUsersPaginated), ctx, page, limit)
 }
 
+// PatchUser mocks base method.
+func (m *MockUserUsecase) PatchUser(ctx context.Context, id, version uint, p patch.Patch) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", ctx, id, version, p)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockUserUsecaseMockRecorder) PatchUser(ctx, id, version, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockUserUsecase)(nil).PatchUser), ctx, id, version, p)
+}
+
 // UpdateUser mocks base method.
-func (m *MockUserUsecase) UpdateUser(ctx context.Context, id uint, name, email string) (*domain.User, error) {
+func (m *MockUserUsecase) UpdateUser(ctx context.Context, id, version uint, name, email string) (*domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, id, name, email)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, id, version, name, email)
 	ret0, _ := ret[0].(*domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserUsecaseMockRecorder) UpdateUser(ctx, id, name, email any) *gomock.Call {
+func (mr *MockUserUsecaseMockRecorder) UpdateUser(ctx, id, version, name, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserUsecase)(nil).UpdateUser), ctx, id, version, name, email)
+}
+
+// UploadAvatar mocks base method.
+func (m *MockUserUsecase) UploadAvatar(ctx context.Context, id, version uint, data []byte) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, id, version, data)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockUserUsecaseMockRecorder) UploadAvatar(ctx, id, version, data any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserUsecase)(nil).UpdateUser), ctx, id, name, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockUserUsecase)(nil).UploadAvatar), ctx, id, version, data)
 }
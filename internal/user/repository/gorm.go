@@ -1,14 +1,20 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
 
 	"gorm.io/gorm"
 )
 
+// sortColumns ユーザーの一覧で並べ替えに使える列
+var sortColumns = []string{"id", "name", "email", "created_at", "updated_at"}
+
 // userRepository GORM実装
 type userRepository struct {
 	db *gorm.DB
@@ -19,22 +25,41 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) GetAll() ([]domain.User, error) {
+func (r *userRepository) GetAll(ctx context.Context) ([]domain.User, error) {
 	var users []domain.User
 
-	if err := r.db.Find(&users).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
 
 	return users, nil
 }
 
-func (r *userRepository) GetByID(id uint) (*domain.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
+	var u domain.User
+
+	if err := infra.Conn(ctx, r.db).First(&u, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user with id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user by id %d: %w", id, err)
+	}
+
+	return &u, nil
+}
+
+// GetByIDWithFields 指定したフィールドのカラムのみSELECTする（ID・Versionは常に取得する）
+func (r *userRepository) GetByIDWithFields(ctx context.Context, id uint, fields ...string) (*domain.User, error) {
+	if len(fields) == 0 {
+		return r.GetByID(ctx, id)
+	}
+
 	var u domain.User
 
-	if err := r.db.First(&u, id).Error; err != nil {
+	columns := append([]string{"ID", "Version"}, fields...)
+	if err := infra.Conn(ctx, r.db).Select(columns).First(&u, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("user with id %d not found", id)
+			return nil, fmt.Errorf("user with id %d %w", id, domain.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get user by id %d: %w", id, err)
 	}
@@ -42,12 +67,12 @@ func (r *userRepository) GetByID(id uint) (*domain.User, error) {
 	return &u, nil
 }
 
-func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var u domain.User
 
-	if err := r.db.Where("email = ?", email).First(&u).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Where("email = ?", email).First(&u).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("user with email %s not found", email)
+			return nil, fmt.Errorf("user with email %s %w", email, domain.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get user by email %s: %w", email, err)
 	}
@@ -55,92 +80,108 @@ func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
 	return &u, nil
 }
 
-func (r *userRepository) Create(u *domain.User) error {
+func (r *userRepository) Create(ctx context.Context, u *domain.User) error {
 	// メール重複チェック
-	exists, err := r.ExistsByEmail(u.Email)
+	exists, err := r.ExistsByEmail(ctx, u.Email)
 	if err != nil {
 		return fmt.Errorf("failed to check email existence: %w", err)
 	}
 	if exists {
-		return fmt.Errorf("user with email %s already exists", u.Email)
+		return fmt.Errorf("user with email %s %w", u.Email, domain.ErrAlreadyExists)
 	}
 
 	// パスワードハッシュ化（ドメインモデルのBeforeCreateフックで実行される）
-	if err := r.db.Create(u).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Create(u).Error; err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
 	return nil
 }
 
-func (r *userRepository) Update(u *domain.User) error {
+func (r *userRepository) Update(ctx context.Context, u *domain.User) error {
 	// ユーザー存在チェック
-	exists, err := r.Exists(u.ID)
+	exists, err := r.Exists(ctx, u.ID)
 	if err != nil {
 		return fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("user with id %d not found", u.ID)
+		return fmt.Errorf("user with id %d %w", u.ID, domain.ErrNotFound)
 	}
 
 	// メール重複チェック（自分以外）
 	var existingUser domain.User
-	if err := r.db.Where("email = ? AND id != ?", u.Email, u.ID).First(&existingUser).Error; err == nil {
-		return fmt.Errorf("user with email %s already exists", u.Email)
+	if err := infra.Conn(ctx, r.db).Where("email = ? AND id != ?", u.Email, u.ID).First(&existingUser).Error; err == nil {
+		return fmt.Errorf("user with email %s %w", u.Email, domain.ErrAlreadyExists)
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return fmt.Errorf("failed to check email uniqueness: %w", err)
 	}
 
-	// 更新実行
-	if err := r.db.Save(u).Error; err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+	return r.updateVersioned(ctx, u, "*")
+}
+
+func (r *userRepository) UpdateFields(ctx context.Context, u *domain.User, fields ...string) error {
+	return r.updateVersioned(ctx, u, append(fields, "Version", "UpdatedAt")...)
+}
+
+// updateVersioned バージョンが一致する場合のみ指定カラムを更新（楽観的排他制御）
+func (r *userRepository) updateVersioned(ctx context.Context, u *domain.User, fields ...string) error {
+	expected := u.Version
+	u.Version = expected + 1
+	result := infra.Conn(ctx, r.db).Model(u).Where("version = ?", expected).Select(fields).Omit("created_at").Updates(u)
+	if result.Error != nil {
+		u.Version = expected
+		return fmt.Errorf("failed to update user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		u.Version = expected
+		return fmt.Errorf("user with id %d version %d: %w", u.ID, expected, domain.ErrVersionConflict)
 	}
 
 	return nil
 }
 
-func (r *userRepository) Delete(id uint) error {
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
 	// ユーザー存在チェック
-	exists, err := r.Exists(id)
+	exists, err := r.Exists(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("user with id %d not found", id)
+		return fmt.Errorf("user with id %d %w", id, domain.ErrNotFound)
 	}
 
 	// 削除実行
-	if err := r.db.Delete(&domain.User{}, id).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Delete(&domain.User{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete user with id %d: %w", id, err)
 	}
 
 	return nil
 }
 
-func (r *userRepository) Exists(id uint) (bool, error) {
+func (r *userRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
 
-	if err := r.db.Model(&domain.User{}).Where("id = ?", id).Count(&count).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Model(&domain.User{}).Where("id = ?", id).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check user existence: %w", err)
 	}
 
 	return count > 0, nil
 }
 
-func (r *userRepository) ExistsByEmail(email string) (bool, error) {
+func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
 
-	if err := r.db.Model(&domain.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Model(&domain.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check user email existence: %w", err)
 	}
 
 	return count > 0, nil
 }
 
-func (r *userRepository) Count() (int64, error) {
+func (r *userRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
 
-	if err := r.db.Model(&domain.User{}).Count(&count).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Model(&domain.User{}).Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count users: %w", err)
 	}
 
@@ -148,12 +189,94 @@ func (r *userRepository) Count() (int64, error) {
 }
 
 // GetPaginated ページネーション付きでユーザーを取得
-func (r *userRepository) GetPaginated(offset, limit int) ([]domain.User, error) {
+func (r *userRepository) GetPaginated(ctx context.Context, offset, limit int) ([]domain.User, error) {
 	var users []domain.User
 
-	if err := r.db.Offset(offset).Limit(limit).Order("created_at DESC").Find(&users).Error; err != nil {
+	if err := infra.Conn(ctx, r.db).Offset(offset).Limit(limit).Order("created_at DESC").Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to get paginated users: %w", err)
 	}
 
 	return users, nil
 }
+
+// GetByIDs 指定したIDのユーザーを取得
+func (r *userRepository) GetByIDs(ctx context.Context, ids []uint) ([]domain.User, error) {
+	var users []domain.User
+
+	if err := infra.Conn(ctx, r.db).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to get users by ids: %w", err)
+	}
+
+	return users, nil
+}
+
+// ListAfter 条件に一致するユーザーをID順に取得（カーソルページネーション用）
+func (r *userRepository) ListAfter(ctx context.Context, filter domain.UserFilter, afterID uint, limit int) ([]domain.User, error) {
+	var users []domain.User
+
+	conditions, args := userConditions(filter)
+	query := infra.Conn(ctx, r.db).Where("id > ?", afterID)
+	if len(conditions) > 0 {
+		query = query.Where(strings.Join(conditions, " AND "), args...)
+	}
+	if err := query.Order("id").Limit(limit).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+// IsVisibleTo ListAfter・Streamと同じ条件でviewerIDのユーザーが参照できるか確認
+func (r *userRepository) IsVisibleTo(ctx context.Context, id, viewerID uint) (bool, error) {
+	var count int64
+
+	conditions, args := userConditions(domain.UserFilter{VisibleTo: viewerID})
+	err := infra.Conn(ctx, r.db).Model(&domain.User{}).
+		Where("id = ?", id).
+		Where(strings.Join(conditions, " AND "), args...).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check user visibility: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Stream 条件に一致するユーザーをサーバーサイドカーソルで取得
+func (r *userRepository) Stream(ctx context.Context, filter domain.UserFilter, fn func([]domain.User) error) error {
+	conditions, args := userConditions(filter)
+
+	query := "SELECT id, name, email, version, created_at, updated_at FROM users"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += infra.OrderBy(filter.SortBy, filter.SortDir, sortColumns)
+
+	if err := infra.StreamCursor(ctx, r.db, infra.DefaultCursorBatchSize, query, args, fn); err != nil {
+		return fmt.Errorf("failed to stream users: %w", err)
+	}
+
+	return nil
+}
+
+// userConditions 絞り込み条件のWHERE句（ANDで連結する）と引数
+func userConditions(filter domain.UserFilter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Query != "" {
+		searchTerm := "%" + strings.ToLower(filter.Query) + "%"
+		conditions = append(conditions, "(LOWER(name) LIKE ? OR LOWER(email) LIKE ?)")
+		args = append(args, searchTerm, searchTerm)
+	}
+	if filter.VisibleTo != 0 {
+		// 本人と、同じ会社に所属するユーザー
+		conditions = append(conditions, `(id = ? OR id IN (
+			SELECT cu2.user_id FROM company_users cu1
+			JOIN company_users cu2 ON cu2.company_id = cu1.company_id
+			WHERE cu1.user_id = ?))`)
+		args = append(args, filter.VisibleTo, filter.VisibleTo)
+	}
+
+	return conditions, args
+}
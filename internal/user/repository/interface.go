@@ -1,16 +1,33 @@
 package repository
 
-import "km-api-go/internal/domain"
+import (
+	"context"
+
+	"km-api-go/internal/domain"
+)
 
 type UserRepository interface {
-	GetAll() ([]domain.User, error)
-	GetByID(id uint) (*domain.User, error)
-	GetByEmail(email string) (*domain.User, error)
-	Create(user *domain.User) error
-	Update(user *domain.User) error
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
-	ExistsByEmail(email string) (bool, error)
-	Count() (int64, error)
-	GetPaginated(offset, limit int) ([]domain.User, error)
+	GetAll(ctx context.Context) ([]domain.User, error)
+	GetByID(ctx context.Context, id uint) (*domain.User, error)
+	// GetByIDWithFields 指定したフィールド（Goのフィールド名）のカラムのみ取得（空の場合はGetByIDと同じ）
+	GetByIDWithFields(ctx context.Context, id uint, fields ...string) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+	// Update user.Versionが一致する場合のみ更新し、Versionを進める（不一致はdomain.ErrVersionConflict）
+	Update(ctx context.Context, user *domain.User) error
+	// UpdateFields 指定したフィールド（Goのフィールド名）のみバージョンチェック付きで更新
+	UpdateFields(ctx context.Context, user *domain.User, fields ...string) error
+	Delete(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	Count(ctx context.Context) (int64, error)
+	GetPaginated(ctx context.Context, offset, limit int) ([]domain.User, error)
+	// GetByIDs 指定したIDのユーザーを取得（存在しないIDは含まない）
+	GetByIDs(ctx context.Context, ids []uint) ([]domain.User, error)
+	// ListAfter 条件に一致するユーザーをID順にafterIDより後から最大limit件取得（SortBy・SortDirは無視する）
+	ListAfter(ctx context.Context, filter domain.UserFilter, afterID uint, limit int) ([]domain.User, error)
+	// IsVisibleTo viewerIDのユーザーが参照できるか（本人・同じ会社に所属するユーザー）
+	IsVisibleTo(ctx context.Context, id, viewerID uint) (bool, error)
+	// Stream 条件に一致するユーザーをサーバーサイドカーソルで取得し、一定件数ずつfnに渡す
+	Stream(ctx context.Context, filter domain.UserFilter, fn func([]domain.User) error) error
 }
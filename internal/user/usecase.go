@@ -3,9 +3,18 @@ package user
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
+	"km-api-go/internal/auth"
 	"km-api-go/internal/domain"
 	"km-api-go/internal/helper"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/metrics"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/patch"
+	"km-api-go/internal/storage"
+	"km-api-go/internal/tracing"
 	"km-api-go/internal/user/repository"
 )
 
@@ -14,34 +23,89 @@ import (
 type UserUsecase interface {
 	Create(ctx context.Context, name, email, password string) (*domain.User, error)
 	GetAllUsers(ctx context.Context) ([]domain.User, error)
+	// CanView 呼び出し元がユーザーを参照できるか（本人・同じ会社に所属するユーザー・APIキー）
+	CanView(ctx context.Context, id uint) (bool, error)
+	// GetUserByID 参照できないユーザーはdomain.ErrNotFoundを返す
 	GetUserByID(ctx context.Context, id uint) (*domain.User, error)
-	UpdateUser(ctx context.Context, id uint, name, email string) (*domain.User, error)
+	GetUserByIDWithFields(ctx context.Context, id uint, fields []string) (*domain.User, error)
+	GetUsersByIDs(ctx context.Context, ids []uint) ([]domain.User, error)
+	UpdateUser(ctx context.Context, id, version uint, name, email string) (*domain.User, error)
+	PatchUser(ctx context.Context, id, version uint, p patch.Patch) (*domain.User, error)
 	DeleteUser(ctx context.Context, id uint) error
 	GetUsersPaginated(ctx context.Context, page, limit int) ([]domain.User, *helper.PaginationResponse, error)
 	AuthenticateUser(ctx context.Context, email, password string) (*domain.User, error)
+	UploadAvatar(ctx context.Context, id, version uint, data []byte) (*domain.User, error)
+	DeleteAvatar(ctx context.Context, id, version uint) (*domain.User, error)
+	AvatarURLs(ctx context.Context, u *domain.User) (*domain.AvatarURLs, error)
 }
 
 // userUsecase implements the UserUsecase interface.
 type userUsecase struct {
 	userRepo repository.UserRepository
+	tx       infra.Transactor
+	events   outbox.Recorder
+	blobs    storage.BlobStore
+	cfg      Config
 }
 
 // NewUserUsecase is the constructor for userUsecase.
-func NewUserUsecase(userRepo repository.UserRepository) UserUsecase {
+// Changes and their domain events are saved in the same transaction; avatar images are kept in blobs.
+func NewUserUsecase(userRepo repository.UserRepository, tx infra.Transactor, events outbox.Recorder, blobs storage.BlobStore, cfg Config) UserUsecase {
 	return &userUsecase{
 		userRepo: userRepo,
+		tx:       tx,
+		events:   events,
+		blobs:    blobs,
+		cfg:      cfg,
 	}
 }
 
+// authorizeSelf 本人またはAPIキーのみ操作できる
+func authorizeSelf(ctx context.Context, id uint) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || (principal.IsUser() && principal.UserID != id) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// CanView reports whether the caller may read the user: API keys, the user themselves,
+// and users who share a company with them.
+func (uc *userUsecase) CanView(ctx context.Context, id uint) (bool, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	if !principal.IsUser() || principal.UserID == id {
+		return true, nil
+	}
+	return uc.userRepo.IsVisibleTo(ctx, id, principal.UserID)
+}
+
+// authorizeView 参照できないユーザーは存在しないものとして扱う
+func (uc *userUsecase) authorizeView(ctx context.Context, id uint) error {
+	visible, err := uc.CanView(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return fmt.Errorf("user with id %d %w", id, domain.ErrNotFound)
+	}
+	return nil
+}
+
 // Create creates a new user.
-func (uc *userUsecase) Create(ctx context.Context, name, email, password string) (*domain.User, error) {
+func (uc *userUsecase) Create(ctx context.Context, name, email, password string) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.Create")
+	defer tracing.End(span, &err)
+
 	// メール重複チェック
-	exists, err := uc.userRepo.ExistsByEmail(email)
+	exists, err := uc.userRepo.ExistsByEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check email existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("user with email %s already exists", email)
+		return nil, fmt.Errorf("user with email %s %w", email, domain.ErrAlreadyExists)
 	}
 
 	// ユーザーオブジェクト作成
@@ -51,10 +115,18 @@ func (uc *userUsecase) Create(ctx context.Context, name, email, password string)
 		Password: password, // BeforeCreateフックでハッシュ化される
 	}
 
-	// リポジトリで保存
-	if err := uc.userRepo.Create(user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	// リポジトリで保存（イベントも同じトランザクションで記録）
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		return uc.events.Record(ctx, domain.UserCreated{UserID: user.ID, Name: user.Name, Email: user.Email})
+	})
+	if err != nil {
+		return nil, err
 	}
+	metrics.UsersCreated.Inc()
+	logging.FromContext(ctx).InfoContext(ctx, "user created", slog.Uint64("user_id", uint64(user.ID)))
 
 	// パスワードを除外したレスポンス用データを返す
 	user.Password = ""
@@ -62,8 +134,11 @@ func (uc *userUsecase) Create(ctx context.Context, name, email, password string)
 }
 
 // GetAllUsers retrieves all users.
-func (uc *userUsecase) GetAllUsers(ctx context.Context) ([]domain.User, error) {
-	users, err := uc.userRepo.GetAll()
+func (uc *userUsecase) GetAllUsers(ctx context.Context) (_ []domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GetAllUsers")
+	defer tracing.End(span, &err)
+
+	users, err := uc.userRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
@@ -77,8 +152,32 @@ func (uc *userUsecase) GetAllUsers(ctx context.Context) ([]domain.User, error) {
 }
 
 // GetUserByID retrieves a user by their ID.
-func (uc *userUsecase) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
-	user, err := uc.userRepo.GetByID(id)
+func (uc *userUsecase) GetUserByID(ctx context.Context, id uint) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GetUserByID")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeView(ctx, id); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by id %d: %w", id, err)
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// GetUserByIDWithFields retrieves a user loading only the given fields (Go field names).
+// ID and Version are always loaded; no fields loads them all.
+func (uc *userUsecase) GetUserByIDWithFields(ctx context.Context, id uint, fields []string) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GetUserByIDWithFields")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorizeView(ctx, id); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByIDWithFields(ctx, id, fields...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by id %d: %w", id, err)
 	}
@@ -87,51 +186,178 @@ func (uc *userUsecase) GetUserByID(ctx context.Context, id uint) (*domain.User,
 	return user, nil
 }
 
-// UpdateUser updates a user's information.
-func (uc *userUsecase) UpdateUser(ctx context.Context, id uint, name, email string) (*domain.User, error) {
-	existingUser, err := uc.userRepo.GetByID(id)
+// GetUsersByIDs retrieves the users with the given IDs in a single query.
+// IDs that do not exist are omitted.
+func (uc *userUsecase) GetUsersByIDs(ctx context.Context, ids []uint) (_ []domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GetUsersByIDs")
+	defer tracing.End(span, &err)
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	users, err := uc.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by ids: %w", err)
+	}
+
+	for i := range users {
+		users[i].Password = ""
+	}
+
+	return users, nil
+}
+
+// UpdateUser updates a user's information. Only the user themself or an API key may update it.
+// version is the version the client last read; 0 skips the check (If-Match: *).
+func (uc *userUsecase) UpdateUser(ctx context.Context, id, version uint, name, email string) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.UpdateUser")
+	defer tracing.End(span, &err)
+
+	if err := authorizeSelf(ctx, id); err != nil {
+		return nil, err
+	}
+
+	existingUser, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user for update: %w", err)
 	}
+	if version != 0 && existingUser.Version != version {
+		return nil, fmt.Errorf("user with id %d version %d: %w", id, version, domain.ErrVersionConflict)
+	}
 
 	if existingUser.Email != email {
-		exists, err := uc.userRepo.ExistsByEmail(email)
+		exists, err := uc.userRepo.ExistsByEmail(ctx, email)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check email existence: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("user with email %s already exists", email)
+			return nil, fmt.Errorf("user with email %s %w", email, domain.ErrAlreadyExists)
 		}
 	}
 
+	var changed []string
+	if existingUser.Name != name {
+		changed = append(changed, "name")
+	}
+	if existingUser.Email != email {
+		changed = append(changed, "email")
+	}
 	existingUser.Name = name
 	existingUser.Email = email
 
-	if err := uc.userRepo.Update(existingUser); err != nil {
-		return nil, fmt.Errorf("failed to update user: %w", err)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Update(ctx, existingUser); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return uc.events.Record(ctx, userUpdated(existingUser, changed))
+	})
+	if err != nil {
+		return nil, err
 	}
+	logging.FromContext(ctx).InfoContext(ctx, "user updated", slog.Uint64("user_id", uint64(id)))
 
 	existingUser.Password = ""
 	return existingUser, nil
 }
 
-// DeleteUser deletes a user by their ID.
-func (uc *userUsecase) DeleteUser(ctx context.Context, id uint) error {
-	return uc.userRepo.Delete(id)
+// userPatchableFields are the fields a client may change with PatchUser.
+var userPatchableFields = []string{"Name", "Email", "DisplayName", "KanaName", "Locale", "Timezone", "Bio"}
+
+// PatchUser applies a JSON Merge Patch or JSON Patch and updates only the changed columns.
+// Only the user themself or an API key may patch it.
+// version is the version the client last read; 0 skips the check (If-Match: *).
+func (uc *userUsecase) PatchUser(ctx context.Context, id, version uint, p patch.Patch) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.PatchUser")
+	defer tracing.End(span, &err)
+
+	if err := authorizeSelf(ctx, id); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for patch: %w", err)
+	}
+	if version != 0 && user.Version != version {
+		return nil, fmt.Errorf("user with id %d version %d: %w", id, version, domain.ErrVersionConflict)
+	}
+
+	originalEmail := user.Email
+	changed, err := patch.Apply(p, user, userPatchableFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changed) > 0 {
+		if err := helper.ValidateStructPartial(user, changed...); err != nil {
+			return nil, err
+		}
+
+		if user.Email != originalEmail {
+			exists, err := uc.userRepo.ExistsByEmail(ctx, user.Email)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check email existence: %w", err)
+			}
+			if exists {
+				return nil, fmt.Errorf("user with email %s %w", user.Email, domain.ErrAlreadyExists)
+			}
+		}
+
+		err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.userRepo.UpdateFields(ctx, user, changed...); err != nil {
+				return fmt.Errorf("failed to patch user: %w", err)
+			}
+			return uc.events.Record(ctx, userUpdated(user, patch.JSONNames(user, changed)))
+		})
+		if err != nil {
+			return nil, err
+		}
+		logging.FromContext(ctx).InfoContext(ctx, "user patched",
+			slog.Uint64("user_id", uint64(id)), slog.Any("fields", changed))
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// DeleteUser deletes a user by their ID. Only the user themself or an API key may delete it.
+func (uc *userUsecase) DeleteUser(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.DeleteUser")
+	defer tracing.End(span, &err)
+
+	if err := authorizeSelf(ctx, id); err != nil {
+		return err
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return uc.events.Record(ctx, domain.UserDeleted{UserID: id})
+	})
+	if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "user deleted", slog.Uint64("user_id", uint64(id)))
+	return nil
 }
 
 // GetUsersPaginated retrieves users with pagination.
-func (uc *userUsecase) GetUsersPaginated(ctx context.Context, page, limit int) ([]domain.User, *helper.PaginationResponse, error) {
+func (uc *userUsecase) GetUsersPaginated(ctx context.Context, page, limit int) (_ []domain.User, _ *helper.PaginationResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GetUsersPaginated")
+	defer tracing.End(span, &err)
+
 	paginationReq := &helper.PaginationRequest{Page: page, Limit: limit}
 	offset := paginationReq.GetOffset()
 	normalizedLimit := paginationReq.GetLimit()
 
-	total, err := uc.userRepo.Count()
+	total, err := uc.userRepo.Count(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to count users: %w", err)
 	}
 
-	users, err := uc.userRepo.GetPaginated(offset, normalizedLimit)
+	users, err := uc.userRepo.GetPaginated(ctx, offset, normalizedLimit)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get paginated users: %w", err)
 	}
@@ -146,16 +372,34 @@ func (uc *userUsecase) GetUsersPaginated(ctx context.Context, page, limit int) (
 }
 
 // AuthenticateUser authenticates a user.
-func (uc *userUsecase) AuthenticateUser(ctx context.Context, email, password string) (*domain.User, error) {
-	user, err := uc.userRepo.GetByEmail(email)
+// dummyPasswordHash メールアドレスが登録されていない場合に照合するハッシュ（bcrypt.DefaultCost）
+// 登録済みの場合と同じだけ時間をかけ、応答時間から登録の有無がわからないようにする
+const dummyPasswordHash = "$2a$10$igcqOHgk2R4fbzEWW7f8F.BI.Q8qkZ2kObY7Rd153PO2lVCbeUACi"
+
+func (uc *userUsecase) AuthenticateUser(ctx context.Context, email, password string) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.AuthenticateUser")
+	defer tracing.End(span, &err)
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
 	if err != nil {
+		(&domain.User{Password: dummyPasswordHash}).CheckPassword(password)
+		metrics.Logins.WithLabelValues(metrics.LoginFailed).Inc()
+		logging.FromContext(ctx).WarnContext(ctx, "authentication failed: user not found")
 		return nil, fmt.Errorf("authentication failed: invalid email or password")
 	}
 
 	if !user.CheckPassword(password) {
+		metrics.Logins.WithLabelValues(metrics.LoginFailed).Inc()
+		logging.FromContext(ctx).WarnContext(ctx, "authentication failed: password mismatch", slog.Uint64("user_id", uint64(user.ID)))
 		return nil, fmt.Errorf("authentication failed: invalid email or password")
 	}
+	metrics.Logins.WithLabelValues(metrics.LoginSucceeded).Inc()
 
 	user.Password = ""
 	return user, nil
 }
+
+// userUpdated builds the UserUpdated event; changed holds JSON field names.
+func userUpdated(u *domain.User, changed []string) domain.UserUpdated {
+	return domain.UserUpdated{UserID: u.ID, Name: u.Name, Email: u.Email, Version: u.Version, Changed: changed}
+}
@@ -6,9 +6,18 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 
+	"km-api-go/internal/auth"
 	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/patch"
+	"km-api-go/internal/user/repository"
 	"km-api-go/internal/user/repository/mocks"
 )
 
@@ -17,7 +26,7 @@ func TestUserUsecase_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := NewUserUsecase(mockRepo)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
 
 	tests := []struct {
 		name        string
@@ -36,12 +45,12 @@ func TestUserUsecase_Create(t *testing.T) {
 			inputPass:  "password123",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					ExistsByEmail("test@example.com").
+					ExistsByEmail(gomock.Any(), "test@example.com").
 					Return(false, nil).
 					Times(1)
 				mockRepo.EXPECT().
-					Create(gomock.Any()).
-					Do(func(user *domain.User) {
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, user *domain.User) {
 						user.ID = 1 // 作成後のIDを設定
 					}).
 					Return(nil).
@@ -62,7 +71,7 @@ func TestUserUsecase_Create(t *testing.T) {
 			inputPass:  "password123",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					ExistsByEmail("duplicate@example.com").
+					ExistsByEmail(gomock.Any(), "duplicate@example.com").
 					Return(true, nil).
 					Times(1)
 			},
@@ -77,7 +86,7 @@ func TestUserUsecase_Create(t *testing.T) {
 			inputPass:  "password123",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					ExistsByEmail("test@example.com").
+					ExistsByEmail(gomock.Any(), "test@example.com").
 					Return(false, errors.New("database error")).
 					Times(1)
 			},
@@ -92,11 +101,11 @@ func TestUserUsecase_Create(t *testing.T) {
 			inputPass:  "password123",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					ExistsByEmail("test@example.com").
+					ExistsByEmail(gomock.Any(), "test@example.com").
 					Return(false, nil).
 					Times(1)
 				mockRepo.EXPECT().
-					Create(gomock.Any()).
+					Create(gomock.Any(), gomock.Any()).
 					Return(errors.New("database error")).
 					Times(1)
 			},
@@ -133,7 +142,7 @@ func TestUserUsecase_GetAllUsers(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := NewUserUsecase(mockRepo)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
 
 	tests := []struct {
 		name        string
@@ -148,7 +157,7 @@ func TestUserUsecase_GetAllUsers(t *testing.T) {
 					{ID: 1, Name: "User1", Email: "user1@example.com", Password: "hashed1"},
 					{ID: 2, Name: "User2", Email: "user2@example.com", Password: "hashed2"},
 				}
-				mockRepo.EXPECT().GetAll().Return(users, nil).Times(1)
+				mockRepo.EXPECT().GetAll(gomock.Any()).Return(users, nil).Times(1)
 			},
 			expectUsers: []domain.User{
 				{ID: 1, Name: "User1", Email: "user1@example.com", Password: ""},
@@ -159,7 +168,7 @@ func TestUserUsecase_GetAllUsers(t *testing.T) {
 		{
 			name: "正常系: 空のユーザー一覧",
 			setupMock: func() {
-				mockRepo.EXPECT().GetAll().Return([]domain.User{}, nil).Times(1)
+				mockRepo.EXPECT().GetAll(gomock.Any()).Return([]domain.User{}, nil).Times(1)
 			},
 			expectUsers: []domain.User{},
 			expectError: false,
@@ -167,7 +176,7 @@ func TestUserUsecase_GetAllUsers(t *testing.T) {
 		{
 			name: "異常系: リポジトリエラー",
 			setupMock: func() {
-				mockRepo.EXPECT().GetAll().Return(nil, errors.New("database error")).Times(1)
+				mockRepo.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New("database error")).Times(1)
 			},
 			expectUsers: nil,
 			expectError: true,
@@ -200,10 +209,14 @@ func TestUserUsecase_GetUserByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := NewUserUsecase(mockRepo)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
+
+	self := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+	other := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 2})
 
 	tests := []struct {
 		name        string
+		ctx         context.Context
 		inputID     uint
 		setupMock   func()
 		expectUser  *domain.User
@@ -211,6 +224,7 @@ func TestUserUsecase_GetUserByID(t *testing.T) {
 	}{
 		{
 			name:    "正常系: ユーザー取得",
+			ctx:     self,
 			inputID: 1,
 			setupMock: func() {
 				user := &domain.User{
@@ -219,7 +233,7 @@ func TestUserUsecase_GetUserByID(t *testing.T) {
 					Email:    "test@example.com",
 					Password: "hashedpassword",
 				}
-				mockRepo.EXPECT().GetByID(uint(1)).Return(user, nil).Times(1)
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(user, nil).Times(1)
 			},
 			expectUser: &domain.User{
 				ID:       1,
@@ -229,11 +243,49 @@ func TestUserUsecase_GetUserByID(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:    "正常系: 同じ会社に所属するユーザーは取得できる",
+			ctx:     other,
+			inputID: 1,
+			setupMock: func() {
+				mockRepo.EXPECT().IsVisibleTo(gomock.Any(), uint(1), uint(2)).Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&domain.User{ID: 1, Name: "Test User", Email: "test@example.com"}, nil).Times(1)
+			},
+			expectUser: &domain.User{ID: 1, Name: "Test User", Email: "test@example.com"},
+		},
+		{
+			name:    "正常系: APIキーはすべてのユーザーを取得できる",
+			ctx:     auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "test"}),
+			inputID: 1,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&domain.User{ID: 1, Name: "Test User", Email: "test@example.com"}, nil).Times(1)
+			},
+			expectUser: &domain.User{ID: 1, Name: "Test User", Email: "test@example.com"},
+		},
+		{
+			name:    "異常系: 同じ会社に所属していないユーザーは見つからないものとして扱う",
+			ctx:     other,
+			inputID: 1,
+			setupMock: func() {
+				mockRepo.EXPECT().IsVisibleTo(gomock.Any(), uint(1), uint(2)).Return(false, nil).Times(1)
+			},
+			expectUser:  nil,
+			expectError: true,
+		},
+		{
+			name:        "異常系: 認証情報がない",
+			ctx:         context.Background(),
+			inputID:     1,
+			setupMock:   func() {},
+			expectUser:  nil,
+			expectError: true,
+		},
 		{
 			name:    "異常系: ユーザーが見つからない",
+			ctx:     auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 999}),
 			inputID: 999,
 			setupMock: func() {
-				mockRepo.EXPECT().GetByID(uint(999)).Return(nil, errors.New("user not found")).Times(1)
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(999)).Return(nil, errors.New("user not found")).Times(1)
 			},
 			expectUser:  nil,
 			expectError: true,
@@ -244,7 +296,7 @@ func TestUserUsecase_GetUserByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			user, err := usecase.GetUserByID(context.Background(), tt.inputID)
+			user, err := usecase.GetUserByID(tt.ctx, tt.inputID)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -261,12 +313,281 @@ func TestUserUsecase_GetUserByID(t *testing.T) {
 	}
 }
 
+func TestUserUsecase_GetUsersByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
+
+	tests := []struct {
+		name        string
+		inputIDs    []uint
+		setupMock   func()
+		expectUsers []domain.User
+		expectError bool
+	}{
+		{
+			name:     "正常系: まとめて取得し、パスワードは除外",
+			inputIDs: []uint{1, 2},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByIDs(gomock.Any(), []uint{1, 2}).Return([]domain.User{
+					{ID: 1, Name: "User 1", Password: "hashedpassword"},
+					{ID: 2, Name: "User 2", Password: "hashedpassword"},
+				}, nil).Times(1)
+			},
+			expectUsers: []domain.User{{ID: 1, Name: "User 1"}, {ID: 2, Name: "User 2"}},
+		},
+		{
+			name:      "正常系: IDがなければ取得しない",
+			inputIDs:  nil,
+			setupMock: func() {},
+		},
+		{
+			name:     "異常系: 取得に失敗",
+			inputIDs: []uint{1},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByIDs(gomock.Any(), []uint{1}).Return(nil, errors.New("db error")).Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			users, err := usecase.GetUsersByIDs(context.Background(), tt.inputIDs)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectUsers, users)
+		})
+	}
+}
+
+func TestUserUsecase_GetUserByIDWithFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
+
+	mockRepo.EXPECT().GetByIDWithFields(gomock.Any(), uint(1), "Name").
+		Return(&domain.User{ID: 1, Name: "Test User", Version: 2}, nil).Times(1)
+
+	user, err := usecase.GetUserByIDWithFields(auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1}), 1, []string{"Name"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.User{ID: 1, Name: "Test User", Version: 2}, user)
+
+	// 同じ会社に所属していないユーザーは見つからないものとして扱う（リポジトリから取得しない）
+	mockRepo.EXPECT().IsVisibleTo(gomock.Any(), uint(1), uint(2)).Return(false, nil).Times(1)
+
+	_, err = usecase.GetUserByIDWithFields(auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 2}), 1, []string{"Name"})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestUserUsecase_UpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
+	self := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+
+	existing := func() *domain.User {
+		return &domain.User{ID: 1, Name: "Old Name", Email: "test@example.com", Password: "hashedpassword", Version: 3}
+	}
+
+	tests := []struct {
+		name          string
+		inputVersion  uint
+		setupMock     func()
+		expectVersion uint
+		expectError   error
+	}{
+		{
+			name:         "正常系: バージョン一致で更新",
+			inputVersion: 3,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.User) error {
+					u.Version++
+					return nil
+				}).Times(1)
+			},
+			expectVersion: 4,
+		},
+		{
+			name:         "正常系: バージョン0（If-Match: *）はチェックしない",
+			inputVersion: 0,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectVersion: 3,
+		},
+		{
+			name:         "異常系: 取得時とバージョンが異なる",
+			inputVersion: 2,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+			},
+			expectError: domain.ErrVersionConflict,
+		},
+		{
+			name:         "異常系: 更新中に他のリクエストで更新された",
+			inputVersion: 3,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(domain.ErrVersionConflict).Times(1)
+			},
+			expectError: domain.ErrVersionConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			user, err := usecase.UpdateUser(self, 1, tt.inputVersion, "New Name", "test@example.com")
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "New Name", user.Name)
+				assert.Equal(t, tt.expectVersion, user.Version)
+				assert.Equal(t, "", user.Password)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_PatchUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
+	self := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+
+	existing := func() *domain.User {
+		return &domain.User{ID: 1, Name: "Old Name", Email: "test@example.com", Password: "hashedpassword", Version: 3}
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		setupMock   func()
+		expectName  string
+		expectEmail string
+		expectError bool
+	}{
+		{
+			name: "正常系: 変更されたフィールドのみ更新",
+			body: `{"name":"New Name"}`,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+				mockRepo.EXPECT().UpdateFields(gomock.Any(), gomock.Any(), "Name").Return(nil).Times(1)
+			},
+			expectName:  "New Name",
+			expectEmail: "test@example.com",
+		},
+		{
+			name: "正常系: 変更がなければ保存しない",
+			body: `{"name":"Old Name"}`,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+			},
+			expectName:  "Old Name",
+			expectEmail: "test@example.com",
+		},
+		{
+			name: "正常系: メールアドレス変更は重複チェック",
+			body: `{"email":"new@example.com"}`,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+				mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "new@example.com").Return(false, nil).Times(1)
+				mockRepo.EXPECT().UpdateFields(gomock.Any(), gomock.Any(), "Email").Return(nil).Times(1)
+			},
+			expectName:  "Old Name",
+			expectEmail: "new@example.com",
+		},
+		{
+			name: "異常系: domain.Userのバリデーションに違反",
+			body: `{"email":"not-an-email"}`,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name: "異常系: 必須フィールドの削除",
+			body: `{"name":null}`,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(existing(), nil).Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			p, err := patch.New(patch.MIMEMergePatch, []byte(tt.body))
+			assert.NoError(t, err)
+
+			user, err := usecase.PatchUser(self, 1, 3, p)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectName, user.Name)
+				assert.Equal(t, tt.expectEmail, user.Email)
+				assert.Equal(t, "", user.Password)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_UpdateUser_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
+
+	// 他のユーザーは更新できない（リポジトリを呼ばない）
+	other := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 2})
+	_, err := usecase.UpdateUser(other, 1, 3, "New Name", "test@example.com")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	p, err := patch.New(patch.MIMEMergePatch, []byte(`{"name":"New Name"}`))
+	assert.NoError(t, err)
+	_, err = usecase.PatchUser(other, 1, 3, p)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	// 認証情報がない場合も拒否する
+	_, err = usecase.UpdateUser(context.Background(), 1, 3, "New Name", "test@example.com")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
 func TestUserUsecase_AuthenticateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := NewUserUsecase(mockRepo)
+	usecase := NewUserUsecase(mockRepo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
 
 	// テスト用のパスワードハッシュを生成
 	testUser := &domain.User{
@@ -295,7 +616,7 @@ func TestUserUsecase_AuthenticateUser(t *testing.T) {
 					Email:    "test@example.com",
 					Password: hashedPassword, // 実際のハッシュ化されたパスワードを使用
 				}
-				mockRepo.EXPECT().GetByEmail("test@example.com").Return(user, nil).Times(1)
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, nil).Times(1)
 			},
 			expectUser: &domain.User{
 				ID:       1,
@@ -316,7 +637,7 @@ func TestUserUsecase_AuthenticateUser(t *testing.T) {
 					Email:    "test@example.com",
 					Password: hashedPassword,
 				}
-				mockRepo.EXPECT().GetByEmail("test@example.com").Return(user, nil).Times(1)
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, nil).Times(1)
 			},
 			expectUser:  nil,
 			expectError: true,
@@ -326,7 +647,7 @@ func TestUserUsecase_AuthenticateUser(t *testing.T) {
 			inputEmail: "notfound@example.com",
 			inputPass:  "password123",
 			setupMock: func() {
-				mockRepo.EXPECT().GetByEmail("notfound@example.com").Return(nil, errors.New("user not found")).Times(1)
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), "notfound@example.com").Return(nil, errors.New("user not found")).Times(1)
 			},
 			expectUser:  nil,
 			expectError: true,
@@ -353,4 +674,57 @@ func TestUserUsecase_AuthenticateUser(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// savedUsers GORMのフックを通して保存したユーザーを記録し、メールアドレスで返す
+// DryRunのDBはSQLを実行しないため、保存内容はここで保持する
+type savedUsers struct {
+	repository.UserRepository
+	byEmail map[string]domain.User
+}
+
+func (r *savedUsers) Create(ctx context.Context, u *domain.User) error {
+	if err := r.UserRepository.Create(ctx, u); err != nil {
+		return err
+	}
+	r.byEmail[u.Email] = *u
+	return nil
+}
+
+func (r *savedUsers) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	u, ok := r.byEmail[email]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &u, nil
+}
+
+func TestUserUsecase_AuthenticateUser_DummyHash(t *testing.T) {
+	// 登録されていないメールアドレスでも、登録済みのパスワードと同じコストで照合する
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	require.NoError(t, err)
+	assert.Equal(t, bcrypt.DefaultCost, cost)
+}
+
+func TestUserUsecase_CreateThenAuthenticate(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	repo := &savedUsers{UserRepository: repository.NewUserRepository(db), byEmail: map[string]domain.User{}}
+	usecase := NewUserUsecase(repo, infra.NoTx, outbox.New(outbox.NewMemoryStore()), nil, Config{})
+	ctx := context.Background()
+
+	_, err = usecase.Create(ctx, "山田太郎", "yamada@example.com", "password123")
+	require.NoError(t, err)
+
+	// 保存時にハッシュ化され、平文は残らない
+	saved := repo.byEmail["yamada@example.com"]
+	assert.NotEqual(t, "password123", saved.Password)
+	assert.True(t, saved.CheckPassword("password123"))
+
+	user, err := usecase.AuthenticateUser(ctx, "yamada@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, "yamada@example.com", user.Email)
+
+	_, err = usecase.AuthenticateUser(ctx, "yamada@example.com", "wrong-password")
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"time"
+
+	"km-api-go/internal/infra"
+)
+
+// Config 会社のWebhookの配信設定
+type Config struct {
+	Enabled              bool          // ドメインイベントをWebhookに配信するか
+	Timeout              time.Duration // 1回の送信のタイムアウト
+	MaxAttempts          int           // 送信回数の上限（再試行の間隔はジョブの JOB_RETRY_BASE・JOB_RETRY_MAX に従う）
+	SecretGracePeriod    time.Duration // シークレットのローテーション後、以前のシークレットでも署名する期間
+	Retention            time.Duration // 終了した配信履歴を保持する期間
+	AllowPrivateNetworks bool          // ループバック・プライベートアドレスへの送信を許可するか（開発用）
+}
+
+// LoadConfig 環境変数からWebhookの配信設定を読み込み
+func LoadConfig() Config {
+	return Config{
+		Enabled:              infra.GetEnvBool("WEBHOOK_ENABLED", true),
+		Timeout:              infra.GetEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		MaxAttempts:          infra.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		SecretGracePeriod:    infra.GetEnvDuration("WEBHOOK_SECRET_GRACE_PERIOD", 24*time.Hour),
+		Retention:            infra.GetEnvDuration("WEBHOOK_DELIVERY_RETENTION", 30*24*time.Hour),
+		AllowPrivateNetworks: infra.GetEnvBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", !infra.IsProduction()),
+	}
+}
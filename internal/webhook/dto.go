@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"time"
+
+	"km-api-go/internal/domain"
+)
+
+// WebhookIDRequest WebhookのパスパラメータID
+type WebhookIDRequest struct {
+	CompanyID uint `param:"id" validate:"required,min=1" example:"1"`         // 会社ID
+	WebhookID uint `param:"webhook_id" validate:"required,min=1" example:"1"` // WebhookID
+}
+
+// DeliveryIDRequest 配信のパスパラメータID
+type DeliveryIDRequest struct {
+	CompanyID  uint `param:"id" validate:"required,min=1" example:"1"`          // 会社ID
+	WebhookID  uint `param:"webhook_id" validate:"required,min=1" example:"1"`  // WebhookID
+	DeliveryID uint `param:"delivery_id" validate:"required,min=1" example:"1"` // 配信ID
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=2048" example:"https://example.com/hooks/km"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=company.updated company.member_added company.member_role_changed company.member_removed" example:"company.member_added,company.member_removed"`
+	Description string   `json:"description" validate:"omitempty,max=255" example:"入退社の通知"`
+	Active      *bool    `json:"active" example:"true"` // 省略時は有効
+}
+
+type UpdateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=2048" example:"https://example.com/hooks/km"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=company.updated company.member_added company.member_role_changed company.member_removed" example:"company.member_added,company.member_removed"`
+	Description string   `json:"description" validate:"omitempty,max=255" example:"入退社の通知"`
+	Active      *bool    `json:"active" validate:"required" example:"true"`
+}
+
+type WebhookResponse struct {
+	ID              uint       `json:"id"`
+	CompanyID       uint       `json:"company_id"`
+	URL             string     `json:"url"`
+	Events          []string   `json:"events"`
+	Description     string     `json:"description"`
+	Active          bool       `json:"active"`
+	Secret          string     `json:"secret,omitempty"` // 署名シークレット（作成時・ローテーション時のみ）
+	SecretRotatedAt *time.Time `json:"secret_rotated_at,omitempty" format:"date-time"`
+	CreatedAt       time.Time  `json:"created_at" format:"date-time"`
+	UpdatedAt       time.Time  `json:"updated_at" format:"date-time"`
+}
+
+// NewWebhookResponse ドメインモデルからレスポンスを作成（シークレットは含まない）
+func NewWebhookResponse(w *domain.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:              w.ID,
+		CompanyID:       w.CompanyID,
+		URL:             w.URL,
+		Events:          w.Events,
+		Description:     w.Description,
+		Active:          w.Active,
+		SecretRotatedAt: w.SecretRotatedAt,
+		CreatedAt:       w.CreatedAt,
+		UpdatedAt:       w.UpdatedAt,
+	}
+}
+
+// NewWebhookSecretResponse シークレットを含むレスポンスを作成（作成時・ローテーション時に1度だけ返す）
+func NewWebhookSecretResponse(w *domain.Webhook) WebhookResponse {
+	resp := NewWebhookResponse(w)
+	resp.Secret = w.Secret
+	return resp
+}
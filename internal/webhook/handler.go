@@ -0,0 +1,327 @@
+package webhook
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+)
+
+type WebhookHandler struct {
+	usecase WebhookUsecase
+}
+
+func NewWebhookHandler(usecase WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{usecase: usecase}
+}
+
+// ListWebhooks godoc
+// @Summary Webhook一覧
+// @ID listWebhooks
+// @Description 会社に登録されたWebhookを取得します。会社の管理者のみ操作できます
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "会社ID"
+// @Success 200 {object} helper.APIResponse{data=[]WebhookResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	var req helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	webhooks, err := h.usecase.ListWebhooks(ctx, req.ID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to list webhooks")
+	}
+
+	responses := make([]WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		responses[i] = NewWebhookResponse(&webhooks[i])
+	}
+	return helper.SuccessResponse(c, http.StatusOK, responses, "")
+}
+
+// CreateWebhook godoc
+// @Summary Webhook登録
+// @ID createWebhook
+// @Description 会社のWebhookを登録します。署名シークレットはこのレスポンスでのみ返します
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param webhook body CreateWebhookRequest true "Webhook"
+// @Success 201 {object} helper.APIResponse{data=WebhookResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var id helper.IDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var req CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	active := req.Active == nil || *req.Active
+
+	ctx := c.Request().Context()
+	w, err := h.usecase.CreateWebhook(ctx, id.ID, req.URL, req.Events, req.Description, active)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to create webhook")
+	}
+
+	return helper.CreatedResponse(c, NewWebhookSecretResponse(w), "")
+}
+
+// GetWebhook godoc
+// @Summary Webhook取得
+// @ID getWebhook
+// @Description 会社のWebhookを取得します
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param webhook_id path int true "WebhookID"
+// @Success 200 {object} helper.APIResponse{data=WebhookResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks/{webhook_id} [get]
+func (h *WebhookHandler) GetWebhook(c echo.Context) error {
+	var req WebhookIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	w, err := h.usecase.GetWebhook(ctx, req.CompanyID, req.WebhookID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to get webhook")
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, NewWebhookResponse(w), "")
+}
+
+// UpdateWebhook godoc
+// @Summary Webhook更新
+// @ID updateWebhook
+// @Description 会社のWebhookの配信先・購読するイベント・有効/無効を更新します
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param webhook_id path int true "WebhookID"
+// @Param webhook body UpdateWebhookRequest true "Webhook"
+// @Success 200 {object} helper.APIResponse{data=WebhookResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks/{webhook_id} [put]
+func (h *WebhookHandler) UpdateWebhook(c echo.Context) error {
+	var id WebhookIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return helper.ErrorResponse(c, http.StatusBadRequest, helper.ErrorCodeValidation, "Invalid request body", err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	w, err := h.usecase.UpdateWebhook(ctx, id.CompanyID, id.WebhookID, req.URL, req.Events, req.Description, *req.Active)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to update webhook")
+	}
+
+	return helper.UpdatedResponse(c, NewWebhookResponse(w), "")
+}
+
+// DeleteWebhook godoc
+// @Summary Webhook削除
+// @ID deleteWebhook
+// @Description 会社のWebhookと配信履歴を削除します
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param webhook_id path int true "WebhookID"
+// @Success 200 {object} helper.APIResponse
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks/{webhook_id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	var req WebhookIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if err := h.usecase.DeleteWebhook(ctx, req.CompanyID, req.WebhookID); err != nil {
+		return h.errorResponse(c, err, "failed to delete webhook")
+	}
+
+	return helper.DeletedResponse(c, "")
+}
+
+// RotateSecret godoc
+// @Summary 署名シークレットのローテーション
+// @ID rotateWebhookSecret
+// @Description 新しい署名シークレットを発行します。猶予期間（WEBHOOK_SECRET_GRACE_PERIOD）の間は以前のシークレットの署名も付与します
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param webhook_id path int true "WebhookID"
+// @Success 200 {object} helper.APIResponse{data=WebhookResponse}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks/{webhook_id}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(c echo.Context) error {
+	var req WebhookIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	w, err := h.usecase.RotateSecret(ctx, req.CompanyID, req.WebhookID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to rotate webhook secret")
+	}
+
+	return helper.SuccessResponse(c, http.StatusOK, NewWebhookSecretResponse(w), "署名シークレットを更新しました")
+}
+
+// ListDeliveries godoc
+// @Summary Webhook配信履歴
+// @ID listWebhookDeliveries
+// @Description Webhookの配信履歴を新しい順に取得します
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param webhook_id path int true "WebhookID"
+// @Param page query int false "ページ番号"
+// @Param limit query int false "1ページあたりの件数"
+// @Success 200 {object} helper.PaginatedResponse{data=[]domain.WebhookDelivery}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks/{webhook_id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	var id WebhookIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&id); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	var page helper.PaginationRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &page); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&page); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	deliveries, pagination, err := h.usecase.ListDeliveries(ctx, id.CompanyID, id.WebhookID, page.Page, page.Limit)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to list webhook deliveries")
+	}
+
+	return helper.PaginatedSuccessResponse(c, deliveries, pagination, "")
+}
+
+// Redeliver godoc
+// @Summary Webhook再配信
+// @ID redeliverWebhookDelivery
+// @Description 終了した配信と同じボディで新しい配信を作成し、非同期で送信します
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "会社ID"
+// @Param webhook_id path int true "WebhookID"
+// @Param delivery_id path int true "配信ID"
+// @Success 202 {object} helper.APIResponse{data=domain.WebhookDelivery}
+// @Failure 400 {object} helper.APIResponse
+// @Failure 403 {object} helper.APIResponse
+// @Failure 404 {object} helper.APIResponse
+// @Failure 409 {object} helper.APIResponse
+// @Failure 500 {object} helper.APIResponse
+// @Router /companies/{id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c echo.Context) error {
+	var req DeliveryIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return helper.ValidationErrorResponse(c, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	d, err := h.usecase.Redeliver(ctx, req.CompanyID, req.WebhookID, req.DeliveryID)
+	if err != nil {
+		return h.errorResponse(c, err, "failed to redeliver webhook")
+	}
+
+	return helper.SuccessResponse(c, http.StatusAccepted, d, "再配信を受け付けました")
+}
+
+// errorResponse ユースケースのエラーをレスポンスに変換
+func (h *WebhookHandler) errorResponse(c echo.Context, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		return helper.ForbiddenResponse(c)
+	case errors.Is(err, domain.ErrNotFound):
+		return helper.NotFoundResponse(c, "")
+	case errors.Is(err, ErrDeliveryInProgress):
+		return helper.ErrorResponse(c, http.StatusConflict, helper.ErrorCodeConflict, "配信が終了していないため再配信できません", "")
+	}
+
+	ctx := c.Request().Context()
+	logging.FromContext(ctx).ErrorContext(ctx, logMessage, slog.Any("error", err))
+	return helper.InternalErrorResponse(c, err.Error())
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/job"
+	"km-api-go/internal/outbox"
+)
+
+// JobDeliver Webhookの配信を送信するジョブ（失敗した場合はジョブの再試行で再送する）
+const JobDeliver = "webhook.deliver"
+
+type deliverPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// RegisterJobs Webhookのジョブハンドラーを登録
+func RegisterJobs(registry *job.Registry, usecase WebhookUsecase) {
+	registry.Handle(JobDeliver, func(ctx context.Context, j *job.Job) error {
+		var p deliverPayload
+		if err := json.Unmarshal(j.Payload, &p); err != nil {
+			return job.Permanent(fmt.Errorf("failed to decode payload of %s: %w", JobDeliver, err))
+		}
+
+		err := usecase.Deliver(ctx, p.DeliveryID, j.Attempts >= j.MaxAttempts)
+		if errors.Is(err, domain.ErrNotFound) {
+			return job.Permanent(err)
+		}
+		return err
+	})
+}
+
+// Sink ドメインイベントを会社のWebhookに配るアウトボックスの配信先
+type Sink struct {
+	usecase WebhookUsecase
+}
+
+// NewSink 配信先のコンストラクタ
+func NewSink(usecase WebhookUsecase) *Sink {
+	return &Sink{usecase: usecase}
+}
+
+func (s *Sink) Publish(ctx context.Context, msg outbox.Message) error {
+	return s.usecase.Dispatch(ctx, msg)
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookRepository GORM実装
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository Webhookリポジトリのコンストラクタ
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id uint) (*domain.Webhook, error) {
+	var w domain.Webhook
+
+	if err := infra.Conn(ctx, r.db).First(&w, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook with id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get webhook by id %d: %w", id, err)
+	}
+
+	return &w, nil
+}
+
+func (r *webhookRepository) GetByCompanyID(ctx context.Context, companyID uint) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+
+	if err := infra.Conn(ctx, r.db).Where("company_id = ?", companyID).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhooks by company id %d: %w", companyID, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) GetSubscribed(ctx context.Context, companyID uint, eventType string) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+
+	// eventsはJSONBの配列のため、包含（@>）で判定する
+	err := infra.Conn(ctx, r.db).
+		Where("company_id = ? AND active AND events @> ?", companyID, domain.WebhookEvents{eventType}).
+		Order("id").
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks subscribed to %s: %w", eventType, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Create(ctx context.Context, w *domain.Webhook) error {
+	if err := infra.Conn(ctx, r.db).Create(w).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, w *domain.Webhook) error {
+	result := infra.Conn(ctx, r.db).Model(w).Select("*").Omit("created_at", "created_by").Updates(w)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook with id %d %w", w.ID, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	result := infra.Conn(ctx, r.db).Delete(&domain.Webhook{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook with id %d %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// webhookDeliveryRepository GORM実装
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository Webhook配信履歴リポジトリのコンストラクタ
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, id uint) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+
+	if err := infra.Conn(ctx, r.db).First(&d, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook delivery with id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery by id %d: %w", id, err)
+	}
+
+	return &d, nil
+}
+
+func (r *webhookDeliveryRepository) GetPaginated(ctx context.Context, webhookID uint, offset, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+
+	err := infra.Conn(ctx, r.db).
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paginated webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) Count(ctx context.Context, webhookID uint) (int64, error) {
+	var count int64
+
+	if err := infra.Conn(ctx, r.db).Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhookID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	// アウトボックスの再配信で同じイベントを受け取った場合は既存の配信を使う
+	result := infra.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "redelivery_of IS NULL"}}},
+		DoNothing:   true,
+	}).Create(d)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	err := infra.Conn(ctx, r.db).
+		Where("webhook_id = ? AND event_id = ? AND redelivery_of IS NULL", d.WebhookID, d.EventID).
+		First(d).Error
+	if err != nil {
+		return fmt.Errorf("failed to get existing webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *webhookDeliveryRepository) UpdateResult(ctx context.Context, d *domain.WebhookDelivery) error {
+	err := infra.Conn(ctx, r.db).Model(d).
+		Select("Status", "Attempts", "ResponseStatus", "ResponseBody", "Error", "DurationMs", "DeliveredAt", "UpdatedAt").
+		Updates(d).Error
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery result: %w", err)
+	}
+
+	return nil
+}
+
+func (r *webhookDeliveryRepository) DeleteBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	result := infra.Conn(ctx, r.db).
+		Where("created_at <= ? AND status <> ?", createdBefore, domain.WebhookDeliveryPending).
+		Delete(&domain.WebhookDelivery{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
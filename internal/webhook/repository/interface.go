@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"km-api-go/internal/domain"
+)
+
+type WebhookRepository interface {
+	GetByID(ctx context.Context, id uint) (*domain.Webhook, error)
+	GetByCompanyID(ctx context.Context, companyID uint) ([]domain.Webhook, error)
+	// GetSubscribed 会社の有効なWebhookのうち、イベントの種類を購読しているものを取得
+	GetSubscribed(ctx context.Context, companyID uint, eventType string) ([]domain.Webhook, error)
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	Update(ctx context.Context, webhook *domain.Webhook) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// Webhook配信履歴リポジトリインターフェース
+type WebhookDeliveryRepository interface {
+	GetByID(ctx context.Context, id uint) (*domain.WebhookDelivery, error)
+	GetPaginated(ctx context.Context, webhookID uint, offset, limit int) ([]domain.WebhookDelivery, error)
+	Count(ctx context.Context, webhookID uint) (int64, error)
+	// Create 配信を作成する。同じWebhook・イベントの配信（再配信を除く）が既にある場合は作成せず、既存の配信をdeliveryに読み込む
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// UpdateResult 送信結果（状態・回数・応答・エラー・配信日時）を更新
+	UpdateResult(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// DeleteBefore createdBefore以前に作成され、終了した配信を削除
+	DeleteBefore(ctx context.Context, createdBefore time.Time) (int64, error)
+}
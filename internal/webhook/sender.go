@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"km-api-go/internal/domain"
+	"km-api-go/internal/outbox"
+)
+
+// Webhookのリクエストヘッダー（イベントID・種類・署名はアウトボックスのWebhookと同じ）
+const (
+	HeaderWebhookID  = "X-Webhook-ID"
+	HeaderDeliveryID = "X-Delivery-ID"
+)
+
+// 記録する応答ボディの上限
+const responseBodyLimit = 1024
+
+// ErrPrivateAddress 送信先がループバック・プライベートアドレスに解決された
+var ErrPrivateAddress = errors.New("webhook destination resolves to a private address")
+
+// Result 1回の送信結果
+type Result struct {
+	StatusCode int           // 応答のステータスコード（応答がない場合は0）
+	Body       string        // 応答のボディ（先頭のみ）
+	Duration   time.Duration // 送信にかかった時間
+}
+
+// OK 2xxの応答か
+func (r Result) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Sender 配信をWebhookのURLにPOSTする
+// 「タイムスタンプ.ボディ」のHMAC-SHA256を X-Signature ヘッダーに付与する。
+// シークレットのローテーション直後は以前のシークレットの署名もカンマ区切りで付与する
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender 送信のコンストラクタ
+func NewSender(cfg Config) *Sender {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = denyPrivateAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &Sender{
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			// リダイレクト先は署名の対象外のため追わない
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		now: time.Now,
+	}
+}
+
+// Send 配信を1回送信する。2xx以外の応答はエラーではなくResultで返す
+func (s *Sender) Send(ctx context.Context, w *domain.Webhook, d *domain.WebhookDelivery) (Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	now := s.now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signatures := make([]string, 0, 2)
+	for _, secret := range w.SigningSecrets(now) {
+		signatures = append(signatures, "sha256="+outbox.Sign([]byte(secret), timestamp, d.Payload))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "km-api-go-webhook/1.0")
+	req.Header.Set(HeaderWebhookID, strconv.FormatUint(uint64(w.ID), 10))
+	req.Header.Set(HeaderDeliveryID, strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(outbox.HeaderEventID, d.EventID)
+	req.Header.Set(outbox.HeaderEventType, d.EventType)
+	req.Header.Set(outbox.HeaderSignatureTimestamp, timestamp)
+	req.Header.Set(outbox.HeaderSignature, strings.Join(signatures, ","))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Result{Duration: time.Since(start)}, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseBodyLimit))
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return Result{
+		StatusCode: resp.StatusCode,
+		Body:       strings.ToValidUTF8(string(body), ""),
+		Duration:   time.Since(start),
+	}, nil
+}
+
+// denyPrivateAddress 接続先のIPアドレスを確認し、内部ネットワークへの送信（SSRF）を防ぐ
+// 名前解決後のアドレスで判定するため、DNSの応答を切り替えられても回避できない
+func denyPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+	return nil
+}
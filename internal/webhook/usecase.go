@@ -0,0 +1,481 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"km-api-go/internal/auth"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/tracing"
+	"km-api-go/internal/webhook/repository"
+)
+
+// ErrDeliveryInProgress 配信が終了していないため再配信できない
+var ErrDeliveryInProgress = errors.New("webhook delivery is in progress")
+
+// Payload Webhookで送信するボディ
+type Payload struct {
+	ID         string          `json:"id"`          // イベントID（受信側の重複排除に使う）
+	Type       string          `json:"type"`        // イベントの種類
+	CompanyID  uint            `json:"company_id"`  // 会社ID
+	Data       json.RawMessage `json:"data"`        // イベントの内容
+	OccurredAt time.Time       `json:"occurred_at"` // 発生日時
+}
+
+// WebhookUsecase 会社のWebhookに関するビジネスロジック
+// 登録・配信履歴の操作は会社の管理者（role=admin）またはAPIキーのみ行える
+type WebhookUsecase interface {
+	ListWebhooks(ctx context.Context, companyID uint) ([]domain.Webhook, error)
+	GetWebhook(ctx context.Context, companyID, id uint) (*domain.Webhook, error)
+	CreateWebhook(ctx context.Context, companyID uint, url string, events []string, description string, active bool) (*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, companyID, id uint, url string, events []string, description string, active bool) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, companyID, id uint) error
+	// RotateSecret 署名シークレットを新しくする（以前のシークレットでも猶予期間の間は署名する）
+	RotateSecret(ctx context.Context, companyID, id uint) (*domain.Webhook, error)
+	ListDeliveries(ctx context.Context, companyID, webhookID uint, page, limit int) ([]domain.WebhookDelivery, *helper.PaginationResponse, error)
+	// Redeliver 終了した配信と同じボディで新しい配信を作成する
+	Redeliver(ctx context.Context, companyID, webhookID, deliveryID uint) (*domain.WebhookDelivery, error)
+	// Dispatch ドメインイベントを購読しているWebhookごとに配信を作成し、送信ジョブを登録する
+	Dispatch(ctx context.Context, msg outbox.Message) error
+	// Deliver 配信を1回送信する。再試行する場合はエラーを返す（lastAttemptの場合は失敗として終了する）
+	Deliver(ctx context.Context, deliveryID uint, lastAttempt bool) error
+}
+
+type webhookUsecase struct {
+	webhookRepo     repository.WebhookRepository
+	deliveryRepo    repository.WebhookDeliveryRepository
+	companyRepo     companyRepo.CompanyRepository
+	companyUserRepo companyRepo.CompanyUserRepository
+	tx              infra.Transactor
+	jobs            job.Enqueuer
+	sender          *Sender
+	cfg             Config
+	now             func() time.Time
+}
+
+// NewWebhookUsecase Webhookユースケースのコンストラクタ
+func NewWebhookUsecase(
+	webhookRepository repository.WebhookRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+	companyRepository companyRepo.CompanyRepository,
+	companyUserRepository companyRepo.CompanyUserRepository,
+	tx infra.Transactor,
+	jobs job.Enqueuer,
+	cfg Config,
+) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo:     webhookRepository,
+		deliveryRepo:    deliveryRepository,
+		companyRepo:     companyRepository,
+		companyUserRepo: companyUserRepository,
+		tx:              tx,
+		jobs:            jobs,
+		sender:          NewSender(cfg),
+		cfg:             cfg,
+		now:             time.Now,
+	}
+}
+
+// authorize 呼び出し元が会社のWebhookを操作できるか確認
+func (uc *webhookUsecase) authorize(ctx context.Context, companyID uint) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrForbidden
+	}
+
+	if !principal.IsUser() {
+		exists, err := uc.companyRepo.Exists(ctx, companyID)
+		if err != nil {
+			return fmt.Errorf("failed to check company existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("company with id %d %w", companyID, domain.ErrNotFound)
+		}
+		return nil
+	}
+
+	relation, err := uc.companyUserRepo.GetRelation(ctx, principal.UserID, companyID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrForbidden
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get company relation: %w", err)
+	}
+	if !relation.IsAdmin() {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// getWebhook 会社のWebhookを取得（他の会社のWebhookはErrNotFound）
+func (uc *webhookUsecase) getWebhook(ctx context.Context, companyID, id uint) (*domain.Webhook, error) {
+	w, err := uc.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if w.CompanyID != companyID {
+		return nil, fmt.Errorf("webhook with id %d %w", id, domain.ErrNotFound)
+	}
+	return w, nil
+}
+
+func (uc *webhookUsecase) ListWebhooks(ctx context.Context, companyID uint) (_ []domain.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.ListWebhooks")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	return uc.webhookRepo.GetByCompanyID(ctx, companyID)
+}
+
+func (uc *webhookUsecase) GetWebhook(ctx context.Context, companyID, id uint) (_ *domain.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.GetWebhook")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	return uc.getWebhook(ctx, companyID, id)
+}
+
+func (uc *webhookUsecase) CreateWebhook(ctx context.Context, companyID uint, url string, events []string, description string, active bool) (_ *domain.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.CreateWebhook")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	w := &domain.Webhook{
+		CompanyID:   companyID,
+		URL:         url,
+		Events:      normalizeEvents(events),
+		Description: description,
+		Active:      active,
+		Secret:      secret,
+	}
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		w.CreatedBy = &userID
+	}
+
+	if err := uc.webhookRepo.Create(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "webhook created",
+		slog.Uint64("webhook_id", uint64(w.ID)),
+		slog.Uint64("company_id", uint64(companyID)),
+		slog.Any("events", w.Events),
+	)
+	return w, nil
+}
+
+func (uc *webhookUsecase) UpdateWebhook(ctx context.Context, companyID, id uint, url string, events []string, description string, active bool) (_ *domain.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.UpdateWebhook")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	w, err := uc.getWebhook(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	w.URL = url
+	w.Events = normalizeEvents(events)
+	w.Description = description
+	w.Active = active
+	if err := uc.webhookRepo.Update(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return w, nil
+}
+
+func (uc *webhookUsecase) DeleteWebhook(ctx context.Context, companyID, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.DeleteWebhook")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return err
+	}
+	if _, err := uc.getWebhook(ctx, companyID, id); err != nil {
+		return err
+	}
+
+	// 配信履歴は外部キーのON DELETE CASCADEで削除される
+	if err := uc.webhookRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+func (uc *webhookUsecase) RotateSecret(ctx context.Context, companyID, id uint) (_ *domain.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.RotateSecret")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	w, err := uc.getWebhook(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	expiresAt := now.Add(uc.cfg.SecretGracePeriod)
+	w.PreviousSecret = w.Secret
+	w.PreviousSecretExpiresAt = &expiresAt
+	w.Secret = secret
+	w.SecretRotatedAt = &now
+	if err := uc.webhookRepo.Update(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "webhook secret rotated",
+		slog.Uint64("webhook_id", uint64(w.ID)),
+		slog.Time("previous_secret_expires_at", expiresAt),
+	)
+	return w, nil
+}
+
+func (uc *webhookUsecase) ListDeliveries(ctx context.Context, companyID, webhookID uint, page, limit int) (_ []domain.WebhookDelivery, _ *helper.PaginationResponse, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.ListDeliveries")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, nil, err
+	}
+	if _, err := uc.getWebhook(ctx, companyID, webhookID); err != nil {
+		return nil, nil, err
+	}
+
+	paginationReq := &helper.PaginationRequest{Page: page, Limit: limit}
+	offset := paginationReq.GetOffset()
+	normalizedLimit := paginationReq.GetLimit()
+
+	total, err := uc.deliveryRepo.Count(ctx, webhookID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	deliveries, err := uc.deliveryRepo.GetPaginated(ctx, webhookID, offset, normalizedLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, helper.NewPaginationResponse(paginationReq.Page, normalizedLimit, total), nil
+}
+
+func (uc *webhookUsecase) Redeliver(ctx context.Context, companyID, webhookID, deliveryID uint) (_ *domain.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.Redeliver")
+	defer tracing.End(span, &err)
+
+	if err := uc.authorize(ctx, companyID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.getWebhook(ctx, companyID, webhookID); err != nil {
+		return nil, err
+	}
+	original, err := uc.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.WebhookID != webhookID {
+		return nil, fmt.Errorf("webhook delivery with id %d %w", deliveryID, domain.ErrNotFound)
+	}
+	if !original.IsFinished() {
+		return nil, ErrDeliveryInProgress
+	}
+
+	// 再配信の再配信も最初の配信を参照する
+	redeliveryOf := original.ID
+	if original.RedeliveryOf != nil {
+		redeliveryOf = *original.RedeliveryOf
+	}
+	d := &domain.WebhookDelivery{
+		WebhookID:    webhookID,
+		EventID:      original.EventID,
+		EventType:    original.EventType,
+		Payload:      original.Payload,
+		Status:       domain.WebhookDeliveryPending,
+		RedeliveryOf: &redeliveryOf,
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.deliveryRepo.Create(ctx, d); err != nil {
+			return err
+		}
+		return uc.enqueue(ctx, d.ID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+	return d, nil
+}
+
+func (uc *webhookUsecase) Dispatch(ctx context.Context, msg outbox.Message) (err error) {
+	if msg.AggregateType != domain.AggregateCompany || !slices.Contains(domain.WebhookEventTypes, msg.Type) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.Dispatch")
+	defer tracing.End(span, &err)
+
+	webhooks, err := uc.webhookRepo.GetSubscribed(ctx, msg.AggregateID, msg.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(Payload{
+		ID:         msg.EventID,
+		Type:       msg.Type,
+		CompanyID:  msg.AggregateID,
+		Data:       msg.Payload,
+		OccurredAt: msg.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, w := range webhooks {
+			d := &domain.WebhookDelivery{
+				WebhookID: w.ID,
+				EventID:   msg.EventID,
+				EventType: msg.Type,
+				Payload:   payload,
+				Status:    domain.WebhookDeliveryPending,
+			}
+			// イベントの再配信で既に作成済みの場合は既存の配信が読み込まれる
+			if err := uc.deliveryRepo.Create(ctx, d); err != nil {
+				return err
+			}
+			if d.IsFinished() {
+				continue
+			}
+			if err := uc.enqueue(ctx, d.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (uc *webhookUsecase) Deliver(ctx context.Context, deliveryID uint, lastAttempt bool) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.Deliver")
+	defer tracing.End(span, &err)
+
+	d, err := uc.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if d.IsFinished() {
+		return nil
+	}
+	w, err := uc.webhookRepo.GetByID(ctx, d.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	if !w.Active {
+		d.Status = domain.WebhookDeliveryFailed
+		d.Error = "webhook is disabled"
+		return uc.deliveryRepo.UpdateResult(ctx, d)
+	}
+
+	result, sendErr := uc.sender.Send(ctx, w, d)
+	d.Attempts++
+	d.DurationMs = result.Duration.Milliseconds()
+	d.ResponseBody = result.Body
+	d.ResponseStatus = nil
+	if result.StatusCode != 0 {
+		d.ResponseStatus = &result.StatusCode
+	}
+
+	switch {
+	case sendErr == nil && result.OK():
+		now := uc.now()
+		d.Status = domain.WebhookDeliverySucceeded
+		d.Error = ""
+		d.DeliveredAt = &now
+	case sendErr != nil:
+		d.Error = sendErr.Error()
+	default:
+		d.Error = fmt.Sprintf("webhook responded with status %d", result.StatusCode)
+	}
+	if d.Status == domain.WebhookDeliveryPending && lastAttempt {
+		d.Status = domain.WebhookDeliveryFailed
+	}
+
+	if err := uc.deliveryRepo.UpdateResult(ctx, d); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "webhook delivered",
+		slog.Uint64("delivery_id", uint64(d.ID)),
+		slog.Uint64("webhook_id", uint64(w.ID)),
+		slog.String("event_type", d.EventType),
+		slog.String("status", d.Status),
+		slog.Int("attempts", d.Attempts),
+		slog.Int("response_status", result.StatusCode),
+		slog.Int64("duration_ms", d.DurationMs),
+	)
+
+	if d.Status == domain.WebhookDeliveryPending {
+		return fmt.Errorf("webhook delivery %d failed: %s", d.ID, d.Error)
+	}
+	return nil
+}
+
+// enqueue 配信の送信ジョブを登録（登録済みの場合は何もしない）
+func (uc *webhookUsecase) enqueue(ctx context.Context, deliveryID uint) error {
+	_, err := uc.jobs.Enqueue(ctx, JobDeliver, deliverPayload{DeliveryID: deliveryID},
+		job.WithUniqueKey(fmt.Sprintf("%s:%d", JobDeliver, deliveryID)),
+		job.WithMaxAttempts(uc.cfg.MaxAttempts),
+	)
+	if err != nil && !errors.Is(err, job.ErrDuplicateJob) {
+		return fmt.Errorf("failed to enqueue webhook delivery %d: %w", deliveryID, err)
+	}
+	return nil
+}
+
+// normalizeEvents 購読するイベントの種類を並べ替えて重複を除く
+func normalizeEvents(events []string) domain.WebhookEvents {
+	normalized := slices.Clone(events)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// newSecret 署名シークレットを生成
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,416 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"km-api-go/internal/auth"
+	companyMocks "km-api-go/internal/company/repository/mocks"
+	"km-api-go/internal/domain"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/webhook/repository/mocks"
+)
+
+func testConfig() Config {
+	return Config{
+		Enabled:              true,
+		Timeout:              time.Second,
+		MaxAttempts:          3,
+		SecretGracePeriod:    time.Hour,
+		AllowPrivateNetworks: true,
+	}
+}
+
+// receiver テスト用のWebhook受信サーバー
+type receiver struct {
+	*httptest.Server
+	status  int
+	header  http.Header
+	body    []byte
+	request int
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.request++
+		r.header = req.Header.Clone()
+		r.body, _ = io.ReadAll(req.Body)
+		w.WriteHeader(r.status)
+		_, _ = w.Write([]byte("received"))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func TestWebhookUsecase_Deliver(t *testing.T) {
+	now := time.Unix(1757246400, 0)
+	previousExpiresAt := now.Add(time.Minute)
+
+	tests := []struct {
+		name           string
+		status         int
+		lastAttempt    bool
+		expectStatus   string
+		expectError    bool
+		expectDelivery bool
+	}{
+		{
+			name:           "正常系: 2xxで配信成功",
+			status:         http.StatusOK,
+			expectStatus:   domain.WebhookDeliverySucceeded,
+			expectDelivery: true,
+		},
+		{
+			name:         "異常系: 5xxは再試行",
+			status:       http.StatusInternalServerError,
+			expectStatus: domain.WebhookDeliveryPending,
+			expectError:  true,
+		},
+		{
+			name:         "異常系: 最後の送信で失敗すると終了",
+			status:       http.StatusGone,
+			lastAttempt:  true,
+			expectStatus: domain.WebhookDeliveryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			recv := newReceiver(t, tt.status)
+			mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+			mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+			uc := NewWebhookUsecase(mockWebhookRepo, mockDeliveryRepo, nil, nil, infra.NoTx, nil, testConfig()).(*webhookUsecase)
+			uc.now = func() time.Time { return now }
+			uc.sender.now = uc.now
+
+			w := &domain.Webhook{
+				ID:                      1,
+				CompanyID:               1,
+				URL:                     recv.URL,
+				Events:                  domain.WebhookEvents{domain.EventMemberAdded},
+				Active:                  true,
+				Secret:                  "new-secret",
+				PreviousSecret:          "old-secret",
+				PreviousSecretExpiresAt: &previousExpiresAt,
+			}
+			d := &domain.WebhookDelivery{
+				ID:        10,
+				WebhookID: 1,
+				EventID:   "0b6b0c1e-8f4a-4a43-9d1e-2b0c6f3e5a10",
+				EventType: domain.EventMemberAdded,
+				Payload:   json.RawMessage(`{"id":"0b6b0c1e-8f4a-4a43-9d1e-2b0c6f3e5a10","type":"company.member_added"}`),
+				Status:    domain.WebhookDeliveryPending,
+			}
+
+			mockDeliveryRepo.EXPECT().GetByID(gomock.Any(), uint(10)).Return(d, nil).Times(1)
+			mockWebhookRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(w, nil).Times(1)
+			mockDeliveryRepo.EXPECT().UpdateResult(gomock.Any(), d).Return(nil).Times(1)
+
+			err := uc.Deliver(context.Background(), 10, tt.lastAttempt)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectStatus, d.Status)
+			assert.Equal(t, 1, d.Attempts)
+			require.NotNil(t, d.ResponseStatus)
+			assert.Equal(t, tt.status, *d.ResponseStatus)
+			assert.Equal(t, "received", d.ResponseBody)
+			assert.Equal(t, tt.expectDelivery, d.DeliveredAt != nil)
+
+			// 受信側で検証できる署名（ローテーション前のシークレットの署名も含む）
+			require.Equal(t, 1, recv.request)
+			assert.JSONEq(t, string(d.Payload), string(recv.body))
+			assert.Equal(t, "10", recv.header.Get(HeaderDeliveryID))
+			assert.Equal(t, d.EventID, recv.header.Get(outbox.HeaderEventID))
+			assert.Equal(t, domain.EventMemberAdded, recv.header.Get(outbox.HeaderEventType))
+			assert.Equal(t, "1757246400", recv.header.Get(outbox.HeaderSignatureTimestamp))
+			assert.Equal(t,
+				"sha256="+outbox.Sign([]byte("new-secret"), "1757246400", recv.body)+","+
+					"sha256="+outbox.Sign([]byte("old-secret"), "1757246400", recv.body),
+				recv.header.Get(outbox.HeaderSignature))
+		})
+	}
+}
+
+func TestWebhookUsecase_Deliver_Inactive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recv := newReceiver(t, http.StatusOK)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	uc := NewWebhookUsecase(mockWebhookRepo, mockDeliveryRepo, nil, nil, infra.NoTx, nil, testConfig())
+
+	d := &domain.WebhookDelivery{ID: 10, WebhookID: 1, Status: domain.WebhookDeliveryPending}
+	mockDeliveryRepo.EXPECT().GetByID(gomock.Any(), uint(10)).Return(d, nil).Times(1)
+	mockWebhookRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&domain.Webhook{ID: 1, URL: recv.URL}, nil).Times(1)
+	mockDeliveryRepo.EXPECT().UpdateResult(gomock.Any(), d).Return(nil).Times(1)
+
+	err := uc.Deliver(context.Background(), 10, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryFailed, d.Status)
+	assert.Equal(t, 0, recv.request)
+}
+
+func TestWebhookUsecase_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	jobStore := job.NewMemoryStore()
+	jobs := job.NewClient(jobStore, job.Config{MaxAttempts: 5})
+	uc := NewWebhookUsecase(mockWebhookRepo, mockDeliveryRepo, nil, nil, infra.NoTx, jobs, testConfig())
+
+	msg := outbox.Message{
+		EventID:       "0b6b0c1e-8f4a-4a43-9d1e-2b0c6f3e5a10",
+		Type:          domain.EventMemberAdded,
+		AggregateType: domain.AggregateCompany,
+		AggregateID:   1,
+		Payload:       json.RawMessage(`{"company_id":1,"user_id":2,"role":"member"}`),
+		OccurredAt:    time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC),
+	}
+
+	mockWebhookRepo.EXPECT().GetSubscribed(gomock.Any(), uint(1), domain.EventMemberAdded).
+		Return([]domain.Webhook{{ID: 1, CompanyID: 1}}, nil).Times(2)
+	var created *domain.WebhookDelivery
+	mockDeliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *domain.WebhookDelivery) error {
+			d.ID = 10
+			created = d
+			return nil
+		}).Times(2)
+
+	require.NoError(t, uc.Dispatch(context.Background(), msg))
+	// アウトボックスの再配信で同じイベントを受け取っても送信ジョブは重複しない
+	require.NoError(t, uc.Dispatch(context.Background(), msg))
+
+	require.NotNil(t, created)
+	assert.Equal(t, domain.WebhookDeliveryPending, created.Status)
+	assert.JSONEq(t, `{
+		"id": "0b6b0c1e-8f4a-4a43-9d1e-2b0c6f3e5a10",
+		"type": "company.member_added",
+		"company_id": 1,
+		"data": {"company_id":1,"user_id":2,"role":"member"},
+		"occurred_at": "2025-09-07T12:00:00Z"
+	}`, string(created.Payload))
+
+	j, ok := jobStore.Get(1)
+	require.True(t, ok)
+	assert.Equal(t, JobDeliver, j.Type)
+	assert.Equal(t, 3, j.MaxAttempts)
+	assert.JSONEq(t, `{"delivery_id":10}`, string(j.Payload))
+	_, ok = jobStore.Get(2)
+	assert.False(t, ok)
+}
+
+func TestWebhookUsecase_Dispatch_Ignored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := NewWebhookUsecase(mockWebhookRepo, nil, nil, nil, infra.NoTx, nil, testConfig())
+
+	// 会社以外のイベント・購読できないイベントは配信しない
+	require.NoError(t, uc.Dispatch(context.Background(), outbox.Message{Type: domain.EventUserCreated, AggregateType: domain.AggregateUser}))
+	require.NoError(t, uc.Dispatch(context.Background(), outbox.Message{Type: domain.EventCompanyDeleted, AggregateType: domain.AggregateCompany}))
+}
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		principal   *auth.Principal
+		setupMocks  func(companyRepo *companyMocks.MockCompanyRepository, companyUserRepo *companyMocks.MockCompanyUserRepository, webhookRepo *mocks.MockWebhookRepository)
+		expectError error
+	}{
+		{
+			name:      "正常系: 会社の管理者",
+			principal: &auth.Principal{UserID: 1},
+			setupMocks: func(_ *companyMocks.MockCompanyRepository, companyUserRepo *companyMocks.MockCompanyUserRepository, webhookRepo *mocks.MockWebhookRepository) {
+				companyUserRepo.EXPECT().GetRelation(gomock.Any(), uint(1), uint(1)).
+					Return(&domain.CompanyUser{UserID: 1, CompanyID: 1, Role: "admin"}, nil).Times(1)
+				webhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:      "正常系: APIキー",
+			principal: &auth.Principal{APIKey: "batch"},
+			setupMocks: func(companyRepo *companyMocks.MockCompanyRepository, _ *companyMocks.MockCompanyUserRepository, webhookRepo *mocks.MockWebhookRepository) {
+				companyRepo.EXPECT().Exists(gomock.Any(), uint(1)).Return(true, nil).Times(1)
+				webhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:      "異常系: 管理者以外のメンバー",
+			principal: &auth.Principal{UserID: 2},
+			setupMocks: func(_ *companyMocks.MockCompanyRepository, companyUserRepo *companyMocks.MockCompanyUserRepository, _ *mocks.MockWebhookRepository) {
+				companyUserRepo.EXPECT().GetRelation(gomock.Any(), uint(2), uint(1)).
+					Return(&domain.CompanyUser{UserID: 2, CompanyID: 1, Role: "member"}, nil).Times(1)
+			},
+			expectError: domain.ErrForbidden,
+		},
+		{
+			name:      "異常系: 会社に所属していない",
+			principal: &auth.Principal{UserID: 3},
+			setupMocks: func(_ *companyMocks.MockCompanyRepository, companyUserRepo *companyMocks.MockCompanyUserRepository, _ *mocks.MockWebhookRepository) {
+				companyUserRepo.EXPECT().GetRelation(gomock.Any(), uint(3), uint(1)).Return(nil, domain.ErrNotFound).Times(1)
+			},
+			expectError: domain.ErrForbidden,
+		},
+		{
+			name:      "異常系: APIキーで存在しない会社",
+			principal: &auth.Principal{APIKey: "batch"},
+			setupMocks: func(companyRepo *companyMocks.MockCompanyRepository, _ *companyMocks.MockCompanyUserRepository, _ *mocks.MockWebhookRepository) {
+				companyRepo.EXPECT().Exists(gomock.Any(), uint(1)).Return(false, nil).Times(1)
+			},
+			expectError: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCompanyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+			mockCompanyUserRepo := companyMocks.NewMockCompanyUserRepository(ctrl)
+			mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+			tt.setupMocks(mockCompanyRepo, mockCompanyUserRepo, mockWebhookRepo)
+			uc := NewWebhookUsecase(mockWebhookRepo, nil, mockCompanyRepo, mockCompanyUserRepo, infra.NoTx, nil, testConfig())
+
+			ctx := auth.WithPrincipal(context.Background(), tt.principal)
+			w, err := uc.CreateWebhook(ctx, 1, "https://example.com/hooks",
+				[]string{domain.EventMemberRemoved, domain.EventMemberAdded, domain.EventMemberAdded}, "", true)
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domain.WebhookEvents{domain.EventMemberAdded, domain.EventMemberRemoved}, w.Events)
+			assert.True(t, strings.HasPrefix(w.Secret, "whsec_"))
+			assert.Equal(t, tt.principal.UserID != 0, w.CreatedBy != nil)
+		})
+	}
+}
+
+func TestWebhookUsecase_RotateSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC)
+	mockCompanyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := NewWebhookUsecase(mockWebhookRepo, nil, mockCompanyRepo, nil, infra.NoTx, nil, testConfig()).(*webhookUsecase)
+	uc.now = func() time.Time { return now }
+
+	mockCompanyRepo.EXPECT().Exists(gomock.Any(), uint(1)).Return(true, nil).Times(1)
+	mockWebhookRepo.EXPECT().GetByID(gomock.Any(), uint(5)).
+		Return(&domain.Webhook{ID: 5, CompanyID: 1, Secret: "old-secret"}, nil).Times(1)
+	mockWebhookRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+	w, err := uc.RotateSecret(ctx, 1, 5)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, "old-secret", w.Secret)
+	assert.Equal(t, "old-secret", w.PreviousSecret)
+	assert.Equal(t, []string{w.Secret, "old-secret"}, w.SigningSecrets(now))
+	assert.Equal(t, []string{w.Secret}, w.SigningSecrets(now.Add(time.Hour)))
+}
+
+func TestWebhookUsecase_Redeliver(t *testing.T) {
+	tests := []struct {
+		name        string
+		original    *domain.WebhookDelivery
+		expectError error
+	}{
+		{
+			name:     "正常系: 失敗した配信を再配信",
+			original: &domain.WebhookDelivery{ID: 10, WebhookID: 5, EventID: "e1", Status: domain.WebhookDeliveryFailed},
+		},
+		{
+			name:        "異常系: 配信中",
+			original:    &domain.WebhookDelivery{ID: 10, WebhookID: 5, EventID: "e1", Status: domain.WebhookDeliveryPending},
+			expectError: ErrDeliveryInProgress,
+		},
+		{
+			name:        "異常系: 他のWebhookの配信",
+			original:    &domain.WebhookDelivery{ID: 10, WebhookID: 6, EventID: "e1", Status: domain.WebhookDeliveryFailed},
+			expectError: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCompanyRepo := companyMocks.NewMockCompanyRepository(ctrl)
+			mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+			mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+			jobStore := job.NewMemoryStore()
+			uc := NewWebhookUsecase(mockWebhookRepo, mockDeliveryRepo, mockCompanyRepo, nil, infra.NoTx,
+				job.NewClient(jobStore, job.Config{}), testConfig())
+
+			mockCompanyRepo.EXPECT().Exists(gomock.Any(), uint(1)).Return(true, nil).Times(1)
+			mockWebhookRepo.EXPECT().GetByID(gomock.Any(), uint(5)).Return(&domain.Webhook{ID: 5, CompanyID: 1}, nil).Times(1)
+			mockDeliveryRepo.EXPECT().GetByID(gomock.Any(), uint(10)).Return(tt.original, nil).Times(1)
+			if tt.expectError == nil {
+				mockDeliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *domain.WebhookDelivery) error {
+						d.ID = 11
+						return nil
+					}).Times(1)
+			}
+
+			ctx := auth.WithPrincipal(context.Background(), &auth.Principal{APIKey: "batch"})
+			d, err := uc.Redeliver(ctx, 1, 5, 10)
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domain.WebhookDeliveryPending, d.Status)
+			require.NotNil(t, d.RedeliveryOf)
+			assert.Equal(t, uint(10), *d.RedeliveryOf)
+			j, ok := jobStore.Get(1)
+			require.True(t, ok)
+			assert.JSONEq(t, `{"delivery_id":11}`, string(j.Payload))
+		})
+	}
+}
+
+func TestSender_DenyPrivateAddress(t *testing.T) {
+	recv := newReceiver(t, http.StatusOK)
+	cfg := testConfig()
+	cfg.AllowPrivateNetworks = false
+
+	_, err := NewSender(cfg).Send(context.Background(),
+		&domain.Webhook{ID: 1, URL: recv.URL, Secret: "secret"},
+		&domain.WebhookDelivery{ID: 1, Payload: json.RawMessage(`{}`)})
+
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+	assert.Equal(t, 0, recv.request)
+}
@@ -0,0 +1,17 @@
+-- Rate limit buckets テーブル作成（複数インスタンス間で共有するトークンバケット）
+CREATE TABLE rate_limit_buckets (
+    key VARCHAR(255) PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- インデックス作成（古いバケットの削除用）
+CREATE INDEX idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
+
+-- テーブルコメント
+COMMENT ON TABLE rate_limit_buckets IS 'レート制限トークンバケットテーブル';
+COMMENT ON COLUMN rate_limit_buckets.key IS 'バケットキー（ポリシー名と呼び出し元）';
+COMMENT ON COLUMN rate_limit_buckets.tokens IS '残りトークン数';
+COMMENT ON COLUMN rate_limit_buckets.allowed IS '直近のリクエストを許可したか';
+COMMENT ON COLUMN rate_limit_buckets.updated_at IS '最終更新日時';
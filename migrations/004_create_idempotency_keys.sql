@@ -0,0 +1,25 @@
+-- Idempotency keys テーブル作成（POSTリクエストの再試行で最初のレスポンスを返す）
+CREATE TABLE idempotency_keys (
+    key VARCHAR(512) PRIMARY KEY,
+    fingerprint VARCHAR(64) NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- インデックス作成（期限切れの記録の削除用）
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- テーブルコメント
+COMMENT ON TABLE idempotency_keys IS 'Idempotency-Keyテーブル';
+COMMENT ON COLUMN idempotency_keys.key IS 'キー（呼び出し元とIdempotency-Keyヘッダー）';
+COMMENT ON COLUMN idempotency_keys.fingerprint IS 'リクエストの指紋（SHA-256）';
+COMMENT ON COLUMN idempotency_keys.completed IS 'レスポンス保存済みか（falseは処理中）';
+COMMENT ON COLUMN idempotency_keys.status_code IS '保存したレスポンスのステータスコード';
+COMMENT ON COLUMN idempotency_keys.content_type IS '保存したレスポンスのContent-Type';
+COMMENT ON COLUMN idempotency_keys.body IS '保存したレスポンスボディ';
+COMMENT ON COLUMN idempotency_keys.created_at IS '作成日時';
+COMMENT ON COLUMN idempotency_keys.expires_at IS '有効期限';
@@ -0,0 +1,7 @@
+-- 楽観的排他制御用のバージョン列を追加（更新ごとに1ずつ増える）
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE companies ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- カラムコメント
+COMMENT ON COLUMN users.version IS 'バージョン（ETag・If-Matchによる楽観的排他制御用）';
+COMMENT ON COLUMN companies.version IS 'バージョン（ETag・If-Matchによる楽観的排他制御用）';
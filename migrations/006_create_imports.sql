@@ -0,0 +1,46 @@
+-- Imports テーブル作成（ユーザー・会社・所属の一括インポート）
+CREATE TABLE imports (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    updated_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    payload BYTEA,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- インデックス作成
+CREATE INDEX idx_imports_status ON imports(status);
+CREATE INDEX idx_imports_created_by ON imports(created_by);
+
+-- updated_at 自動更新トリガー
+CREATE TRIGGER update_imports_updated_at
+    BEFORE UPDATE ON imports
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- テーブルコメント
+COMMENT ON TABLE imports IS '一括インポートテーブル';
+COMMENT ON COLUMN imports.id IS 'インポートID（主キー）';
+COMMENT ON COLUMN imports.kind IS '対象（users, companies, memberships）';
+COMMENT ON COLUMN imports.format IS '形式（csv, jsonl）';
+COMMENT ON COLUMN imports.status IS '状態（pending, processing, completed, failed）';
+COMMENT ON COLUMN imports.total_rows IS '総行数';
+COMMENT ON COLUMN imports.processed_rows IS '処理済み行数';
+COMMENT ON COLUMN imports.created_rows IS '作成した行数';
+COMMENT ON COLUMN imports.updated_rows IS '更新した行数';
+COMMENT ON COLUMN imports.failed_rows IS '失敗した行数';
+COMMENT ON COLUMN imports.errors IS '行ごとのエラー';
+COMMENT ON COLUMN imports.payload IS 'アップロードされたファイル';
+COMMENT ON COLUMN imports.created_by IS '作成したユーザーID（外部キー）';
+COMMENT ON COLUMN imports.created_at IS '作成日時';
+COMMENT ON COLUMN imports.updated_at IS '更新日時';
+COMMENT ON COLUMN imports.completed_at IS '完了日時';
@@ -0,0 +1,46 @@
+-- Jobs テーブル作成（バックグラウンドジョブのキュー）
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    queue VARCHAR(100) NOT NULL DEFAULT 'default',
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    unique_key VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_at TIMESTAMP WITH TIME ZONE,
+    locked_by VARCHAR(255) NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- インデックス作成
+-- 実行待ちのジョブの取得用
+CREATE INDEX idx_jobs_pending ON jobs(queue, run_at) WHERE status = 'pending';
+-- 放置された実行中ジョブの回収用
+CREATE INDEX idx_jobs_running ON jobs(locked_at) WHERE status = 'running';
+-- 完了したジョブの削除用
+CREATE INDEX idx_jobs_completed_at ON jobs(completed_at) WHERE status = 'completed';
+-- 重複登録の防止（定期実行など）
+CREATE UNIQUE INDEX idx_jobs_unique_key ON jobs(unique_key) WHERE unique_key IS NOT NULL;
+
+-- テーブルコメント
+COMMENT ON TABLE jobs IS 'バックグラウンドジョブテーブル';
+COMMENT ON COLUMN jobs.id IS 'ジョブID（主キー）';
+COMMENT ON COLUMN jobs.queue IS 'キュー名';
+COMMENT ON COLUMN jobs.type IS 'ジョブの種類';
+COMMENT ON COLUMN jobs.payload IS 'ペイロード';
+COMMENT ON COLUMN jobs.unique_key IS '重複登録防止のキー';
+COMMENT ON COLUMN jobs.status IS '状態（pending, running, completed, dead）';
+COMMENT ON COLUMN jobs.attempts IS '実行した回数';
+COMMENT ON COLUMN jobs.max_attempts IS '実行回数の上限';
+COMMENT ON COLUMN jobs.run_at IS '実行予定日時';
+COMMENT ON COLUMN jobs.locked_at IS '実行を開始した日時';
+COMMENT ON COLUMN jobs.locked_by IS '実行中のワーカーID';
+COMMENT ON COLUMN jobs.last_error IS '最後の失敗理由';
+COMMENT ON COLUMN jobs.created_at IS '作成日時';
+COMMENT ON COLUMN jobs.updated_at IS '更新日時';
+COMMENT ON COLUMN jobs.completed_at IS '完了日時';
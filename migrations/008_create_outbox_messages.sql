@@ -0,0 +1,38 @@
+-- Outbox Messages テーブル作成（トランザクショナルアウトボックス）
+CREATE TABLE outbox_messages (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL UNIQUE,
+    type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id BIGINT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+-- インデックス作成
+-- 未配信のメッセージの取得用
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages(next_attempt_at, id) WHERE published_at IS NULL;
+-- 配信済みのメッセージの削除用
+CREATE INDEX idx_outbox_messages_published_at ON outbox_messages(published_at) WHERE published_at IS NOT NULL;
+-- 対象ごとのイベント履歴の参照用
+CREATE INDEX idx_outbox_messages_aggregate ON outbox_messages(aggregate_type, aggregate_id);
+
+-- テーブルコメント
+COMMENT ON TABLE outbox_messages IS 'ドメインイベントのアウトボックステーブル';
+COMMENT ON COLUMN outbox_messages.id IS 'メッセージID（主キー、配信順序）';
+COMMENT ON COLUMN outbox_messages.event_id IS 'イベントID（UUID）';
+COMMENT ON COLUMN outbox_messages.type IS 'イベントの種類';
+COMMENT ON COLUMN outbox_messages.aggregate_type IS '対象の種類（user, company）';
+COMMENT ON COLUMN outbox_messages.aggregate_id IS '対象のID';
+COMMENT ON COLUMN outbox_messages.payload IS 'イベントの内容';
+COMMENT ON COLUMN outbox_messages.request_id IS '発生元のリクエストID';
+COMMENT ON COLUMN outbox_messages.occurred_at IS '発生日時';
+COMMENT ON COLUMN outbox_messages.attempts IS '配信に失敗した回数';
+COMMENT ON COLUMN outbox_messages.last_error IS '最後の配信失敗理由';
+COMMENT ON COLUMN outbox_messages.next_attempt_at IS '次の配信予定日時';
+COMMENT ON COLUMN outbox_messages.published_at IS '配信日時';
@@ -0,0 +1,88 @@
+-- Webhooks テーブル作成（会社ごとのWebhookの配信先）
+CREATE TABLE webhooks (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    url VARCHAR(2048) NOT NULL,
+    events JSONB NOT NULL DEFAULT '[]',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    secret VARCHAR(100) NOT NULL,
+    previous_secret VARCHAR(100) NOT NULL DEFAULT '',
+    previous_secret_expires_at TIMESTAMP WITH TIME ZONE,
+    secret_rotated_at TIMESTAMP WITH TIME ZONE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Webhook Deliveries テーブル作成（Webhookの配信履歴）
+CREATE TABLE webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    response_body TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    redelivery_of BIGINT REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+-- インデックス作成
+CREATE INDEX idx_webhooks_company_id ON webhooks(company_id);
+-- 配信履歴の一覧用
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, id DESC);
+-- 終了した配信履歴の削除用
+CREATE INDEX idx_webhook_deliveries_created_at ON webhook_deliveries(created_at) WHERE status <> 'pending';
+-- アウトボックスの再配信で同じイベントの配信を重複して作成しない（再配信を除く）
+CREATE UNIQUE INDEX idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id) WHERE redelivery_of IS NULL;
+
+-- updated_at 自動更新トリガー
+CREATE TRIGGER update_webhooks_updated_at
+    BEFORE UPDATE ON webhooks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_webhook_deliveries_updated_at
+    BEFORE UPDATE ON webhook_deliveries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- テーブルコメント
+COMMENT ON TABLE webhooks IS '会社のWebhookテーブル';
+COMMENT ON COLUMN webhooks.id IS 'WebhookID（主キー）';
+COMMENT ON COLUMN webhooks.company_id IS '会社ID';
+COMMENT ON COLUMN webhooks.url IS '配信先URL';
+COMMENT ON COLUMN webhooks.events IS '購読するイベントの種類';
+COMMENT ON COLUMN webhooks.description IS '説明';
+COMMENT ON COLUMN webhooks.active IS '配信を有効にするか';
+COMMENT ON COLUMN webhooks.secret IS '署名シークレット';
+COMMENT ON COLUMN webhooks.previous_secret IS 'ローテーション前の署名シークレット';
+COMMENT ON COLUMN webhooks.previous_secret_expires_at IS 'ローテーション前のシークレットで署名する期限';
+COMMENT ON COLUMN webhooks.secret_rotated_at IS 'シークレットのローテーション日時';
+COMMENT ON COLUMN webhooks.created_by IS '作成したユーザーID';
+COMMENT ON COLUMN webhooks.created_at IS '作成日時';
+COMMENT ON COLUMN webhooks.updated_at IS '更新日時';
+
+COMMENT ON TABLE webhook_deliveries IS 'Webhookの配信履歴テーブル';
+COMMENT ON COLUMN webhook_deliveries.id IS '配信ID（主キー）';
+COMMENT ON COLUMN webhook_deliveries.webhook_id IS 'WebhookID';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'イベントID';
+COMMENT ON COLUMN webhook_deliveries.event_type IS 'イベントの種類';
+COMMENT ON COLUMN webhook_deliveries.payload IS '送信するボディ';
+COMMENT ON COLUMN webhook_deliveries.status IS '状態（pending, succeeded, failed）';
+COMMENT ON COLUMN webhook_deliveries.attempts IS '送信した回数';
+COMMENT ON COLUMN webhook_deliveries.response_status IS '最後の応答のステータスコード';
+COMMENT ON COLUMN webhook_deliveries.response_body IS '最後の応答のボディ（先頭のみ）';
+COMMENT ON COLUMN webhook_deliveries.error IS '最後の失敗理由';
+COMMENT ON COLUMN webhook_deliveries.duration_ms IS '最後の送信にかかった時間（ミリ秒）';
+COMMENT ON COLUMN webhook_deliveries.redelivery_of IS '再配信元の配信ID';
+COMMENT ON COLUMN webhook_deliveries.created_at IS '作成日時';
+COMMENT ON COLUMN webhook_deliveries.updated_at IS '更新日時';
+COMMENT ON COLUMN webhook_deliveries.delivered_at IS '配信に成功した日時';
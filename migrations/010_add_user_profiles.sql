@@ -0,0 +1,19 @@
+-- ユーザーのプロフィールとアバター画像の列を追加
+ALTER TABLE users ADD COLUMN display_name VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN kana_name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN bio TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN avatar_key VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN avatar_type VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN avatar_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- カラムコメント
+COMMENT ON COLUMN users.display_name IS '表示名';
+COMMENT ON COLUMN users.kana_name IS '氏名のふりがな（カタカナ・ひらがな）';
+COMMENT ON COLUMN users.locale IS 'ロケール（BCP 47の言語タグ）';
+COMMENT ON COLUMN users.timezone IS 'タイムゾーン（IANAのタイムゾーン名）';
+COMMENT ON COLUMN users.bio IS '自己紹介';
+COMMENT ON COLUMN users.avatar_key IS 'アバター画像の保存先のキーのプレフィックス（未設定は空文字）';
+COMMENT ON COLUMN users.avatar_type IS 'アバター画像のContent-Type';
+COMMENT ON COLUMN users.avatar_updated_at IS 'アバター画像の更新日時';
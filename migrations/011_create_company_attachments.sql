@@ -0,0 +1,34 @@
+-- Company Attachments テーブル作成（会社の添付ファイル・ロゴのメタデータ。ファイル本体はストレージに保存する）
+CREATE TABLE company_attachments (
+    id BIGSERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL DEFAULT 'document',
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    storage_key VARCHAR(1024) NOT NULL,
+    scan_status VARCHAR(20) NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- インデックス作成
+-- 一覧・使用量の集計用
+CREATE INDEX idx_company_attachments_company_id ON company_attachments(company_id, id DESC);
+-- ロゴは会社ごとに1つ
+CREATE UNIQUE INDEX idx_company_attachments_logo ON company_attachments(company_id) WHERE kind = 'logo';
+
+-- テーブルコメント
+COMMENT ON TABLE company_attachments IS '会社の添付ファイルテーブル';
+COMMENT ON COLUMN company_attachments.id IS '添付ファイルID（主キー）';
+COMMENT ON COLUMN company_attachments.company_id IS '会社ID';
+COMMENT ON COLUMN company_attachments.kind IS '種類（document, logo）';
+COMMENT ON COLUMN company_attachments.filename IS 'アップロード時のファイル名';
+COMMENT ON COLUMN company_attachments.content_type IS '内容から判定したContent-Type';
+COMMENT ON COLUMN company_attachments.size IS 'サイズ（バイト）';
+COMMENT ON COLUMN company_attachments.checksum IS 'SHA-256（16進数）';
+COMMENT ON COLUMN company_attachments.storage_key IS 'ストレージのキー';
+COMMENT ON COLUMN company_attachments.scan_status IS 'ウイルススキャンの結果（clean, skipped）';
+COMMENT ON COLUMN company_attachments.uploaded_by IS 'アップロードしたユーザーID（APIキーの場合はNULL）';
+COMMENT ON COLUMN company_attachments.created_at IS 'アップロード日時';
@@ -0,0 +1,88 @@
+-- Departments テーブル作成（会社ごとの部署の木構造）
+CREATE TABLE departments (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES departments(id) ON DELETE RESTRICT,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Department Paths テーブル作成（閉包テーブル。祖先・子孫のすべての組と距離を持ち、自分自身との組は距離0）
+CREATE TABLE department_paths (
+    ancestor_id INTEGER NOT NULL REFERENCES departments(id) ON DELETE CASCADE,
+    descendant_id INTEGER NOT NULL REFERENCES departments(id) ON DELETE CASCADE,
+    depth INTEGER NOT NULL,
+    PRIMARY KEY (ancestor_id, descendant_id)
+);
+
+-- Department Members テーブル作成（部署の所属。1人が複数の部署に所属できる）
+CREATE TABLE department_members (
+    id SERIAL PRIMARY KEY,
+    department_id INTEGER NOT NULL REFERENCES departments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(department_id, user_id)
+);
+
+-- インデックス作成
+CREATE INDEX idx_departments_company_id ON departments(company_id);
+CREATE INDEX idx_departments_parent_id ON departments(parent_id);
+-- 同じ親の下で部署名は重複しない（最上位の部署はparent_idがNULLのため0として扱う）
+CREATE UNIQUE INDEX idx_departments_sibling_name ON departments(company_id, COALESCE(parent_id, 0), name);
+-- 祖先の一覧（部署の移動・パンくず）用
+CREATE INDEX idx_department_paths_descendant_id ON department_paths(descendant_id);
+CREATE INDEX idx_department_members_user_id ON department_members(user_id);
+
+-- updated_at 自動更新トリガー
+CREATE TRIGGER update_departments_updated_at
+    BEFORE UPDATE ON departments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_department_members_updated_at
+    BEFORE UPDATE ON department_members
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 会社から外れたユーザーはその会社の部署からも外す
+CREATE OR REPLACE FUNCTION remove_department_members_on_company_leave()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM department_members m
+    USING departments d
+    WHERE m.department_id = d.id AND d.company_id = OLD.company_id AND m.user_id = OLD.user_id;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER remove_department_members_on_company_leave
+    AFTER DELETE ON company_users
+    FOR EACH ROW
+    EXECUTE FUNCTION remove_department_members_on_company_leave();
+
+-- テーブルコメント
+COMMENT ON TABLE departments IS '部署テーブル';
+COMMENT ON COLUMN departments.id IS '部署ID（主キー）';
+COMMENT ON COLUMN departments.company_id IS '会社ID';
+COMMENT ON COLUMN departments.parent_id IS '親部署ID（最上位の部署はNULL）';
+COMMENT ON COLUMN departments.name IS '部署名';
+COMMENT ON COLUMN departments.description IS '説明';
+COMMENT ON COLUMN departments.created_at IS '作成日時';
+COMMENT ON COLUMN departments.updated_at IS '更新日時';
+
+COMMENT ON TABLE department_paths IS '部署の閉包テーブル（祖先・子孫の組）';
+COMMENT ON COLUMN department_paths.ancestor_id IS '祖先の部署ID';
+COMMENT ON COLUMN department_paths.descendant_id IS '子孫の部署ID';
+COMMENT ON COLUMN department_paths.depth IS '祖先から子孫までの距離（自分自身は0）';
+
+COMMENT ON TABLE department_members IS '部署の所属テーブル';
+COMMENT ON COLUMN department_members.id IS '所属ID（主キー）';
+COMMENT ON COLUMN department_members.department_id IS '部署ID';
+COMMENT ON COLUMN department_members.user_id IS 'ユーザーID';
+COMMENT ON COLUMN department_members.role IS '部署での役割（manager, member）';
+COMMENT ON COLUMN department_members.created_at IS '所属した日時';
+COMMENT ON COLUMN department_members.updated_at IS '更新日時';
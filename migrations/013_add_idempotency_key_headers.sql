@@ -0,0 +1,5 @@
+-- 再試行のレスポンスに付けるヘッダー（ETag・Location）の列を追加
+ALTER TABLE idempotency_keys ADD COLUMN headers JSONB NOT NULL DEFAULT '{}';
+
+-- カラムコメント
+COMMENT ON COLUMN idempotency_keys.headers IS '保存したレスポンスヘッダー（ETag・Location）';
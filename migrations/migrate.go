@@ -1,15 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"io/fs"
-	"log"
+	"log/slog"
 	"os"
-	"path/filepath"
-	"sort"
-	"strings"
 
 	"km-api-go/internal/infra"
+	"km-api-go/internal/logging"
 
 	"github.com/joho/godotenv"
 	"gorm.io/gorm"
@@ -17,71 +15,63 @@ import (
 
 func main() {
 	// .envファイル読み込み
-	if err := godotenv.Overload(); err != nil {
-		log.Println("No .env file found, using system environment variables")
+	envErr := godotenv.Overload()
+
+	logger := logging.Init()
+	if envErr != nil {
+		logger.Info("No .env file found, using system environment variables")
 	}
 
 	// データベース接続
 	db, err := infra.InitDatabase()
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		logger.Error("Failed to connect to database", slog.Any("error", err))
+		os.Exit(1)
 	}
-	defer func() {
-		if err := infra.CloseDatabase(db); err != nil {
-			log.Printf("Failed to close database: %v", err)
-		}
-	}()
 
 	// マイグレーション実行
 	if err := runMigrations(db); err != nil {
-		log.Fatalf("Migration failed: %v", err)
+		logger.Error("Migration failed", slog.Any("error", err))
+		_ = infra.CloseDatabase(db)
+		os.Exit(1)
+	}
+
+	if err := infra.CloseDatabase(db); err != nil {
+		logger.Error("Failed to close database", slog.Any("error", err))
 	}
 
-	log.Println("✅ All migrations completed successfully!")
+	logger.Info("✅ All migrations completed successfully!")
 }
 
-func runMigrations(db interface{}) error {
-	migrationsDir := "migrations"
-	
-	// マイグレーションファイルを取得
-	var migrationFiles []string
-	err := filepath.WalkDir(migrationsDir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if !d.IsDir() && strings.HasSuffix(path, ".sql") {
-			migrationFiles = append(migrationFiles, path)
-		}
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
+func runMigrations(db *gorm.DB) error {
+	ctx := context.Background()
+
+	if err := infra.EnsureMigrationTable(ctx, db); err != nil {
+		return err
 	}
 
-	// ファイル名でソート
-	sort.Strings(migrationFiles)
+	// 未適用のマイグレーションファイルを取得（ファイル名順）
+	pending, err := infra.PendingMigrations(ctx, db, infra.MigrationsDir())
+	if err != nil {
+		return err
+	}
 
-	log.Printf("Found %d migration files", len(migrationFiles))
+	slog.Info("Found pending migrations", slog.Int("count", len(pending)))
 
 	// 各マイグレーションファイルを実行
-	gormDB, ok := db.(*gorm.DB)
-	if !ok {
-		return fmt.Errorf("db is not a *gorm.DB")
-	}
-
-	for _, file := range migrationFiles {
-		log.Printf("Running migration: %s", file)
+	for _, m := range pending {
+		slog.Info("Running migration", slog.String("file", m.Path))
 
-		content, err := os.ReadFile(file)
+		content, err := os.ReadFile(m.Path)
 		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %w", file, err)
+			return fmt.Errorf("failed to read migration file %s: %w", m.Path, err)
 		}
 
-		// SQLを実行
-		if err := gormDB.Exec(string(content)).Error; err != nil {
-			return fmt.Errorf("failed to execute migration file %s: %w", file, err)
+		// SQLの実行と適用記録を同一トランザクションで行う
+		if err := infra.ApplyMigration(ctx, db, string(content), m); err != nil {
+			return err
 		}
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,1114 @@
+// Code generated by cmd/openapi from swagger/src/swagger.json. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// BasePath APIのベースパス
+const BasePath = "/api/v1"
+
+// APIError APIエラーの詳細情報
+type APIError struct {
+	Code    ErrorCode `json:"code,omitempty"`    // エラーコード
+	Details string    `json:"details,omitempty"` // エラー詳細
+	Message string    `json:"message,omitempty"` // エラーメッセージ
+}
+
+// AssignMemberRequest department.AssignMemberRequest
+type AssignMemberRequest struct {
+	Role string `json:"role"` // 部署での役割（manager, member）
+}
+
+// AvatarResponse user.AvatarResponse
+type AvatarResponse struct {
+	ExpiresAt time.Time `json:"expires_at,omitzero"` // URLの有効期限
+	Large     string    `json:"large,omitempty"`     // 256x256
+	Medium    string    `json:"medium,omitempty"`    // 128x128
+	Original  string    `json:"original,omitempty"`  // 元画像（最大1024px）
+	Small     string    `json:"small,omitempty"`     // 64x64
+	UpdatedAt time.Time `json:"updated_at,omitzero"` // 画像の更新日時
+}
+
+// BatchOperation batch.BatchOperation
+type BatchOperation struct {
+	Body    any               `json:"body,omitempty"`    // リクエストボディ（JSON。JSON Patchの場合は配列）
+	Headers map[string]string `json:"headers,omitempty"` // 追加のヘッダー（If-Match等）
+	Method  string            `json:"method"`            // HTTPメソッド
+	Path    string            `json:"path"`              // APIのベースパスからのパス（クエリを含められる）
+}
+
+// BatchRequest batch.BatchRequest
+type BatchRequest struct {
+	Atomic     *bool            `json:"atomic,omitempty"` // trueの場合、いずれかの操作が失敗するとすべて取り消す
+	Operations []BatchOperation `json:"operations"`       // 先頭から順に実行する操作
+}
+
+// BatchResponse batch.BatchResponse
+type BatchResponse struct {
+	Atomic     bool              `json:"atomic,omitempty"`      // すべてか無しかで実行したか
+	Results    []OperationResult `json:"results,omitempty"`     // 操作ごとの結果（リクエストと同じ順）
+	RolledBack bool              `json:"rolled_back,omitempty"` // 失敗した操作があり、すべて取り消したか
+}
+
+// CheckResult health.CheckResult
+type CheckResult struct {
+	Error     string  `json:"error,omitempty"`      // 失敗理由
+	LatencyMs float64 `json:"latency_ms,omitempty"` // 所要時間（ミリ秒）
+	Name      string  `json:"name,omitempty"`       // チェック名
+	Status    string  `json:"status,omitempty"`     // ok, fail
+}
+
+// CompanyAttachment 会社の添付ファイル
+type CompanyAttachment struct {
+	Checksum    string `json:"checksum,omitempty"`     // SHA-256（16進数）
+	CompanyID   int64  `json:"company_id,omitempty"`   // 会社ID
+	ContentType string `json:"content_type,omitempty"` // 内容から判定したContent-Type
+	CreatedAt   string `json:"created_at,omitempty"`   // アップロード日時
+	Filename    string `json:"filename,omitempty"`     // アップロード時のファイル名
+	ID          int64  `json:"id,omitempty"`           // 添付ファイルID
+	Kind        string `json:"kind,omitempty"`         // 種類（document, logo）
+	ScanStatus  string `json:"scan_status,omitempty"`  // ウイルススキャンの結果（clean, skipped）
+	Size        int64  `json:"size,omitempty"`         // サイズ（バイト）
+	UploadedBy  int64  `json:"uploaded_by,omitempty"`  // アップロードしたユーザーID（APIキーの場合は空）
+}
+
+// CompanyResponse company.CompanyResponse
+type CompanyResponse struct {
+	Address     string    `json:"address,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitzero"`
+	Description string    `json:"description,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	ID          int64     `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Phone       string    `json:"phone,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitzero"`
+	Version     int64     `json:"version,omitempty"`
+	Website     string    `json:"website,omitempty"`
+}
+
+// CreateCompanyRequest company.CreateCompanyRequest
+type CreateCompanyRequest struct {
+	Address     string `json:"address,omitempty"`
+	Description string `json:"description,omitempty"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Phone       string `json:"phone,omitempty"`
+	Website     string `json:"website,omitempty"`
+}
+
+// CreateDepartmentRequest department.CreateDepartmentRequest
+type CreateDepartmentRequest struct {
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name"`
+	ParentID    *int64 `json:"parent_id,omitempty"` // 親部署ID（省略時は最上位）
+}
+
+// CreateUserRequest user.CreateUserRequest
+type CreateUserRequest struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// CreateWebhookRequest webhook.CreateWebhookRequest
+type CreateWebhookRequest struct {
+	Active      *bool    `json:"active,omitempty"` // 省略時は有効
+	Description string   `json:"description,omitempty"`
+	Events      []string `json:"events"`
+	URL         string   `json:"url"`
+}
+
+// Department 部署
+type Department struct {
+	CompanyID   int64  `json:"company_id,omitempty"`  // 会社ID
+	CreatedAt   string `json:"created_at,omitempty"`  // 作成日時
+	Description string `json:"description,omitempty"` // 説明
+	ID          int64  `json:"id,omitempty"`          // 部署ID
+	Name        string `json:"name,omitempty"`        // 部署名
+	ParentID    int64  `json:"parent_id,omitempty"`   // 親部署ID（最上位の部署はnull）
+	UpdatedAt   string `json:"updated_at,omitempty"`  // 更新日時
+}
+
+// DepartmentMember 部署の所属
+type DepartmentMember struct {
+	CreatedAt    string `json:"created_at,omitempty"`    // 所属した日時
+	DepartmentID int64  `json:"department_id,omitempty"` // 部署ID
+	ID           int64  `json:"id,omitempty"`            // 所属ID
+	Role         string `json:"role,omitempty"`          // 部署での役割（manager, member）
+	UpdatedAt    string `json:"updated_at,omitempty"`    // 更新日時
+	UserID       int64  `json:"user_id,omitempty"`       // ユーザーID
+}
+
+// DepartmentMemberDetail domain.DepartmentMemberDetail
+type DepartmentMemberDetail struct {
+	CreatedAt      string `json:"created_at,omitempty"`      // 所属した日時
+	DepartmentID   int64  `json:"department_id,omitempty"`   // 部署ID
+	DepartmentName string `json:"department_name,omitempty"` // 部署名
+	Role           string `json:"role,omitempty"`            // 部署での役割（manager, member）
+	UserEmail      string `json:"user_email,omitempty"`      // メールアドレス
+	UserID         int64  `json:"user_id,omitempty"`         // ユーザーID
+	UserName       string `json:"user_name,omitempty"`       // ユーザー名
+}
+
+// DepartmentNode department.DepartmentNode
+type DepartmentNode struct {
+	Children    []DepartmentNode `json:"children,omitempty"`
+	CreatedAt   time.Time        `json:"created_at,omitzero"`
+	Depth       int64            `json:"depth,omitempty"` // 最上位の部署からの深さ（最上位は0）
+	Description string           `json:"description,omitempty"`
+	ID          int64            `json:"id,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	ParentID    int64            `json:"parent_id,omitempty"`
+	UpdatedAt   time.Time        `json:"updated_at,omitzero"`
+}
+
+// ErrorCode helper.ErrorCode
+type ErrorCode string
+
+const (
+	ErrorCodeValidation           ErrorCode = "VALIDATION_ERROR"
+	ErrorCodeNotFound             ErrorCode = "NOT_FOUND"
+	ErrorCodeAlreadyExists        ErrorCode = "ALREADY_EXISTS"
+	ErrorCodeConflict             ErrorCode = "CONFLICT"
+	ErrorCodeUnauthorized         ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden            ErrorCode = "FORBIDDEN"
+	ErrorCodeInternalError        ErrorCode = "INTERNAL_ERROR"
+	ErrorCodeDatabaseError        ErrorCode = "DATABASE_ERROR"
+	ErrorCodeExternalAPI          ErrorCode = "EXTERNAL_API_ERROR"
+	ErrorCodeRateLimited          ErrorCode = "RATE_LIMITED"
+	ErrorCodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeRequestInProgress    ErrorCode = "REQUEST_IN_PROGRESS"
+	ErrorCodePreconditionFailed   ErrorCode = "PRECONDITION_FAILED"
+	ErrorCodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+	ErrorCodeUnsupportedMedia     ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	ErrorCodeServiceUnavailable   ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrorCodeContractViolation    ErrorCode = "CONTRACT_VIOLATION"
+	ErrorCodeBatchFailed          ErrorCode = "BATCH_FAILED"
+	ErrorCodePayloadTooLarge      ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrorCodeQuotaExceeded        ErrorCode = "QUOTA_EXCEEDED"
+	ErrorCodeInfected             ErrorCode = "INFECTED_FILE"
+)
+
+// Import 一括インポートの状態と結果
+type Import struct {
+	CompletedAt   time.Time        `json:"completed_at,omitzero"`    // 完了日時
+	CreatedAt     time.Time        `json:"created_at,omitzero"`      // 作成日時
+	CreatedBy     int64            `json:"created_by,omitempty"`     // 作成したユーザーID
+	CreatedRows   int64            `json:"created_rows,omitempty"`   // 作成した行数
+	Errors        []ImportRowError `json:"errors,omitempty"`         // 行ごとのエラー
+	FailedRows    int64            `json:"failed_rows,omitempty"`    // 失敗した行数
+	Format        string           `json:"format,omitempty"`         // 形式（csv, jsonl）
+	ID            int64            `json:"id,omitempty"`             // インポートID
+	ProcessedRows int64            `json:"processed_rows,omitempty"` // 処理済み行数
+	Status        string           `json:"status,omitempty"`         // 状態
+	TotalRows     int64            `json:"total_rows,omitempty"`     // 総行数
+	Type          string           `json:"type,omitempty"`           // 対象（users, companies, memberships）
+	UpdatedAt     time.Time        `json:"updated_at,omitzero"`      // 更新日時
+	UpdatedRows   int64            `json:"updated_rows,omitempty"`   // 更新した行数
+}
+
+// ImportRowError domain.ImportRowError
+type ImportRowError struct {
+	Field   string `json:"field,omitempty"`   // エラーのあるフィールド
+	Line    int64  `json:"line,omitempty"`    // ファイル内の行番号（CSVはヘッダーが1行目）
+	Message string `json:"message,omitempty"` // エラーメッセージ
+}
+
+// LoginRequest auth.LoginRequest
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse auth.LoginResponse
+type LoginResponse struct {
+	AccessToken string    `json:"access_token,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at,omitzero"`
+	TokenType   string    `json:"token_type,omitempty"`
+}
+
+// MoveDepartmentRequest department.MoveDepartmentRequest
+type MoveDepartmentRequest struct {
+	ParentID *int64 `json:"parent_id,omitempty"` // 新しい親部署ID（nullの場合は最上位）
+}
+
+// OperationResult batch.OperationResult
+type OperationResult struct {
+	Body    any               `json:"body,omitempty"`    // レスポンスボディ（JSON以外は文字列）
+	Headers map[string]string `json:"headers,omitempty"` // レスポンスのヘッダー（ETag, Location）
+	Status  int64             `json:"status,omitempty"`  // ステータスコード（実行しなかった操作は424）
+}
+
+// PaginationResponse helper.PaginationResponse
+type PaginationResponse struct {
+	Limit      int64 `json:"limit,omitempty"`       // 1ページあたりの件数
+	Page       int64 `json:"page,omitempty"`        // 現在のページ番号
+	Total      int64 `json:"total,omitempty"`       // 総件数
+	TotalPages int64 `json:"total_pages,omitempty"` // 総ページ数
+}
+
+// Report health.Report
+type Report struct {
+	Checks []CheckResult `json:"checks,omitempty"` // 個別チェックの結果
+	Status string        `json:"status,omitempty"` // ok, fail
+}
+
+// UpdateCompanyRequest company.UpdateCompanyRequest
+type UpdateCompanyRequest struct {
+	Address     string `json:"address,omitempty"`
+	Description string `json:"description,omitempty"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Phone       string `json:"phone,omitempty"`
+	Website     string `json:"website,omitempty"`
+}
+
+// UpdateDepartmentRequest department.UpdateDepartmentRequest
+type UpdateDepartmentRequest struct {
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name"`
+}
+
+// UpdateUserRequest user.UpdateUserRequest
+type UpdateUserRequest struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// UpdateWebhookRequest webhook.UpdateWebhookRequest
+type UpdateWebhookRequest struct {
+	Active      bool     `json:"active"`
+	Description string   `json:"description,omitempty"`
+	Events      []string `json:"events"`
+	URL         string   `json:"url"`
+}
+
+// Usage attachment.Usage
+type Usage struct {
+	Count      int64 `json:"count,omitempty"`       // 添付ファイル数（ロゴを含む）
+	QuotaBytes int64 `json:"quota_bytes,omitempty"` // 上限（バイト。0は無制限）
+	UsedBytes  int64 `json:"used_bytes,omitempty"`  // 合計サイズ（バイト）
+}
+
+// UserResponse user.UserResponse
+type UserResponse struct {
+	Avatar      AvatarResponse `json:"avatar,omitempty"` // 未設定の場合はnull
+	Bio         string         `json:"bio,omitempty"`
+	DisplayName string         `json:"display_name,omitempty"`
+	Email       string         `json:"email,omitempty"`
+	ID          int64          `json:"id,omitempty"`
+	KanaName    string         `json:"kana_name,omitempty"`
+	Locale      string         `json:"locale,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Timezone    string         `json:"timezone,omitempty"`
+	Version     int64          `json:"version,omitempty"`
+}
+
+// WebhookDelivery Webhookの配信履歴（再試行のたびに最後の結果で更新する）
+type WebhookDelivery struct {
+	Attempts       int64          `json:"attempts,omitempty"`        // 送信した回数
+	CreatedAt      time.Time      `json:"created_at,omitzero"`       // 作成日時
+	DeliveredAt    time.Time      `json:"delivered_at,omitzero"`     // 配信に成功した日時
+	DurationMs     int64          `json:"duration_ms,omitempty"`     // 最後の送信にかかった時間（ミリ秒）
+	Error          string         `json:"error,omitempty"`           // 最後の失敗理由
+	EventID        string         `json:"event_id,omitempty"`        // イベントID
+	EventType      string         `json:"event_type,omitempty"`      // イベントの種類
+	ID             int64          `json:"id,omitempty"`              // 配信ID
+	Payload        map[string]any `json:"payload,omitempty"`         // 送信するボディ
+	RedeliveryOf   int64          `json:"redelivery_of,omitempty"`   // 再配信元の配信ID
+	ResponseBody   string         `json:"response_body,omitempty"`   // 最後の応答のボディ（先頭のみ）
+	ResponseStatus int64          `json:"response_status,omitempty"` // 最後の応答のステータスコード
+	Status         string         `json:"status,omitempty"`          // 状態（pending, succeeded, failed）
+	UpdatedAt      time.Time      `json:"updated_at,omitzero"`       // 更新日時
+	WebhookID      int64          `json:"webhook_id,omitempty"`      // WebhookID
+}
+
+// WebhookResponse webhook.WebhookResponse
+type WebhookResponse struct {
+	Active          bool      `json:"active,omitempty"`
+	CompanyID       int64     `json:"company_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at,omitzero"`
+	Description     string    `json:"description,omitempty"`
+	Events          []string  `json:"events,omitempty"`
+	ID              int64     `json:"id,omitempty"`
+	Secret          string    `json:"secret,omitempty"` // 署名シークレット（作成時・ローテーション時のみ）
+	SecretRotatedAt time.Time `json:"secret_rotated_at,omitzero"`
+	UpdatedAt       time.Time `json:"updated_at,omitzero"`
+	URL             string    `json:"url,omitempty"`
+}
+
+// Login ログイン
+// メールアドレスとパスワードで認証し、アクセストークンを発行します
+//
+// POST /api/v1/auth/login
+func (c *Client) Login(ctx context.Context, credentials LoginRequest) (*LoginResponse, error) {
+	req := newRequest(http.MethodPost, "/auth/login")
+	req.setJSON("application/json", credentials)
+	var out LoginResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExecuteBatch 一括リクエスト
+// 複数の操作（メソッド・パス・ボディ）を先頭から順に実行し、操作ごとの結果を返します。パスは同じAPIバージョンのベースパスからの相対パスで、各操作は一括リクエストと同じ認証で実行します。atomicがtrueの場合は1つのトランザクションで実行し、いずれかの操作が失敗（4xx・5xx）すると、すべての操作を取り消して422を返します（以降の操作は実行せず424になります）
+//
+// POST /api/v1/batch
+func (c *Client) ExecuteBatch(ctx context.Context, batch BatchRequest) (*BatchResponse, error) {
+	req := newRequest(http.MethodPost, "/batch")
+	req.setJSON("application/json", batch)
+	var out BatchResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCompany 会社作成
+// 新しい会社を作成します
+//
+// POST /api/v1/companies
+func (c *Client) CreateCompany(ctx context.Context, company CreateCompanyRequest) (*CompanyResponse, error) {
+	req := newRequest(http.MethodPost, "/companies")
+	req.setJSON("application/json", company)
+	var out CompanyResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCompanyParams GetCompanyのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type GetCompanyParams struct {
+	Fields      string // 返すフィールド（カンマ区切り）
+	Include     string // 展開する関連（カンマ区切り）: members, members.user
+	IfNoneMatch string // 取得済みのETag（If-None-Matchヘッダー）
+}
+
+// GetCompany 会社取得
+// 指定したIDの会社を取得します。ETagを返し、If-None-Matchが一致する場合は304を返します。fieldsで返すフィールドを選択でき（idは常に返します）、include=membersで所属するユーザー、include=members.userでユーザーの情報も展開します。includeを指定した場合は304を返しません。所属していない会社は404を返します
+//
+// GET /api/v1/companies/{id}
+func (c *Client) GetCompany(ctx context.Context, id int64, params *GetCompanyParams) (*CompanyResponse, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id))
+	if params != nil {
+		req.setQuery("fields", params.Fields)
+		req.setQuery("include", params.Include)
+		req.setHeader("If-None-Match", params.IfNoneMatch)
+	}
+	var out CompanyResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateCompanyParams UpdateCompanyのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type UpdateCompanyParams struct {
+	IfMatch string // 取得時のETag（If-Matchヘッダー・必須）
+}
+
+// UpdateCompany 会社更新
+// 指定したIDの会社を更新します。取得時のETagをIf-Matchに指定する必要があります。会社の管理者とAPIキーのみ操作できます
+//
+// PUT /api/v1/companies/{id}
+func (c *Client) UpdateCompany(ctx context.Context, id int64, company UpdateCompanyRequest, params *UpdateCompanyParams) (*CompanyResponse, error) {
+	req := newRequest(http.MethodPut, "/companies/"+pathParam(id))
+	if params != nil {
+		req.setHeader("If-Match", params.IfMatch)
+	}
+	req.setJSON("application/json", company)
+	var out CompanyResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchCompanyParams PatchCompanyのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type PatchCompanyParams struct {
+	IfMatch string // 取得時のETag（If-Matchヘッダー・必須）
+}
+
+// PatchCompany 会社部分更新
+// 指定したIDの会社を部分更新します。application/merge-patch+json（RFC 7396）または application/json-patch+json（RFC 6902）を受け付け、変更されたフィールドのみ保存します。取得時のETagをIf-Matchに指定する必要があります。会社の管理者とAPIキーのみ操作できます
+//
+// PATCH /api/v1/companies/{id}
+func (c *Client) PatchCompany(ctx context.Context, id int64, contentType string, patch any, params *PatchCompanyParams) (*CompanyResponse, error) {
+	req := newRequest(http.MethodPatch, "/companies/"+pathParam(id))
+	if params != nil {
+		req.setHeader("If-Match", params.IfMatch)
+	}
+	req.setJSON(contentType, patch)
+	var out CompanyResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListCompanyAttachmentsParams ListCompanyAttachmentsのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type ListCompanyAttachmentsParams struct {
+	Page  int64 // ページ番号
+	Limit int64 // 1ページあたりの件数
+}
+
+// ListCompanyAttachments 添付ファイル一覧
+// 会社の添付ファイルを新しい順に取得します。会社のメンバーのみ操作できます
+//
+// GET /api/v1/companies/{id}/attachments
+func (c *Client) ListCompanyAttachments(ctx context.Context, id int64, params *ListCompanyAttachmentsParams) (*Page[CompanyAttachment], error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/attachments")
+	if params != nil {
+		req.setQuery("page", params.Page)
+		req.setQuery("limit", params.Limit)
+	}
+	return doPage[CompanyAttachment](ctx, c, req)
+}
+
+// ListCompanyAttachmentsAll ListCompanyAttachmentsの全ページを順に取得する（params.Pageのページから開始）
+func (c *Client) ListCompanyAttachmentsAll(ctx context.Context, id int64, params *ListCompanyAttachmentsParams) iter.Seq2[CompanyAttachment, error] {
+	var p ListCompanyAttachmentsParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(page int64) (*Page[CompanyAttachment], error) {
+		q := p
+		q.Page = page
+		return c.ListCompanyAttachments(ctx, id, &q)
+	})
+}
+
+// UploadCompanyAttachment 添付ファイルのアップロード
+// 会社の添付ファイルをmultipart/form-dataのfileフィールドで登録します。形式は内容から判定し（ATTACHMENT_ALLOWED_TYPES）、ウイルススキャンと会社ごとの容量（ATTACHMENT_QUOTA_BYTES）を確認してから保存します
+//
+// POST /api/v1/companies/{id}/attachments
+func (c *Client) UploadCompanyAttachment(ctx context.Context, id int64, filename string, file io.Reader) (*CompanyAttachment, error) {
+	req := newRequest(http.MethodPost, "/companies/"+pathParam(id)+"/attachments")
+	if err := req.setMultipart("file", filename, file); err != nil {
+		return nil, err
+	}
+	var out CompanyAttachment
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCompanyAttachment 添付ファイル取得
+// 会社の添付ファイルのメタデータ（アップロードしたユーザー・サイズ・SHA-256・形式）を取得します
+//
+// GET /api/v1/companies/{id}/attachments/{attachment_id}
+func (c *Client) GetCompanyAttachment(ctx context.Context, id int64, attachmentID int64) (*CompanyAttachment, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/attachments/"+pathParam(attachmentID))
+	var out CompanyAttachment
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteCompanyAttachment 添付ファイル削除
+// 会社の添付ファイルを削除します。会社の管理者とアップロードした本人のみ操作できます
+//
+// DELETE /api/v1/companies/{id}/attachments/{attachment_id}
+func (c *Client) DeleteCompanyAttachment(ctx context.Context, id int64, attachmentID int64) error {
+	req := newRequest(http.MethodDelete, "/companies/"+pathParam(id)+"/attachments/"+pathParam(attachmentID))
+	return c.doData(ctx, req, nil)
+}
+
+// DownloadCompanyAttachment 添付ファイルのダウンロード
+// 期限付きの署名付きURL（ATTACHMENT_URL_TTL）にリダイレクトします
+// 成功時のレスポンスをそのまま返すため、呼び出し元がボディを閉じる
+//
+// GET /api/v1/companies/{id}/attachments/{attachment_id}/download
+func (c *Client) DownloadCompanyAttachment(ctx context.Context, id int64, attachmentID int64) (*http.Response, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/attachments/"+pathParam(attachmentID)+"/download")
+	return c.do(ctx, req)
+}
+
+// ListDepartments 部署の一覧（木構造）
+// 会社の部署を木構造で取得します。会社のメンバーのみ操作できます
+//
+// GET /api/v1/companies/{id}/departments
+func (c *Client) ListDepartments(ctx context.Context, id int64) ([]DepartmentNode, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/departments")
+	var out []DepartmentNode
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateDepartment 部署作成
+// 会社の部署を作成します。parent_idを省略すると最上位の部署になります。同じ親の下で部署名は重複できません。会社の管理者のみ操作できます
+//
+// POST /api/v1/companies/{id}/departments
+func (c *Client) CreateDepartment(ctx context.Context, id int64, department CreateDepartmentRequest) (*Department, error) {
+	req := newRequest(http.MethodPost, "/companies/"+pathParam(id)+"/departments")
+	req.setJSON("application/json", department)
+	var out Department
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDepartment 部署取得
+// 会社の部署を取得します
+//
+// GET /api/v1/companies/{id}/departments/{department_id}
+func (c *Client) GetDepartment(ctx context.Context, id int64, departmentID int64) (*Department, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/departments/"+pathParam(departmentID))
+	var out Department
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateDepartment 部署更新
+// 部署の名前・説明を更新します。親部署の変更は移動（/move）で行います
+//
+// PUT /api/v1/companies/{id}/departments/{department_id}
+func (c *Client) UpdateDepartment(ctx context.Context, id int64, departmentID int64, department UpdateDepartmentRequest) (*Department, error) {
+	req := newRequest(http.MethodPut, "/companies/"+pathParam(id)+"/departments/"+pathParam(departmentID))
+	req.setJSON("application/json", department)
+	var out Department
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDepartment 部署削除
+// 部署と所属を削除します。配下の部署がある場合は先に移動・削除してください
+//
+// DELETE /api/v1/companies/{id}/departments/{department_id}
+func (c *Client) DeleteDepartment(ctx context.Context, id int64, departmentID int64) error {
+	req := newRequest(http.MethodDelete, "/companies/"+pathParam(id)+"/departments/"+pathParam(departmentID))
+	return c.doData(ctx, req, nil)
+}
+
+// ListDepartmentMembersParams ListDepartmentMembersのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type ListDepartmentMembersParams struct {
+	Recursive bool  // 配下の部署を含める
+	Page      int64 // ページ番号
+	Limit     int64 // 1ページあたりの件数
+}
+
+// ListDepartmentMembers 部署の所属一覧
+// 部署の所属をユーザーID順に取得します。recursive=trueの場合は配下のすべての部署の所属を含めます（複数の部署に所属するユーザーは部署ごとに含まれます）
+//
+// GET /api/v1/companies/{id}/departments/{department_id}/members
+func (c *Client) ListDepartmentMembers(ctx context.Context, id int64, departmentID int64, params *ListDepartmentMembersParams) (*Page[DepartmentMemberDetail], error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/departments/"+pathParam(departmentID)+"/members")
+	if params != nil {
+		req.setQuery("recursive", params.Recursive)
+		req.setQuery("page", params.Page)
+		req.setQuery("limit", params.Limit)
+	}
+	return doPage[DepartmentMemberDetail](ctx, c, req)
+}
+
+// ListDepartmentMembersAll ListDepartmentMembersの全ページを順に取得する（params.Pageのページから開始）
+func (c *Client) ListDepartmentMembersAll(ctx context.Context, id int64, departmentID int64, params *ListDepartmentMembersParams) iter.Seq2[DepartmentMemberDetail, error] {
+	var p ListDepartmentMembersParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(page int64) (*Page[DepartmentMemberDetail], error) {
+		q := p
+		q.Page = page
+		return c.ListDepartmentMembers(ctx, id, departmentID, &q)
+	})
+}
+
+// AssignDepartmentMember 部署への所属
+// 会社のメンバーを部署に所属させます。既に所属している場合は役割を変更します。会社の管理者と、部署またはその上位の部署のmanagerが操作できます
+//
+// PUT /api/v1/companies/{id}/departments/{department_id}/members/{user_id}
+func (c *Client) AssignDepartmentMember(ctx context.Context, id int64, departmentID int64, userID int64, member AssignMemberRequest) (*DepartmentMember, error) {
+	req := newRequest(http.MethodPut, "/companies/"+pathParam(id)+"/departments/"+pathParam(departmentID)+"/members/"+pathParam(userID))
+	req.setJSON("application/json", member)
+	var out DepartmentMember
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RemoveDepartmentMember 部署からの除外
+// ユーザーを部署から外します（会社・他の部署の所属は変わりません）
+//
+// DELETE /api/v1/companies/{id}/departments/{department_id}/members/{user_id}
+func (c *Client) RemoveDepartmentMember(ctx context.Context, id int64, departmentID int64, userID int64) error {
+	req := newRequest(http.MethodDelete, "/companies/"+pathParam(id)+"/departments/"+pathParam(departmentID)+"/members/"+pathParam(userID))
+	return c.doData(ctx, req, nil)
+}
+
+// MoveDepartment 部署の移動
+// 部署を配下の部署・所属ごと別の親部署の下に移動します（parent_idがnullの場合は最上位）。自分自身や配下の部署の下には移動できません
+//
+// POST /api/v1/companies/{id}/departments/{department_id}/move
+func (c *Client) MoveDepartment(ctx context.Context, id int64, departmentID int64, move MoveDepartmentRequest) (*Department, error) {
+	req := newRequest(http.MethodPost, "/companies/"+pathParam(id)+"/departments/"+pathParam(departmentID)+"/move")
+	req.setJSON("application/json", move)
+	var out Department
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StreamCompanyEventsParams StreamCompanyEventsのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type StreamCompanyEventsParams struct {
+	LastEventID      string // 最後に受け取ったイベントのid（Last-Event-IDヘッダー）
+	LastEventIDQuery string // 最後に受け取ったイベントのid（ヘッダーを指定できない場合）
+}
+
+// StreamCompanyEvents 会社のアクティビティストリーム
+// 会社情報の更新・メンバーの追加・ロール変更・削除をServer-Sent Eventsで配信します。会社に所属するユーザーまたはAPIキーのみ購読できます。
+// 各イベントのidはLast-Event-IDヘッダー（またはlast_event_idクエリ）に渡すと、それ以降のイベントから再開できます。
+// 履歴の上限を超えた・履歴が削除済みで再開できない場合はresetイベント（dataのreasonはhistory_limit・history_truncated）を送るため、最新の状態を取得し直してください。接続の維持のため定期的にコメント行を送ります
+// 成功時のレスポンスをそのまま返すため、呼び出し元がボディを閉じる
+//
+// GET /api/v1/companies/{id}/events
+func (c *Client) StreamCompanyEvents(ctx context.Context, id int64, params *StreamCompanyEventsParams) (*http.Response, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/events")
+	if params != nil {
+		req.setHeader("Last-Event-ID", params.LastEventID)
+		req.setQuery("last_event_id", params.LastEventIDQuery)
+	}
+	return c.do(ctx, req)
+}
+
+// GetCompanyLogo 会社ロゴの取得
+// 会社のロゴの署名付きURLにリダイレクトします
+// 成功時のレスポンスをそのまま返すため、呼び出し元がボディを閉じる
+//
+// GET /api/v1/companies/{id}/logo
+func (c *Client) GetCompanyLogo(ctx context.Context, id int64) (*http.Response, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/logo")
+	return c.do(ctx, req)
+}
+
+// UploadCompanyLogo 会社ロゴのアップロード
+// 会社のロゴをmultipart/form-dataのfileフィールドで登録・置き換えます。形式（JPEG, PNG, GIF, WebP）は内容から判定し、最大512pxに縮小してメタデータを除いて保存します。会社の管理者のみ操作できます
+//
+// PUT /api/v1/companies/{id}/logo
+func (c *Client) UploadCompanyLogo(ctx context.Context, id int64, filename string, file io.Reader) (*CompanyAttachment, error) {
+	req := newRequest(http.MethodPut, "/companies/"+pathParam(id)+"/logo")
+	if err := req.setMultipart("file", filename, file); err != nil {
+		return nil, err
+	}
+	var out CompanyAttachment
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteCompanyLogo 会社ロゴの削除
+// 会社のロゴを削除します。会社の管理者のみ操作できます
+//
+// DELETE /api/v1/companies/{id}/logo
+func (c *Client) DeleteCompanyLogo(ctx context.Context, id int64) error {
+	req := newRequest(http.MethodDelete, "/companies/"+pathParam(id)+"/logo")
+	return c.doData(ctx, req, nil)
+}
+
+// GetCompanyAttachmentUsage 添付ファイルの使用量
+// 会社の添付ファイル（ロゴを含む）の件数・合計サイズと上限を取得します
+//
+// GET /api/v1/companies/{id}/storage
+func (c *Client) GetCompanyAttachmentUsage(ctx context.Context, id int64) (*Usage, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/storage")
+	var out Usage
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListWebhooks Webhook一覧
+// 会社に登録されたWebhookを取得します。会社の管理者のみ操作できます
+//
+// GET /api/v1/companies/{id}/webhooks
+func (c *Client) ListWebhooks(ctx context.Context, id int64) ([]WebhookResponse, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/webhooks")
+	var out []WebhookResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateWebhook Webhook登録
+// 会社のWebhookを登録します。署名シークレットはこのレスポンスでのみ返します
+//
+// POST /api/v1/companies/{id}/webhooks
+func (c *Client) CreateWebhook(ctx context.Context, id int64, webhook CreateWebhookRequest) (*WebhookResponse, error) {
+	req := newRequest(http.MethodPost, "/companies/"+pathParam(id)+"/webhooks")
+	req.setJSON("application/json", webhook)
+	var out WebhookResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWebhook Webhook取得
+// 会社のWebhookを取得します
+//
+// GET /api/v1/companies/{id}/webhooks/{webhook_id}
+func (c *Client) GetWebhook(ctx context.Context, id int64, webhookID int64) (*WebhookResponse, error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/webhooks/"+pathParam(webhookID))
+	var out WebhookResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateWebhook Webhook更新
+// 会社のWebhookの配信先・購読するイベント・有効/無効を更新します
+//
+// PUT /api/v1/companies/{id}/webhooks/{webhook_id}
+func (c *Client) UpdateWebhook(ctx context.Context, id int64, webhookID int64, webhook UpdateWebhookRequest) (*WebhookResponse, error) {
+	req := newRequest(http.MethodPut, "/companies/"+pathParam(id)+"/webhooks/"+pathParam(webhookID))
+	req.setJSON("application/json", webhook)
+	var out WebhookResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhook Webhook削除
+// 会社のWebhookと配信履歴を削除します
+//
+// DELETE /api/v1/companies/{id}/webhooks/{webhook_id}
+func (c *Client) DeleteWebhook(ctx context.Context, id int64, webhookID int64) error {
+	req := newRequest(http.MethodDelete, "/companies/"+pathParam(id)+"/webhooks/"+pathParam(webhookID))
+	return c.doData(ctx, req, nil)
+}
+
+// ListWebhookDeliveriesParams ListWebhookDeliveriesのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type ListWebhookDeliveriesParams struct {
+	Page  int64 // ページ番号
+	Limit int64 // 1ページあたりの件数
+}
+
+// ListWebhookDeliveries Webhook配信履歴
+// Webhookの配信履歴を新しい順に取得します
+//
+// GET /api/v1/companies/{id}/webhooks/{webhook_id}/deliveries
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id int64, webhookID int64, params *ListWebhookDeliveriesParams) (*Page[WebhookDelivery], error) {
+	req := newRequest(http.MethodGet, "/companies/"+pathParam(id)+"/webhooks/"+pathParam(webhookID)+"/deliveries")
+	if params != nil {
+		req.setQuery("page", params.Page)
+		req.setQuery("limit", params.Limit)
+	}
+	return doPage[WebhookDelivery](ctx, c, req)
+}
+
+// ListWebhookDeliveriesAll ListWebhookDeliveriesの全ページを順に取得する（params.Pageのページから開始）
+func (c *Client) ListWebhookDeliveriesAll(ctx context.Context, id int64, webhookID int64, params *ListWebhookDeliveriesParams) iter.Seq2[WebhookDelivery, error] {
+	var p ListWebhookDeliveriesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(page int64) (*Page[WebhookDelivery], error) {
+		q := p
+		q.Page = page
+		return c.ListWebhookDeliveries(ctx, id, webhookID, &q)
+	})
+}
+
+// RedeliverWebhookDelivery Webhook再配信
+// 終了した配信と同じボディで新しい配信を作成し、非同期で送信します
+//
+// POST /api/v1/companies/{id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, id int64, webhookID int64, deliveryID int64) (*WebhookDelivery, error) {
+	req := newRequest(http.MethodPost, "/companies/"+pathParam(id)+"/webhooks/"+pathParam(webhookID)+"/deliveries/"+pathParam(deliveryID)+"/redeliver")
+	var out WebhookDelivery
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RotateWebhookSecret 署名シークレットのローテーション
+// 新しい署名シークレットを発行します。猶予期間（WEBHOOK_SECRET_GRACE_PERIOD）の間は以前のシークレットの署名も付与します
+//
+// POST /api/v1/companies/{id}/webhooks/{webhook_id}/rotate-secret
+func (c *Client) RotateWebhookSecret(ctx context.Context, id int64, webhookID int64) (*WebhookResponse, error) {
+	req := newRequest(http.MethodPost, "/companies/"+pathParam(id)+"/webhooks/"+pathParam(webhookID)+"/rotate-secret")
+	var out WebhookResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportCompaniesParams ExportCompaniesのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type ExportCompaniesParams struct {
+	Format  string // 出力形式
+	Columns string // 出力する列（カンマ区切り）: id, name, email, phone, address, website, description, version, created_at, updated_at
+	Q       string // 会社名の部分一致
+	SortBy  string // ソート項目
+	SortDir string // ソート方向
+}
+
+// ExportCompanies 会社エクスポート
+// 会社をCSV（UTF-8 BOM付き）・JSONL・XLSXでダウンロードします。ユーザー認証の場合は自分が所属する会社のみ出力します
+// 成功時のレスポンスをそのまま返すため、呼び出し元がボディを閉じる
+//
+// GET /api/v1/exports/companies
+func (c *Client) ExportCompanies(ctx context.Context, params *ExportCompaniesParams) (*http.Response, error) {
+	req := newRequest(http.MethodGet, "/exports/companies")
+	if params != nil {
+		req.setQuery("format", params.Format)
+		req.setQuery("columns", params.Columns)
+		req.setQuery("q", params.Q)
+		req.setQuery("sort_by", params.SortBy)
+		req.setQuery("sort_dir", params.SortDir)
+	}
+	return c.do(ctx, req)
+}
+
+// ExportMembershipsParams ExportMembershipsのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type ExportMembershipsParams struct {
+	Format    string // 出力形式
+	Columns   string // 出力する列（カンマ区切り）: company_id, company_name, company_email, user_id, user_name, user_email, role, created_at
+	CompanyID int64  // 会社ID
+	Role      string // 役割
+}
+
+// ExportMemberships 所属エクスポート
+// 会社とユーザーの所属をCSV（UTF-8 BOM付き）・JSONL・XLSXでダウンロードします。ユーザー認証の場合は自分が所属する会社のみ出力します
+// 成功時のレスポンスをそのまま返すため、呼び出し元がボディを閉じる
+//
+// GET /api/v1/exports/memberships
+func (c *Client) ExportMemberships(ctx context.Context, params *ExportMembershipsParams) (*http.Response, error) {
+	req := newRequest(http.MethodGet, "/exports/memberships")
+	if params != nil {
+		req.setQuery("format", params.Format)
+		req.setQuery("columns", params.Columns)
+		req.setQuery("company_id", params.CompanyID)
+		req.setQuery("role", params.Role)
+	}
+	return c.do(ctx, req)
+}
+
+// ExportUsersParams ExportUsersのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type ExportUsersParams struct {
+	Format  string // 出力形式
+	Columns string // 出力する列（カンマ区切り）: id, name, email, version, created_at, updated_at
+	Q       string // 名前・メールアドレスの部分一致
+	SortBy  string // ソート項目
+	SortDir string // ソート方向
+}
+
+// ExportUsers ユーザーエクスポート
+// ユーザーをCSV（UTF-8 BOM付き）・JSONL・XLSXでダウンロードします。ユーザー認証の場合は自分と同じ会社に所属するユーザーのみ出力します
+// 成功時のレスポンスをそのまま返すため、呼び出し元がボディを閉じる
+//
+// GET /api/v1/exports/users
+func (c *Client) ExportUsers(ctx context.Context, params *ExportUsersParams) (*http.Response, error) {
+	req := newRequest(http.MethodGet, "/exports/users")
+	if params != nil {
+		req.setQuery("format", params.Format)
+		req.setQuery("columns", params.Columns)
+		req.setQuery("q", params.Q)
+		req.setQuery("sort_by", params.SortBy)
+		req.setQuery("sort_dir", params.SortDir)
+	}
+	return c.do(ctx, req)
+}
+
+// GetHealth レディネスチェック
+// データベース・マイグレーション・シャットダウン状態を確認し、チェックごとの結果と所要時間を返します（/readyz と同じ）
+//
+// GET /api/v1/health
+func (c *Client) GetHealth(ctx context.Context) (*Report, error) {
+	req := newRequest(http.MethodGet, "/health")
+	var out Report
+	if err := c.doJSON(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateImportParams CreateImportのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type CreateImportParams struct {
+	Type   string // インポート対象（必須）
+	DryRun bool   // 検証のみ行う
+}
+
+// CreateImport 一括インポート
+// CSV（text/csv）またはJSONL（application/x-ndjson）でユーザー・会社・所属を一括登録します。ファイル全体を検証し、エラーがなければ非同期で処理します。既存のデータはメールアドレスで照合して更新します。ユーザー・会社のインポートはAPIキーのみ、所属のインポートはファイル内のすべての会社の管理者またはAPIキーのみ実行できます
+//
+// POST /api/v1/imports
+func (c *Client) CreateImport(ctx context.Context, contentType string, file io.Reader, params *CreateImportParams) (*Import, error) {
+	req := newRequest(http.MethodPost, "/imports")
+	if params != nil {
+		req.setQuery("type", params.Type)
+		req.setQuery("dry_run", params.DryRun)
+	}
+	req.setRaw(contentType, file)
+	var out Import
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetImport インポート状況取得
+// インポートの処理状況と行ごとのエラーを取得します。ユーザーは自分が作成したインポートのみ取得できます
+//
+// GET /api/v1/imports/{id}
+func (c *Client) GetImport(ctx context.Context, id int64) (*Import, error) {
+	req := newRequest(http.MethodGet, "/imports/"+pathParam(id))
+	var out Import
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateUser ユーザー作成
+// 新しいユーザーを作成します
+//
+// POST /api/v1/users
+func (c *Client) CreateUser(ctx context.Context, user CreateUserRequest) (*UserResponse, error) {
+	req := newRequest(http.MethodPost, "/users")
+	req.setJSON("application/json", user)
+	var out UserResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserParams GetUserのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type GetUserParams struct {
+	Fields      string // 返すフィールド（カンマ区切り）
+	Include     string // 展開する関連（カンマ区切り）: companies
+	IfNoneMatch string // 取得済みのETag（If-None-Matchヘッダー）
+}
+
+// GetUser ユーザー取得
+// 指定したIDのユーザーを取得します。ETagを返し、If-None-Matchが一致する場合は304を返します。fieldsで返すフィールドを選択でき（idは常に返します）、include=companiesで所属する会社を展開します（本人以外には呼び出し元も所属している会社のみ）。includeを指定した場合は304を返しません。本人と同じ会社に所属するユーザー以外は404を返します
+//
+// GET /api/v1/users/{id}
+func (c *Client) GetUser(ctx context.Context, id int64, params *GetUserParams) (*UserResponse, error) {
+	req := newRequest(http.MethodGet, "/users/"+pathParam(id))
+	if params != nil {
+		req.setQuery("fields", params.Fields)
+		req.setQuery("include", params.Include)
+		req.setHeader("If-None-Match", params.IfNoneMatch)
+	}
+	var out UserResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateUserParams UpdateUserのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type UpdateUserParams struct {
+	IfMatch string // 取得時のETag（If-Matchヘッダー・必須）
+}
+
+// UpdateUser ユーザー更新
+// 指定したIDのユーザーを更新します。取得時のETagをIf-Matchに指定する必要があります。本人とAPIキーのみ操作できます
+//
+// PUT /api/v1/users/{id}
+func (c *Client) UpdateUser(ctx context.Context, id int64, user UpdateUserRequest, params *UpdateUserParams) (*UserResponse, error) {
+	req := newRequest(http.MethodPut, "/users/"+pathParam(id))
+	if params != nil {
+		req.setHeader("If-Match", params.IfMatch)
+	}
+	req.setJSON("application/json", user)
+	var out UserResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchUserParams PatchUserのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type PatchUserParams struct {
+	IfMatch string // 取得時のETag（If-Matchヘッダー・必須）
+}
+
+// PatchUser ユーザー部分更新
+// 指定したIDのユーザーを部分更新します。application/merge-patch+json（RFC 7396）または application/json-patch+json（RFC 6902）を受け付け、変更されたフィールドのみ保存します。name, email とプロフィール（display_name, kana_name, locale, timezone, bio）を変更できます。取得時のETagをIf-Matchに指定する必要があります。本人とAPIキーのみ操作できます
+//
+// PATCH /api/v1/users/{id}
+func (c *Client) PatchUser(ctx context.Context, id int64, contentType string, patch any, params *PatchUserParams) (*UserResponse, error) {
+	req := newRequest(http.MethodPatch, "/users/"+pathParam(id))
+	if params != nil {
+		req.setHeader("If-Match", params.IfMatch)
+	}
+	req.setJSON(contentType, patch)
+	var out UserResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UploadUserAvatarParams UploadUserAvatarのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type UploadUserAvatarParams struct {
+	IfMatch string // 取得時のETag（If-Matchヘッダー）
+}
+
+// UploadUserAvatar アバター画像のアップロード
+// 本人のアバター画像をmultipart/form-dataのavatarフィールドで登録・置き換えます。形式（JPEG, PNG, GIF, WebP）は内容から判定し、元画像（最大1024px）と正方形のサムネイル（64, 128, 256px）をメタデータを除いて保存します。レスポンスのavatarに期限付きの署名付きURLを返します。If-Matchは任意で、指定した場合はバージョンを確認します
+//
+// PUT /api/v1/users/{id}/avatar
+func (c *Client) UploadUserAvatar(ctx context.Context, id int64, filename string, avatar io.Reader, params *UploadUserAvatarParams) (*UserResponse, error) {
+	req := newRequest(http.MethodPut, "/users/"+pathParam(id)+"/avatar")
+	if params != nil {
+		req.setHeader("If-Match", params.IfMatch)
+	}
+	if err := req.setMultipart("avatar", filename, avatar); err != nil {
+		return nil, err
+	}
+	var out UserResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteUserAvatarParams DeleteUserAvatarのクエリ・ヘッダーのパラメーター（ゼロ値は送らない）
+type DeleteUserAvatarParams struct {
+	IfMatch string // 取得時のETag（If-Matchヘッダー）
+}
+
+// DeleteUserAvatar アバター画像の削除
+// 本人のアバター画像を削除します。設定されていない場合もそのままユーザーを返します。If-Matchは任意で、指定した場合はバージョンを確認します
+//
+// DELETE /api/v1/users/{id}/avatar
+func (c *Client) DeleteUserAvatar(ctx context.Context, id int64, params *DeleteUserAvatarParams) (*UserResponse, error) {
+	req := newRequest(http.MethodDelete, "/users/"+pathParam(id)+"/avatar")
+	if params != nil {
+		req.setHeader("If-Match", params.IfMatch)
+	}
+	var out UserResponse
+	if err := c.doData(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TokenSource アクセストークンの取得元
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// WithBearerToken アクセストークン（POST /auth/login で発行）で認証
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.auth = func(ctx context.Context, header http.Header) error {
+			header.Set(headerAuthorization, "Bearer "+token)
+			return nil
+		}
+	}
+}
+
+// WithAPIKey APIキーで認証（社内サービス用）
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.auth = func(ctx context.Context, header http.Header) error {
+			header.Set(headerAPIKey, key)
+			return nil
+		}
+	}
+}
+
+// WithTokenSource 送信のたびにTokenSourceからアクセストークンを取得して認証
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) {
+		c.auth = func(ctx context.Context, header http.Header) error {
+			token, err := source.Token(ctx)
+			if err != nil {
+				return err
+			}
+			header.Set(headerAuthorization, "Bearer "+token)
+			return nil
+		}
+	}
+}
+
+// tokenRefreshMargin 有効期限のこの時間前にトークンを取り直す
+const tokenRefreshMargin = time.Minute
+
+// PasswordTokenSource メールアドレス・パスワードでログインし、有効期限まで同じトークンを使う
+// loginには認証を設定していないクライアントを渡す（このTokenSourceを使うクライアント自身を渡すと再帰する）
+func PasswordTokenSource(login *Client, email, password string) TokenSource {
+	return &passwordTokenSource{client: login, email: email, password: password, now: time.Now}
+}
+
+type passwordTokenSource struct {
+	client   *Client
+	email    string
+	password string
+	now      func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (s *passwordTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(tokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+	resp, err := s.client.Login(ctx, LoginRequest{Email: s.email, Password: s.password})
+	if err != nil {
+		return "", err
+	}
+	s.token = resp.AccessToken
+	s.expiresAt = resp.ExpiresAt
+	return s.token, nil
+}
@@ -0,0 +1,424 @@
+// Package client KM APIのGoクライアント
+//
+// エンドポイントごとの型・メソッド（api.gen.go）はswagで生成したOpenAPI仕様から
+// cmd/openapi で生成する。ハンドラーのアノテーションを変更したら `task docs` で再生成すること
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// リクエストに付けるヘッダー
+const (
+	headerAuthorization  = "Authorization"
+	headerAPIKey         = "X-API-Key"
+	headerIdempotencyKey = "Idempotency-Key"
+	headerRequestID      = "X-Request-ID"
+	headerRetryAfter     = "Retry-After"
+)
+
+// PATCHで送るパッチの形式
+const (
+	ContentTypeMergePatch = "application/merge-patch+json" // RFC 7396
+	ContentTypeJSONPatch  = "application/json-patch+json"  // RFC 6902
+)
+
+// Client KM APIのクライアント
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	auth       func(ctx context.Context, header http.Header) error
+	retry      RetryPolicy
+	userAgent  string
+}
+
+// Option クライアントの設定
+type Option func(*Client)
+
+// RetryPolicy 再試行の設定
+// 429はすべてのメソッド、通信エラー・502・503・504は冪等なメソッド（GET, PUT, DELETE）と
+// Idempotency-Keyを付けたPOSTのみ再試行する
+type RetryPolicy struct {
+	MaxAttempts int           // 最初の送信を含む最大回数（1で再試行しない）
+	MinBackoff  time.Duration // 最初の待ち時間
+	MaxBackoff  time.Duration // 待ち時間の上限（Retry-Afterもこれを超えない）
+}
+
+// DefaultRetryPolicy 既定の再試行の設定
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+// New クライアントを作成
+// baseURLはサーバーのURL（http://localhost:8080）で、各エンドポイントにはBasePath（/api/v1）を付けて送る
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base url: %q", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + BasePath
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+		userAgent:  "km-api-go-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// WithHTTPClient 送信に使うhttp.Clientを指定
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetry 再試行の設定を指定
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithUserAgent User-Agentを指定
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// do リクエストを送信し、2xx以外はErrorを返す（呼び出し元がボディを閉じる）
+func (c *Client) do(ctx context.Context, req *request) (*http.Response, error) {
+	body, err := req.encodeBody()
+	if err != nil {
+		return nil, err
+	}
+
+	// POSTの再試行で二重に処理されないよう、すべての送信で同じキーを使う
+	if req.method == http.MethodPost && req.headers.Get(headerIdempotencyKey) == "" {
+		req.headers.Set(headerIdempotencyKey, uuid.NewString())
+	}
+
+	// そのまま送るボディは、最初の送信でhttp.NewRequestが設定したGetBodyで作り直す
+	var getBody func() (io.ReadCloser, error)
+	newBody := func() (io.Reader, error) {
+		switch {
+		case body != nil:
+			return bytes.NewReader(body), nil
+		case req.raw != nil && getBody != nil:
+			return getBody()
+		}
+		return req.raw, nil
+	}
+
+	attempts := max(c.retry.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		reader, err := newBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		httpReq, err := c.newHTTPRequest(ctx, req, reader)
+		if err != nil {
+			return nil, err
+		}
+		if attempt == 1 {
+			getBody = httpReq.GetBody
+		}
+		replayable := req.raw == nil || getBody != nil
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= attempts || !req.idempotent() || !replayable {
+				return nil, err
+			}
+			if err := sleep(ctx, c.backoff(attempt, nil)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode < 300 {
+			return resp, nil
+		}
+		if attempt < attempts && replayable && c.shouldRetry(req, resp) {
+			drain(resp)
+			if err := sleep(ctx, c.backoff(attempt, resp)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return nil, newError(resp)
+	}
+}
+
+// newHTTPRequest 送信ごとにhttp.Requestを作成（認証ヘッダーはトークンの更新に備えて毎回設定する）
+func (c *Client) newHTTPRequest(ctx context.Context, req *request, body io.Reader) (*http.Request, error) {
+	u := *c.baseURL
+	u.RawPath = c.baseURL.EscapedPath() + req.path
+	path, err := url.PathUnescape(u.RawPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request path: %w", err)
+	}
+	u.Path = path
+	u.RawQuery = req.query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range req.headers {
+		httpReq.Header[name] = values
+	}
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.auth != nil {
+		if err := c.auth(ctx, httpReq.Header); err != nil {
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	return httpReq, nil
+}
+
+// shouldRetry ステータスから再試行するか判定
+func (c *Client) shouldRetry(req *request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// 処理の前に拒否されるため、どのメソッドでも再試行できる
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return req.idempotent()
+	}
+	return false
+}
+
+// backoff 次の送信までの待ち時間（Retry-Afterがあれば従い、なければ指数的に増やす）
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get(headerRetryAfter)); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, c.retry.MaxBackoff)
+		}
+	}
+	backoff := c.retry.MinBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > c.retry.MaxBackoff {
+		backoff = c.retry.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// 同時に失敗したクライアントが一斉に再送しないようにずらす
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drain 接続を再利用できるようボディを読み捨てて閉じる
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
+
+// envelope APIの共通のレスポンス形式（helper.APIResponse / helper.PaginatedResponse）
+type envelope struct {
+	Success    bool                `json:"success"`
+	Data       json.RawMessage     `json:"data"`
+	Pagination *PaginationResponse `json:"pagination"`
+	Message    string              `json:"message"`
+	Error      *APIError           `json:"error"`
+}
+
+// doData 共通のレスポンス形式のdataをoutに読み込む（outがnilなら読み捨てる）
+func (c *Client) doData(ctx context.Context, req *request, out any) error {
+	_, err := c.doEnvelope(ctx, req, out)
+	return err
+}
+
+// doPage ページネーション付きのレスポンスを読み込む
+func doPage[T any](ctx context.Context, c *Client, req *request) (*Page[T], error) {
+	page := &Page[T]{}
+	pagination, err := c.doEnvelope(ctx, req, &page.Items)
+	if err != nil {
+		return nil, err
+	}
+	if pagination != nil {
+		page.Pagination = *pagination
+	}
+	return page, nil
+}
+
+func (c *Client) doEnvelope(ctx context.Context, req *request, out any) (*PaginationResponse, error) {
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer drain(resp)
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if out != nil && len(env.Data) > 0 && string(env.Data) != "null" {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return nil, fmt.Errorf("failed to decode response data: %w", err)
+		}
+	}
+	return env.Pagination, nil
+}
+
+// doJSON 共通のレスポンス形式でないJSONをoutに読み込む
+func (c *Client) doJSON(ctx context.Context, req *request, out any) error {
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer drain(resp)
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// request 送信するリクエスト（生成したメソッドが組み立てる）
+type request struct {
+	method      string
+	path        string // BasePathからの相対パス（パスパラメーターはエスケープ済み）
+	query       url.Values
+	headers     http.Header
+	contentType string
+	body        any       // JSONで送るボディ
+	raw         io.Reader // そのまま送るボディ（CSV等）
+}
+
+func newRequest(method, path string) *request {
+	return &request{method: method, path: path, query: url.Values{}, headers: http.Header{}}
+}
+
+// setQuery クエリパラメーターを設定（ゼロ値は送らない）
+func (r *request) setQuery(name string, value any) {
+	if s, ok := formatParam(value); ok {
+		r.query.Set(name, s)
+	}
+}
+
+// setHeader ヘッダーを設定（ゼロ値は送らない）
+func (r *request) setHeader(name string, value any) {
+	if s, ok := formatParam(value); ok {
+		r.headers.Set(name, s)
+	}
+}
+
+// setJSON JSONのボディを設定
+func (r *request) setJSON(contentType string, body any) {
+	r.contentType = contentType
+	r.body = body
+}
+
+// setRaw そのまま送るボディを設定（bytes.Reader・strings.Reader等でなければ再試行しない）
+func (r *request) setRaw(contentType string, body io.Reader) {
+	r.contentType = contentType
+	r.raw = body
+}
+
+// setMultipart ファイルをmultipart/form-dataのfieldとして送る（再試行できるようメモリに読み込む）
+func (r *request) setMultipart(field, filename string, file io.Reader) error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile(field, filename)
+	if err != nil {
+		return fmt.Errorf("failed to encode request body: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("failed to read %s: %w", field, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to encode request body: %w", err)
+	}
+	r.setRaw(w.FormDataContentType(), bytes.NewReader(buf.Bytes()))
+	return nil
+}
+
+func (r *request) encodeBody() ([]byte, error) {
+	if r.body == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(r.body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %w", err)
+	}
+	return b, nil
+}
+
+// idempotent 同じリクエストを再送しても結果が変わらないか
+func (r *request) idempotent() bool {
+	switch r.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return r.headers.Get(headerIdempotencyKey) != ""
+	}
+	return false
+}
+
+// formatParam パラメーターの値を文字列にする（ゼロ値はfalse）
+func formatParam(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case int64:
+		return strconv.FormatInt(v, 10), v != 0
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), v != 0
+	case bool:
+		return strconv.FormatBool(v), v
+	case []string:
+		return strings.Join(v, ","), len(v) > 0
+	}
+	panic(fmt.Sprintf("client: unsupported parameter type %T", value))
+}
+
+// pathParam パスパラメーターをエスケープ
+func pathParam(value any) string {
+	s, _ := formatParam(value)
+	return url.PathEscape(s)
+}
+
+// ETag バージョンからIf-Match・If-None-Matchに指定するETagを作成
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// Ptr 値のポインター（省略可能なフィールドの指定用）
+func Ptr[T any](v T) *T {
+	return &v
+}
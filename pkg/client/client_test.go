@@ -0,0 +1,425 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder テスト用サーバーが受け取ったリクエスト
+type recorder struct {
+	mu       sync.Mutex
+	requests []recorded
+}
+
+type recorded struct {
+	method string
+	path   string
+	query  string
+	header http.Header
+	body   string
+}
+
+func (r *recorder) all() []recorded {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]recorded(nil), r.requests...)
+}
+
+// newTestServer 送信ごとにresponsesを順に返すサーバー
+func newTestServer(t *testing.T, responses ...func(w http.ResponseWriter)) (*httptest.Server, *recorder) {
+	rec := &recorder{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		n := len(rec.requests)
+		rec.requests = append(rec.requests, recorded{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery, header: r.Header.Clone(), body: string(body)})
+		rec.mu.Unlock()
+		if n >= len(responses) {
+			t.Errorf("unexpected request #%d: %s %s", n+1, r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		responses[n](w)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, rec
+}
+
+func respond(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(headerRequestID, "req-1")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...Option) *Client {
+	opts = append([]Option{WithRetry(RetryPolicy{MaxAttempts: 3})}, opts...)
+	c, err := New(srv.URL, opts...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_GetUser(t *testing.T) {
+	srv, rec := newTestServer(t, respond(http.StatusOK, `{"success":true,"data":{"id":1,"name":"山田太郎","email":"yamada@example.com","version":2}}`))
+	c := newTestClient(t, srv, WithBearerToken("token"))
+
+	user, err := c.GetUser(context.Background(), 1, &GetUserParams{IfNoneMatch: ETag(1)})
+
+	require.NoError(t, err)
+	assert.Equal(t, &UserResponse{ID: 1, Name: "山田太郎", Email: "yamada@example.com", Version: 2}, user)
+	requests := rec.all()
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodGet, requests[0].method)
+	assert.Equal(t, "/api/v1/users/1", requests[0].path)
+	assert.Equal(t, "Bearer token", requests[0].header.Get("Authorization"))
+	assert.Equal(t, `"1"`, requests[0].header.Get("If-None-Match"))
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		response func(w http.ResponseWriter)
+		check    func(t *testing.T, err error)
+	}{
+		{
+			name:     "異常系: 共通のレスポンス形式のエラー",
+			response: respond(http.StatusNotFound, `{"success":false,"error":{"code":"NOT_FOUND","message":"ユーザーが見つかりません"}}`),
+			check: func(t *testing.T, err error) {
+				var apiErr *Error
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+				assert.Equal(t, ErrorCodeNotFound, apiErr.Code)
+				assert.Equal(t, "ユーザーが見つかりません", apiErr.Message)
+				assert.Equal(t, "req-1", apiErr.RequestID)
+				assert.Equal(t, "km api: 404 NOT_FOUND: ユーザーが見つかりません", err.Error())
+			},
+		},
+		{
+			name:     "異常系: OpenAPI検証の違反箇所",
+			response: respond(http.StatusBadRequest, `{"success":false,"data":[{"in":"path","name":"id","message":"value must be an integer"}],"error":{"code":"VALIDATION_ERROR","message":"リクエストがAPI仕様に合いません"}}`),
+			check: func(t *testing.T, err error) {
+				var apiErr *Error
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, ErrorCodeValidation, apiErr.Code)
+				assert.JSONEq(t, `[{"in":"path","name":"id","message":"value must be an integer"}]`, string(apiErr.Data))
+			},
+		},
+		{
+			name: "異常系: 304はErrNotModified",
+			response: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusNotModified)
+			},
+			check: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrNotModified)
+				assert.Equal(t, http.StatusNotModified, StatusCode(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newTestServer(t, tt.response)
+			c := newTestClient(t, srv)
+
+			user, err := c.GetUser(context.Background(), 1, nil)
+
+			assert.Nil(t, user)
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	unavailable := respond(http.StatusServiceUnavailable, `{"success":false,"error":{"code":"SERVICE_UNAVAILABLE","message":"停止中です"}}`)
+	rateLimited := func(w http.ResponseWriter) {
+		w.Header().Set(headerRetryAfter, "0")
+		respond(http.StatusTooManyRequests, `{"success":false,"error":{"code":"RATE_LIMITED","message":"リクエストが多すぎます"}}`)(w)
+	}
+	userOK := respond(http.StatusOK, `{"success":true,"data":{"id":1}}`)
+
+	tests := []struct {
+		name          string
+		responses     []func(w http.ResponseWriter)
+		call          func(c *Client) error
+		expectedCalls int
+		expectedError bool
+	}{
+		{
+			name:      "正常系: GETは503を再試行",
+			responses: []func(w http.ResponseWriter){unavailable, unavailable, userOK},
+			call: func(c *Client) error {
+				_, err := c.GetUser(context.Background(), 1, nil)
+				return err
+			},
+			expectedCalls: 3,
+		},
+		{
+			name:      "正常系: POSTはIdempotency-Keyを付けて再試行",
+			responses: []func(w http.ResponseWriter){unavailable, respond(http.StatusCreated, `{"success":true,"data":{"id":1}}`)},
+			call: func(c *Client) error {
+				_, err := c.CreateUser(context.Background(), CreateUserRequest{Name: "山田太郎", Email: "yamada@example.com", Password: "password123"})
+				return err
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:      "正常系: 429はPATCHも再試行",
+			responses: []func(w http.ResponseWriter){rateLimited, userOK},
+			call: func(c *Client) error {
+				_, err := c.PatchUser(context.Background(), 1, ContentTypeMergePatch, map[string]any{"name": "山田花子"}, &PatchUserParams{IfMatch: ETag(1)})
+				return err
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:      "異常系: PATCHは503を再試行しない",
+			responses: []func(w http.ResponseWriter){unavailable},
+			call: func(c *Client) error {
+				_, err := c.PatchUser(context.Background(), 1, ContentTypeMergePatch, map[string]any{"name": "山田花子"}, &PatchUserParams{IfMatch: ETag(1)})
+				return err
+			},
+			expectedCalls: 1,
+			expectedError: true,
+		},
+		{
+			name:      "異常系: 最大回数まで失敗",
+			responses: []func(w http.ResponseWriter){unavailable, unavailable, unavailable},
+			call: func(c *Client) error {
+				_, err := c.GetUser(context.Background(), 1, nil)
+				return err
+			},
+			expectedCalls: 3,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, rec := newTestServer(t, tt.responses...)
+			c := newTestClient(t, srv)
+
+			err := tt.call(c)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			requests := rec.all()
+			require.Len(t, requests, tt.expectedCalls)
+			// 再試行でも同じボディ・Idempotency-Keyを送る
+			for _, r := range requests[1:] {
+				assert.Equal(t, requests[0].body, r.body)
+				assert.Equal(t, requests[0].header.Get(headerIdempotencyKey), r.header.Get(headerIdempotencyKey))
+			}
+			if requests[0].method == http.MethodPost {
+				assert.NotEmpty(t, requests[0].header.Get(headerIdempotencyKey))
+			}
+		})
+	}
+}
+
+func TestClient_CreateImport_RetryRewindsBody(t *testing.T) {
+	srv, rec := newTestServer(t,
+		respond(http.StatusServiceUnavailable, `{"success":false,"error":{"code":"SERVICE_UNAVAILABLE","message":"停止中です"}}`),
+		respond(http.StatusAccepted, `{"success":true,"data":{"id":1,"status":"pending"}}`),
+	)
+	c := newTestClient(t, srv, WithAPIKey("key"))
+
+	imp, err := c.CreateImport(context.Background(), "text/csv", strings.NewReader("name,email\n"), &CreateImportParams{Type: "users"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "pending", imp.Status)
+	requests := rec.all()
+	require.Len(t, requests, 2)
+	for _, r := range requests {
+		assert.Equal(t, "type=users", r.query)
+		assert.Equal(t, "text/csv", r.header.Get("Content-Type"))
+		assert.Equal(t, "key", r.header.Get("X-API-Key"))
+		assert.Equal(t, "name,email\n", r.body)
+	}
+}
+
+func TestClient_UploadUserAvatar_RetryRewindsBody(t *testing.T) {
+	srv, rec := newTestServer(t,
+		respond(http.StatusServiceUnavailable, `{"success":false,"error":{"code":"SERVICE_UNAVAILABLE","message":"停止中です"}}`),
+		respond(http.StatusOK, `{"success":true,"data":{"id":1,"avatar":{"small":"https://files.example.com/small.png"},"version":2}}`),
+	)
+	c := newTestClient(t, srv, WithAPIKey("key"))
+
+	u, err := c.UploadUserAvatar(context.Background(), 1, "me.png", strings.NewReader("png"), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://files.example.com/small.png", u.Avatar.Small)
+	requests := rec.all()
+	require.Len(t, requests, 2)
+	for _, r := range requests {
+		assert.Equal(t, "/api/v1/users/1/avatar", r.path)
+		mediaType, params, err := mime.ParseMediaType(r.header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/form-data", mediaType)
+		form, err := multipart.NewReader(strings.NewReader(r.body), params["boundary"]).ReadForm(1 << 10)
+		require.NoError(t, err)
+		require.Len(t, form.File["avatar"], 1)
+		assert.Equal(t, "me.png", form.File["avatar"][0].Filename)
+	}
+}
+
+func TestClient_ListWebhookDeliveriesAll(t *testing.T) {
+	page := func(n, totalPages int, ids ...int) func(w http.ResponseWriter) {
+		items := make([]string, len(ids))
+		for i, id := range ids {
+			items[i] = fmt.Sprintf(`{"id":%d}`, id)
+		}
+		return respond(http.StatusOK, fmt.Sprintf(`{"success":true,"data":[%s],"pagination":{"page":%d,"limit":2,"total":5,"total_pages":%d}}`, strings.Join(items, ","), n, totalPages))
+	}
+
+	t.Run("正常系: 全ページを順に取得", func(t *testing.T) {
+		srv, rec := newTestServer(t, page(1, 3, 1, 2), page(2, 3, 3, 4), page(3, 3, 5))
+		c := newTestClient(t, srv)
+
+		var ids []int64
+		for delivery, err := range c.ListWebhookDeliveriesAll(context.Background(), 1, 2, &ListWebhookDeliveriesParams{Limit: 2}) {
+			require.NoError(t, err)
+			ids = append(ids, delivery.ID)
+		}
+
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, ids)
+		requests := rec.all()
+		require.Len(t, requests, 3)
+		for i, r := range requests {
+			assert.Equal(t, "/api/v1/companies/1/webhooks/2/deliveries", r.path)
+			assert.Equal(t, "limit=2&page="+strconv.Itoa(i+1), r.query)
+		}
+	})
+
+	t.Run("正常系: 途中で止めると次のページを取得しない", func(t *testing.T) {
+		srv, rec := newTestServer(t, page(1, 3, 1, 2))
+		c := newTestClient(t, srv)
+
+		for delivery, err := range c.ListWebhookDeliveriesAll(context.Background(), 1, 2, nil) {
+			require.NoError(t, err)
+			if delivery.ID == 2 {
+				break
+			}
+		}
+
+		assert.Len(t, rec.all(), 1)
+	})
+
+	t.Run("異常系: 取得に失敗するとエラーを返して終了", func(t *testing.T) {
+		srv, _ := newTestServer(t, page(1, 2, 1), respond(http.StatusForbidden, `{"success":false,"error":{"code":"FORBIDDEN","message":"権限がありません"}}`))
+		c := newTestClient(t, srv)
+
+		var errs []error
+		for _, err := range c.ListWebhookDeliveriesAll(context.Background(), 1, 2, nil) {
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		require.Len(t, errs, 1)
+		assert.Equal(t, http.StatusForbidden, StatusCode(errs[0]))
+	})
+}
+
+func TestPasswordTokenSource(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+	login := func(token string) func(w http.ResponseWriter) {
+		return respond(http.StatusOK, fmt.Sprintf(`{"success":true,"data":{"access_token":%q,"token_type":"Bearer","expires_at":%q}}`, token, expiresAt.Format(time.RFC3339)))
+	}
+	srv, rec := newTestServer(t, login("token-1"), login("token-2"))
+	c := newTestClient(t, srv)
+
+	now := expiresAt.Add(-time.Hour)
+	source := PasswordTokenSource(c, "yamada@example.com", "password123").(*passwordTokenSource)
+	source.now = func() time.Time { return now }
+
+	// 有効期限までは同じトークンを使う
+	for range 2 {
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+	}
+
+	// 有効期限の直前に取り直す
+	now = expiresAt.Add(-30 * time.Second)
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+
+	requests := rec.all()
+	require.Len(t, requests, 2)
+	var body LoginRequest
+	require.NoError(t, json.Unmarshal([]byte(requests[0].body), &body))
+	assert.Equal(t, LoginRequest{Email: "yamada@example.com", Password: "password123"}, body)
+}
+
+func TestClient_GraphQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		response func(w http.ResponseWriter)
+		expected *GraphQLResponse
+		status   int
+	}{
+		{
+			name:     "正常系: dataを返す",
+			response: respond(http.StatusOK, `{"data":{"viewer":{"id":"1"}}}`),
+			expected: &GraphQLResponse{Data: json.RawMessage(`{"viewer":{"id":"1"}}`)},
+		},
+		{
+			name:     "正常系: 実行前の検証エラーもerrorsで返す",
+			response: respond(http.StatusBadRequest, `{"errors":[{"message":"query is too deep","extensions":{"code":"VALIDATION_ERROR"}}]}`),
+			expected: &GraphQLResponse{Errors: []GraphQLError{{Message: "query is too deep", Extensions: map[string]any{"code": "VALIDATION_ERROR"}}}},
+		},
+		{
+			name:     "異常系: 認証エラー",
+			response: respond(http.StatusUnauthorized, `{"success":false,"error":{"code":"UNAUTHORIZED","message":"認証が必要です"}}`),
+			status:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, rec := newTestServer(t, tt.response)
+			c := newTestClient(t, srv)
+
+			resp, err := c.GraphQL(context.Background(), GraphQLRequest{Query: `{ viewer { id } }`})
+
+			if tt.status != 0 {
+				assert.Equal(t, tt.status, StatusCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resp)
+			assert.JSONEq(t, `{"query":"{ viewer { id } }"}`, rec.all()[0].body)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("localhost:8080")
+	assert.Error(t, err)
+
+	c, err := New("https://api.example.com/km/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/km/api/v1", c.baseURL.String())
+	assert.True(t, errors.Is(&Error{StatusCode: http.StatusNotModified}, ErrNotModified))
+}
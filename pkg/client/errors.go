@@ -0,0 +1,81 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotModified If-None-Matchが一致した（304 Not Modified）
+var ErrNotModified = errors.New("not modified")
+
+// Error APIが2xx以外のステータスを返した
+type Error struct {
+	StatusCode int             // HTTPステータスコード
+	Code       ErrorCode       // エラーコード（VALIDATION_ERROR等）
+	Message    string          // エラーメッセージ
+	Details    string          // エラー詳細
+	Data       json.RawMessage // エラーの付加情報（OpenAPI検証の違反箇所等）
+	RequestID  string          // サーバーのログと照合するためのX-Request-ID
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "km api: %d", e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " %s", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.Details != "" {
+		fmt.Fprintf(&b, " (%s)", e.Details)
+	}
+	return b.String()
+}
+
+// Is errors.Is(err, ErrNotModified) で304を判定できるようにする
+func (e *Error) Is(target error) bool {
+	return target == ErrNotModified && e.StatusCode == http.StatusNotModified
+}
+
+// StatusCode エラーのHTTPステータスコード（APIのエラーでなければ0）
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// newError レスポンスからErrorを作成
+func newError(resp *http.Response) error {
+	defer drain(resp)
+
+	apiErr := &Error{StatusCode: resp.StatusCode, RequestID: resp.Header.Get(headerRequestID)}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil || len(body) == 0 {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil || env.Error == nil {
+		// 共通のレスポンス形式でないJSON（GraphQLのエラー等）はそのまま残す
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		if json.Valid(body) {
+			apiErr.Data = body
+		}
+		return apiErr
+	}
+	apiErr.Code = env.Error.Code
+	apiErr.Message = env.Error.Message
+	apiErr.Details = env.Error.Details
+	if len(env.Data) > 0 && string(env.Data) != "null" {
+		apiErr.Data = env.Data
+	}
+	return apiErr
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// Page ページネーション付きの一覧の1ページ
+type Page[T any] struct {
+	Items      []T
+	Pagination PaginationResponse
+}
+
+// HasNext 次のページがあるか
+func (p *Page[T]) HasNext() bool {
+	return p.Pagination.Page < p.Pagination.TotalPages
+}
+
+// paginate startのページから最後のページまで順に取得し、要素を1件ずつ返す
+// 取得に失敗した場合はエラーを返して終了する
+func paginate[T any](ctx context.Context, start int64, fetch func(page int64) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page := max(start, 1)
+		for {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			p, err := fetch(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range p.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(p.Items) == 0 || !p.HasNext() {
+				return
+			}
+			page = p.Pagination.Page + 1
+		}
+	}
+}
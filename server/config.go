@@ -0,0 +1,67 @@
+package server
+
+import (
+	"km-api-go/internal/activity"
+	"km-api-go/internal/attachment"
+	"km-api-go/internal/auth"
+	"km-api-go/internal/batch"
+	"km-api-go/internal/graph"
+	"km-api-go/internal/idempotency"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/openapi"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/ratelimit"
+	"km-api-go/internal/rpc"
+	"km-api-go/internal/storage"
+	"km-api-go/internal/user"
+	"km-api-go/internal/webhook"
+	"km-api-go/server/middleware"
+)
+
+// Config サーバー設定
+type Config struct {
+	SwaggerEnabled bool                         // Swagger UIを公開するか
+	MetricsEnabled bool                         // /metrics を公開するか
+	Security       middleware.SecurityConfig    // CORS・セキュリティヘッダー設定
+	Auth           auth.Config                  // 認証設定
+	RateLimit      ratelimit.Config             // レート制限設定
+	Idempotency    idempotency.Config           // Idempotency-Key設定
+	Jobs           job.Config                   // バックグラウンドジョブ設定
+	Outbox         outbox.Config                // ドメインイベントの配信設定
+	Webhook        webhook.Config               // 会社のWebhookの配信設定
+	Activity       activity.Config              // 会社のアクティビティストリームの設定
+	GraphQL        graph.Config                 // GraphQLエンドポイントの設定
+	GRPC           rpc.Config                   // gRPCサーバーの設定
+	OpenAPI        openapi.Config               // OpenAPI仕様によるリクエスト・レスポンス検証の設定
+	Deprecation    middleware.DeprecationConfig // 非推奨のAPIバージョン（/api/v1）の設定
+	Batch          batch.Config                 // 一括リクエスト（POST /batch）の設定
+	Storage        storage.Config               // アップロードされたファイルの保存先の設定
+	User           user.Config                  // ユーザーのアバター画像の設定
+	Attachment     attachment.Config            // 会社の添付ファイル・ロゴの設定
+}
+
+// LoadConfig 環境変数からサーバー設定を読み込み
+// 本番環境ではSWAGGER_ENABLEDに関わらずSwagger UIを無効化する
+func LoadConfig() Config {
+	return Config{
+		SwaggerEnabled: infra.GetEnvBool("SWAGGER_ENABLED", true) && !infra.IsProduction(),
+		MetricsEnabled: infra.GetEnvBool("METRICS_ENABLED", true),
+		Security:       middleware.LoadSecurityConfig(),
+		Auth:           auth.LoadConfig(),
+		RateLimit:      ratelimit.LoadConfig(),
+		Idempotency:    idempotency.LoadConfig(),
+		Jobs:           job.LoadConfig(),
+		Outbox:         outbox.LoadConfig(),
+		Webhook:        webhook.LoadConfig(),
+		Activity:       activity.LoadConfig(),
+		GraphQL:        graph.LoadConfig(),
+		GRPC:           rpc.LoadConfig(),
+		OpenAPI:        openapi.LoadConfig(),
+		Deprecation:    middleware.LoadDeprecationConfig(),
+		Batch:          batch.LoadConfig(),
+		Storage:        storage.LoadConfig(),
+		User:           user.LoadConfig(),
+		Attachment:     attachment.LoadConfig(),
+	}
+}
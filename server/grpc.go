@@ -0,0 +1,35 @@
+package server
+
+import (
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/company"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/rpc"
+	"km-api-go/internal/storage"
+	"km-api-go/internal/user"
+	userRepo "km-api-go/internal/user/repository"
+)
+
+// NewGRPCServer ユーザー・会社のユースケースをgRPCで公開するサーバーを作成
+// HTTPのルーターと同じユースケース・認証設定を使い、別のポートで待ち受ける
+func NewGRPCServer(db *gorm.DB, logger *slog.Logger, cfg Config, blobs storage.BlobStore) *rpc.Server {
+	transactor := infra.NewTransactor(db)
+	events := outbox.New(outbox.NewPostgresStore(db))
+
+	userUsecase := user.NewUserUsecase(userRepo.NewUserRepository(db), transactor, events, blobs, cfg.User)
+	companyUsecase := company.NewCompanyUsecase(
+		companyRepo.NewCompanyRepository(db),
+		companyRepo.NewCompanyUserRepository(db),
+		transactor,
+		events,
+	)
+
+	tokenService := auth.NewTokenService(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL)
+	return rpc.NewServer(cfg.GRPC, logger, tokenService, cfg.Auth, userUsecase, companyUsecase)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/idempotency"
+	"km-api-go/internal/importer"
+	importRepo "km-api-go/internal/importer/repository"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/ratelimit"
+	userRepo "km-api-go/internal/user/repository"
+	"km-api-go/internal/webhook"
+	webhookRepo "km-api-go/internal/webhook/repository"
+)
+
+// 定期実行するメンテナンスジョブ
+const (
+	jobCleanupIdempotencyKeys   = "maintenance.cleanup_idempotency_keys"
+	jobCleanupRateLimitBuckets  = "maintenance.cleanup_rate_limit_buckets"
+	jobCleanupOutbox            = "maintenance.cleanup_outbox"
+	jobCleanupWebhookDeliveries = "maintenance.cleanup_webhook_deliveries"
+	rateLimitBucketIdleDuration = 24 * time.Hour
+)
+
+// NewJobRegistry バックグラウンドジョブのハンドラーと定期実行を登録
+// APIサーバーに組み込む場合もcmd/workerで別プロセスとして動かす場合も同じ登録を使う
+func NewJobRegistry(db *gorm.DB, jobs job.Enqueuer, cfg Config) *job.Registry {
+	registry := job.NewRegistry()
+
+	outboxStore := outbox.NewPostgresStore(db)
+	importer.RegisterJobs(registry, importer.NewImportUsecase(
+		importRepo.NewImportRepository(db),
+		userRepo.NewUserRepository(db),
+		companyRepo.NewCompanyRepository(db),
+		companyRepo.NewCompanyUserRepository(db),
+		infra.NewTransactor(db),
+		outbox.New(outboxStore),
+		jobs,
+	))
+
+	webhook.RegisterJobs(registry, newWebhookUsecase(db, jobs, cfg))
+
+	// 配信済みのドメインイベントを削除
+	if cfg.Outbox.Retention > 0 {
+		registry.Handle(jobCleanupOutbox, func(ctx context.Context, _ *job.Job) error {
+			_, err := outboxStore.Cleanup(ctx, time.Now().Add(-cfg.Outbox.Retention))
+			return err
+		})
+		registry.Schedule(jobCleanupOutbox, time.Hour, nil)
+	}
+
+	// 終了したWebhookの配信履歴を削除
+	if cfg.Webhook.Retention > 0 {
+		deliveryRepository := webhookRepo.NewWebhookDeliveryRepository(db)
+		registry.Handle(jobCleanupWebhookDeliveries, func(ctx context.Context, _ *job.Job) error {
+			_, err := deliveryRepository.DeleteBefore(ctx, time.Now().Add(-cfg.Webhook.Retention))
+			return err
+		})
+		registry.Schedule(jobCleanupWebhookDeliveries, time.Hour, nil)
+	}
+
+	// Postgresに保存した期限切れのデータを削除（メモリの場合は各ストアが自分で破棄する）
+	if cfg.Idempotency.Backend == idempotency.BackendPostgres {
+		store := idempotency.NewPostgresStore(db)
+		registry.Handle(jobCleanupIdempotencyKeys, func(ctx context.Context, _ *job.Job) error {
+			_, err := store.Cleanup(ctx)
+			return err
+		})
+		registry.Schedule(jobCleanupIdempotencyKeys, time.Hour, nil)
+	}
+	if cfg.RateLimit.Backend == ratelimit.BackendPostgres {
+		store := ratelimit.NewPostgresStore(db)
+		registry.Handle(jobCleanupRateLimitBuckets, func(ctx context.Context, _ *job.Job) error {
+			_, err := store.Cleanup(ctx, rateLimitBucketIdleDuration)
+			return err
+		})
+		registry.Schedule(jobCleanupRateLimitBuckets, time.Hour, nil)
+	}
+
+	return registry
+}
+
+// NewOutboxRelay ドメインイベントを設定した配信先と会社のWebhookに送るリレーを作成
+// バックグラウンドジョブと同じプロセス（APIサーバーに組み込み、またはcmd/worker）で動かす
+func NewOutboxRelay(db *gorm.DB, jobs job.Enqueuer, cfg Config) (*outbox.Relay, error) {
+	sinks, err := outbox.NewSinks(cfg.Outbox)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Webhook.Enabled {
+		sinks = append(sinks, webhook.NewSink(newWebhookUsecase(db, jobs, cfg)))
+	}
+	return outbox.NewRelay(outbox.NewPostgresStore(db), sinks, cfg.Outbox), nil
+}
+
+func newWebhookUsecase(db *gorm.DB, jobs job.Enqueuer, cfg Config) webhook.WebhookUsecase {
+	return webhook.NewWebhookUsecase(
+		webhookRepo.NewWebhookRepository(db),
+		webhookRepo.NewWebhookDeliveryRepository(db),
+		companyRepo.NewCompanyRepository(db),
+		companyRepo.NewCompanyUserRepository(db),
+		infra.NewTransactor(db),
+		jobs,
+		cfg.Webhook,
+	)
+}
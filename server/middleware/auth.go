@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/helper"
+)
+
+// HeaderAPIKey APIキーを渡すヘッダー
+const HeaderAPIKey = "X-API-Key"
+
+// Authenticate Bearerトークン・APIキーを検証し、呼び出し元をコンテキストに設定するミドルウェア
+// 認証情報がなければ匿名のまま通し、不正な認証情報は401で拒否する
+func Authenticate(tokens *auth.TokenService, cfg auth.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			principal, err := auth.Authenticate(tokens, cfg, req.Header.Get(echo.HeaderAuthorization), req.Header.Get(HeaderAPIKey))
+			if err != nil {
+				return helper.UnauthorizedResponse(c)
+			}
+
+			if principal != nil {
+				c.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), principal)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireAuth 認証済みの呼び出し元のみ許可するミドルウェア
+func RequireAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := auth.PrincipalFromContext(c.Request().Context()); !ok {
+				return helper.UnauthorizedResponse(c)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/infra"
+)
+
+// 非推奨のAPIバージョンのレスポンスに付けるヘッダー
+const (
+	HeaderDeprecation = "Deprecation" // RFC 9745
+	HeaderSunset      = "Sunset"      // RFC 8594
+)
+
+// DeprecationConfig 非推奨のAPIバージョン（/api/v1）の設定
+type DeprecationConfig struct {
+	Enabled   bool      // Deprecation/Sunset/Linkヘッダーを付けるか
+	Date      time.Time // 非推奨になる（なった）日時
+	Sunset    time.Time // 提供を終了する日時（ゼロ値の場合はSunsetヘッダーを付けない）
+	Prefix    string    // 非推奨のバージョンのベースパス
+	Successor string    // 後継のバージョンのベースパス（Link rel="successor-version"）
+	DocURL    string    // 移行ガイドのURL（Link rel="deprecation"。空の場合は付けない）
+}
+
+// LoadDeprecationConfig 環境変数から/api/v1の非推奨の設定を読み込み
+func LoadDeprecationConfig() DeprecationConfig {
+	return DeprecationConfig{
+		Enabled:   infra.GetEnvBool("API_V1_DEPRECATION_ENABLED", true),
+		Date:      infra.GetEnvTime("API_V1_DEPRECATED_AT", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)),
+		Sunset:    infra.GetEnvTime("API_V1_SUNSET_AT", time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC)),
+		Prefix:    "/api/v1",
+		Successor: "/api/v2",
+		DocURL:    infra.GetEnv("API_V1_DEPRECATION_DOC_URL", ""),
+	}
+}
+
+// Deprecation 非推奨のバージョンのレスポンスにDeprecation・Sunset・Linkヘッダーを付けるミドルウェア
+// 後継のリンクは同じリソースの後継バージョンのパス（/api/v1/users/1 → /api/v2/users/1）を指す
+func Deprecation(cfg DeprecationConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !cfg.Enabled {
+			return next
+		}
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set(HeaderDeprecation, "@"+strconv.FormatInt(cfg.Date.Unix(), 10))
+			if !cfg.Sunset.IsZero() {
+				header.Set(HeaderSunset, cfg.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if rest, ok := strings.CutPrefix(c.Request().URL.Path, cfg.Prefix); ok && cfg.Successor != "" {
+				header.Add("Link", "<"+cfg.Successor+rest+`>; rel="successor-version"`)
+			}
+			if cfg.DocURL != "" {
+				header.Add("Link", "<"+cfg.DocURL+`>; rel="deprecation"; type="text/html"`)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecation(t *testing.T) {
+	date := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		cfg               DeprecationConfig
+		path              string
+		expectDeprecation string
+		expectSunset      string
+		expectLinks       []string
+	}{
+		{
+			name:              "正常系: 非推奨のバージョンにヘッダーを付ける",
+			cfg:               DeprecationConfig{Enabled: true, Date: date, Sunset: sunset, Prefix: "/api/v1", Successor: "/api/v2", DocURL: "https://example.com/migration"},
+			path:              "/api/v1/users/1",
+			expectDeprecation: "@1793491200",
+			expectSunset:      "Sat, 01 May 2027 00:00:00 GMT",
+			expectLinks: []string{
+				`</api/v2/users/1>; rel="successor-version"`,
+				`<https://example.com/migration>; rel="deprecation"; type="text/html"`,
+			},
+		},
+		{
+			name:              "正常系: 終了日時・移行ガイドがない場合",
+			cfg:               DeprecationConfig{Enabled: true, Date: date, Prefix: "/api/v1", Successor: "/api/v2"},
+			path:              "/api/v1/companies/1/webhooks",
+			expectDeprecation: "@1793491200",
+			expectLinks:       []string{`</api/v2/companies/1/webhooks>; rel="successor-version"`},
+		},
+		{
+			name: "正常系: 無効の場合は付けない",
+			cfg:  DeprecationConfig{Enabled: false, Date: date, Sunset: sunset, Prefix: "/api/v1", Successor: "/api/v2"},
+			path: "/api/v1/users/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			g := e.Group("/api/v1", Deprecation(tt.cfg))
+			g.GET("/*", func(c echo.Context) error {
+				return c.NoContent(http.StatusNotFound)
+			})
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			// エラーのレスポンスにも付ける
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Equal(t, tt.expectDeprecation, rec.Header().Get(HeaderDeprecation))
+			assert.Equal(t, tt.expectSunset, rec.Header().Get(HeaderSunset))
+			assert.Equal(t, tt.expectLinks, rec.Header().Values("Link"))
+		})
+	}
+}
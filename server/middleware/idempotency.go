@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/helper"
+	"km-api-go/internal/idempotency"
+	"km-api-go/internal/logging"
+)
+
+// Idempotency-Key関連のヘッダー
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength Idempotency-Keyヘッダーの最大長
+const maxIdempotencyKeyLength = 255
+
+// replayedHeaders 保存し、再試行のレスポンスにも付けるヘッダー
+var replayedHeaders = []string{helper.HeaderETag, echo.HeaderLocation}
+
+// Idempotency POSTリクエストのIdempotency-Keyを処理するミドルウェア
+// 同じキーの再試行には最初のレスポンスを返し、異なるボディでの再利用は422で拒否する
+// 5xx・ハンドラーのエラーは保存せず、再試行で処理し直せるようにする
+// scopeFuncでキーを呼び出し元ごとに分離する（Authenticateより後に登録する）
+func Idempotency(store idempotency.Store, cfg idempotency.Config, scopeFunc RateLimitKeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if req.Method != http.MethodPost || key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return helper.ValidationErrorResponse(c, "Idempotency-Keyが長すぎます")
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			logger := logging.FromContext(ctx)
+			storeKey := scopeFunc(c) + ":" + key
+			fingerprint := idempotency.Fingerprint(req.Method, req.URL.Path, body)
+
+			record, acquired, err := store.Acquire(ctx, storeKey, fingerprint, cfg.TTL, cfg.LockTimeout)
+			if err != nil {
+				logger.ErrorContext(ctx, "idempotency store unavailable", slog.Any("error", err))
+				return helper.InternalErrorResponse(c, "")
+			}
+
+			if !acquired {
+				return replay(c, record, fingerprint)
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			// 保存処理はクライアントの切断に影響されないようにする
+			storeCtx := context.WithoutCancel(ctx)
+			// レスポンスを保存しなかった場合（エラー・5xx・panic）はキーを解放する
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if releaseErr := store.Release(storeCtx, storeKey); releaseErr != nil {
+					logger.WarnContext(ctx, "failed to release idempotency key", slog.Any("error", releaseErr))
+				}
+			}()
+
+			err = next(c)
+
+			status := c.Response().Status
+			if err != nil || status >= http.StatusInternalServerError {
+				return err
+			}
+
+			resp := idempotency.Response{
+				StatusCode:  status,
+				ContentType: c.Response().Header().Get(echo.HeaderContentType),
+				Headers:     make(map[string]string),
+				Body:        recorder.body.Bytes(),
+			}
+			for _, name := range replayedHeaders {
+				if v := c.Response().Header().Get(name); v != "" {
+					resp.Headers[name] = v
+				}
+			}
+			completed = true
+			if err := store.Complete(storeCtx, storeKey, resp); err != nil {
+				logger.WarnContext(ctx, "failed to save idempotent response", slog.Any("error", err))
+			}
+			return nil
+		}
+	}
+}
+
+// replay 保存済みの記録に応じてレスポンスを返す
+func replay(c echo.Context, record *idempotency.Record, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return helper.ErrorResponse(c, http.StatusUnprocessableEntity, helper.ErrorCodeIdempotencyKeyReused,
+			"Idempotency-Keyが異なるリクエストで使用されています", "")
+	}
+	if !record.Completed {
+		return helper.ErrorResponse(c, http.StatusConflict, helper.ErrorCodeRequestInProgress,
+			"同じIdempotency-Keyのリクエストを処理中です", "")
+	}
+
+	for name, value := range record.Headers {
+		c.Response().Header().Set(name, value)
+	}
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	return c.Blob(record.StatusCode, record.ContentType, record.Body)
+}
+
+// responseRecorder 書き込まれたレスポンスボディを保持する
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/helper"
+	"km-api-go/internal/idempotency"
+)
+
+func TestIdempotency(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	cfg := idempotency.Config{Enabled: true, TTL: time.Hour, LockTimeout: time.Minute}
+
+	calls := 0
+	e := echo.New()
+	e.Use(echoMiddleware.Recover())
+	e.Use(Idempotency(store, cfg, KeyByPrincipal))
+	e.POST("/api/v1/users", func(c echo.Context) error {
+		calls++
+		c.Response().Header().Set(helper.HeaderETag, `"1"`)
+		c.Response().Header().Set(echo.HeaderLocation, "/api/v1/users/"+strconv.Itoa(calls))
+		return c.JSON(http.StatusCreated, map[string]int{"id": calls})
+	})
+	e.POST("/api/v1/panic", func(c echo.Context) error {
+		calls++
+		panic("handler panicked")
+	})
+	e.POST("/api/v1/fail", func(c echo.Context) error {
+		calls++
+		return c.NoContent(http.StatusInternalServerError)
+	})
+
+	serve := func(path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 処理中のキーを用意
+	_, acquired, err := store.Acquire(t.Context(), "ip:192.0.2.1:in-progress",
+		idempotency.Fingerprint(http.MethodPost, "/api/v1/users", []byte(`{"name":"a"}`)), time.Hour, time.Hour)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	tests := []struct {
+		name           string
+		path           string
+		key            string
+		body           string
+		expectedStatus int
+		expectedCalls  int
+		expectReplayed bool
+		expectBody     string
+		expectLocation string
+	}{
+		{name: "正常系: 初回リクエストは処理される", path: "/api/v1/users", key: "k1", body: `{"name":"a"}`, expectedStatus: http.StatusCreated, expectedCalls: 1, expectBody: `{"id":1}`, expectLocation: "/api/v1/users/1"},
+		{name: "正常系: 再試行は最初のレスポンスとETag・Locationを返す", path: "/api/v1/users", key: "k1", body: `{"name":"a"}`, expectedStatus: http.StatusCreated, expectedCalls: 1, expectReplayed: true, expectBody: `{"id":1}`, expectLocation: "/api/v1/users/1"},
+		{name: "異常系: 異なるボディでのキー再利用", path: "/api/v1/users", key: "k1", body: `{"name":"b"}`, expectedStatus: http.StatusUnprocessableEntity, expectedCalls: 1},
+		{name: "異常系: 処理中のキー", path: "/api/v1/users", key: "in-progress", body: `{"name":"a"}`, expectedStatus: http.StatusConflict, expectedCalls: 1},
+		{name: "正常系: キーなしは毎回処理される", path: "/api/v1/users", body: `{"name":"a"}`, expectedStatus: http.StatusCreated, expectedCalls: 2, expectBody: `{"id":2}`},
+		{name: "正常系: 5xxは保存されない", path: "/api/v1/fail", key: "k2", expectedStatus: http.StatusInternalServerError, expectedCalls: 3},
+		{name: "正常系: 5xx後の再試行は処理し直す", path: "/api/v1/fail", key: "k2", expectedStatus: http.StatusInternalServerError, expectedCalls: 4},
+		{name: "正常系: panicしたリクエストはキーを解放する", path: "/api/v1/panic", key: "k3", expectedStatus: http.StatusInternalServerError, expectedCalls: 5},
+		{name: "正常系: panic後の再試行は処理し直す", path: "/api/v1/panic", key: "k3", expectedStatus: http.StatusInternalServerError, expectedCalls: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.path, tt.key, tt.body)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.expectReplayed {
+				assert.Equal(t, "true", rec.Header().Get(HeaderIdempotentReplayed))
+			} else {
+				assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+			}
+			if tt.expectBody != "" {
+				assert.JSONEq(t, tt.expectBody, rec.Body.String())
+			}
+			if tt.expectLocation != "" {
+				assert.Equal(t, tt.expectLocation, rec.Header().Get(echo.HeaderLocation))
+				assert.Equal(t, `"1"`, rec.Header().Get(helper.HeaderETag))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/logging"
+)
+
+// maxRequestIDLength 受け入れるX-Request-IDの最大長
+const maxRequestIDLength = 128
+
+// RequestID X-Request-IDを受け入れ（なければ生成し）、レスポンスヘッダーと
+// リクエストコンテキストのロガーに設定するミドルウェア
+func RequestID(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			requestID := req.Header.Get(echo.HeaderXRequestID)
+			if requestID == "" || len(requestID) > maxRequestIDLength {
+				requestID = generateRequestID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			ctx := logging.WithLogger(req.Context(), logger)
+			ctx = logging.WithRequestID(ctx, requestID)
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// RequestLogger アクセスログをslogで出力するミドルウェア
+// RequestIDより後に登録し、コンテキストのロガーを利用する
+func RequestLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				// エラーハンドラーを先に実行し、確定したステータスを記録する
+				c.Error(err)
+			}
+
+			req := c.Request()
+			res := c.Response()
+			attrs := []any{
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+				slog.String("route", c.Path()),
+				slog.Int("status", res.Status),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int64("bytes_out", res.Size),
+				slog.String("remote_ip", c.RealIP()),
+				slog.String("user_agent", req.UserAgent()),
+			}
+
+			logger := logging.FromContext(req.Context())
+			switch {
+			case res.Status >= 500:
+				if err != nil {
+					attrs = append(attrs, slog.String("error", err.Error()))
+				}
+				logger.ErrorContext(req.Context(), "request", attrs...)
+			case res.Status >= 400:
+				logger.WarnContext(req.Context(), "request", attrs...)
+			default:
+				logger.InfoContext(req.Context(), "request", attrs...)
+			}
+
+			return nil
+		}
+	}
+}
+
+// generateRequestID ランダムなリクエストIDを生成
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"km-api-go/internal/logging"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name          string
+		incomingID    string
+		expectSameID  bool
+		expectedIDLen int
+	}{
+		{name: "正常系: 受け取ったX-Request-IDを引き継ぐ", incomingID: "abc-123", expectSameID: true},
+		{name: "正常系: X-Request-IDがなければ生成する", incomingID: "", expectedIDLen: 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logging.New(logging.Config{Level: slog.LevelInfo, Format: "json"}, &buf)
+
+			e := echo.New()
+			e.Use(RequestID(logger))
+			e.Use(RequestLogger())
+
+			var ctxRequestID string
+			e.GET("/api/v1/health", func(c echo.Context) error {
+				ctxRequestID = logging.RequestIDFromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+			if tt.incomingID != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.incomingID)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			responseID := rec.Header().Get(echo.HeaderXRequestID)
+			if tt.expectSameID {
+				assert.Equal(t, tt.incomingID, responseID)
+			} else {
+				assert.Len(t, responseID, tt.expectedIDLen)
+			}
+			assert.Equal(t, responseID, ctxRequestID)
+
+			// アクセスログにリクエストIDとルートが出力されていること
+			var entry map[string]interface{}
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, responseID, entry["request_id"])
+			assert.Equal(t, "/api/v1/health", entry["route"])
+			assert.Equal(t, float64(http.StatusOK), entry["status"])
+		})
+	}
+}
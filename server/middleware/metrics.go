@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/metrics"
+)
+
+// unmatchedRoute ルートに一致しなかったリクエストのラベル（カーディナリティ抑制のため）
+const unmatchedRoute = "unmatched"
+
+// Metrics HTTPリクエストの処理時間をルートテンプレート・ステータス別に記録するミドルウェア
+func Metrics(skipPaths ...string) echo.MiddlewareFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := skip[c.Path()]; ok {
+				return next(c)
+			}
+
+			start := time.Now()
+			metrics.HTTPRequestsInFlight.Inc()
+			defer metrics.HTTPRequestsInFlight.Dec()
+
+			err := next(c)
+
+			route := c.Path()
+			if errors.Is(err, echo.ErrNotFound) || errors.Is(err, echo.ErrMethodNotAllowed) {
+				route = unmatchedRoute
+			}
+
+			metrics.HTTPRequestDuration.
+				WithLabelValues(c.Request().Method, route, strconv.Itoa(responseStatus(c, err))).
+				Observe(time.Since(start).Seconds())
+
+			// エラーは外側のミドルウェア・エラーハンドラーに任せる
+			return err
+		}
+	}
+}
+
+// responseStatus エラーハンドラーが返すステータスを推定する
+// レスポンスを書き込み済みの場合はそのステータス、echo.HTTPErrorの場合はそのコード、それ以外は500
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+
+	"km-api-go/internal/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	var returned error
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			returned = next(c)
+			return returned
+		}
+	})
+	e.Use(Metrics("/metrics"))
+	e.GET("/api/v1/users/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/api/v1/conflict", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusConflict)
+	})
+	e.GET("/api/v1/fail", func(c echo.Context) error {
+		return errors.New("unexpected")
+	})
+	e.GET("/metrics", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name        string
+		path        string
+		route       string
+		status      string
+		expectCount uint64
+		expectErr   bool
+	}{
+		{name: "正常系: ルートテンプレートでラベル付けされる", path: "/api/v1/users/42", route: "/api/v1/users/:id", status: "200", expectCount: 1},
+		{name: "正常系: 未定義ルートはunmatchedにまとめる", path: "/api/v1/no/such/path", route: unmatchedRoute, status: "404", expectCount: 1, expectErr: true},
+		{name: "正常系: HTTPErrorはそのステータスで記録し、エラーを返す", path: "/api/v1/conflict", route: "/api/v1/conflict", status: "409", expectCount: 1, expectErr: true},
+		{name: "正常系: その他のエラーは500で記録し、エラーを返す", path: "/api/v1/fail", route: "/api/v1/fail", status: "500", expectCount: 1, expectErr: true},
+		{name: "正常系: スキップ対象のパスは記録しない", path: "/metrics", route: "/metrics", status: "200", expectCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectErr, returned != nil)
+			assert.Equal(t, tt.status, strconv.Itoa(rec.Code))
+
+			var m dto.Metric
+			observer := metrics.HTTPRequestDuration.WithLabelValues(http.MethodGet, tt.route, tt.status)
+			assert.NoError(t, observer.(prometheus.Metric).Write(&m))
+			assert.Equal(t, tt.expectCount, m.GetHistogram().GetSampleCount())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/openapi"
+)
+
+// OpenAPIValidation 生成したOpenAPI仕様でパス・クエリ・ボディを検証するミドルウェア
+// 仕様に合わないリクエストは400で拒否し、指摘をdataに返す。仕様にないルートはそのまま通す
+// cfg.ValidateResponsesの場合はJSONのレスポンスも検証し、仕様違反を500に置き換える（テストで契約違反を検出する）
+func OpenAPIValidation(validator *openapi.Validator, cfg openapi.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route, err := validator.Route(req.Method, c.Path())
+			if err != nil {
+				return next(c)
+			}
+
+			pathParams := make(map[string]string, len(c.ParamNames()))
+			for i, name := range c.ParamNames() {
+				pathParams[name] = c.ParamValues()[i]
+			}
+
+			ctx := req.Context()
+			if err := validator.ValidateRequest(ctx, req, route, pathParams); err != nil {
+				var validationErr *openapi.ValidationError
+				if !errors.As(err, &validationErr) {
+					return err
+				}
+				return c.JSON(http.StatusBadRequest, helper.APIResponse{
+					Success: false,
+					Data:    validationErr.Issues,
+					Error: &helper.APIError{
+						Code:    helper.ErrorCodeValidation,
+						Message: "リクエストがAPI仕様に合いません",
+						Details: validationErr.Error(),
+					},
+				})
+			}
+
+			if !cfg.ValidateResponses {
+				return next(c)
+			}
+
+			res := c.Response()
+			original := res.Writer
+			buffer := &bufferedResponse{ResponseWriter: original}
+			res.Writer = buffer
+			err = next(c)
+			res.Writer = original
+
+			if !buffer.buffering {
+				return err
+			}
+
+			body := buffer.body.Bytes()
+			if validateErr := validator.ValidateResponse(ctx, req, route, pathParams, buffer.status, res.Header(), body); validateErr != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "response does not match openapi spec",
+					slog.String("route", c.Path()),
+					slog.Int("status", buffer.status),
+					slog.Any("error", validateErr),
+				)
+				res.Status = http.StatusInternalServerError
+				body, _ = json.Marshal(helper.APIResponse{
+					Success: false,
+					Error: &helper.APIError{
+						Code:    helper.ErrorCodeContractViolation,
+						Message: "レスポンスがAPI仕様に合いません",
+						Details: validateErr.Error(),
+					},
+				})
+				res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				buffer.status = http.StatusInternalServerError
+			}
+
+			res.Header().Set(echo.HeaderContentLength, strconv.Itoa(len(body)))
+			original.WriteHeader(buffer.status)
+			_, writeErr := original.Write(body)
+			return errors.Join(err, writeErr)
+		}
+	}
+}
+
+// bufferedResponse JSONのレスポンスを検証のために保持する
+// JSON以外（SSE・CSVのエクスポート等）はストリーミングを妨げないようそのまま書き込む
+type bufferedResponse struct {
+	http.ResponseWriter
+	wroteHeader bool
+	buffering   bool
+	status      int
+	body        bytes.Buffer
+}
+
+func (r *bufferedResponse) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	r.status = status
+	r.buffering = openapi.IsJSON(r.Header().Get(echo.HeaderContentType))
+	if !r.buffering {
+		r.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (r *bufferedResponse) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	if r.buffering {
+		return r.body.Write(b)
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *bufferedResponse) Flush() {
+	if r.buffering {
+		return
+	}
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *bufferedResponse) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"km-api-go/internal/openapi"
+)
+
+// testSpec 検証用の最小限の仕様（swagの出力と同じSwagger 2.0）
+const testSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "test", "version": "1.0"},
+	"basePath": "/api/v1",
+	"paths": {
+		"/items/{id}": {
+			"put": {
+				"consumes": ["application/json"],
+				"produces": ["application/json"],
+				"parameters": [
+					{"name": "id", "in": "path", "type": "integer", "required": true},
+					{"name": "body", "in": "body", "required": true, "schema": {
+						"type": "object",
+						"required": ["name"],
+						"properties": {"name": {"type": "string", "minLength": 2}}
+					}}
+				],
+				"responses": {"200": {"description": "OK", "schema": {
+					"type": "object",
+					"required": ["id"],
+					"properties": {"id": {"type": "integer"}}
+				}}}
+			}
+		},
+		"/items/{id}/export": {
+			"get": {
+				"produces": ["text/csv"],
+				"parameters": [{"name": "id", "in": "path", "type": "integer", "required": true}],
+				"responses": {"200": {"description": "OK"}}
+			}
+		}
+	}
+}`
+
+func TestOpenAPIValidation(t *testing.T) {
+	validator, err := openapi.NewValidator([]byte(testSpec))
+	require.NoError(t, err)
+
+	newEcho := func(cfg openapi.Config, response string) *echo.Echo {
+		e := echo.New()
+		api := e.Group("/api/v1", OpenAPIValidation(validator, cfg))
+		api.PUT("/items/:id", func(c echo.Context) error {
+			return c.JSONBlob(http.StatusOK, []byte(response))
+		})
+		api.GET("/items/:id/export", func(c echo.Context) error {
+			c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+			c.Response().WriteHeader(http.StatusOK)
+			c.Response().Flush()
+			_, err := c.Response().Write([]byte("id\n1\n"))
+			return err
+		})
+		api.GET("/undocumented", func(c echo.Context) error {
+			return c.String(http.StatusOK, "ok")
+		})
+		return e
+	}
+
+	tests := []struct {
+		name           string
+		cfg            openapi.Config
+		response       string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "正常系: 仕様に合うリクエスト",
+			cfg:            openapi.Config{Enabled: true},
+			response:       `{"id":1}`,
+			method:         http.MethodPut,
+			path:           "/api/v1/items/1",
+			body:           `{"name":"商品A"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1}`,
+		},
+		{
+			name:           "正常系: 仕様にないルートは検証しない",
+			cfg:            openapi.Config{Enabled: true, ValidateResponses: true},
+			method:         http.MethodGet,
+			path:           "/api/v1/undocumented?x=1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: パスとボディが仕様に合わない",
+			cfg:            openapi.Config{Enabled: true},
+			response:       `{"id":1}`,
+			method:         http.MethodPut,
+			path:           "/api/v1/items/abc",
+			body:           `{"name":"A"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"success":false,"data":[
+				{"in":"path","name":"id","message":"value abc: an invalid integer: invalid syntax"},
+				{"in":"body","name":"name","message":"minimum string length is 2"}
+			],"error":{"code":"VALIDATION_ERROR","message":"リクエストがAPI仕様に合いません",
+				"details":"path id: value abc: an invalid integer: invalid syntax; body name: minimum string length is 2"}}`,
+		},
+		{
+			name:           "正常系: レスポンスの検証（仕様に合う）",
+			cfg:            openapi.Config{Enabled: true, ValidateResponses: true},
+			response:       `{"id":1}`,
+			method:         http.MethodPut,
+			path:           "/api/v1/items/1",
+			body:           `{"name":"商品A"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1}`,
+		},
+		{
+			name:           "異常系: レスポンスが仕様に合わない場合は500",
+			cfg:            openapi.Config{Enabled: true, ValidateResponses: true},
+			response:       `{"id":"1"}`,
+			method:         http.MethodPut,
+			path:           "/api/v1/items/1",
+			body:           `{"name":"商品A"}`,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: `{"success":false,"error":{"code":"CONTRACT_VIOLATION","message":"レスポンスがAPI仕様に合いません",
+				"details":"response id: value must be an integer"}}`,
+		},
+		{
+			name:           "正常系: JSON以外のレスポンスはそのまま流す",
+			cfg:            openapi.Config{Enabled: true, ValidateResponses: true},
+			method:         http.MethodGet,
+			path:           "/api/v1/items/1/export",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEcho(tt.cfg, tt.response)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			if tt.path == "/api/v1/items/1/export" {
+				assert.Equal(t, "id\n1\n", rec.Body.String())
+				assert.True(t, rec.Flushed)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/helper"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/ratelimit"
+)
+
+// RateLimit レスポンスヘッダー（draft-ietf-httpapi-ratelimit-headers）
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimitKeyFunc レート制限のバケットを識別するキーを返す
+type RateLimitKeyFunc func(c echo.Context) string
+
+// KeyByPrincipal 認証済みユーザー → APIキー → クライアントIPの順でキーを決める
+// Authenticateより後に登録する
+func KeyByPrincipal(c echo.Context) string {
+	if p, ok := auth.PrincipalFromContext(c.Request().Context()); ok {
+		if p.IsUser() {
+			return "user:" + strconv.FormatUint(uint64(p.UserID), 10)
+		}
+		if p.APIKey != "" {
+			return "key:" + p.APIKey
+		}
+	}
+	return "ip:" + c.RealIP()
+}
+
+// RateLimit トークンバケットによるレート制限ミドルウェア
+// バックエンドの障害時はリクエストを許可する（fail-open）
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy, keyFunc RateLimitKeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			key := fmt.Sprintf("%s:%s", policy.Name, keyFunc(c))
+
+			result, err := store.Take(ctx, key, policy)
+			if err != nil {
+				logging.FromContext(ctx).WarnContext(ctx, "rate limit backend unavailable", slog.Any("error", err))
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set(HeaderRateLimitPolicy, policy.String())
+			h.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			h.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			h.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				h.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				return helper.ErrorResponse(c, http.StatusTooManyRequests, helper.ErrorCodeRateLimited,
+					"リクエスト数が上限を超えました。しばらく待ってから再試行してください", "")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
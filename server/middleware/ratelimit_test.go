@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"km-api-go/internal/auth"
+	"km-api-go/internal/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", time.Hour)
+	userToken, _, err := tokens.Issue(7)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	e.Use(Authenticate(tokens, auth.Config{APIKeys: []auth.APIKey{{Name: "crm", Key: "crm-key"}}}))
+	policy := ratelimit.Policy{Name: "test", Limit: 2, Period: time.Minute}
+	e.Use(RateLimit(ratelimit.NewMemoryStore(time.Minute), policy, KeyByPrincipal))
+	e.GET("/api/v1/users", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	serve := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+		req.RemoteAddr = "192.0.2.1:12345"
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{name: "正常系: クライアントIP単位で制限"},
+		{name: "正常系: 認証済みユーザー単位で制限", header: echo.HeaderAuthorization, value: "Bearer " + userToken},
+		{name: "正常系: APIキー単位で制限", header: HeaderAPIKey, value: "crm-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// キーごとに独立したバケットのため、各ケースで2回まで許可される
+			rec := serve(tt.header, tt.value)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "2", rec.Header().Get(HeaderRateLimitLimit))
+			assert.Equal(t, "1", rec.Header().Get(HeaderRateLimitRemaining))
+			assert.Equal(t, "2;w=60", rec.Header().Get(HeaderRateLimitPolicy))
+
+			rec = serve(tt.header, tt.value)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "0", rec.Header().Get(HeaderRateLimitRemaining))
+
+			rec = serve(tt.header, tt.value)
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+			assert.Equal(t, "60", rec.Header().Get(HeaderRateLimitReset))
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	tokens := auth.NewTokenService("test-secret", time.Hour)
+	validToken, _, err := tokens.Issue(7)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	e.Use(Authenticate(tokens, auth.Config{}))
+	e.GET("/private", func(c echo.Context) error {
+		userID, _ := auth.UserIDFromContext(c.Request().Context())
+		return c.JSON(http.StatusOK, userID)
+	}, RequireAuth())
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "正常系: 有効なトークン", authorization: "Bearer " + validToken, expectedStatus: http.StatusOK},
+		{name: "異常系: 認証情報なし", expectedStatus: http.StatusUnauthorized},
+		{name: "異常系: 不正なトークン", authorization: "Bearer invalid", expectedStatus: http.StatusUnauthorized},
+		{name: "異常系: Bearer以外の形式", authorization: "Basic dXNlcjpwYXNz", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/private", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+
+	"km-api-go/internal/helper"
+	"km-api-go/internal/infra"
+)
+
+// SwaggerPathPrefix Swagger UIのパスプレフィックス
+const SwaggerPathPrefix = "/swagger/"
+
+// SecurityConfig CORS・セキュリティヘッダー・ボディサイズ制限の設定
+type SecurityConfig struct {
+	CORSOrigins          []string // 許可するオリジン
+	CORSMethods          []string // 許可するHTTPメソッド
+	CORSHeaders          []string // 許可するリクエストヘッダー
+	CORSExposeHeaders    []string // クライアントに公開するレスポンスヘッダー
+	CORSAllowCredentials bool     // Cookie等の資格情報を許可するか
+	CORSMaxAge           int      // プリフライト結果のキャッシュ秒数
+
+	HSTSMaxAge            int    // Strict-Transport-Securityのmax-age（0で無効）
+	ContentSecurityPolicy string // API用のContent-Security-Policy
+	SwaggerCSP            string // Swagger UI用のContent-Security-Policy
+	ReferrerPolicy        string // Referrer-Policy
+
+	BodyLimit       string // リクエストボディの最大サイズ（例: 2M）
+	UploadBodyLimit string // ファイルをアップロードするルートのリクエストボディの最大サイズ
+
+	TrustedProxies []string // X-Forwarded-Forを信頼するプロキシのIPアドレス・CIDR（空の場合は接続元のIPを使う）
+}
+
+// LoadSecurityConfig 環境変数からセキュリティ設定を読み込み
+func LoadSecurityConfig() SecurityConfig {
+	return SecurityConfig{
+		CORSOrigins: infra.GetEnvList("CORS_ORIGINS", []string{"http://localhost:3000"}),
+		CORSMethods: infra.GetEnvList("CORS_METHODS", []string{
+			http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+		}),
+		CORSHeaders: infra.GetEnvList("CORS_HEADERS", []string{
+			echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, HeaderAPIKey,
+			helper.HeaderIfMatch, helper.HeaderIfNoneMatch, HeaderIdempotencyKey, helper.HeaderLastEventID,
+		}),
+		CORSExposeHeaders: infra.GetEnvList("CORS_EXPOSE_HEADERS", []string{
+			helper.HeaderETag, echo.HeaderLocation, echo.HeaderRetryAfter, echo.HeaderXRequestID,
+			HeaderRateLimitLimit, HeaderRateLimitRemaining, HeaderRateLimitReset, HeaderRateLimitPolicy,
+			HeaderIdempotentReplayed, HeaderDeprecation, HeaderSunset, "Link",
+		}),
+		CORSAllowCredentials: infra.GetEnvBool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAge:           infra.GetEnvInt("CORS_MAX_AGE", 600),
+
+		HSTSMaxAge:            infra.GetEnvInt("HSTS_MAX_AGE", 31536000),
+		ContentSecurityPolicy: infra.GetEnv("CSP", "default-src 'none'; frame-ancestors 'none'"),
+		SwaggerCSP: infra.GetEnv("SWAGGER_CSP",
+			"default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:"),
+		ReferrerPolicy: infra.GetEnv("REFERRER_POLICY", "strict-origin-when-cross-origin"),
+
+		BodyLimit:       infra.GetEnv("BODY_LIMIT", "2M"),
+		UploadBodyLimit: infra.GetEnv("UPLOAD_BODY_LIMIT", "20M"),
+
+		TrustedProxies: infra.GetEnvList("TRUSTED_PROXIES", nil),
+	}
+}
+
+// Validate 設定を検証
+// TRUSTED_PROXIESにIPアドレス・CIDRとして解釈できない値がある場合はエラーを返す
+func (c SecurityConfig) Validate() error {
+	_, err := parseTrustedProxies(c.TrustedProxies)
+	return err
+}
+
+// IPExtractor クライアントIPの取得方法
+// TrustedProxiesが空の場合は接続元のIPを使い、クライアントが送ったX-Forwarded-For・X-Real-IPは無視する
+// 指定されている場合は、それらのプロキシを経由した分だけX-Forwarded-Forをたどる
+func IPExtractor(cfg SecurityConfig) echo.IPExtractor {
+	ranges, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil || len(ranges) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, r := range ranges {
+		options = append(options, echo.TrustIPRange(r))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// parseTrustedProxies IPアドレス・CIDRの一覧を解析（IPアドレスは単一ホストの範囲として扱う）
+func parseTrustedProxies(values []string) ([]*net.IPNet, error) {
+	ranges := make([]*net.IPNet, 0, len(values))
+	for _, v := range values {
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %q", v)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %q", v)
+		}
+		ranges = append(ranges, ipNet)
+	}
+	return ranges, nil
+}
+
+// CORS 設定に基づくCORSミドルウェア
+func CORS(cfg SecurityConfig) echo.MiddlewareFunc {
+	return echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
+		AllowOrigins:     cfg.CORSOrigins,
+		AllowMethods:     cfg.CORSMethods,
+		AllowHeaders:     cfg.CORSHeaders,
+		ExposeHeaders:    cfg.CORSExposeHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	})
+}
+
+// SecurityHeaders セキュリティヘッダーを付与するミドルウェア
+// Swagger UIはインラインスクリプトを使うため、専用のCSPを適用する
+func SecurityHeaders(cfg SecurityConfig) echo.MiddlewareFunc {
+	base := echoMiddleware.SecureConfig{
+		XSSProtection:      "0",
+		ContentTypeNosniff: "nosniff",
+		XFrameOptions:      "DENY",
+		HSTSMaxAge:         cfg.HSTSMaxAge,
+		ReferrerPolicy:     cfg.ReferrerPolicy,
+	}
+
+	api := base
+	api.ContentSecurityPolicy = cfg.ContentSecurityPolicy
+	api.Skipper = isSwaggerRequest
+	apiSecure := echoMiddleware.SecureWithConfig(api)
+
+	swagger := base
+	swagger.XFrameOptions = "SAMEORIGIN"
+	swagger.ContentSecurityPolicy = cfg.SwaggerCSP
+	swagger.Skipper = func(c echo.Context) bool { return !isSwaggerRequest(c) }
+	swaggerSecure := echoMiddleware.SecureWithConfig(swagger)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return apiSecure(swaggerSecure(next))
+	}
+}
+
+// BodyLimit リクエストボディのサイズ制限ミドルウェア
+// uploadRoutesで終わるルート（例: /users/:id/avatar）にはUploadBodyLimitを適用する
+// ファイルごとの上限は各ハンドラー・ユースケースで検証する
+func BodyLimit(cfg SecurityConfig, uploadRoutes ...string) echo.MiddlewareFunc {
+	isUpload := func(c echo.Context) bool {
+		for _, route := range uploadRoutes {
+			if strings.HasSuffix(c.Path(), route) {
+				return true
+			}
+		}
+		return false
+	}
+	limit := echoMiddleware.BodyLimitWithConfig(echoMiddleware.BodyLimitConfig{
+		Skipper: isUpload,
+		Limit:   cfg.BodyLimit,
+	})
+	if len(uploadRoutes) == 0 || cfg.UploadBodyLimit == "" {
+		return limit
+	}
+	uploadLimit := echoMiddleware.BodyLimitWithConfig(echoMiddleware.BodyLimitConfig{
+		Skipper: func(c echo.Context) bool { return !isUpload(c) },
+		Limit:   cfg.UploadBodyLimit,
+	})
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return limit(uploadLimit(next))
+	}
+}
+
+// isSwaggerRequest Swagger UIへのリクエストか判定
+func isSwaggerRequest(c echo.Context) bool {
+	return strings.HasPrefix(c.Request().URL.Path, SwaggerPathPrefix)
+}
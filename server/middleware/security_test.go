@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSecurityTestEcho(cfg SecurityConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(CORS(cfg))
+	e.Use(SecurityHeaders(cfg))
+	e.Use(BodyLimit(cfg))
+
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	e.GET("/api/v1/health", ok)
+	e.POST("/api/v1/users", ok)
+	e.GET(SwaggerPathPrefix+"*", ok)
+	return e
+}
+
+func TestCORS(t *testing.T) {
+	cfg := SecurityConfig{
+		CORSOrigins:          []string{"http://localhost:3000"},
+		CORSMethods:          []string{http.MethodGet, http.MethodPost},
+		CORSAllowCredentials: true,
+		CORSMaxAge:           600,
+		BodyLimit:            "1K",
+	}
+	e := newSecurityTestEcho(cfg)
+
+	tests := []struct {
+		name          string
+		origin        string
+		expectAllowed bool
+	}{
+		{name: "正常系: 許可されたオリジン", origin: "http://localhost:3000", expectAllowed: true},
+		{name: "異常系: 許可されていないオリジン", origin: "http://evil.example.com", expectAllowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/api/v1/health", nil)
+			req.Header.Set(echo.HeaderOrigin, tt.origin)
+			req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if tt.expectAllowed {
+				assert.Equal(t, tt.origin, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+				assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+				assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+			} else {
+				assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	cfg := SecurityConfig{
+		HSTSMaxAge:            3600,
+		ContentSecurityPolicy: "default-src 'none'",
+		SwaggerCSP:            "default-src 'self'",
+		ReferrerPolicy:        "no-referrer",
+		BodyLimit:             "1K",
+	}
+	e := newSecurityTestEcho(cfg)
+
+	tests := []struct {
+		name      string
+		path      string
+		expectCSP string
+	}{
+		{name: "正常系: APIには厳格なCSPを適用", path: "/api/v1/health", expectCSP: "default-src 'none'"},
+		{name: "正常系: Swagger UIには専用のCSPを適用", path: "/swagger/index.html", expectCSP: "default-src 'self'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(echo.HeaderXForwardedProto, "https")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expectCSP, rec.Header().Get(echo.HeaderContentSecurityPolicy))
+			assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+			assert.Equal(t, "no-referrer", rec.Header().Get(echo.HeaderReferrerPolicy))
+			assert.Equal(t, "max-age=3600; includeSubdomains", rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		})
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	e := newSecurityTestEcho(SecurityConfig{BodyLimit: "1K"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(strings.Repeat("a", 2048)))
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestBodyLimit_UploadRoute(t *testing.T) {
+	e := echo.New()
+	e.Use(BodyLimit(SecurityConfig{BodyLimit: "1K", UploadBodyLimit: "4K"}, "/users/:id/avatar"))
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	e.POST("/api/v1/users", ok)
+	e.PUT("/api/v1/users/:id/avatar", ok)
+
+	tests := []struct {
+		name string
+		path string
+		size int
+		want int
+	}{
+		{"通常のルートはBodyLimit", "/api/v1/users", 2048, http.StatusRequestEntityTooLarge},
+		{"アップロードのルートはUploadBodyLimit", "/api/v1/users/1/avatar", 2048, http.StatusOK},
+		{"アップロードのルートもUploadBodyLimitを超えると413", "/api/v1/users/1/avatar", 8192, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodPost
+			if strings.HasSuffix(tt.path, "/avatar") {
+				method = http.MethodPut
+			}
+			req := httptest.NewRequest(method, tt.path, strings.NewReader(strings.Repeat("a", tt.size)))
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		xff            string
+		want           string
+	}{
+		{name: "信頼するプロキシがない場合は接続元のIP", remoteAddr: "10.0.0.1:1234", xff: "203.0.113.1", want: "10.0.0.1"},
+		{name: "信頼するプロキシ経由の場合はX-Forwarded-ForのIP", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "10.0.0.1:1234", xff: "203.0.113.1", want: "203.0.113.1"},
+		{name: "信頼しない接続元のX-Forwarded-Forは無視", trustedProxies: []string{"10.0.0.1"}, remoteAddr: "198.51.100.1:1234", xff: "203.0.113.1", want: "198.51.100.1"},
+		{name: "クライアントが付けたX-Forwarded-Forは信頼するプロキシの手前で止まる", trustedProxies: []string{"10.0.0.1"}, remoteAddr: "10.0.0.1:1234", xff: "192.0.2.1, 203.0.113.1", want: "203.0.113.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extract := IPExtractor(SecurityConfig{TrustedProxies: tt.trustedProxies})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, tt.xff)
+
+			assert.Equal(t, tt.want, extract(req))
+		})
+	}
+}
+
+func TestSecurityConfig_Validate(t *testing.T) {
+	assert.NoError(t, SecurityConfig{TrustedProxies: []string{"10.0.0.1", "172.16.0.0/12", "::1"}}.Validate())
+	assert.Error(t, SecurityConfig{TrustedProxies: []string{"proxy.example.com"}}.Validate())
+}
+
+func TestCORS_DefaultHeaders(t *testing.T) {
+	cfg := LoadSecurityConfig()
+	e := newSecurityTestEcho(cfg)
+
+	// プリフライト: 条件付きリクエスト・Idempotency-Key・APIキーのヘッダーを許可
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/users", nil)
+	req.Header.Set(echo.HeaderOrigin, "http://localhost:3000")
+	req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	allowed := rec.Header().Get(echo.HeaderAccessControlAllowHeaders)
+	for _, name := range []string{"If-Match", "If-None-Match", "Idempotency-Key", "X-API-Key", "Last-Event-ID"} {
+		assert.Contains(t, allowed, name)
+	}
+
+	// 実際のリクエスト: ETag・Location・レート制限のヘッダーを公開
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+	req.Header.Set(echo.HeaderOrigin, "http://localhost:3000")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	exposed := rec.Header().Get(echo.HeaderAccessControlExposeHeaders)
+	for _, name := range []string{"ETag", "Location", "Retry-After", "X-Request-Id", "RateLimit-Remaining"} {
+		assert.Contains(t, exposed, name)
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"km-api-go/internal/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = tp.Shutdown(t.Context()) })
+
+	e := echo.New()
+	e.Use(Tracing("/metrics"))
+	e.GET("/api/v1/users/:id", func(c echo.Context) error {
+		// ユースケース相当の内部スパン
+		_, span := tracing.Start(c.Request().Context(), "UserUsecase.GetUserByID")
+		span.End()
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/api/v1/fail", func(c echo.Context) error {
+		return c.NoContent(http.StatusInternalServerError)
+	})
+	e.GET("/metrics", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	const parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	tests := []struct {
+		name         string
+		path         string
+		traceparent  string
+		expectSpans  []string
+		expectTrace  string
+		expectStatus codes.Code
+	}{
+		{
+			name:         "正常系: traceparentを引き継ぎ、内部スパンが子になる",
+			path:         "/api/v1/users/1",
+			traceparent:  "00-" + parentTraceID + "-00f067aa0ba902b7-01",
+			expectSpans:  []string{"UserUsecase.GetUserByID", "GET /api/v1/users/:id"},
+			expectTrace:  parentTraceID,
+			expectStatus: codes.Unset,
+		},
+		{
+			name:         "異常系: 5xxはスパンをエラーにする",
+			path:         "/api/v1/fail",
+			expectSpans:  []string{"GET /api/v1/fail"},
+			expectStatus: codes.Error,
+		},
+		{
+			name:        "正常系: スキップ対象のパスはスパンを生成しない",
+			path:        "/metrics",
+			expectSpans: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter.Reset()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			spans := exporter.GetSpans()
+			var names []string
+			for _, s := range spans {
+				names = append(names, s.Name)
+			}
+			assert.Equal(t, tt.expectSpans, names)
+			if len(spans) == 0 {
+				return
+			}
+
+			server := spans[len(spans)-1]
+			assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+			assert.Equal(t, tt.expectStatus, server.Status.Code)
+			if tt.expectTrace != "" {
+				assert.Equal(t, tt.expectTrace, server.SpanContext.TraceID().String())
+				assert.True(t, server.Parent.IsRemote())
+			}
+			if len(spans) > 1 {
+				require.Equal(t, server.SpanContext.SpanID(), spans[0].Parent.SpanID())
+			}
+		})
+	}
+}
@@ -1,48 +1,353 @@
 package server
 
 import (
-	"net/http"
+	"log/slog"
+	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"gorm.io/gorm"
 
+	"km-api-go/internal/activity"
+	"km-api-go/internal/apiv1"
+	"km-api-go/internal/apiv2"
+	"km-api-go/internal/attachment"
+	attachmentRepo "km-api-go/internal/attachment/repository"
+	"km-api-go/internal/auth"
+	"km-api-go/internal/batch"
+	"km-api-go/internal/company"
+	companyRepo "km-api-go/internal/company/repository"
+	"km-api-go/internal/department"
+	departmentRepo "km-api-go/internal/department/repository"
+	"km-api-go/internal/export"
+	"km-api-go/internal/graph"
+	"km-api-go/internal/health"
 	"km-api-go/internal/helper"
+	"km-api-go/internal/idempotency"
+	"km-api-go/internal/importer"
+	importRepo "km-api-go/internal/importer/repository"
+	"km-api-go/internal/infra"
+	"km-api-go/internal/job"
+	"km-api-go/internal/logging"
+	"km-api-go/internal/openapi"
+	"km-api-go/internal/outbox"
+	"km-api-go/internal/ratelimit"
+	"km-api-go/internal/relation"
+	"km-api-go/internal/storage"
 	"km-api-go/internal/user"
 	userRepo "km-api-go/internal/user/repository"
+	"km-api-go/internal/webhook"
+	webhookRepo "km-api-go/internal/webhook/repository"
+	"km-api-go/server/middleware"
+	"km-api-go/swagger/src"
+	v2docs "km-api-go/swagger/v2"
 )
 
-func SetupRouter(db *gorm.DB) *echo.Echo {
+// 監視系エンドポイントのパス（メトリクス・トレースの対象外）
+const (
+	metricsPath = "/metrics"
+	livezPath   = "/livez"
+	readyzPath  = "/readyz"
+)
+
+// uploadRoutes ファイルをアップロードするルート（BODY_LIMITの代わりにUPLOAD_BODY_LIMITを適用する）
+var uploadRoutes = []string{"/users/:id/avatar", "/companies/:id/attachments", "/companies/:id/logo"}
+
+func SetupRouter(db *gorm.DB, logger *slog.Logger, healthChecker *health.Health, cfg Config, jobs job.Enqueuer, activityHub *activity.Hub, blobs storage.BlobStore) *echo.Echo {
 	e := echo.New()
+	e.HideBanner = true
+	e.IPExtractor = middleware.IPExtractor(cfg.Security)
 
 	// ミドルウェア設定
-	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	e.Use(middleware.RequestID(logger))
+	e.Use(middleware.Tracing(metricsPath, livezPath, readyzPath))
+	e.Use(middleware.RequestLogger())
+	if cfg.MetricsEnabled {
+		e.Use(middleware.Metrics(metricsPath, livezPath, readyzPath))
+	}
+	e.Use(echoMiddleware.RecoverWithConfig(echoMiddleware.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+			logging.FromContext(c.Request().Context()).Error("panic recovered",
+				slog.Any("error", err), slog.String("stack", string(stack)))
+			return err
+		},
+	}))
+	e.Use(middleware.CORS(cfg.Security))
+	e.Use(middleware.SecurityHeaders(cfg.Security))
+	e.Use(middleware.BodyLimit(cfg.Security, uploadRoutes...))
+
+	// 認証（トークン・APIキーがあれば呼び出し元をコンテキストに設定）
+	tokenService := auth.NewTokenService(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL)
+	e.Use(middleware.Authenticate(tokenService, cfg.Auth))
 
 	// カスタムバリデータ設定
 	e.Validator = helper.NewValidator()
 
 	// 依存関係の注入 (Dependency Injection)
+	transactor := infra.NewTransactor(db)
+	events := outbox.New(outbox.NewPostgresStore(db))
+
 	userRepository := userRepo.NewUserRepository(db)
-	userUsecase := user.NewUserUsecase(userRepository)
-	userHandler := user.NewUserHandler(userUsecase)
+	userUsecase := user.NewUserUsecase(userRepository, transactor, events, blobs, cfg.User)
+	authHandler := auth.NewAuthHandler(userUsecase, tokenService)
+
+	companyRepository := companyRepo.NewCompanyRepository(db)
+	companyUserRepository := companyRepo.NewCompanyUserRepository(db)
+	companyUsecase := company.NewCompanyUsecase(companyRepository, companyUserRepository, transactor, events)
+
+	importRepository := importRepo.NewImportRepository(db)
+	importUsecase := importer.NewImportUsecase(importRepository, userRepository, companyRepository, companyUserRepository, transactor, events, jobs)
+	importHandler := importer.NewImportHandler(importUsecase)
+
+	exportUsecase := export.NewExportUsecase(userRepository, companyRepository, companyUserRepository)
+	exportHandler := export.NewExportHandler(exportUsecase)
+
+	webhookUsecase := webhook.NewWebhookUsecase(
+		webhookRepo.NewWebhookRepository(db),
+		webhookRepo.NewWebhookDeliveryRepository(db),
+		companyRepository,
+		companyUserRepository,
+		transactor,
+		jobs,
+		cfg.Webhook,
+	)
+	webhookHandler := webhook.NewWebhookHandler(webhookUsecase)
+
+	attachmentUsecase := attachment.NewAttachmentUsecase(
+		attachmentRepo.NewAttachmentRepository(db),
+		companyRepository,
+		companyUserRepository,
+		transactor,
+		blobs,
+		nil, // ウイルススキャンはScannerを実装して渡す
+		cfg.Attachment,
+	)
+	attachmentHandler := attachment.NewAttachmentHandler(attachmentUsecase)
+
+	departmentUsecase := department.NewDepartmentUsecase(
+		departmentRepo.NewDepartmentRepository(db),
+		departmentRepo.NewDepartmentMemberRepository(db),
+		companyRepository,
+		companyUserRepository,
+		transactor,
+	)
+	departmentHandler := department.NewDepartmentHandler(departmentUsecase)
+
+	activityUsecase := activity.NewActivityUsecase(activityHub, companyRepository, companyUserRepository)
+	activityHandler := activity.NewActivityHandler(activityUsecase, cfg.Activity)
+
+	// include=による関連の展開（v1・v2で共通）
+	expander := relation.NewExpander(userUsecase, companyUsecase)
+
+	graphSchema, err := graph.NewSchema(companyUsecase, userRepository, companyRepository, companyUserRepository, cfg.GraphQL)
+	if err != nil {
+		// スキーマの定義の誤りのため起動時に止める
+		panic(err)
+	}
+	graphHandler := graph.NewGraphQLHandler(graphSchema)
+
+	// 一括リクエスト（各操作はこのルーターで実行する）
+	batchHandler := batch.NewBatchHandler(e, transactor, cfg.Batch)
+
+	healthChecker.Register("database", health.DatabaseCheck(db))
+	healthChecker.Register("migrations", health.MigrationCheck(db, infra.MigrationsDir()))
+	healthHandler := health.NewHealthHandler(healthChecker)
+
+	// ライブネス・レディネス（オーケストレーター用）
+	e.GET(livezPath, healthHandler.Livez)
+	e.GET(readyzPath, healthHandler.Readyz)
+
+	// ローカルに保存したファイルの配信（署名付きURLのみ。S3互換ストレージの場合はストレージから直接取得する）
+	if local, ok := blobs.(*storage.LocalStore); ok {
+		e.GET(storage.FilesPath+"/*", storage.NewFileHandler(local).Serve)
+	}
+
+	// Prometheusメトリクス
+	if cfg.MetricsEnabled {
+		e.GET(metricsPath, echo.WrapHandler(promhttp.Handler()))
+	}
+
+	// Swagger UI（本番環境・SWAGGER_ENABLED=false では公開しない）
+	if cfg.SwaggerEnabled {
+		e.GET(middleware.SwaggerPathPrefix+"v2/*", echoSwagger.EchoWrapHandler(echoSwagger.InstanceName(v2docs.SwaggerInfov2.InstanceName())))
+		e.GET(middleware.SwaggerPathPrefix+"*", echoSwagger.WrapHandler)
+	}
+
+	// レート制限（認証済みユーザー → APIキー → クライアントIPの単位。バージョン間で共有）
+	rateLimitStore := newRateLimitStore(db, cfg.RateLimit)
+	rateLimit := func(policy ratelimit.Policy) echo.MiddlewareFunc {
+		if !cfg.RateLimit.Enabled {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+		}
+		return middleware.RateLimit(rateLimitStore, policy, middleware.KeyByPrincipal)
+	}
+
+	var idempotencyStore idempotency.Store
+	if cfg.Idempotency.Enabled {
+		idempotencyStore = newIdempotencyStore(db, cfg.Idempotency)
+	}
 
-	// ルーティング
-	apiV1 := e.Group("/api/v1")
+	// APIのバージョン
+	// ユースケースと大半のハンドラーは共通で、ユーザー・会社のレスポンス（DTO）と仕様だけが異なる
+	// v1は非推奨で、Deprecation・Sunsetヘッダーと後継（v2）へのLinkヘッダーを付ける
+	versions := []apiVersion{
+		{
+			group:     e.Group("/api/v1", middleware.Deprecation(cfg.Deprecation)),
+			spec:      src.SwaggerInfo.ReadDoc(),
+			users:     apiv1.NewUserHandler(userUsecase, expander),
+			companies: apiv1.NewCompanyHandler(companyUsecase, expander),
+		},
+		{
+			group:     e.Group("/api/v2"),
+			spec:      v2docs.SwaggerInfov2.ReadDoc(),
+			users:     apiv2.NewUserHandler(userUsecase, companyUsecase, expander),
+			companies: apiv2.NewCompanyHandler(companyUsecase, expander),
+		},
+	}
 
-	// ヘルスチェック
-	apiV1.GET("/health", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
-	})
+	for _, v := range versions {
+		api := v.group
+		api.Use(rateLimit(cfg.RateLimit.Default))
 
-	// Swagger UI
-	e.GET("/swagger/*", echoSwagger.WrapHandler)
+		// バージョンごとに生成したOpenAPI仕様（swagger/src, swagger/v2）に合わないリクエストを拒否
+		if cfg.OpenAPI.Enabled {
+			validator, err := openapi.NewValidator([]byte(v.spec))
+			if err != nil {
+				// 仕様の生成の誤りのため起動時に止める
+				panic(err)
+			}
+			api.Use(middleware.OpenAPIValidation(validator, cfg.OpenAPI))
+		}
 
-	// ユーザー関連
-	usersGroup := apiV1.Group("/users")
-	usersGroup.POST("", userHandler.CreateUser)
+		// Idempotency-Key（POSTの再試行に最初のレスポンスを返す）
+		if cfg.Idempotency.Enabled {
+			api.Use(middleware.Idempotency(idempotencyStore, cfg.Idempotency, middleware.KeyByPrincipal))
+		}
+
+		// ヘルスチェック（レディネスと同じ判定）
+		api.GET("/health", healthHandler.Readyz)
+
+		// 認証関連（総当たり対策で厳しめの制限）
+		authGroup := api.Group("/auth", rateLimit(cfg.RateLimit.Auth))
+		authGroup.POST("/login", authHandler.Login)
+
+		// ユーザー関連
+		usersGroup := api.Group("/users")
+		usersGroup.POST("", v.users.CreateUser)
+		usersGroup.GET("/:id", v.users.GetUser, middleware.RequireAuth())
+		usersGroup.PUT("/:id", v.users.UpdateUser, middleware.RequireAuth())
+		usersGroup.PATCH("/:id", v.users.PatchUser, middleware.RequireAuth())
+		usersGroup.PUT("/:id/avatar", v.users.UploadAvatar, middleware.RequireAuth())
+		usersGroup.DELETE("/:id/avatar", v.users.DeleteAvatar, middleware.RequireAuth())
+
+		// 会社関連
+		companiesGroup := api.Group("/companies", middleware.RequireAuth())
+		companiesGroup.POST("", v.companies.CreateCompany)
+		companiesGroup.GET("/:id", v.companies.GetCompany)
+		companiesGroup.PUT("/:id", v.companies.UpdateCompany)
+		companiesGroup.PATCH("/:id", v.companies.PatchCompany)
+
+		// 会社のアクティビティストリーム（Server-Sent Events）
+		companiesGroup.GET("/:id/events", activityHandler.Stream)
+
+		// 会社のWebhook（会社の管理者のみ）
+		companiesGroup.GET("/:id/webhooks", webhookHandler.ListWebhooks)
+		companiesGroup.POST("/:id/webhooks", webhookHandler.CreateWebhook)
+		companiesGroup.GET("/:id/webhooks/:webhook_id", webhookHandler.GetWebhook)
+		companiesGroup.PUT("/:id/webhooks/:webhook_id", webhookHandler.UpdateWebhook)
+		companiesGroup.DELETE("/:id/webhooks/:webhook_id", webhookHandler.DeleteWebhook)
+		companiesGroup.POST("/:id/webhooks/:webhook_id/rotate-secret", webhookHandler.RotateSecret)
+		companiesGroup.GET("/:id/webhooks/:webhook_id/deliveries", webhookHandler.ListDeliveries)
+		companiesGroup.POST("/:id/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+
+		// 会社の添付ファイル・ロゴ（会社のメンバーのみ）
+		companiesGroup.GET("/:id/attachments", attachmentHandler.ListAttachments)
+		companiesGroup.POST("/:id/attachments", attachmentHandler.UploadAttachment)
+		companiesGroup.GET("/:id/attachments/:attachment_id", attachmentHandler.GetAttachment)
+		companiesGroup.GET("/:id/attachments/:attachment_id/download", attachmentHandler.DownloadAttachment)
+		companiesGroup.DELETE("/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+		companiesGroup.GET("/:id/storage", attachmentHandler.GetUsage)
+		companiesGroup.PUT("/:id/logo", attachmentHandler.UploadLogo)
+		companiesGroup.GET("/:id/logo", attachmentHandler.GetLogo)
+		companiesGroup.DELETE("/:id/logo", attachmentHandler.DeleteLogo)
+
+		// 会社の部署（参照は会社のメンバー、部署の変更は会社の管理者、所属の変更は部署のmanagerも可）
+		companiesGroup.GET("/:id/departments", departmentHandler.ListDepartments)
+		companiesGroup.POST("/:id/departments", departmentHandler.CreateDepartment)
+		companiesGroup.GET("/:id/departments/:department_id", departmentHandler.GetDepartment)
+		companiesGroup.PUT("/:id/departments/:department_id", departmentHandler.UpdateDepartment)
+		companiesGroup.DELETE("/:id/departments/:department_id", departmentHandler.DeleteDepartment)
+		companiesGroup.POST("/:id/departments/:department_id/move", departmentHandler.MoveDepartment)
+		companiesGroup.GET("/:id/departments/:department_id/members", departmentHandler.ListMembers)
+		companiesGroup.PUT("/:id/departments/:department_id/members/:user_id", departmentHandler.AssignMember)
+		companiesGroup.DELETE("/:id/departments/:department_id/members/:user_id", departmentHandler.RemoveMember)
+
+		// GraphQL（RESTと同じ認証・レート制限）
+		if cfg.GraphQL.Enabled {
+			api.GET("/graphql", graphHandler.Query, middleware.RequireAuth())
+			api.POST("/graphql", graphHandler.Query, middleware.RequireAuth())
+		}
+
+		// 一括インポート
+		importsGroup := api.Group("/imports", middleware.RequireAuth())
+		importsGroup.POST("", importHandler.CreateImport)
+		importsGroup.GET("/:id", importHandler.GetImport)
+
+		// エクスポート
+		exportsGroup := api.Group("/exports", middleware.RequireAuth())
+		exportsGroup.GET("/users", exportHandler.ExportUsers)
+		exportsGroup.GET("/companies", exportHandler.ExportCompanies)
+		exportsGroup.GET("/memberships", exportHandler.ExportMemberships)
+
+		// 一括リクエスト（同じバージョンの複数の操作を1回で実行）
+		if cfg.Batch.Enabled {
+			api.POST("/batch", batchHandler.Batch, middleware.RequireAuth())
+		}
+	}
 
 	return e
 }
+
+// apiVersion APIのバージョンごとのグループ・仕様・ハンドラー
+type apiVersion struct {
+	group     *echo.Group
+	spec      string // swagが生成した仕様（Swagger 2.0のJSON）
+	users     userHandler
+	companies companyHandler
+}
+
+// userHandler バージョンごとに異なるユーザーのハンドラー
+type userHandler interface {
+	CreateUser(c echo.Context) error
+	GetUser(c echo.Context) error
+	UpdateUser(c echo.Context) error
+	PatchUser(c echo.Context) error
+	UploadAvatar(c echo.Context) error
+	DeleteAvatar(c echo.Context) error
+}
+
+// companyHandler バージョンごとに異なる会社のハンドラー
+type companyHandler interface {
+	CreateCompany(c echo.Context) error
+	GetCompany(c echo.Context) error
+	UpdateCompany(c echo.Context) error
+	PatchCompany(c echo.Context) error
+}
+
+// newRateLimitStore 設定に応じたレート制限バックエンドを作成
+func newRateLimitStore(db *gorm.DB, cfg ratelimit.Config) ratelimit.Store {
+	if cfg.Backend == ratelimit.BackendPostgres {
+		return ratelimit.NewPostgresStore(db)
+	}
+	return ratelimit.NewMemoryStore(10 * time.Minute)
+}
+
+// newIdempotencyStore 設定に応じたIdempotency-Keyの保存先を作成
+func newIdempotencyStore(db *gorm.DB, cfg idempotency.Config) idempotency.Store {
+	if cfg.Backend == idempotency.BackendMemory {
+		return idempotency.NewMemoryStore()
+	}
+	return idempotency.NewPostgresStore(db)
+}